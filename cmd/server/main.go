@@ -3,15 +3,36 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/art-injener/satwatch-go/internal/catalog"
 	"github.com/art-injener/satwatch-go/internal/config"
+	"github.com/art-injener/satwatch-go/internal/demod"
 	"github.com/art-injener/satwatch-go/internal/handlers"
+	"github.com/art-injener/satwatch-go/internal/kiss"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/receiver"
+	"github.com/art-injener/satwatch-go/internal/rig"
+	"github.com/art-injener/satwatch-go/internal/rotator"
+	"github.com/art-injener/satwatch-go/internal/schedule"
+	"github.com/art-injener/satwatch-go/internal/sdr"
+	"github.com/art-injener/satwatch-go/internal/sids"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/simulation"
+	"github.com/art-injener/satwatch-go/internal/station"
+	"github.com/art-injener/satwatch-go/internal/telemetry"
+	"github.com/art-injener/satwatch-go/internal/tlefetch"
+	"github.com/art-injener/satwatch-go/internal/tracking"
 )
 
 const (
@@ -19,30 +40,236 @@ const (
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv(config.EnvFile),
+		"путь к файлу конфигурации JSON (переменные окружения важнее файла)")
+	flag.Parse()
+
+	// Загрузка конфигурации: при ошибках сервер не запускается
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	// Настройка структурированного логгера
-	logger := slog.New(slog.NewTextHandler(
-		os.Stdout,
-		&slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		}))
-	slog.SetDefault(logger)
-
-	// Загрузка конфигурации
-	cfg := config.Load()
+	slog.SetDefault(newLogger(cfg, os.Stdout))
+
+	// Станции: оборудование подключается для станции, активной при
+	// запуске, и активной затем можно сделать только станцию с тем же
+	// оборудованием; прогнозы трекера, расписания и имитации следуют
+	// за активной станцией и её маской горизонта
+	passOpts := pass.Options{
+		MinElevation: cfg.PassMinElevation,
+		Lookahead:    time.Duration(cfg.PassLookaheadHours * float64(time.Hour)),
+	}
+	stations, err := newStationRegistry(cfg, passOpts)
+	if err != nil {
+		slog.Error("failed to load stations", slogKeyError, err)
+		os.Exit(1)
+	}
+	home, predictor, err := stations.Resolve("")
+	if err != nil {
+		slog.Error("failed to initialize pass predictor", slogKeyError, err)
+		os.Exit(1)
+	}
+	useStationHardware(cfg, stations)
+
 	slog.Info("configuration loaded",
+		"config", *configPath,
 		"port", cfg.Port,
-		"observer_lat", cfg.ObserverLat,
-		"observer_lon", cfg.ObserverLon,
+		"station", home.ID,
+		"observer_lat", home.Latitude,
+		"observer_lon", home.Longitude,
+		"catalog_path", cfg.CatalogPath,
+		"tle_source", cfg.TLESource,
+		"rotator_addr", cfg.RotatorAddr,
+		"rig_addr", cfg.RigAddr,
+		"sdr_source", cfg.SDRSource,
+		"sdr_mode", cfg.SDRMode,
+		"pass_min_elevation", cfg.PassMinElevation,
+		"schedule_enabled", cfg.ScheduleEnabled,
 	)
 
 	// Инициализация обработчиков
-	pageHandler, err := handlers.NewPageHandler("templates", true)
+	pageHandler, err := handlers.NewPageHandler("templates", true, stations)
 	if err != nil {
 		slog.Error("failed to initialize page handler", slogKeyError, err)
 		os.Exit(1)
 	}
 
-	apiHandler := handlers.NewAPIHandler(cfg)
+	apiHandler := handlers.NewAPIHandler(stations)
+	stationHandler := handlers.NewStationHandler(stations)
+
+	store, err := newCatalogStore(cfg.CatalogPath)
+	if err != nil {
+		slog.Error("failed to open satellite catalog", slogKeyError, err)
+		os.Exit(1)
+	}
+	satelliteHandler := handlers.NewSatelliteHandler(store)
+
+	// Фоновые задачи останавливаются вместе с сервером
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.TLESource != "" {
+		fetcher, err := newTLEFetcher(cfg, store)
+		if err != nil {
+			slog.Error("failed to initialize TLE fetcher", slogKeyError, err)
+			os.Exit(1)
+		}
+		go fetcher.Run(ctx)
+	}
+
+	// Общие модельные часы: прогнозы и потоки SSE считаются на их время
+	clock := simclock.New()
+
+	passHandler := handlers.NewPassHandler(store, pageHandler, stations, clock)
+	clockHandler := handlers.NewClockHandler(clock, store, stations)
+	tracker := tracking.NewTracker(store, predictor)
+	streamHandler := handlers.NewStreamHandler(store, stations, clock)
+
+	// Оборудование станции, которым управляет расписание; отключённые
+	// устройства остаются nil
+	var devices schedule.Devices
+
+	// Поворотное устройство антенны (только если задан адрес rotctld)
+	var rotatorHandler *handlers.RotatorHandler
+	if cfg.RotatorAddr != "" {
+		ctrl, err := newRotatorController(cfg, tracker, clock)
+		if err != nil {
+			slog.Error("failed to initialize rotator", slogKeyError, err)
+			os.Exit(1)
+		}
+		go ctrl.Run(ctx)
+		devices.Antenna = ctrl
+		rotatorHandler = handlers.NewRotatorHandler(ctrl, store)
+	}
+
+	// Доплеровская подстройка радиостанции (только если задан адрес rigctld)
+	var rigHandler *handlers.RigHandler
+	if cfg.RigAddr != "" {
+		tuner, err := newRigTuner(cfg, tracker, clock)
+		if err != nil {
+			slog.Error("failed to initialize rig tuner", slogKeyError, err)
+			os.Exit(1)
+		}
+		go tuner.Run(ctx)
+		devices.Radio = tuner
+		rigHandler = handlers.NewRigHandler(tuner, store)
+	}
+
+	// Декодирование телеметрии по схемам спутников каталога
+	registry, err := newTelemetryRegistry(cfg.TelemetrySchemas)
+	if err != nil {
+		slog.Error("failed to load telemetry schemas", slogKeyError, err)
+		os.Exit(1)
+	}
+	// Кадры помечены реальным временем, поэтому пролёт для них ищет
+	// отдельный трекер: общий кэширует пролёты по модельному времени
+	frameTracker := tracking.NewTracker(store, predictor)
+	monitor := telemetry.NewMonitor(store, registry, newPassResolver(frameTracker))
+	var archive *telemetry.Archive
+	if cfg.TelemetryArchive != "" {
+		archive, err = telemetry.OpenArchive(cfg.TelemetryArchive)
+		if err != nil {
+			slog.Error("failed to open telemetry archive", slogKeyError, err)
+			os.Exit(1)
+		}
+		go archive.Run(ctx)
+	}
+	telemetryHandler := handlers.NewTelemetryHandler(monitor, archive, pageHandler)
+
+	// Отправка кадров в SatNOGS DB (только если задан URL приёмника SiDS)
+	var uploader *sids.Uploader
+	if cfg.SatNOGSURL != "" {
+		uploader, err = newSIDSUploader(cfg, home)
+		if err != nil {
+			slog.Error("failed to initialize SatNOGS upload", slogKeyError, err)
+			os.Exit(1)
+		}
+		go uploader.Run(ctx)
+	}
+
+	// Приёмник SDR, водопад и декодер пакетов (только если задан источник
+	// отсчётов); принятые кадры раздаются клиентам KISS TCP, декодируются
+	// как телеметрия, сохраняются в архив и отправляются в SatNOGS DB
+	// для спутников, разрешивших отправку; отсчёты пролётов по расписанию
+	// записываются в SigMF
+	var receiverHandler *handlers.ReceiverHandler
+	if cfg.SDRSource != "" {
+		var sinks []receiver.Sink
+		if cfg.RecordingsDir != "" {
+			recorder := receiver.NewRecorder(cfg.RecordingsDir)
+			devices.Recorder = recorder
+			sinks = append(sinks, recorder)
+		}
+		kissServer := kiss.NewServer()
+		go monitor.Run(ctx, func(rec telemetry.Record) {
+			if archive != nil {
+				archive.Enqueue(rec)
+			}
+			if uploader != nil {
+				if sat, err := store.Get(rec.NoradID); err == nil && sat.SatNOGSUpload {
+					uploader.Enqueue(sids.Frame{NoradID: rec.NoradID, Time: rec.Time, Data: rec.Raw})
+				}
+			}
+		})
+		rx, waterfall, err := newReceiver(cfg, func(p receiver.Packet) {
+			kissServer.Broadcast(p.Raw)
+			monitor.Submit(p.Time, p.Frame, telemetry.Signal{Frequency: p.Frequency, Mode: string(p.Mode)})
+		}, sinks...)
+		if err != nil {
+			slog.Error("failed to initialize SDR receiver", slogKeyError, err)
+			os.Exit(1)
+		}
+		if cfg.KISSAddr != "" {
+			ln, err := net.Listen("tcp", cfg.KISSAddr)
+			if err != nil {
+				slog.Error("failed to start KISS server", slogKeyError, err)
+				os.Exit(1)
+			}
+			slog.Info("KISS server listening", "addr", ln.Addr().String())
+			go func() {
+				if err := kissServer.Serve(ctx, ln); err != nil {
+					slog.Error("KISS server failed", slogKeyError, err)
+				}
+			}()
+		}
+		go rx.Run(ctx)
+		devices.Receiver = rx
+		receiverHandler = handlers.NewReceiverHandler(rx, waterfall)
+	}
+
+	// Автоматический приём пролётов спутников, отмеченных в каталоге
+	var (
+		sched           *schedule.Scheduler
+		scheduleHandler *handlers.ScheduleHandler
+	)
+	if cfg.ScheduleEnabled {
+		sched, err = newScheduler(cfg, store, predictor, devices, clock)
+		if err != nil {
+			slog.Error("failed to initialize schedule", slogKeyError, err)
+			os.Exit(1)
+		}
+		go sched.Run(ctx)
+		scheduleHandler = handlers.NewScheduleHandler(sched, pageHandler)
+	}
+
+	simulator := simulation.NewSimulator(predictor, simulation.NewSynthesizer().Generate, clock.Now)
+	simulationHandler := handlers.NewSimulationHandler(simulator, pageHandler)
+
+	// Смена активной станции или её маски горизонта доходит до трекеров
+	// (а через них до поворотного устройства, подстройки и телеметрии),
+	// расписания и имитации
+	stations.Watch(func(st station.Station, p *pass.Predictor) {
+		tracker.SetPredictor(p)
+		frameTracker.SetPredictor(p)
+		simulator.SetPredictor(p)
+		if sched != nil {
+			sched.SetPredictor(p)
+		}
+		slog.Info("pass predictions follow station", "station", st.ID)
+	})
 
 	mux := http.NewServeMux()
 
@@ -60,14 +287,89 @@ func main() {
 	mux.HandleFunc("GET /api/health", apiHandler.HealthCheck)
 	mux.HandleFunc("GET /api/config", apiHandler.GetConfig)
 
+	// API станций
+	mux.HandleFunc("GET /api/stations", stationHandler.List)
+	mux.HandleFunc("POST /api/stations/active", stationHandler.SetActive)
+	mux.HandleFunc("GET /api/stations/{id}/horizon", stationHandler.Horizon)
+	mux.HandleFunc("PUT /api/stations/{id}/horizon", stationHandler.ImportHorizon)
+
+	// Каталог спутников
+	mux.HandleFunc("GET /api/satellites", satelliteHandler.List)
+	mux.HandleFunc("POST /api/satellites", satelliteHandler.Create)
+	mux.HandleFunc("GET /api/satellites/{id}", satelliteHandler.Get)
+	mux.HandleFunc("PUT /api/satellites/{id}", satelliteHandler.Update)
+	mux.HandleFunc("DELETE /api/satellites/{id}", satelliteHandler.Delete)
+
+	// Прогноз пролётов
+	mux.HandleFunc("GET /api/passes", passHandler.List)
+
+	// Архив телеметрии
+	mux.HandleFunc("GET /api/telemetry", telemetryHandler.Query)
+
+	// Локальная замена приёмника SiDS для проверки отправки без интернета
+	if cfg.SatNOGSStandIn {
+		standIn := sids.NewServer()
+		mux.Handle("GET /api/sids/telemetry/", standIn)
+		mux.Handle("POST /api/sids/telemetry/", standIn)
+	}
+
+	// Потоки SSE (WriteTimeout сервера для них снимается в обработчике)
+	mux.HandleFunc("GET /api/stream/tracking", streamHandler.Tracking)
+	mux.HandleFunc("GET /api/stream/telemetry", telemetryHandler.Stream)
+
+	// Модельные часы
+	mux.HandleFunc("GET /api/clock", clockHandler.State)
+	mux.HandleFunc("POST /api/clock/rate", clockHandler.SetRate)
+	mux.HandleFunc("POST /api/clock/pause", clockHandler.Pause)
+	mux.HandleFunc("POST /api/clock/resume", clockHandler.Resume)
+	mux.HandleFunc("POST /api/clock/jump", clockHandler.Jump)
+	mux.HandleFunc("POST /api/clock/next-aos", clockHandler.JumpNextAOS)
+	mux.HandleFunc("POST /api/clock/reset", clockHandler.Reset)
+
+	// Поворотное устройство
+	if rotatorHandler != nil {
+		mux.HandleFunc("GET /api/rotator", rotatorHandler.Status)
+		mux.HandleFunc("GET /api/rotator/plan", rotatorHandler.Plan)
+		mux.HandleFunc("POST /api/rotator/track", rotatorHandler.Track)
+		mux.HandleFunc("POST /api/rotator/park", rotatorHandler.Park)
+		mux.HandleFunc("POST /api/rotator/stop", rotatorHandler.Stop)
+	}
+
+	// Радиостанция
+	if rigHandler != nil {
+		mux.HandleFunc("GET /api/rig", rigHandler.Status)
+		mux.HandleFunc("POST /api/rig/track", rigHandler.Track)
+		mux.HandleFunc("POST /api/rig/stop", rigHandler.Stop)
+	}
+
+	// Приёмник SDR
+	if receiverHandler != nil {
+		mux.HandleFunc("GET /api/receiver", receiverHandler.Status)
+		mux.HandleFunc("POST /api/receiver/tune", receiverHandler.Tune)
+		mux.HandleFunc("GET /api/stream/waterfall", receiverHandler.Waterfall)
+	}
+
+	// Расписание станции
+	if scheduleHandler != nil {
+		mux.HandleFunc("GET /api/schedule", scheduleHandler.List)
+		mux.HandleFunc("GET /api/schedule/plan", scheduleHandler.Plan)
+		mux.HandleFunc("POST /api/schedule/{id}/cancel", scheduleHandler.Cancel)
+	}
+
+	// Управление имитацией
+	mux.HandleFunc("POST /api/simulation/config", simulationHandler.Config)
+	mux.HandleFunc("POST /api/simulation/generate-tle", simulationHandler.GenerateTLE)
+	mux.HandleFunc("POST /api/simulation/start", simulationHandler.Start)
+	mux.HandleFunc("POST /api/simulation/stop", simulationHandler.Stop)
+	mux.HandleFunc("POST /api/simulation/reset", simulationHandler.Reset)
+
 	// Частичные шаблоны (HTMX)
-	mux.HandleFunc("GET /partials/passes", func(w http.ResponseWriter, r *http.Request) {
-		// TODO: реализовать частичный шаблон таблицы пролётов
-		w.Header().Set("Content-Type", "text/html")
-		if _, err := w.Write([]byte(`<p class="empty-state">Нет запланированных пролётов</p>`)); err != nil {
-			slog.Error("failed to write response", slogKeyError, err)
-		}
-	})
+	mux.HandleFunc("GET /partials/passes", passHandler.Partial)
+	mux.HandleFunc("GET /partials/simulation/status", simulationHandler.Status)
+	if scheduleHandler != nil {
+		mux.HandleFunc("GET /partials/schedule", scheduleHandler.Partial)
+		mux.HandleFunc("GET /partials/schedule/plan", scheduleHandler.PlanPartial)
+	}
 
 	// Создание сервера с таймаутами
 	server := &http.Server{
@@ -101,6 +403,7 @@ func main() {
 	}
 
 	slog.Info("shutting down server...")
+	cancel()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 
@@ -114,6 +417,207 @@ func main() {
 	slog.Info("server stopped gracefully")
 }
 
+// newCatalogStore открывает файловый каталог спутников или, если путь не задан,
+// создаёт каталог в памяти.
+func newCatalogStore(path string) (catalog.Store, error) {
+	if path == "" {
+		slog.Warn("catalog path is empty, satellites are kept in memory")
+		return catalog.NewMemoryStore(), nil
+	}
+	return catalog.NewFileStore(path)
+}
+
+// newPassResolver определяет пролёт, во время которого принят кадр,
+// по снимку сопровождения спутника. tracker должен использоваться только
+// для кадров: их время реальное, а не модельное.
+func newPassResolver(tracker *tracking.Tracker) telemetry.PassFunc {
+	return func(noradID int, t time.Time) (time.Time, bool) {
+		snap, err := tracker.Snapshot(noradID, t)
+		if err != nil || snap.NextAOS == nil || snap.NextLOS == nil {
+			return time.Time{}, false
+		}
+		if t.Before(*snap.NextAOS) || t.After(*snap.NextLOS) {
+			return time.Time{}, false
+		}
+		return *snap.NextAOS, true
+	}
+}
+
+// newTelemetryRegistry загружает схемы телеметрии из каталога dir;
+// отсутствующий каталог не считается ошибкой.
+func newTelemetryRegistry(dir string) (*telemetry.Registry, error) {
+	registry := telemetry.NewRegistry()
+	if dir == "" {
+		return registry, nil
+	}
+	n, err := registry.LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("telemetry schemas loaded", "dir", dir, "count", n)
+	return registry, nil
+}
+
+// newTLEFetcher создаёт обновление TLE из URL в формате GP Celestrak
+// или, если источник не является URL, из локального каталога.
+func newTLEFetcher(cfg *config.Config, store catalog.Store) (*tlefetch.Fetcher, error) {
+	var source tlefetch.Source
+	if strings.HasPrefix(cfg.TLESource, "http://") || strings.HasPrefix(cfg.TLESource, "https://") {
+		source = tlefetch.NewHTTPSource(cfg.TLESource, nil)
+	} else {
+		dir, err := tlefetch.NewDirSource(cfg.TLESource)
+		if err != nil {
+			return nil, err
+		}
+		source = dir
+	}
+
+	opts := tlefetch.DefaultOptions()
+	opts.Interval = time.Duration(cfg.TLEUpdateHours * float64(time.Hour))
+	opts.AddNew = cfg.TLEAddNew
+	return tlefetch.NewFetcher(store, source, opts)
+}
+
+// defaultStationID — идентификатор станции из параметров наблюдателя
+// конфигурации, если файла станций нет.
+const defaultStationID = "default"
+
+// newStationRegistry загружает профили станций из файла; без файла
+// единственная станция — наблюдатель из конфигурации.
+func newStationRegistry(cfg *config.Config, opts pass.Options) (*station.Registry, error) {
+	fallback := station.Station{
+		ID:        defaultStationID,
+		Name:      cfg.ObserverName,
+		Latitude:  cfg.ObserverLat,
+		Longitude: cfg.ObserverLon,
+		Altitude:  cfg.ObserverAlt,
+	}
+	return station.NewRegistry(cfg.StationsPath, fallback, opts)
+}
+
+// useStationHardware закрепляет оборудование за активной станцией
+// реестра и заменяет адреса в конфигурации заданными в её профиле.
+func useStationHardware(cfg *config.Config, stations *station.Registry) {
+	hw := stations.BindHardware(station.Hardware{
+		RotatorAddr: cfg.RotatorAddr,
+		RigAddr:     cfg.RigAddr,
+		SDRSource:   cfg.SDRSource,
+	})
+	cfg.RotatorAddr, cfg.RigAddr, cfg.SDRSource = hw.RotatorAddr, hw.RigAddr, hw.SDRSource
+}
+
+// newLogger создаёт журнал с уровнем и форматом из конфигурации.
+func newLogger(cfg *config.Config, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.SlogLevel()}
+	if cfg.LogFormat == config.LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// newRotatorController создаёт контроллер поворотного устройства,
+// наводящего антенну по модельному времени clock.
+func newRotatorController(cfg *config.Config, tracker *tracking.Tracker, clock *simclock.Clock) (*rotator.Controller, error) {
+	return rotator.NewController(rotator.NewClient(cfg.RotatorAddr), tracker, clock.Now, rotatorConfig(cfg))
+}
+
+// rotatorConfig возвращает параметры поворотного устройства.
+func rotatorConfig(cfg *config.Config) rotator.Config {
+	rcfg := rotator.DefaultConfig()
+	rcfg.Park = rotator.Position{Azimuth: cfg.RotatorParkAzimuth, Elevation: cfg.RotatorParkElevation}
+	rcfg.AzimuthRate = cfg.RotatorAzimuthRate
+	rcfg.ElevationRate = cfg.RotatorElevationRate
+	rcfg.MaxAzimuth = cfg.RotatorMaxAzimuth
+	if cfg.RotatorFlip {
+		rcfg.MaxElevation, rcfg.Flip = 180, true
+	}
+	return rcfg
+}
+
+// newRigTuner создаёт доплеровскую подстройку радиостанции по модельному
+// времени clock.
+func newRigTuner(cfg *config.Config, tracker *tracking.Tracker, clock *simclock.Clock) (*rig.Tuner, error) {
+	rcfg := rig.Config{
+		Interval:  time.Duration(cfg.RigUpdateSeconds * float64(time.Second)),
+		Tolerance: cfg.RigToleranceHz,
+	}
+	return rig.NewTuner(rig.NewClient(cfg.RigAddr), tracker, clock.Now, rcfg)
+}
+
+// newSIDSUploader создаёт отправку кадров в SatNOGS DB от имени станции
+// home, к которой подключён приёмник.
+func newSIDSUploader(cfg *config.Config, home station.Station) (*sids.Uploader, error) {
+	rx := sids.Station{Callsign: cfg.SatNOGSCallsign, Latitude: home.Latitude, Longitude: home.Longitude}
+	opts := sids.DefaultOptions()
+	opts.Interval = time.Duration(cfg.SatNOGSIntervalSeconds * float64(time.Second))
+	return sids.NewUploader(sids.NewClient(cfg.SatNOGSURL, nil), rx, cfg.SatNOGSQueue, opts)
+}
+
+// newScheduler создаёт расписание автоматического приёма пролётов
+// по модельному времени clock; план учитывает скорости поворотного
+// устройства.
+func newScheduler(cfg *config.Config, store catalog.Store, predictor *pass.Predictor, devices schedule.Devices, clock *simclock.Clock) (*schedule.Scheduler, error) {
+	scfg := schedule.DefaultConfig()
+	scfg.Lead = time.Duration(cfg.ScheduleLeadSeconds * float64(time.Second))
+	scfg.Rotator = rotatorConfig(cfg)
+	return schedule.NewScheduler(store, predictor, devices, cfg.SchedulePath, clock.Now, scfg)
+}
+
+// newReceiver создаёт приёмник SDR с водопадом; в цифровых режимах
+// принятые кадры AX.25 передаются handle. Блоки отсчётов получают также
+// обработчики extra.
+func newReceiver(cfg *config.Config, handle func(receiver.Packet), extra ...receiver.Sink) (*receiver.Receiver, *receiver.Waterfall, error) {
+	mode, err := demod.ParseMode(cfg.SDRMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wcfg := receiver.DefaultWaterfallConfig()
+	wcfg.FFTSize = cfg.SDRFFTSize
+	wcfg.FrameRate = cfg.SDRWaterfallFPS
+	waterfall, err := receiver.NewWaterfall(wcfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sinks := []receiver.Sink{waterfall}
+	if mode.Digital() {
+		packets, err := receiver.NewPacketDecoder(mode, handle)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, packets)
+	}
+	sinks = append(sinks, extra...)
+	return receiver.NewReceiver(newSDROpener(cfg), receiver.DefaultConfig(), sinks...), waterfall, nil
+}
+
+// newSDROpener возвращает открытие источника отсчётов: сервера rtl_tcp
+// для адреса вида "rtl_tcp://host:port" или записи, воспроизводимой
+// по кругу в реальном времени, для пути к файлу.
+func newSDROpener(cfg *config.Config) receiver.Opener {
+	if addr, ok := strings.CutPrefix(cfg.SDRSource, "rtl_tcp://"); ok {
+		rtl := sdr.RTLConfig{
+			Frequency:  cfg.SDRFrequencyHz,
+			SampleRate: cfg.SDRSampleRate,
+			Gain:       cfg.SDRGain,
+		}
+		return func(ctx context.Context) (sdr.IQSource, error) {
+			return sdr.DialRTLTCP(ctx, addr, rtl)
+		}
+	}
+
+	opts := sdr.FileOptions{
+		SampleRate: cfg.SDRSampleRate,
+		Frequency:  cfg.SDRFrequencyHz,
+		Loop:       true,
+		Realtime:   true,
+	}
+	return func(context.Context) (sdr.IQSource, error) {
+		return sdr.OpenFile(cfg.SDRSource, opts)
+	}
+}
+
 // loggingMiddleware логирует HTTP запросы.
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -148,3 +652,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.status = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap открывает исходный writer для http.ResponseController
+// (Flush и управление тайм-аутами в потоках SSE).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
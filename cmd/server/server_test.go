@@ -1,9 +1,23 @@
 package main
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/config"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/receiver"
+	"github.com/art-injener/satwatch-go/internal/schedule"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/station"
 )
 
 func TestLoggingMiddleware(t *testing.T) {
@@ -115,8 +129,379 @@ func TestResponseWriter_MultipleWriteHeader(t *testing.T) {
 	}
 }
 
+func TestLoggingMiddleware_StreamOutlivesWriteTimeout(t *testing.T) {
+	// Обработчик потока снимает WriteTimeout через middleware и пишет дольше него.
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			t.Errorf("SetWriteDeadline through middleware: %v", err)
+			return
+		}
+		for range 5 {
+			if _, err := io.WriteString(w, "tick\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				t.Errorf("Flush through middleware: %v", err)
+				return
+			}
+			time.Sleep(40 * time.Millisecond)
+		}
+	})
+
+	srv := httptest.NewUnstartedServer(loggingMiddleware(stream))
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/stream/tracking")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("stream interrupted: %v", err)
+	}
+	if got := strings.Count(string(body), "tick"); got != 5 {
+		t.Errorf("Expected 5 ticks, got %d", got)
+	}
+}
+
 func TestSlogKeyError(t *testing.T) {
 	if slogKeyError != "error" {
 		t.Errorf("Expected slogKeyError to be 'error', got '%s'", slogKeyError)
 	}
 }
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  string
+		format string
+		want   string
+	}{
+		{"text debug", "debug", "text", `level=DEBUG msg=hidden`},
+		{"json info", "info", "json", `"level":"INFO","msg":"shown"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			logger := newLogger(&config.Config{LogLevel: tt.level, LogFormat: tt.format}, &buf)
+			logger.Debug("hidden")
+			logger.Info("shown")
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("log = %q, want %q", buf.String(), tt.want)
+			}
+			if tt.level == "info" && strings.Contains(buf.String(), "hidden") {
+				t.Errorf("log = %q, debug record is not filtered", buf.String())
+			}
+		})
+	}
+}
+
+func TestNewCatalogStore(t *testing.T) {
+	store, err := newCatalogStore("")
+	if err != nil {
+		t.Fatalf("newCatalogStore(\"\") error: %v", err)
+	}
+	if _, ok := store.(*catalog.MemoryStore); !ok {
+		t.Errorf("Expected *catalog.MemoryStore for empty path, got %T", store)
+	}
+
+	store, err = newCatalogStore(filepath.Join(t.TempDir(), "catalog.json"))
+	if err != nil {
+		t.Fatalf("newCatalogStore(path) error: %v", err)
+	}
+	if _, ok := store.(*catalog.FileStore); !ok {
+		t.Errorf("Expected *catalog.FileStore for file path, got %T", store)
+	}
+}
+
+func TestNewTLEFetcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		hours   float64
+		wantErr bool
+	}{
+		{"url", "https://celestrak.org/NORAD/elements/gp.php?GROUP=amateur&FORMAT=tle", 6, false},
+		{"directory", t.TempDir(), 1, false},
+		{"missing directory", filepath.Join(t.TempDir(), "missing"), 6, true},
+		{"interval too short", t.TempDir(), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{TLESource: tt.source, TLEUpdateHours: tt.hours}
+			_, err := newTLEFetcher(cfg, catalog.NewMemoryStore())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newTLEFetcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRotatorController(t *testing.T) {
+	tests := []struct {
+		name    string
+		parkAz  float64
+		azRate  float64
+		maxAz   float64
+		flip    bool
+		wantErr bool
+	}{
+		{"defaults", 0, 6, 360, false, false},
+		{"park to south", 180, 6, 360, false, false},
+		{"park outside limits", 400, 6, 360, false, true},
+		{"park in overlap", 400, 6, 450, false, false},
+		{"flip", 0, 6, 360, true, false},
+		{"zero slew rate", 0, 0, 360, false, true},
+		{"azimuth range too large", 0, 6, 800, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				RotatorAddr:          "localhost:4533",
+				RotatorParkAzimuth:   tt.parkAz,
+				RotatorAzimuthRate:   tt.azRate,
+				RotatorElevationRate: 3,
+				RotatorMaxAzimuth:    tt.maxAz,
+				RotatorFlip:          tt.flip,
+			}
+			_, err := newRotatorController(cfg, nil, simclock.New())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRotatorController() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRigTuner(t *testing.T) {
+	tests := []struct {
+		name      string
+		seconds   float64
+		tolerance float64
+		wantErr   bool
+	}{
+		{"defaults", 1, 10, false},
+		{"fast updates", 0.2, 1, false},
+		{"interval too short", 0.01, 10, true},
+		{"negative tolerance", 1, -5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{RigAddr: "localhost:4532", RigUpdateSeconds: tt.seconds, RigToleranceHz: tt.tolerance}
+			_, err := newRigTuner(cfg, nil, simclock.New())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRigTuner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewReceiver(t *testing.T) {
+	recording := filepath.Join(t.TempDir(), "pass.cu8")
+	if err := os.WriteFile(recording, []byte{127, 128, 127, 128}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		mode     string
+		fftSize  int
+		fps      float64
+		wantErr  bool
+		wantOpen bool
+	}{
+		{"recording", recording, "fsk", 1024, 10, false, true},
+		{"voice FM without packets", recording, "fm", 1024, 10, false, true},
+		{"missing recording", filepath.Join(t.TempDir(), "none.cu8"), "afsk", 1024, 10, false, false},
+		{"rtl_tcp unreachable", "rtl_tcp://127.0.0.1:1", "fsk", 1024, 10, false, false},
+		{"FFT size not a power of two", recording, "fsk", 1000, 10, true, false},
+		{"zero frame rate", recording, "fsk", 1024, 0, true, false},
+		{"unknown mode", recording, "bpsk", 1024, 10, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				SDRSource:       tt.source,
+				SDRSampleRate:   48000,
+				SDRFrequencyHz:  145.8e6,
+				SDRGain:         -1,
+				SDRFFTSize:      tt.fftSize,
+				SDRWaterfallFPS: tt.fps,
+				SDRMode:         tt.mode,
+			}
+			_, _, err := newReceiver(cfg, func(receiver.Packet) {})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newReceiver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			src, err := newSDROpener(cfg)(context.Background())
+			if (err == nil) != tt.wantOpen {
+				t.Fatalf("open source error = %v, want success %v", err, tt.wantOpen)
+			}
+			if err == nil {
+				if src.SampleRate() != 48000 || src.CenterFrequency() != 145.8e6 {
+					t.Errorf("source rate %v, frequency %v", src.SampleRate(), src.CenterFrequency())
+				}
+				src.Close()
+			}
+		})
+	}
+}
+
+func TestNewTelemetryRegistry(t *testing.T) {
+	valid := t.TempDir()
+	schema := `{"name":"beacon","fields":[{"name":"voltage","byte":0,"bits":16,"scale":0.001,"unit":"V"}]}`
+	if err := os.WriteFile(filepath.Join(valid, "beacon.json"), []byte(schema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	broken := t.TempDir()
+	if err := os.WriteFile(filepath.Join(broken, "broken.json"), []byte(`{"name":`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		want    int
+		wantErr bool
+	}{
+		{"disabled", "", 0, false},
+		{"missing directory", filepath.Join(valid, "none"), 0, false},
+		{"schema directory", valid, 1, false},
+		{"broken schema", broken, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := newTelemetryRegistry(tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTelemetryRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(registry.Names()) != tt.want {
+				t.Errorf("schemas = %v, want %d", registry.Names(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSIDSUploader(t *testing.T) {
+	tests := []struct {
+		name     string
+		callsign string
+		seconds  float64
+		wantErr  bool
+	}{
+		{"defaults", "R4UAB", 1, false},
+		{"no station callsign", "", 1, true},
+		{"zero interval", "R4UAB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				SatNOGSURL:             "http://localhost:8080/api/sids/telemetry/",
+				SatNOGSCallsign:        tt.callsign,
+				SatNOGSQueue:           filepath.Join(t.TempDir(), "queue.json"),
+				SatNOGSIntervalSeconds: tt.seconds,
+			}
+			home := station.Station{ID: "roof", Name: "Крыша", Latitude: 55.7558, Longitude: 37.6173}
+			_, err := newSIDSUploader(cfg, home)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newSIDSUploader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewScheduler(t *testing.T) {
+	tests := []struct {
+		name    string
+		lead    float64
+		azRate  float64
+		file    string
+		wantErr bool
+	}{
+		{"defaults", 120, 6, "", false},
+		{"no lead", 0, 6, "", false},
+		{"negative lead", -1, 6, "", true},
+		{"no rotator rate", 120, 0, "", true},
+		{"persisted jobs", 120, 6, `{"jobs":[{"id":"25544-20261016T120000Z","norad_id":25544,"state":"pending"}]}`, false},
+		{"corrupted file", 120, 6, `{"jobs":`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schedule.json")
+			if tt.file != "" {
+				if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := &config.Config{
+				SchedulePath:         path,
+				ScheduleLeadSeconds:  tt.lead,
+				RotatorAzimuthRate:   tt.azRate,
+				RotatorElevationRate: 3,
+				RotatorMaxAzimuth:    360,
+			}
+			_, err := newScheduler(cfg, catalog.NewMemoryStore(), nil, schedule.Devices{}, simclock.New())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newScheduler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewStationRegistry(t *testing.T) {
+	cfg := &config.Config{
+		ObserverName: "Ростов-на-Дону",
+		ObserverLat:  47.315813,
+		ObserverLon:  39.788243,
+		ObserverAlt:  70,
+		StationsPath: filepath.Join(t.TempDir(), "stations.json"),
+		RotatorAddr:  "localhost:4533",
+	}
+
+	// Без файла станций используется наблюдатель из конфигурации.
+	stations, err := newStationRegistry(cfg, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := stations.Active()
+	if home.ID != defaultStationID || home.Name != cfg.ObserverName || home.Latitude != cfg.ObserverLat {
+		t.Errorf("default station = %+v", home)
+	}
+
+	content := `{"stations": [{"id": "roof", "name": "Крыша", "lat": 55.76, "lon": 37.62,
+		"hardware": {"rig_addr": "localhost:4532", "sdr_source": "rtl_tcp://localhost:1234"}}]}`
+	if err := os.WriteFile(cfg.StationsPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stations, err = newStationRegistry(cfg, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	home = stations.Active()
+	if home.ID != "roof" {
+		t.Fatalf("active station = %q, want roof", home.ID)
+	}
+
+	// Адреса из профиля заменяют конфигурацию, незаданные остаются.
+	useStationHardware(cfg, stations)
+	if cfg.RotatorAddr != "localhost:4533" || cfg.RigAddr != "localhost:4532" || cfg.SDRSource != "rtl_tcp://localhost:1234" {
+		t.Errorf("hardware = %q, %q, %q", cfg.RotatorAddr, cfg.RigAddr, cfg.SDRSource)
+	}
+}
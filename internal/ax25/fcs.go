@@ -0,0 +1,19 @@
+package ax25
+
+// FCS вычисляет контрольную сумму кадра CRC-16-CCITT в варианте X.25:
+// отражённый полином 0x8408, начальное значение 0xFFFF, инверсия
+// результата. В эфире передаётся младшим байтом вперёд.
+func FCS(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for range 8 {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return ^crc
+}
@@ -0,0 +1,18 @@
+package ax25
+
+import "testing"
+
+func TestFCS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint16
+	}{
+		{in: "123456789", want: 0x906E},
+		{in: "", want: 0x0000},
+	}
+	for _, tt := range tests {
+		if got := FCS([]byte(tt.in)); got != tt.want {
+			t.Errorf("FCS(%q) = %#04x, want %#04x", tt.in, got, tt.want)
+		}
+	}
+}
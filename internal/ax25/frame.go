@@ -0,0 +1,229 @@
+// Package ax25 разбирает кадры AX.25, которые передают большинство
+// CubeSat: выделение кадров HDLC из потока бит, проверка FCS, разбор
+// адресов и поля управления.
+package ax25
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Поля кадра.
+const (
+	addrLen     = 7
+	maxPath     = 8 // ретрансляторов в пути
+	callLen     = 6
+	controlUI   = 0x03
+	controlPF   = 0x10
+	pidNoLayer3 = 0xF0
+
+	addrLast     = 0x01 // бит расширения: последний адрес
+	addrReserved = 0x60
+	addrCH       = 0x80 // бит C (команда) или H (ретранслирован)
+)
+
+// Ошибки разбора кадров.
+var (
+	ErrFrameTooShort  = errors.New("ax25: frame is too short")
+	ErrInvalidAddress = errors.New("ax25: invalid address")
+)
+
+// Address — позывной со SSID.
+type Address struct {
+	Call string
+	SSID uint8
+	// Repeated — бит H адреса ретранслятора: кадр через него уже прошёл;
+	// для адресов получателя и отправителя — бит C.
+	Repeated bool
+}
+
+// ParseAddress разбирает позывной в виде "CALL" или "CALL-SSID".
+func ParseAddress(s string) (Address, error) {
+	call, ssid, found := strings.Cut(strings.ToUpper(s), "-")
+	var a Address
+	if found {
+		n, err := strconv.ParseUint(ssid, 10, 8)
+		if err != nil || n > 15 {
+			return a, fmt.Errorf("%w: SSID in %q", ErrInvalidAddress, s)
+		}
+		a.SSID = uint8(n)
+	}
+	if call == "" || len(call) > callLen || strings.IndexFunc(call, func(r rune) bool { return !validCallRune(r) }) >= 0 {
+		return a, fmt.Errorf("%w: %q", ErrInvalidAddress, s)
+	}
+	a.Call = call
+	return a, nil
+}
+
+// String возвращает адрес в виде "CALL" или "CALL-SSID".
+func (a Address) String() string {
+	if a.SSID == 0 {
+		return a.Call
+	}
+	return a.Call + "-" + strconv.Itoa(int(a.SSID))
+}
+
+// MarshalText кодирует адрес строкой для JSON.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func validCallRune(r rune) bool {
+	return r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// Frame — разобранный кадр AX.25 без FCS.
+type Frame struct {
+	Destination Address   `json:"destination"`
+	Source      Address   `json:"source"`
+	Path        []Address `json:"path,omitempty"`
+	Control     byte      `json:"control"`
+	// PID присутствует только в кадрах I и UI.
+	PID  byte   `json:"pid,omitempty"`
+	Info []byte `json:"info,omitempty"`
+}
+
+// Parse разбирает кадр: адреса, поле управления, PID и данные.
+func Parse(raw []byte) (Frame, error) {
+	var f Frame
+	var addrs []Address
+	pos := 0
+	for {
+		if len(raw) < pos+addrLen {
+			return f, fmt.Errorf("%w: %d bytes", ErrFrameTooShort, len(raw))
+		}
+		a, last, err := decodeAddress(raw[pos : pos+addrLen])
+		if err != nil {
+			return f, err
+		}
+		addrs = append(addrs, a)
+		pos += addrLen
+		if last {
+			break
+		}
+		if len(addrs) == 2+maxPath {
+			return f, fmt.Errorf("%w: more than %d digipeaters", ErrInvalidAddress, maxPath)
+		}
+	}
+	if len(addrs) < 2 {
+		return f, fmt.Errorf("%w: missing source address", ErrInvalidAddress)
+	}
+	if len(raw) < pos+1 {
+		return f, fmt.Errorf("%w: missing control field", ErrFrameTooShort)
+	}
+
+	f.Destination, f.Source = addrs[0], addrs[1]
+	f.Path = addrs[2:]
+	if len(f.Path) == 0 {
+		f.Path = nil
+	}
+	f.Control = raw[pos]
+	pos++
+	if f.hasPID() {
+		if len(raw) < pos+1 {
+			return f, fmt.Errorf("%w: missing PID", ErrFrameTooShort)
+		}
+		f.PID = raw[pos]
+		pos++
+	}
+	if pos < len(raw) {
+		f.Info = raw[pos:]
+	}
+	return f, nil
+}
+
+// decodeAddress разбирает поле адреса: символы сдвинуты на один разряд
+// влево, в последнем байте — SSID и служебные биты.
+func decodeAddress(b []byte) (Address, bool, error) {
+	var call [callLen]byte
+	for i := range call {
+		c := b[i]
+		if c&1 != 0 {
+			return Address{}, false, fmt.Errorf("%w: extension bit inside callsign", ErrInvalidAddress)
+		}
+		call[i] = c >> 1
+	}
+	a := Address{
+		Call:     strings.TrimRight(string(call[:]), " "),
+		SSID:     b[6] >> 1 & 0x0F,
+		Repeated: b[6]&addrCH != 0,
+	}
+	if a.Call == "" || strings.IndexFunc(a.Call, func(r rune) bool { return !validCallRune(r) }) >= 0 {
+		return Address{}, false, fmt.Errorf("%w: callsign %q", ErrInvalidAddress, string(call[:]))
+	}
+	return a, b[6]&addrLast != 0, nil
+}
+
+// hasPID сообщает, содержит ли кадр поле PID: кадры I и UI.
+func (f Frame) hasPID() bool {
+	return f.Control&0x01 == 0 || f.IsUI()
+}
+
+// IsUI сообщает, является ли кадр ненумерованным информационным (UI).
+func (f Frame) IsUI() bool {
+	return f.Control&^controlPF == controlUI
+}
+
+// Encode кодирует кадр в байты без FCS.
+func (f Frame) Encode() []byte {
+	addrs := append([]Address{f.Destination, f.Source}, f.Path...)
+	out := make([]byte, 0, len(addrs)*addrLen+2+len(f.Info))
+	for i, a := range addrs {
+		call := fmt.Sprintf("%-6s", a.Call)
+		for j := range callLen {
+			out = append(out, call[j]<<1)
+		}
+		last := addrReserved | a.SSID<<1
+		if a.Repeated {
+			last |= addrCH
+		}
+		if i == len(addrs)-1 {
+			last |= addrLast
+		}
+		out = append(out, last)
+	}
+	out = append(out, f.Control)
+	if f.hasPID() {
+		out = append(out, f.PID)
+	}
+	return append(out, f.Info...)
+}
+
+// NewUI создаёт кадр UI без протокола третьего уровня.
+func NewUI(dst, src Address, info []byte, path ...Address) Frame {
+	return Frame{
+		Destination: dst,
+		Source:      src,
+		Path:        path,
+		Control:     controlUI,
+		PID:         pidNoLayer3,
+		Info:        info,
+	}
+}
+
+// String возвращает кадр в текстовом формате TNC2:
+// "SRC>DST,DIGI*:данные"; непечатаемые байты данных — в виде <0xNN>.
+func (f Frame) String() string {
+	var sb strings.Builder
+	sb.WriteString(f.Source.String())
+	sb.WriteByte('>')
+	sb.WriteString(f.Destination.String())
+	for _, a := range f.Path {
+		sb.WriteByte(',')
+		sb.WriteString(a.String())
+		if a.Repeated {
+			sb.WriteByte('*')
+		}
+	}
+	sb.WriteByte(':')
+	for _, c := range f.Info {
+		if c >= 0x20 && c < 0x7F {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "<0x%02x>", c)
+		}
+	}
+	return sb.String()
+}
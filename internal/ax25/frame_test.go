@@ -0,0 +1,86 @@
+package ax25
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Address
+		wantErr bool
+	}{
+		{in: "RS40S", want: Address{Call: "RS40S"}},
+		{in: "r4uab-15", want: Address{Call: "R4UAB", SSID: 15}},
+		{in: "R4UAB-16", wantErr: true},
+		{in: "TOOLONG", wantErr: true},
+		{in: "R4_AB", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAddress(%q) = %+v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestFrame_RoundTrip(t *testing.T) {
+	dst := Address{Call: "CQ"}
+	src := Address{Call: "RS40S", SSID: 1}
+	digi := Address{Call: "WIDE2", SSID: 1, Repeated: true}
+	want := NewUI(dst, src, []byte("T#001\r"), digi)
+
+	raw := want.Encode()
+	// Позывной дополняется пробелами, символы сдвинуты на разряд.
+	if !bytes.Equal(raw[:7], []byte{'C' << 1, 'Q' << 1, ' ' << 1, ' ' << 1, ' ' << 1, ' ' << 1, 0x60}) {
+		t.Errorf("destination field = %x", raw[:7])
+	}
+
+	got, err := Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "RS40S-1>CQ,WIDE2-1*:T#001<0x0d>" {
+		t.Errorf("String() = %q", got.String())
+	}
+	if !got.IsUI() || got.PID != pidNoLayer3 || len(got.Path) != 1 || got.Path[0] != digi {
+		t.Errorf("Parse() = %+v", got)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"source":"RS40S-1"`)) {
+		t.Errorf("JSON = %s", data)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	valid := NewUI(Address{Call: "CQ"}, Address{Call: "RS40S"}, nil).Encode()
+
+	single := append([]byte(nil), valid[:7]...)
+	single[6] |= addrLast
+	badCall := append([]byte(nil), valid...)
+	badCall[0] = '_' << 1
+
+	tests := []struct {
+		name string
+		raw  []byte
+		want error
+	}{
+		{name: "truncated address", raw: valid[:10], want: ErrFrameTooShort},
+		{name: "no control field", raw: valid[:14], want: ErrFrameTooShort},
+		{name: "single address", raw: append(single, controlUI), want: ErrInvalidAddress},
+		{name: "invalid callsign", raw: badCall, want: ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.raw); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Parse() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ax25
+
+// Ограничения длины кадра HDLC с контрольной суммой: два адреса,
+// поле управления и FCS — минимум; максимум с запасом покрывает
+// N1 = 256 и кадры спутников с увеличенным полем данных.
+const (
+	minFrameLen = 2*addrLen + 1 + 2
+	maxFrameLen = 2048
+)
+
+// Deframer выделяет кадры HDLC из потока бит: находит флаги 0x7E,
+// удаляет вставленные нули, проверяет FCS. Биты подаются по одному
+// в байте (0 или 1) после декодирования NRZI, как их выдают
+// демодуляторы пакета demod. Нулевое значение готово к работе.
+type Deframer struct {
+	ones    int // подряд принятых единиц
+	inFrame bool
+	cur     byte
+	nbits   int
+	buf     []byte
+}
+
+// Process дописывает к dst кадры с верной контрольной суммой, завершённые
+// в bits; FCS из кадров удаляется. Состояние сохраняется между вызовами.
+func (d *Deframer) Process(dst [][]byte, bits []byte) [][]byte {
+	for _, bit := range bits {
+		if bit != 0 {
+			d.ones++
+			if d.ones > 6 {
+				// Семь единиц подряд — прерывание кадра или простой линии.
+				d.inFrame = false
+				continue
+			}
+			d.push(1)
+			continue
+		}
+
+		switch d.ones {
+		case 6:
+			// Флаг: шесть единиц флага и предшествующий ноль уже
+			// записаны в кадр, данные кончаются на границе байта перед ними.
+			if d.inFrame && d.nbits == 7 {
+				dst = d.emit(dst)
+			}
+			d.inFrame = true
+			d.buf, d.cur, d.nbits = d.buf[:0], 0, 0
+		case 5:
+			// Вставленный передатчиком ноль.
+		default:
+			d.push(0)
+		}
+		d.ones = 0
+	}
+	return dst
+}
+
+// push добавляет бит данных, младшим разрядом вперёд.
+func (d *Deframer) push(bit byte) {
+	if !d.inFrame {
+		return
+	}
+	d.cur |= bit << d.nbits
+	if d.nbits++; d.nbits < 8 {
+		return
+	}
+	d.buf = append(d.buf, d.cur)
+	d.cur, d.nbits = 0, 0
+	if len(d.buf) > maxFrameLen {
+		d.inFrame = false
+	}
+}
+
+// emit проверяет контрольную сумму накопленного кадра.
+func (d *Deframer) emit(dst [][]byte) [][]byte {
+	n := len(d.buf)
+	if n < minFrameLen {
+		return dst
+	}
+	data := d.buf[:n-2]
+	if FCS(data) != uint16(d.buf[n-2])|uint16(d.buf[n-1])<<8 {
+		return dst
+	}
+	return append(dst, append([]byte(nil), data...))
+}
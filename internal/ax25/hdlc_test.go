@@ -0,0 +1,79 @@
+package ax25
+
+import (
+	"bytes"
+	"testing"
+)
+
+// hdlcBits кодирует кадры в поток бит HDLC: флаги между кадрами, FCS,
+// вставка нулей после пяти единиц, младший разряд вперёд.
+func hdlcBits(frames ...[]byte) []byte {
+	flag := func(bits []byte) []byte {
+		for i := range 8 {
+			bits = append(bits, 0x7E>>i&1)
+		}
+		return bits
+	}
+
+	bits := flag(flag(nil))
+	for _, f := range frames {
+		fcs := FCS(f)
+		data := append(append([]byte(nil), f...), byte(fcs), byte(fcs>>8))
+		ones := 0
+		for _, b := range data {
+			for i := range 8 {
+				bit := b >> i & 1
+				bits = append(bits, bit)
+				if bit == 0 {
+					ones = 0
+					continue
+				}
+				if ones++; ones == 5 {
+					bits = append(bits, 0)
+					ones = 0
+				}
+			}
+		}
+		bits = flag(bits)
+	}
+	return flag(bits)
+}
+
+func testFrame(t *testing.T, info string) []byte {
+	t.Helper()
+	dst, _ := ParseAddress("CQ")
+	src, _ := ParseAddress("RS40S-1")
+	// Данные с длинными сериями единиц проверяют вставку нулей.
+	return NewUI(dst, src, append([]byte(info), 0xFF, 0x7E, 0xFE)).Encode()
+}
+
+func TestDeframer(t *testing.T) {
+	first := testFrame(t, "hello")
+	second := testFrame(t, "world")
+	corrupt := hdlcBits(testFrame(t, "broken"))
+	corrupt[40] ^= 1
+
+	bits := append(hdlcBits(first), corrupt...)
+	// Прерывание кадра: семь единиц подряд посреди данных.
+	aborted := hdlcBits(testFrame(t, "aborted"))
+	aborted = append(aborted[:60], 1, 1, 1, 1, 1, 1, 1, 1)
+	bits = append(bits, aborted...)
+	bits = append(bits, hdlcBits(second)...)
+
+	var d Deframer
+	var got [][]byte
+	// Подача по одному биту проверяет сохранение состояния.
+	for i := range bits {
+		got = d.Process(got, bits[i:i+1])
+	}
+	if len(got) != 2 || !bytes.Equal(got[0], first) || !bytes.Equal(got[1], second) {
+		t.Fatalf("frames = %x, want %x and %x", got, first, second)
+	}
+}
+
+func TestDeframer_Short(t *testing.T) {
+	var d Deframer
+	if got := d.Process(nil, hdlcBits([]byte("short"))); len(got) != 0 {
+		t.Errorf("frames = %x, want none", got)
+	}
+}
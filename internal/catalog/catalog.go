@@ -0,0 +1,130 @@
+// Package catalog хранит каталог отслеживаемых спутников: идентификаторы,
+// историю орбитальных элементов и параметры радиолиний.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// Ошибки каталога.
+var (
+	ErrNotFound = errors.New("satellite not found")
+	ErrExists   = errors.New("satellite already exists")
+	ErrInvalid  = errors.New("invalid satellite")
+)
+
+// MaxPriority — наибольший приоритет спутника в расписании.
+const MaxPriority = 10
+
+// Satellite описывает спутник в каталоге.
+type Satellite struct {
+	NoradID    int              `json:"norad_id"`
+	Name       string           `json:"name"`
+	Downlink   float64          `json:"downlink_mhz,omitempty"` // MHz
+	Uplink     float64          `json:"uplink_mhz,omitempty"`   // MHz
+	Modulation string           `json:"modulation,omitempty"`
+	TLEHistory []tle.ElementSet `json:"tle_history,omitempty"` // по возрастанию эпохи
+
+	// Callsign — позывной в кадрах AX.25 (без SSID — любой SSID),
+	// TelemetrySchema — имя схемы декодирования телеметрии.
+	Callsign        string `json:"callsign,omitempty"`
+	TelemetrySchema string `json:"telemetry_schema,omitempty"`
+
+	// SatNOGSUpload разрешает отправку принятых кадров в SatNOGS DB.
+	SatNOGSUpload bool `json:"satnogs_upload,omitempty"`
+
+	// Schedule включает автоматический приём пролётов по расписанию,
+	// Priority — приоритет при пересечении пролётов (больше — важнее),
+	// MinElevation — наименьший полезный угол места (°; 0 — маска
+	// прогноза), MinContactSeconds — наименьшая длительность контакта
+	// выше MinElevation.
+	Schedule          bool    `json:"schedule,omitempty"`
+	Priority          int     `json:"priority,omitempty"`
+	MinElevation      float64 `json:"min_elevation,omitempty"`
+	MinContactSeconds float64 `json:"min_contact_seconds,omitempty"`
+}
+
+// LatestTLE возвращает самый свежий набор элементов.
+func (s *Satellite) LatestTLE() (tle.ElementSet, bool) {
+	if len(s.TLEHistory) == 0 {
+		return tle.ElementSet{}, false
+	}
+	return s.TLEHistory[len(s.TLEHistory)-1], true
+}
+
+// Validate проверяет обязательные поля спутника.
+func (s *Satellite) Validate() error {
+	switch {
+	case s.NoradID <= 0:
+		return fieldError("norad_id must be positive")
+	case strings.TrimSpace(s.Name) == "":
+		return fieldError("name is required")
+	case s.Downlink < 0 || s.Uplink < 0:
+		return fieldError("frequencies must not be negative")
+	case s.Priority < 0 || s.Priority > MaxPriority:
+		return fieldError(fmt.Sprintf("priority must be in [0, %d]", MaxPriority))
+	case s.MinElevation < 0 || s.MinElevation >= 90:
+		return fieldError("min_elevation must be in [0, 90)")
+	case s.MinContactSeconds < 0:
+		return fieldError("min_contact_seconds must not be negative")
+	}
+	for _, set := range s.TLEHistory {
+		if set.NoradID != s.NoradID {
+			return fieldError("element set belongs to another satellite")
+		}
+	}
+	return nil
+}
+
+// sortHistory упорядочивает историю элементов по эпохе.
+func (s *Satellite) sortHistory() {
+	slices.SortStableFunc(s.TLEHistory, func(a, b tle.ElementSet) int {
+		return a.Epoch.Compare(b.Epoch)
+	})
+}
+
+// clone возвращает копию спутника, не разделяющую историю с оригиналом.
+func (s *Satellite) clone() Satellite {
+	c := *s
+	c.TLEHistory = slices.Clone(s.TLEHistory)
+	return c
+}
+
+// Store — хранилище каталога спутников.
+type Store interface {
+	// List возвращает все спутники, упорядоченные по номеру NORAD.
+	List() ([]Satellite, error)
+	// Get возвращает спутник по номеру NORAD.
+	Get(noradID int) (Satellite, error)
+	// Create добавляет новый спутник.
+	Create(sat Satellite) error
+	// Update заменяет существующий спутник.
+	Update(sat Satellite) error
+	// Modify атомарно изменяет существующий спутник функцией apply и
+	// возвращает результат. Ошибка apply отменяет изменение.
+	Modify(noradID int, apply func(sat *Satellite) error) (Satellite, error)
+	// Delete удаляет спутник.
+	Delete(noradID int) error
+}
+
+func fieldError(msg string) error {
+	return &ValidationError{Msg: msg}
+}
+
+// ValidationError описывает нарушение ограничений на данные спутника.
+type ValidationError struct {
+	Msg string
+}
+
+func (e *ValidationError) Error() string {
+	return "catalog: " + e.Msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalid
+}
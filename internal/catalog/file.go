@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+
+	"github.com/art-injener/satwatch-go/internal/atomicfile"
+)
+
+// FileStore хранит каталог в JSON-файле. Все изменения сразу записываются
+// на диск атомарно (через временный файл и переименование).
+type FileStore struct {
+	mem  *MemoryStore
+	path string
+}
+
+// fileFormat — содержимое файла каталога.
+type fileFormat struct {
+	Satellites []Satellite `json:"satellites"`
+}
+
+// NewFileStore открывает каталог в файле path. Если файла нет,
+// каталог создаётся пустым при первой записи.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		mem:  NewMemoryStore(),
+		path: path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode catalog %s: %w", path, err)
+	}
+	for _, sat := range f.Satellites {
+		if err := s.mem.Create(sat); err != nil {
+			return nil, fmt.Errorf("load satellite %d: %w", sat.NoradID, err)
+		}
+	}
+
+	return s, nil
+}
+
+// List возвращает все спутники, упорядоченные по номеру NORAD.
+func (s *FileStore) List() ([]Satellite, error) {
+	return s.mem.List()
+}
+
+// Get возвращает спутник по номеру NORAD.
+func (s *FileStore) Get(noradID int) (Satellite, error) {
+	return s.mem.Get(noradID)
+}
+
+// Create добавляет новый спутник и сохраняет каталог.
+func (s *FileStore) Create(sat Satellite) error {
+	if err := sat.Validate(); err != nil {
+		return err
+	}
+	return s.modify(func(m *MemoryStore) error {
+		if _, ok := m.sats[sat.NoradID]; ok {
+			return ErrExists
+		}
+		m.put(sat)
+		return nil
+	})
+}
+
+// Update заменяет существующий спутник и сохраняет каталог.
+func (s *FileStore) Update(sat Satellite) error {
+	if err := sat.Validate(); err != nil {
+		return err
+	}
+	return s.modify(func(m *MemoryStore) error {
+		if _, ok := m.sats[sat.NoradID]; !ok {
+			return ErrNotFound
+		}
+		m.put(sat)
+		return nil
+	})
+}
+
+// Modify атомарно изменяет существующий спутник и сохраняет каталог.
+func (s *FileStore) Modify(noradID int, apply func(sat *Satellite) error) (Satellite, error) {
+	var sat Satellite
+	err := s.modify(func(m *MemoryStore) error {
+		var err error
+		sat, err = m.modify(noradID, apply)
+		return err
+	})
+	if err != nil {
+		return Satellite{}, err
+	}
+	return sat, nil
+}
+
+// Delete удаляет спутник и сохраняет каталог.
+func (s *FileStore) Delete(noradID int) error {
+	return s.modify(func(m *MemoryStore) error {
+		if _, ok := m.sats[noradID]; !ok {
+			return ErrNotFound
+		}
+		delete(m.sats, noradID)
+		return nil
+	})
+}
+
+// modify применяет изменение под блокировкой и записывает файл.
+// При ошибке записи изменение в памяти откатывается.
+func (s *FileStore) modify(apply func(m *MemoryStore) error) error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	backup := maps.Clone(s.mem.sats)
+
+	if err := apply(s.mem); err != nil {
+		return err
+	}
+	if err := s.save(s.mem.snapshot()); err != nil {
+		s.mem.sats = backup
+		return err
+	}
+	return nil
+}
+
+// save атомарно записывает каталог на диск.
+func (s *FileStore) save(sats []Satellite) error {
+	data, err := json.MarshalIndent(fileFormat{Satellites: sats}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode catalog: %w", err)
+	}
+	if err := atomicfile.WriteFile(s.path, data); err != nil {
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "catalog.json")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error: %v", err)
+	}
+	if err := store.Create(testSatellite()); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if err := store.Create(Satellite{NoradID: 43017, Name: "FOX-1B"}); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if err := store.Delete(43017); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := store.Modify(25544, func(sat *Satellite) error {
+		sat.Callsign = "RS0ISS"
+		return nil
+	}); err != nil {
+		t.Fatalf("Modify() error: %v", err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen error: %v", err)
+	}
+	list, err := reopened.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].NoradID != 25544 || list[0].Modulation != "fm" || list[0].Callsign != "RS0ISS" {
+		t.Errorf("reopened catalog = %+v, want only ISS", list)
+	}
+}
+
+func TestFileStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected error for malformed catalog file")
+	}
+}
+
+func TestFileStore_RollbackOnWriteError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "catalog.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Каталог на месте файла делает запись невозможной.
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(testSatellite()); err == nil {
+		t.Fatal("expected write error")
+	}
+	if _, err := store.Get(25544); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after failed write error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStore_ImplementsStore(t *testing.T) {
+	var _ Store = (*FileStore)(nil)
+	var _ Store = (*MemoryStore)(nil)
+}
@@ -0,0 +1,133 @@
+package catalog
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// MemoryStore хранит каталог в памяти. Безопасен для конкурентного доступа.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	sats map[int]Satellite
+}
+
+// NewMemoryStore создаёт пустое хранилище в памяти.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sats: make(map[int]Satellite),
+	}
+}
+
+// List возвращает все спутники, упорядоченные по номеру NORAD.
+func (m *MemoryStore) List() ([]Satellite, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.snapshot(), nil
+}
+
+// Get возвращает спутник по номеру NORAD.
+func (m *MemoryStore) Get(noradID int) (Satellite, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sat, ok := m.sats[noradID]
+	if !ok {
+		return Satellite{}, ErrNotFound
+	}
+	return sat.clone(), nil
+}
+
+// Create добавляет новый спутник.
+func (m *MemoryStore) Create(sat Satellite) error {
+	if err := sat.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sats[sat.NoradID]; ok {
+		return ErrExists
+	}
+	m.put(sat)
+	return nil
+}
+
+// Update заменяет существующий спутник.
+func (m *MemoryStore) Update(sat Satellite) error {
+	if err := sat.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sats[sat.NoradID]; !ok {
+		return ErrNotFound
+	}
+	m.put(sat)
+	return nil
+}
+
+// Modify атомарно изменяет существующий спутник.
+func (m *MemoryStore) Modify(noradID int, apply func(sat *Satellite) error) (Satellite, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.modify(noradID, apply)
+}
+
+// Delete удаляет спутник.
+func (m *MemoryStore) Delete(noradID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sats[noradID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.sats, noradID)
+	return nil
+}
+
+// modify применяет apply к копии спутника и сохраняет её.
+// Вызывается под блокировкой записи.
+func (m *MemoryStore) modify(noradID int, apply func(sat *Satellite) error) (Satellite, error) {
+	cur, ok := m.sats[noradID]
+	if !ok {
+		return Satellite{}, ErrNotFound
+	}
+	sat := cur.clone()
+	if err := apply(&sat); err != nil {
+		return Satellite{}, err
+	}
+	if sat.NoradID != noradID {
+		return Satellite{}, fieldError("norad_id cannot be changed")
+	}
+	if err := sat.Validate(); err != nil {
+		return Satellite{}, err
+	}
+	m.put(sat)
+	stored := m.sats[noradID]
+	return stored.clone(), nil
+}
+
+// put сохраняет копию спутника. Вызывается под блокировкой записи.
+func (m *MemoryStore) put(sat Satellite) {
+	c := sat.clone()
+	c.sortHistory()
+	m.sats[c.NoradID] = c
+}
+
+// snapshot возвращает копию каталога. Вызывается под блокировкой.
+func (m *MemoryStore) snapshot() []Satellite {
+	list := make([]Satellite, 0, len(m.sats))
+	for _, sat := range m.sats {
+		list = append(list, sat.clone())
+	}
+	slices.SortFunc(list, func(a, b Satellite) int {
+		return cmp.Compare(a.NoradID, b.NoradID)
+	})
+	return list
+}
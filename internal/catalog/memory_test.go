@@ -0,0 +1,220 @@
+package catalog
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+func testSatellite() Satellite {
+	return Satellite{
+		NoradID:    25544,
+		Name:       "ISS (ZARYA)",
+		Downlink:   145.800,
+		Uplink:     145.990,
+		Modulation: "fm",
+	}
+}
+
+func TestMemoryStore_CRUD(t *testing.T) {
+	store := NewMemoryStore()
+	sat := testSatellite()
+
+	if err := store.Create(sat); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if err := store.Create(sat); !errors.Is(err, ErrExists) {
+		t.Errorf("second Create() error = %v, want ErrExists", err)
+	}
+
+	got, err := store.Get(sat.NoradID)
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if got.Name != sat.Name || got.Downlink != sat.Downlink {
+		t.Errorf("Get() = %+v, want %+v", got, sat)
+	}
+
+	sat.Name = "ISS"
+	if err := store.Update(sat); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	got, _ = store.Get(sat.NoradID)
+	if got.Name != "ISS" {
+		t.Errorf("Name after update = %q, want ISS", got.Name)
+	}
+
+	if err := store.Update(Satellite{NoradID: 1, Name: "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() of missing satellite error = %v, want ErrNotFound", err)
+	}
+
+	if err := store.Delete(sat.NoradID); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := store.Get(sat.NoradID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(sat.NoradID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStore_Modify(t *testing.T) {
+	store := NewMemoryStore()
+	if err := store.Create(testSatellite()); err != nil {
+		t.Fatal(err)
+	}
+	errAbort := errors.New("abort")
+
+	tests := []struct {
+		name     string
+		id       int
+		apply    func(sat *Satellite) error
+		wantErr  error
+		wantName string
+	}{
+		{"rename", 25544, func(sat *Satellite) error { sat.Name = "ISS"; return nil }, nil, "ISS"},
+		{"apply error", 25544, func(sat *Satellite) error { sat.Name = "lost"; return errAbort }, errAbort, "ISS"},
+		{"invalid result", 25544, func(sat *Satellite) error { sat.Name = ""; return nil }, ErrInvalid, "ISS"},
+		{"norad id changed", 25544, func(sat *Satellite) error { sat.NoradID = 1; return nil }, ErrInvalid, "ISS"},
+		{"missing", 1, func(*Satellite) error { return nil }, ErrNotFound, "ISS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Modify(tt.id, tt.apply)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Modify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name != tt.wantName {
+				t.Errorf("Modify() = %+v, want name %q", got, tt.wantName)
+			}
+			if stored, _ := store.Get(25544); stored.Name != tt.wantName {
+				t.Errorf("stored name = %q, want %q", stored.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_ModifyConcurrent(t *testing.T) {
+	store := NewMemoryStore()
+	if err := store.Create(testSatellite()); err != nil {
+		t.Fatal(err)
+	}
+	epoch := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Каждое изменение видит все предыдущие: ни один набор не теряется.
+	const n = 50
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.Modify(25544, func(sat *Satellite) error {
+				sat.TLEHistory = append(sat.TLEHistory, tle.ElementSet{NoradID: 25544, Epoch: epoch.Add(time.Duration(i) * time.Hour)})
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sat, _ := store.Get(25544)
+	if len(sat.TLEHistory) != n {
+		t.Errorf("history has %d sets, want %d", len(sat.TLEHistory), n)
+	}
+}
+
+func TestMemoryStore_ListSorted(t *testing.T) {
+	store := NewMemoryStore()
+	for _, id := range []int{43017, 25544, 40074} {
+		if err := store.Create(Satellite{NoradID: id, Name: "sat"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{25544, 40074, 43017}
+	for i, sat := range list {
+		if sat.NoradID != want[i] {
+			t.Errorf("List()[%d] = %d, want %d", i, sat.NoradID, want[i])
+		}
+	}
+}
+
+func TestMemoryStore_Isolation(t *testing.T) {
+	store := NewMemoryStore()
+	sat := testSatellite()
+	sat.TLEHistory = []tle.ElementSet{{NoradID: sat.NoradID, MeanMotion: 15.5}}
+	if err := store.Create(sat); err != nil {
+		t.Fatal(err)
+	}
+
+	// Изменение исходного значения не должно влиять на хранилище.
+	sat.TLEHistory[0].MeanMotion = 1
+	got, _ := store.Get(sat.NoradID)
+	if got.TLEHistory[0].MeanMotion != 15.5 {
+		t.Error("store shares TLE history with caller")
+	}
+}
+
+func TestSatellite_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sat     Satellite
+		wantErr bool
+	}{
+		{"valid", testSatellite(), false},
+		{"zero norad id", Satellite{Name: "x"}, true},
+		{"empty name", Satellite{NoradID: 1, Name: "  "}, true},
+		{"negative frequency", Satellite{NoradID: 1, Name: "x", Downlink: -1}, true},
+		{"foreign tle", Satellite{NoradID: 1, Name: "x", TLEHistory: []tle.ElementSet{{NoradID: 2}}}, true},
+		{"schedule settings", Satellite{NoradID: 1, Name: "x", Priority: MaxPriority, MinElevation: 20, MinContactSeconds: 180}, false},
+		{"negative priority", Satellite{NoradID: 1, Name: "x", Priority: -1}, true},
+		{"priority too high", Satellite{NoradID: 1, Name: "x", Priority: MaxPriority + 1}, true},
+		{"min elevation at zenith", Satellite{NoradID: 1, Name: "x", MinElevation: 90}, true},
+		{"negative contact", Satellite{NoradID: 1, Name: "x", MinContactSeconds: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sat.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestSatellite_LatestTLE(t *testing.T) {
+	store := NewMemoryStore()
+	sat := testSatellite()
+	epoch := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sat.TLEHistory = []tle.ElementSet{
+		{NoradID: sat.NoradID, Epoch: epoch.Add(24 * time.Hour), ElementSetNo: 2},
+		{NoradID: sat.NoradID, Epoch: epoch, ElementSetNo: 1},
+	}
+	if err := store.Create(sat); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := store.Get(sat.NoradID)
+	latest, ok := got.LatestTLE()
+	if !ok || latest.ElementSetNo != 2 {
+		t.Errorf("LatestTLE() = %+v, %v; want element set 2", latest, ok)
+	}
+
+	if _, ok := (&Satellite{}).LatestTLE(); ok {
+		t.Error("LatestTLE() on empty history returned ok")
+	}
+}
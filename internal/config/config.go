@@ -1,21 +1,130 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
-	"strconv"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
 )
 
 const (
 	// Координаты Ростова-на-Дону по умолчанию.
-	defaultObserverLat = 47.315813
-	defaultObserverLon = 39.788243
-	defaultObserverAlt = 70.0
+	defaultObserverLat  = 47.315813
+	defaultObserverLon  = 39.788243
+	defaultObserverAlt  = 70.0
+	defaultObserverName = "Ростов-на-Дону"
+
+	defaultCatalogPath  = "data/catalog.json"
+	defaultStationsPath = "data/stations.json"
+
+	// Параметры прогноза пролётов по умолчанию.
+	defaultPassMinElevation   = 0.0
+	defaultPassLookaheadHours = 24.0
+
+	// Период обновления TLE по умолчанию, часы.
+	defaultTLEUpdateHours = 6.0
+
+	// Скорости поворота антенны по умолчанию, °/с.
+	defaultRotatorAzimuthRate   = 6.0
+	defaultRotatorElevationRate = 3.0
+
+	// Верхний предел азимута поворотного устройства по умолчанию, градусы.
+	defaultRotatorMaxAzimuth = 360.0
+
+	// Доплеровская подстройка по умолчанию: период, секунды, и допуск, Гц.
+	defaultRigUpdateSeconds = 1.0
+	defaultRigToleranceHz   = 10.0
+
+	// Приёмник SDR по умолчанию: диапазон 2 м, 1,024 Мотсч/с,
+	// автоматическое усиление; водопад — БПФ 1024, 10 строк/с.
+	defaultSDRSampleRate   = 1.024e6
+	defaultSDRFrequencyHz  = 145.8e6
+	defaultSDRGain         = -1.0
+	defaultSDRFFTSize      = 1024
+	defaultSDRWaterfallFPS = 10.0
+	defaultSDRMode         = "fsk"
+
+	// Адрес сервера KISS TCP по умолчанию — порт Direwolf.
+	defaultKISSAddr = ":8001"
+
+	defaultTelemetrySchemas = "data/telemetry"
+	defaultTelemetryArchive = "data/telemetry-archive"
+
+	defaultSatNOGSQueue           = "data/satnogs-queue.json"
+	defaultSatNOGSIntervalSeconds = 1.0
+
+	defaultSchedulePath        = "data/schedule.json"
+	defaultScheduleLeadSeconds = 120.0
+	defaultRecordingsDir       = "data/recordings"
+
+	defaultLogLevel  = "debug"
+	defaultLogFormat = "text"
 
 	// Имена переменных окружения.
-	envPort        = "PORT"
-	envObserverLat = "OBSERVER_LAT"
-	envObserverLon = "OBSERVER_LON"
-	envObserverAlt = "OBSERVER_ALT"
+	envPort         = "PORT"
+	envObserverLat  = "OBSERVER_LAT"
+	envObserverLon  = "OBSERVER_LON"
+	envObserverAlt  = "OBSERVER_ALT"
+	envObserverName = "OBSERVER_NAME"
+	envCatalogPath  = "CATALOG_PATH"
+	envStationsPath = "STATIONS_PATH"
+
+	envPassMinElevation   = "PASS_MIN_ELEVATION"
+	envPassLookaheadHours = "PASS_LOOKAHEAD_HOURS"
+
+	envTLESource      = "TLE_SOURCE"
+	envTLEUpdateHours = "TLE_UPDATE_HOURS"
+	envTLEAddNew      = "TLE_ADD_NEW"
+
+	envRotatorAddr          = "ROTATOR_ADDR"
+	envRotatorParkAzimuth   = "ROTATOR_PARK_AZ"
+	envRotatorParkElevation = "ROTATOR_PARK_EL"
+	envRotatorAzimuthRate   = "ROTATOR_AZ_RATE"
+	envRotatorElevationRate = "ROTATOR_EL_RATE"
+	envRotatorMaxAzimuth    = "ROTATOR_MAX_AZ"
+	envRotatorFlip          = "ROTATOR_FLIP"
+
+	envRigAddr          = "RIG_ADDR"
+	envRigUpdateSeconds = "RIG_UPDATE_SECONDS"
+	envRigToleranceHz   = "RIG_TOLERANCE_HZ"
+
+	envSDRSource       = "SDR_SOURCE"
+	envSDRSampleRate   = "SDR_SAMPLE_RATE"
+	envSDRFrequencyHz  = "SDR_FREQUENCY_HZ"
+	envSDRGain         = "SDR_GAIN"
+	envSDRFFTSize      = "SDR_FFT_SIZE"
+	envSDRWaterfallFPS = "SDR_WATERFALL_FPS"
+	envSDRMode         = "SDR_MODE"
+	envKISSAddr        = "KISS_ADDR"
+
+	envTelemetrySchemas = "TELEMETRY_SCHEMAS"
+	envTelemetryArchive = "TELEMETRY_ARCHIVE"
+
+	envSatNOGSURL             = "SATNOGS_URL"
+	envSatNOGSCallsign        = "SATNOGS_CALLSIGN"
+	envSatNOGSQueue           = "SATNOGS_QUEUE"
+	envSatNOGSIntervalSeconds = "SATNOGS_INTERVAL_SECONDS"
+	envSatNOGSStandIn         = "SATNOGS_STANDIN"
+
+	envScheduleEnabled     = "SCHEDULE_ENABLED"
+	envSchedulePath        = "SCHEDULE_PATH"
+	envScheduleLeadSeconds = "SCHEDULE_LEAD_SECONDS"
+	envRecordingsDir       = "RECORDINGS_DIR"
+
+	envLogLevel  = "LOG_LEVEL"
+	envLogFormat = "LOG_FORMAT"
+)
+
+// EnvFile — переменная окружения с путём к файлу конфигурации.
+const EnvFile = "CONFIG_FILE"
+
+// Ошибки конфигурации.
+var (
+	ErrInvalidConfig = errors.New("config: invalid configuration")
+	ErrUnknownKey    = errors.New("unknown key")
+	ErrInvalidValue  = errors.New("invalid value")
 )
 
 // Config содержит конфигурацию приложения.
@@ -23,21 +132,171 @@ type Config struct {
 	// Настройки сервера
 	Port string
 
-	// Местоположение наблюдателя (по умолчанию: Ростов-на-Дону)
-	ObserverLat float64
-	ObserverLon float64
-	ObserverAlt float64 // метры над уровнем моря
+	// Местоположение наблюдателя (по умолчанию: Ростов-на-Дону) — станция,
+	// если файл станций не задан
+	ObserverLat  float64
+	ObserverLon  float64
+	ObserverAlt  float64 // метры над уровнем моря
+	ObserverName string
+
+	// Путь к файлу каталога спутников (пустая строка — хранение в памяти)
+	CatalogPath string
+
+	// Путь к файлу профилей станций с выбором активной (пустая строка
+	// или отсутствующий файл — единственная станция из Observer*);
+	// файл создаётся при первом изменении, пустая строка — хранение в памяти
+	StationsPath string
+
+	// Прогноз пролётов: маска по углу места (градусы) и окно поиска (часы)
+	PassMinElevation   float64
+	PassLookaheadHours float64
+
+	// Обновление TLE: URL в формате GP Celestrak или локальный каталог
+	// (пустая строка — обновление отключено), период в часах и разрешение
+	// добавлять спутники, которых нет в каталоге
+	TLESource      string
+	TLEUpdateHours float64
+	TLEAddNew      bool
+
+	// Поворотное устройство: адрес rotctld (пустая строка — управление
+	// отключено), положение стоянки и скорости поворота (°, °/с), верхний
+	// предел азимута (360° или 450° для устройств с перекрытием) и режим
+	// переворота с углом места до 180°
+	RotatorAddr          string
+	RotatorParkAzimuth   float64
+	RotatorParkElevation float64
+	RotatorAzimuthRate   float64
+	RotatorElevationRate float64
+	RotatorMaxAzimuth    float64
+	RotatorFlip          bool
+
+	// Радиостанция: адрес rigctld (пустая строка — подстройка отключена),
+	// период пересчёта доплеровской поправки (секунды) и допуск (Гц)
+	RigAddr          string
+	RigUpdateSeconds float64
+	RigToleranceHz   float64
+
+	// Приёмник SDR: источник отсчётов — "rtl_tcp://host:port" или путь
+	// к записи cu8/cs16/cf32/SigMF (пустая строка — приёмник отключён),
+	// частота дискретизации (отсчётов/с; для записей без метаданных),
+	// частота (Гц), усиление (дБ, отрицательное — автоматическое), размер
+	// БПФ, частота строк водопада и режим демодуляции (fsk, afsk, fm)
+	SDRSource       string
+	SDRSampleRate   float64
+	SDRFrequencyHz  float64
+	SDRGain         float64
+	SDRFFTSize      int
+	SDRWaterfallFPS float64
+	SDRMode         string
+
+	// Адрес сервера KISS TCP для принятых кадров AX.25 (пустая строка —
+	// сервер отключён)
+	KISSAddr string
+
+	// Каталог схем телеметрии *.json (пустая строка — схемы не загружаются)
+	TelemetrySchemas string
+	// Каталог архива принятых кадров и значений телеметрии (пустая
+	// строка — архив отключён)
+	TelemetryArchive string
+
+	// SatNOGS DB: URL приёмника SiDS (пустая строка — отправка отключена),
+	// позывной станции, файл очереди отправки (пустая строка — очередь
+	// в памяти), минимальный интервал между запросами (секунды)
+	// и локальная замена приёмника по адресу /api/sids/telemetry/
+	SatNOGSURL             string
+	SatNOGSCallsign        string
+	SatNOGSQueue           string
+	SatNOGSIntervalSeconds float64
+	SatNOGSStandIn         bool
+
+	// Автоматический приём пролётов спутников, отмеченных в каталоге:
+	// включение, файл заданий (пустая строка — задания в памяти), время
+	// выхода антенны в начальную точку до AOS (секунды) и каталог записей
+	// отсчётов SigMF (пустая строка — запись отключена)
+	ScheduleEnabled     bool
+	SchedulePath        string
+	ScheduleLeadSeconds float64
+	RecordingsDir       string
+
+	// Журнал: уровень (debug, info, warn, error) и формат (text, json)
+	LogLevel  string
+	LogFormat string
+}
+
+// Load возвращает конфигурацию: значения по умолчанию, поверх них файл
+// path в формате JSON (пустая строка — без файла) и переменные окружения.
+// Ошибки разбора и проверки возвращаются списком, обёрнутым
+// в ErrInvalidConfig.
+func Load(path string) (*Config, error) {
+	cfg := defaults()
+
+	var errs []error
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		errs = append(errs, cfg.decode(data)...)
+	}
+	for _, f := range cfg.fields() {
+		if err := f.setEnv(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, cfg.problems()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w:\n%w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return cfg, nil
 }
 
-// Load возвращает конфигурацию из переменных окружения с значениями по умолчанию.
-func Load() *Config {
-	cfg := &Config{
-		Port:        getEnv(envPort, "8080"),
-		ObserverLat: getEnvFloat(envObserverLat, defaultObserverLat),
-		ObserverLon: getEnvFloat(envObserverLon, defaultObserverLon),
-		ObserverAlt: getEnvFloat(envObserverAlt, defaultObserverAlt),
+// defaults возвращает конфигурацию по умолчанию.
+func defaults() *Config {
+	return &Config{
+		Port:        "8080",
+		ObserverLat: defaultObserverLat,
+		ObserverLon: defaultObserverLon,
+		ObserverAlt: defaultObserverAlt,
+		CatalogPath: defaultCatalogPath,
+
+		ObserverName: defaultObserverName,
+		StationsPath: defaultStationsPath,
+
+		PassMinElevation:   defaultPassMinElevation,
+		PassLookaheadHours: defaultPassLookaheadHours,
+
+		TLEUpdateHours: defaultTLEUpdateHours,
+
+		RotatorAzimuthRate:   defaultRotatorAzimuthRate,
+		RotatorElevationRate: defaultRotatorElevationRate,
+		RotatorMaxAzimuth:    defaultRotatorMaxAzimuth,
+
+		RigUpdateSeconds: defaultRigUpdateSeconds,
+		RigToleranceHz:   defaultRigToleranceHz,
+
+		SDRSampleRate:   defaultSDRSampleRate,
+		SDRFrequencyHz:  defaultSDRFrequencyHz,
+		SDRGain:         defaultSDRGain,
+		SDRFFTSize:      defaultSDRFFTSize,
+		SDRWaterfallFPS: defaultSDRWaterfallFPS,
+		SDRMode:         defaultSDRMode,
+
+		KISSAddr: defaultKISSAddr,
+
+		TelemetrySchemas: defaultTelemetrySchemas,
+		TelemetryArchive: defaultTelemetryArchive,
+
+		SatNOGSQueue:           defaultSatNOGSQueue,
+		SatNOGSIntervalSeconds: defaultSatNOGSIntervalSeconds,
+
+		ScheduleEnabled:     true,
+		SchedulePath:        defaultSchedulePath,
+		ScheduleLeadSeconds: defaultScheduleLeadSeconds,
+		RecordingsDir:       defaultRecordingsDir,
+
+		LogLevel:  defaultLogLevel,
+		LogFormat: defaultLogFormat,
 	}
-	return cfg
 }
 
 // Addr возвращает адрес сервера в формате ":port".
@@ -45,18 +304,20 @@ func (c *Config) Addr() string {
 	return ":" + c.Port
 }
 
-func getEnv(key, defaultVal string) string {
-	if val := os.Getenv(key); val != "" {
-		return val
+// Observer возвращает местоположение наблюдателя для расчётов орбит.
+func (c *Config) Observer() orbit.Observer {
+	return orbit.Observer{
+		Latitude:  c.ObserverLat,
+		Longitude: c.ObserverLon,
+		Altitude:  c.ObserverAlt,
 	}
-	return defaultVal
 }
 
-func getEnvFloat(key string, defaultVal float64) float64 {
-	if val := os.Getenv(key); val != "" {
-		if f, err := strconv.ParseFloat(val, 64); err == nil {
-			return f
-		}
+// SlogLevel возвращает уровень журнала; неизвестный уровень — info.
+func (c *Config) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
 	}
-	return defaultVal
+	return level
 }
@@ -1,10 +1,23 @@
 package config
 
 import (
+	"errors"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
+// mustLoad загружает конфигурацию из переменных окружения.
+func mustLoad(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return cfg
+}
+
 func TestLoad_DefaultValues(t *testing.T) {
 	// Очищаем переменные окружения
 	_ = os.Unsetenv("PORT")
@@ -12,7 +25,7 @@ func TestLoad_DefaultValues(t *testing.T) {
 	_ = os.Unsetenv("OBSERVER_LON")
 	_ = os.Unsetenv("OBSERVER_ALT")
 
-	cfg := Load()
+	cfg := mustLoad(t)
 
 	if cfg.Port != "8080" {
 		t.Errorf("Expected default port 8080, got %s", cfg.Port)
@@ -44,7 +57,7 @@ func TestLoad_CustomValues(t *testing.T) {
 		_ = os.Unsetenv("OBSERVER_ALT")
 	})
 
-	cfg := Load()
+	cfg := mustLoad(t)
 
 	if cfg.Port != "3000" {
 		t.Errorf("Expected port 3000, got %s", cfg.Port)
@@ -63,8 +76,8 @@ func TestLoad_CustomValues(t *testing.T) {
 	}
 }
 
-func TestLoad_InvalidFloatValues(t *testing.T) {
-	// Устанавливаем невалидные значения
+func TestLoad_InvalidEnvValues(t *testing.T) {
+	// Невалидные значения не заменяются значениями по умолчанию
 	_ = os.Setenv("OBSERVER_LAT", "invalid")
 	_ = os.Setenv("OBSERVER_LON", "not-a-number")
 	t.Cleanup(func() {
@@ -72,15 +85,162 @@ func TestLoad_InvalidFloatValues(t *testing.T) {
 		_ = os.Unsetenv("OBSERVER_LON")
 	})
 
-	cfg := Load()
+	_, err := Load("")
+	if !errors.Is(err, ErrInvalidConfig) || !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Load() error = %v, want invalid value", err)
+	}
+	for _, want := range []string{`OBSERVER_LAT: invalid value "invalid"`, `OBSERVER_LON: invalid value "not-a-number"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Load() error = %v, want %q", err, want)
+		}
+	}
+}
 
-	// Должны использоваться значения по умолчанию
-	if cfg.ObserverLat != 47.315813 {
-		t.Errorf("Expected default lat 47.315813 for invalid value, got %f", cfg.ObserverLat)
+func TestLoad_CatalogPath(t *testing.T) {
+	_ = os.Unsetenv("CATALOG_PATH")
+	if cfg := mustLoad(t); cfg.CatalogPath != "data/catalog.json" {
+		t.Errorf("Expected default catalog path, got %q", cfg.CatalogPath)
 	}
 
-	if cfg.ObserverLon != 39.788243 {
-		t.Errorf("Expected default lon 39.788243 for invalid value, got %f", cfg.ObserverLon)
+	// Пустое значение явно включает хранение каталога в памяти
+	_ = os.Setenv("CATALOG_PATH", "")
+	t.Cleanup(func() { _ = os.Unsetenv("CATALOG_PATH") })
+
+	if cfg := mustLoad(t); cfg.CatalogPath != "" {
+		t.Errorf("Expected empty catalog path, got %q", cfg.CatalogPath)
+	}
+}
+
+func TestLoad_StationSettings(t *testing.T) {
+	keys := []string{"OBSERVER_NAME", "STATIONS_PATH"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if cfg.ObserverName != "Ростов-на-Дону" || cfg.StationsPath != "data/stations.json" {
+		t.Errorf("Expected default station settings, got %q/%q", cfg.ObserverName, cfg.StationsPath)
+	}
+
+	// Пустой путь отключает файл станций
+	_ = os.Setenv("OBSERVER_NAME", "Крыша")
+	_ = os.Setenv("STATIONS_PATH", "")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.ObserverName != "Крыша" || cfg.StationsPath != "" {
+		t.Errorf("Expected custom station settings, got %q/%q", cfg.ObserverName, cfg.StationsPath)
+	}
+}
+
+func TestLoad_PassSettings(t *testing.T) {
+	_ = os.Unsetenv("PASS_MIN_ELEVATION")
+	_ = os.Unsetenv("PASS_LOOKAHEAD_HOURS")
+
+	cfg := mustLoad(t)
+	if cfg.PassMinElevation != 0 || cfg.PassLookaheadHours != 24 {
+		t.Errorf("Expected default pass settings 0/24, got %v/%v", cfg.PassMinElevation, cfg.PassLookaheadHours)
+	}
+
+	_ = os.Setenv("PASS_MIN_ELEVATION", "10")
+	_ = os.Setenv("PASS_LOOKAHEAD_HOURS", "48")
+	t.Cleanup(func() {
+		_ = os.Unsetenv("PASS_MIN_ELEVATION")
+		_ = os.Unsetenv("PASS_LOOKAHEAD_HOURS")
+	})
+
+	cfg = mustLoad(t)
+	if cfg.PassMinElevation != 10 || cfg.PassLookaheadHours != 48 {
+		t.Errorf("Expected pass settings 10/48, got %v/%v", cfg.PassMinElevation, cfg.PassLookaheadHours)
+	}
+}
+
+func TestLoad_TLESettings(t *testing.T) {
+	_ = os.Unsetenv("TLE_SOURCE")
+	_ = os.Unsetenv("TLE_UPDATE_HOURS")
+	_ = os.Unsetenv("TLE_ADD_NEW")
+
+	cfg := mustLoad(t)
+	if cfg.TLESource != "" || cfg.TLEUpdateHours != 6 || cfg.TLEAddNew {
+		t.Errorf("Expected TLE updates disabled with 6 h period, got %q/%v/%v", cfg.TLESource, cfg.TLEUpdateHours, cfg.TLEAddNew)
+	}
+
+	_ = os.Setenv("TLE_SOURCE", "https://celestrak.org/NORAD/elements/gp.php?GROUP=amateur&FORMAT=json")
+	_ = os.Setenv("TLE_UPDATE_HOURS", "12")
+	_ = os.Setenv("TLE_ADD_NEW", "true")
+	t.Cleanup(func() {
+		_ = os.Unsetenv("TLE_SOURCE")
+		_ = os.Unsetenv("TLE_UPDATE_HOURS")
+		_ = os.Unsetenv("TLE_ADD_NEW")
+	})
+
+	cfg = mustLoad(t)
+	if !strings.HasPrefix(cfg.TLESource, "https://celestrak.org/") || cfg.TLEUpdateHours != 12 || !cfg.TLEAddNew {
+		t.Errorf("Expected custom TLE settings, got %q/%v/%v", cfg.TLESource, cfg.TLEUpdateHours, cfg.TLEAddNew)
+	}
+}
+
+func TestLoad_RotatorSettings(t *testing.T) {
+	keys := []string{"ROTATOR_ADDR", "ROTATOR_PARK_AZ", "ROTATOR_PARK_EL", "ROTATOR_AZ_RATE", "ROTATOR_EL_RATE",
+		"ROTATOR_MAX_AZ", "ROTATOR_FLIP"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if cfg.RotatorAddr != "" || cfg.RotatorAzimuthRate != 6 || cfg.RotatorElevationRate != 3 ||
+		cfg.RotatorMaxAzimuth != 360 || cfg.RotatorFlip {
+		t.Errorf("Expected rotator disabled with default limits, got %+v", cfg)
+	}
+
+	_ = os.Setenv("ROTATOR_ADDR", "localhost:4533")
+	_ = os.Setenv("ROTATOR_PARK_AZ", "180")
+	_ = os.Setenv("ROTATOR_PARK_EL", "90")
+	_ = os.Setenv("ROTATOR_AZ_RATE", "4.5")
+	_ = os.Setenv("ROTATOR_EL_RATE", "2")
+	_ = os.Setenv("ROTATOR_MAX_AZ", "450")
+	_ = os.Setenv("ROTATOR_FLIP", "true")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.RotatorAddr != "localhost:4533" || cfg.RotatorParkAzimuth != 180 || cfg.RotatorParkElevation != 90 ||
+		cfg.RotatorAzimuthRate != 4.5 || cfg.RotatorElevationRate != 2 ||
+		cfg.RotatorMaxAzimuth != 450 || !cfg.RotatorFlip {
+		t.Errorf("Expected custom rotator settings, got %+v", cfg)
+	}
+}
+
+func TestLoad_RigSettings(t *testing.T) {
+	keys := []string{"RIG_ADDR", "RIG_UPDATE_SECONDS", "RIG_TOLERANCE_HZ"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if cfg.RigAddr != "" || cfg.RigUpdateSeconds != 1 || cfg.RigToleranceHz != 10 {
+		t.Errorf("Expected rig disabled with defaults, got %q/%v/%v", cfg.RigAddr, cfg.RigUpdateSeconds, cfg.RigToleranceHz)
+	}
+
+	_ = os.Setenv("RIG_ADDR", "localhost:4532")
+	_ = os.Setenv("RIG_UPDATE_SECONDS", "0.5")
+	_ = os.Setenv("RIG_TOLERANCE_HZ", "50")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.RigAddr != "localhost:4532" || cfg.RigUpdateSeconds != 0.5 || cfg.RigToleranceHz != 50 {
+		t.Errorf("Expected custom rig settings, got %q/%v/%v", cfg.RigAddr, cfg.RigUpdateSeconds, cfg.RigToleranceHz)
 	}
 }
 
@@ -117,96 +277,174 @@ func TestConfig_Addr(t *testing.T) {
 	}
 }
 
-func TestGetEnv(t *testing.T) {
-	tests := []struct {
-		name       string
-		key        string
-		envValue   string
-		defaultVal string
-		want       string
-	}{
-		{
-			name:       "existing env var",
-			key:        "TEST_KEY",
-			envValue:   "test_value",
-			defaultVal: "default",
-			want:       "test_value",
-		},
-		{
-			name:       "missing env var",
-			key:        "MISSING_KEY",
-			envValue:   "",
-			defaultVal: "default",
-			want:       "default",
-		},
+func TestConfig_Observer(t *testing.T) {
+	cfg := &Config{ObserverLat: 47.3, ObserverLon: 39.8, ObserverAlt: 70}
+	obs := cfg.Observer()
+	if obs.Latitude != 47.3 || obs.Longitude != 39.8 || obs.Altitude != 70 {
+		t.Errorf("Observer() = %+v, want config coordinates", obs)
 	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.envValue != "" {
-				_ = os.Setenv(tt.key, tt.envValue)
-				t.Cleanup(func() { _ = os.Unsetenv(tt.key) })
-			} else {
-				_ = os.Unsetenv(tt.key)
-			}
+func TestLoad_SDRSettings(t *testing.T) {
+	keys := []string{"SDR_SOURCE", "SDR_SAMPLE_RATE", "SDR_FREQUENCY_HZ", "SDR_GAIN", "SDR_FFT_SIZE", "SDR_WATERFALL_FPS", "SDR_MODE"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
 
-			if got := getEnv(tt.key, tt.defaultVal); got != tt.want {
-				t.Errorf("getEnv() = %v, want %v", got, tt.want)
-			}
-		})
+	cfg := mustLoad(t)
+	if cfg.SDRSource != "" || cfg.SDRSampleRate != 1.024e6 || cfg.SDRFrequencyHz != 145.8e6 ||
+		cfg.SDRGain != -1 || cfg.SDRFFTSize != 1024 || cfg.SDRWaterfallFPS != 10 || cfg.SDRMode != "fsk" {
+		t.Errorf("Expected SDR disabled with defaults, got %+v", cfg)
+	}
+
+	_ = os.Setenv("SDR_SOURCE", "rtl_tcp://localhost:1234")
+	_ = os.Setenv("SDR_SAMPLE_RATE", "2048000")
+	_ = os.Setenv("SDR_FREQUENCY_HZ", "437800000")
+	_ = os.Setenv("SDR_GAIN", "29.7")
+	_ = os.Setenv("SDR_FFT_SIZE", "2048")
+	_ = os.Setenv("SDR_WATERFALL_FPS", "5")
+	_ = os.Setenv("SDR_MODE", "afsk")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.SDRSource != "rtl_tcp://localhost:1234" || cfg.SDRSampleRate != 2.048e6 || cfg.SDRFrequencyHz != 437.8e6 ||
+		cfg.SDRGain != 29.7 || cfg.SDRFFTSize != 2048 || cfg.SDRWaterfallFPS != 5 || cfg.SDRMode != "afsk" {
+		t.Errorf("Expected custom SDR settings, got %+v", cfg)
+	}
+
+	_ = os.Setenv("SDR_FFT_SIZE", "large")
+	if _, err := Load(""); !errors.Is(err, ErrInvalidValue) || !strings.Contains(err.Error(), "SDR_FFT_SIZE") {
+		t.Errorf("Expected SDR_FFT_SIZE error for invalid value, got %v", err)
 	}
 }
 
-func TestGetEnvFloat(t *testing.T) {
-	tests := []struct {
-		name       string
-		key        string
-		envValue   string
-		defaultVal float64
-		want       float64
-	}{
-		{
-			name:       "valid float",
-			key:        "TEST_FLOAT",
-			envValue:   "123.456",
-			defaultVal: 0.0,
-			want:       123.456,
-		},
-		{
-			name:       "invalid float",
-			key:        "TEST_FLOAT",
-			envValue:   "not-a-float",
-			defaultVal: 99.9,
-			want:       99.9,
-		},
-		{
-			name:       "missing env var",
-			key:        "MISSING_FLOAT",
-			envValue:   "",
-			defaultVal: 42.0,
-			want:       42.0,
-		},
-		{
-			name:       "negative float",
-			key:        "TEST_FLOAT",
-			envValue:   "-12.34",
-			defaultVal: 0.0,
-			want:       -12.34,
-		},
+func TestLoad_KISSAddr(t *testing.T) {
+	_ = os.Unsetenv("KISS_ADDR")
+	if cfg := mustLoad(t); cfg.KISSAddr != ":8001" {
+		t.Errorf("Expected default KISS address :8001, got %q", cfg.KISSAddr)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.envValue != "" {
-				_ = os.Setenv(tt.key, tt.envValue)
-				t.Cleanup(func() { _ = os.Unsetenv(tt.key) })
-			} else {
-				_ = os.Unsetenv(tt.key)
-			}
+	_ = os.Setenv("KISS_ADDR", "")
+	t.Cleanup(func() { _ = os.Unsetenv("KISS_ADDR") })
+	if cfg := mustLoad(t); cfg.KISSAddr != "" {
+		t.Errorf("Expected KISS server disabled by empty value, got %q", cfg.KISSAddr)
+	}
 
-			if got := getEnvFloat(tt.key, tt.defaultVal); got != tt.want {
-				t.Errorf("getEnvFloat() = %v, want %v", got, tt.want)
-			}
-		})
+	_ = os.Setenv("KISS_ADDR", "127.0.0.1:8101")
+	if cfg := mustLoad(t); cfg.KISSAddr != "127.0.0.1:8101" {
+		t.Errorf("Expected custom KISS address, got %q", cfg.KISSAddr)
+	}
+}
+
+func TestLoad_TelemetrySchemas(t *testing.T) {
+	_ = os.Unsetenv("TELEMETRY_SCHEMAS")
+	if cfg := mustLoad(t); cfg.TelemetrySchemas != "data/telemetry" {
+		t.Errorf("Expected default schema directory, got %q", cfg.TelemetrySchemas)
+	}
+
+	_ = os.Setenv("TELEMETRY_SCHEMAS", "/etc/satwatch/schemas")
+	t.Cleanup(func() { _ = os.Unsetenv("TELEMETRY_SCHEMAS") })
+	if cfg := mustLoad(t); cfg.TelemetrySchemas != "/etc/satwatch/schemas" {
+		t.Errorf("Expected custom schema directory, got %q", cfg.TelemetrySchemas)
+	}
+}
+
+func TestLoad_TelemetryArchive(t *testing.T) {
+	_ = os.Unsetenv("TELEMETRY_ARCHIVE")
+	if cfg := mustLoad(t); cfg.TelemetryArchive != "data/telemetry-archive" {
+		t.Errorf("Expected default archive directory, got %q", cfg.TelemetryArchive)
+	}
+
+	_ = os.Setenv("TELEMETRY_ARCHIVE", "")
+	t.Cleanup(func() { _ = os.Unsetenv("TELEMETRY_ARCHIVE") })
+	if cfg := mustLoad(t); cfg.TelemetryArchive != "" {
+		t.Errorf("Expected disabled archive, got %q", cfg.TelemetryArchive)
+	}
+}
+
+func TestLoad_SatNOGSSettings(t *testing.T) {
+	keys := []string{"SATNOGS_URL", "SATNOGS_CALLSIGN", "SATNOGS_QUEUE", "SATNOGS_INTERVAL_SECONDS", "SATNOGS_STANDIN"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if cfg.SatNOGSURL != "" || cfg.SatNOGSQueue != "data/satnogs-queue.json" || cfg.SatNOGSIntervalSeconds != 1 || cfg.SatNOGSStandIn {
+		t.Errorf("Expected upload disabled with defaults, got %q/%q/%v/%v",
+			cfg.SatNOGSURL, cfg.SatNOGSQueue, cfg.SatNOGSIntervalSeconds, cfg.SatNOGSStandIn)
+	}
+
+	_ = os.Setenv("SATNOGS_URL", "http://localhost:8080/api/sids/telemetry/")
+	_ = os.Setenv("SATNOGS_CALLSIGN", "R4UAB")
+	_ = os.Setenv("SATNOGS_QUEUE", "")
+	_ = os.Setenv("SATNOGS_INTERVAL_SECONDS", "5")
+	_ = os.Setenv("SATNOGS_STANDIN", "true")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.SatNOGSURL != "http://localhost:8080/api/sids/telemetry/" || cfg.SatNOGSCallsign != "R4UAB" ||
+		cfg.SatNOGSQueue != "" || cfg.SatNOGSIntervalSeconds != 5 || !cfg.SatNOGSStandIn {
+		t.Errorf("Expected custom SatNOGS settings, got %+v", cfg)
+	}
+}
+
+func TestLoad_ScheduleSettings(t *testing.T) {
+	keys := []string{"SCHEDULE_ENABLED", "SCHEDULE_PATH", "SCHEDULE_LEAD_SECONDS", "RECORDINGS_DIR"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if !cfg.ScheduleEnabled || cfg.SchedulePath != "data/schedule.json" || cfg.ScheduleLeadSeconds != 120 || cfg.RecordingsDir != "data/recordings" {
+		t.Errorf("Expected schedule enabled with defaults, got %v/%q/%v/%q",
+			cfg.ScheduleEnabled, cfg.SchedulePath, cfg.ScheduleLeadSeconds, cfg.RecordingsDir)
+	}
+
+	_ = os.Setenv("SCHEDULE_ENABLED", "false")
+	_ = os.Setenv("SCHEDULE_PATH", "")
+	_ = os.Setenv("SCHEDULE_LEAD_SECONDS", "300")
+	_ = os.Setenv("RECORDINGS_DIR", "")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.ScheduleEnabled || cfg.SchedulePath != "" || cfg.ScheduleLeadSeconds != 300 || cfg.RecordingsDir != "" {
+		t.Errorf("Expected custom schedule settings, got %+v", cfg)
+	}
+}
+
+func TestLoad_LogSettings(t *testing.T) {
+	keys := []string{"LOG_LEVEL", "LOG_FORMAT"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+
+	cfg := mustLoad(t)
+	if cfg.LogLevel != "debug" || cfg.LogFormat != "text" || cfg.SlogLevel() != slog.LevelDebug {
+		t.Errorf("Expected debug text log, got %q/%q", cfg.LogLevel, cfg.LogFormat)
+	}
+
+	_ = os.Setenv("LOG_LEVEL", "warn")
+	_ = os.Setenv("LOG_FORMAT", "json")
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	cfg = mustLoad(t)
+	if cfg.LogFormat != "json" || cfg.SlogLevel() != slog.LevelWarn {
+		t.Errorf("Expected warn json log, got %q/%q", cfg.LogLevel, cfg.LogFormat)
 	}
 }
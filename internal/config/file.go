@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// field связывает параметр конфигурации с ключом файла и переменной
+// окружения.
+type field struct {
+	key   string // ключ файла: "раздел.имя"
+	env   string // переменная окружения
+	value any    // *string, *float64, *int или *bool
+	// empty — пустая переменная окружения задаёт пустое значение
+	// (например, отключает файл), а не остаётся без внимания.
+	empty bool
+}
+
+// fields возвращает параметры конфигурации в порядке полей Config.
+func (c *Config) fields() []field {
+	return []field{
+		{key: "server.port", env: envPort, value: &c.Port},
+
+		{key: "observer.latitude", env: envObserverLat, value: &c.ObserverLat},
+		{key: "observer.longitude", env: envObserverLon, value: &c.ObserverLon},
+		{key: "observer.altitude", env: envObserverAlt, value: &c.ObserverAlt},
+		{key: "observer.name", env: envObserverName, value: &c.ObserverName},
+
+		{key: "catalog.path", env: envCatalogPath, value: &c.CatalogPath, empty: true},
+
+		{key: "stations.path", env: envStationsPath, value: &c.StationsPath, empty: true},
+
+		{key: "passes.min_elevation", env: envPassMinElevation, value: &c.PassMinElevation},
+		{key: "passes.lookahead_hours", env: envPassLookaheadHours, value: &c.PassLookaheadHours},
+
+		{key: "tle.source", env: envTLESource, value: &c.TLESource},
+		{key: "tle.update_hours", env: envTLEUpdateHours, value: &c.TLEUpdateHours},
+		{key: "tle.add_new", env: envTLEAddNew, value: &c.TLEAddNew},
+
+		{key: "rotator.addr", env: envRotatorAddr, value: &c.RotatorAddr},
+		{key: "rotator.park_azimuth", env: envRotatorParkAzimuth, value: &c.RotatorParkAzimuth},
+		{key: "rotator.park_elevation", env: envRotatorParkElevation, value: &c.RotatorParkElevation},
+		{key: "rotator.azimuth_rate", env: envRotatorAzimuthRate, value: &c.RotatorAzimuthRate},
+		{key: "rotator.elevation_rate", env: envRotatorElevationRate, value: &c.RotatorElevationRate},
+		{key: "rotator.max_azimuth", env: envRotatorMaxAzimuth, value: &c.RotatorMaxAzimuth},
+		{key: "rotator.flip", env: envRotatorFlip, value: &c.RotatorFlip},
+
+		{key: "rig.addr", env: envRigAddr, value: &c.RigAddr},
+		{key: "rig.update_seconds", env: envRigUpdateSeconds, value: &c.RigUpdateSeconds},
+		{key: "rig.tolerance_hz", env: envRigToleranceHz, value: &c.RigToleranceHz},
+
+		{key: "sdr.source", env: envSDRSource, value: &c.SDRSource},
+		{key: "sdr.sample_rate", env: envSDRSampleRate, value: &c.SDRSampleRate},
+		{key: "sdr.frequency_hz", env: envSDRFrequencyHz, value: &c.SDRFrequencyHz},
+		{key: "sdr.gain", env: envSDRGain, value: &c.SDRGain},
+		{key: "sdr.fft_size", env: envSDRFFTSize, value: &c.SDRFFTSize},
+		{key: "sdr.waterfall_fps", env: envSDRWaterfallFPS, value: &c.SDRWaterfallFPS},
+		{key: "sdr.mode", env: envSDRMode, value: &c.SDRMode},
+
+		{key: "kiss.addr", env: envKISSAddr, value: &c.KISSAddr, empty: true},
+
+		{key: "telemetry.schemas", env: envTelemetrySchemas, value: &c.TelemetrySchemas, empty: true},
+		{key: "telemetry.archive", env: envTelemetryArchive, value: &c.TelemetryArchive, empty: true},
+
+		{key: "satnogs.url", env: envSatNOGSURL, value: &c.SatNOGSURL},
+		{key: "satnogs.callsign", env: envSatNOGSCallsign, value: &c.SatNOGSCallsign},
+		{key: "satnogs.queue", env: envSatNOGSQueue, value: &c.SatNOGSQueue, empty: true},
+		{key: "satnogs.interval_seconds", env: envSatNOGSIntervalSeconds, value: &c.SatNOGSIntervalSeconds},
+		{key: "satnogs.standin", env: envSatNOGSStandIn, value: &c.SatNOGSStandIn},
+
+		{key: "schedule.enabled", env: envScheduleEnabled, value: &c.ScheduleEnabled},
+		{key: "schedule.path", env: envSchedulePath, value: &c.SchedulePath, empty: true},
+		{key: "schedule.lead_seconds", env: envScheduleLeadSeconds, value: &c.ScheduleLeadSeconds},
+		{key: "schedule.recordings_dir", env: envRecordingsDir, value: &c.RecordingsDir, empty: true},
+
+		{key: "log.level", env: envLogLevel, value: &c.LogLevel},
+		{key: "log.format", env: envLogFormat, value: &c.LogFormat},
+	}
+}
+
+// name возвращает ключ файла и переменную окружения параметра.
+func (f field) name() string {
+	return f.key + " (" + f.env + ")"
+}
+
+// format возвращает значение параметра для сообщения об ошибке.
+func (f field) format() string {
+	switch v := f.value.(type) {
+	case *string:
+		return strconv.Quote(*v)
+	case *float64:
+		return strconv.FormatFloat(*v, 'g', -1, 64)
+	case *int:
+		return strconv.Itoa(*v)
+	case *bool:
+		return strconv.FormatBool(*v)
+	}
+	return ""
+}
+
+// setEnv заменяет значение параметра значением переменной окружения,
+// если она задана.
+func (f field) setEnv() error {
+	val, ok := os.LookupEnv(f.env)
+	if !ok || (val == "" && !f.empty) {
+		return nil
+	}
+
+	var err error
+	switch v := f.value.(type) {
+	case *string:
+		*v = val
+	case *float64:
+		err = parse(v, val, "a number", func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	case *int:
+		err = parse(v, val, "an integer", strconv.Atoi)
+	case *bool:
+		err = parse(v, val, "true or false", strconv.ParseBool)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.env, err)
+	}
+	return nil
+}
+
+// parse записывает в dst разобранное значение val; при ошибке dst
+// не меняется.
+func parse[T any](dst *T, val, want string, fn func(string) (T, error)) error {
+	v, err := fn(val)
+	if err != nil {
+		return fmt.Errorf("%w %q, want %s", ErrInvalidValue, val, want)
+	}
+	*dst = v
+	return nil
+}
+
+// decode применяет файл конфигурации: объект JSON с разделами,
+// например {"observer": {"latitude": 55.75}}. Отсутствующие ключи
+// сохраняют прежние значения, неизвестные ключи и значения не того типа
+// возвращаются списком ошибок в порядке ключей.
+func (c *Config) decode(data []byte) []error {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return []error{fmt.Errorf("config file: %w", err)}
+	}
+
+	fields := make(map[string]field)
+	known := make(map[string]bool)
+	for _, f := range c.fields() {
+		fields[f.key] = f
+		section, _, _ := strings.Cut(f.key, ".")
+		known[section] = true
+	}
+
+	var errs []error
+	for _, section := range slices.Sorted(maps.Keys(sections)) {
+		if !known[section] {
+			errs = append(errs, fmt.Errorf("%w %q", ErrUnknownKey, section))
+			continue
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(sections[section], &values); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w: want an object", section, ErrInvalidValue))
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(values)) {
+			f, ok := fields[section+"."+name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%w %q", ErrUnknownKey, section+"."+name))
+				continue
+			}
+			if err := json.Unmarshal(values[name], f.value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w %s", f.key, ErrInvalidValue, values[name]))
+			}
+		}
+	}
+	return errs
+}
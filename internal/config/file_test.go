@@ -0,0 +1,194 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig записывает файл конфигурации во временный каталог.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "satwatch.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_File(t *testing.T) {
+	keys := []string{"PORT", "OBSERVER_LAT", "CATALOG_PATH"}
+	for _, key := range keys {
+		_ = os.Unsetenv(key)
+	}
+	t.Cleanup(func() {
+		for _, key := range keys {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	path := writeConfig(t, `{
+		"server": {"port": "9090"},
+		"observer": {"latitude": 55.75, "longitude": 37.62},
+		"catalog": {"path": ""},
+		"sdr": {"fft_size": 2048, "mode": "afsk"},
+		"schedule": {"enabled": false}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Port != "9090" || cfg.ObserverLat != 55.75 || cfg.ObserverLon != 37.62 || cfg.CatalogPath != "" ||
+		cfg.SDRFFTSize != 2048 || cfg.SDRMode != "afsk" || cfg.ScheduleEnabled {
+		t.Errorf("Expected file settings, got %+v", cfg)
+	}
+	// Параметры, которых нет в файле, сохраняют значения по умолчанию
+	if cfg.ObserverAlt != 70 || cfg.KISSAddr != ":8001" {
+		t.Errorf("Expected defaults for missing keys, got %v/%q", cfg.ObserverAlt, cfg.KISSAddr)
+	}
+
+	// Переменные окружения важнее файла
+	_ = os.Setenv("PORT", "3000")
+	_ = os.Setenv("OBSERVER_LAT", "47.3")
+	_ = os.Setenv("CATALOG_PATH", "/var/lib/satwatch/catalog.json")
+	if cfg, err = Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Port != "3000" || cfg.ObserverLat != 47.3 || cfg.ObserverLon != 37.62 || cfg.CatalogPath != "/var/lib/satwatch/catalog.json" {
+		t.Errorf("Expected env to override file, got %q/%v/%v/%q", cfg.Port, cfg.ObserverLat, cfg.ObserverLon, cfg.CatalogPath)
+	}
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+		// want — строки, которые должно содержать сообщение об ошибке.
+		want []string
+	}{
+		{
+			name:    "unknown keys",
+			content: `{"observer": {"latitude": 47.3, "lattitude": 47.3}, "obsever": {}}`,
+			wantErr: ErrUnknownKey,
+			want:    []string{`unknown key "observer.lattitude"`, `unknown key "obsever"`},
+		},
+		{
+			name:    "wrong types",
+			content: `{"server": {"port": 8080}, "sdr": {"fft_size": "large"}, "log": "debug"}`,
+			wantErr: ErrInvalidValue,
+			want:    []string{"server.port: invalid value 8080", `sdr.fft_size: invalid value "large"`, "log: invalid value"},
+		},
+		{
+			name:    "all problems at once",
+			content: `{"server": {"port": "70000"}, "observer": {"latitude": 95}, "colour": {}}`,
+			wantErr: ErrInvalidConfig,
+			want: []string{
+				`unknown key "colour"`,
+				`server.port (PORT): invalid value "70000"`,
+				"observer.latitude (OBSERVER_LAT): invalid value 95, must be within -90..90°",
+			},
+		},
+		{
+			name:    "not an object",
+			content: `[1, 2, 3]`,
+			wantErr: ErrInvalidConfig,
+			want:    []string{"config file:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.content))
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load(missing) error = %v, want not exist", err)
+	}
+}
+
+func TestLoad_ExampleFile(t *testing.T) {
+	data, err := os.ReadFile("../../config.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Пример перечисляет все параметры со значениями по умолчанию
+	var sections map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range defaults().fields() {
+		section, name, _ := strings.Cut(f.key, ".")
+		if _, ok := sections[section][name]; !ok {
+			t.Errorf("example does not list %s", f.key)
+		}
+	}
+
+	cfg := defaults()
+	if errs := cfg.decode(data); len(errs) > 0 {
+		t.Fatalf("decode() errors = %v", errs)
+	}
+	if want := defaults(); !reflect.DeepEqual(cfg, want) {
+		t.Errorf("example differs from defaults:\n got %+v\nwant %+v", cfg, want)
+	}
+}
+
+func TestField_SetEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		set     bool
+		want    float64
+		wantErr bool
+	}{
+		{name: "unset", want: 1},
+		{name: "empty", env: "", set: true, want: 1},
+		{name: "number", env: "-12.34", set: true, want: -12.34},
+		{name: "not a number", env: "not-a-float", set: true, want: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				_ = os.Setenv("TEST_FLOAT", tt.env)
+				t.Cleanup(func() { _ = os.Unsetenv("TEST_FLOAT") })
+			} else {
+				_ = os.Unsetenv("TEST_FLOAT")
+			}
+
+			got := 1.0
+			err := field{key: "test.float", env: "TEST_FLOAT", value: &got}.setEnv()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("setEnv() = %v, %v; want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	// Пустая переменная задаёт пустое значение только для таких параметров
+	_ = os.Setenv("TEST_PATH", "")
+	t.Cleanup(func() { _ = os.Unsetenv("TEST_PATH") })
+	for _, empty := range []bool{false, true} {
+		path := "data/file.json"
+		if err := (field{env: "TEST_PATH", value: &path, empty: empty}).setEnv(); err != nil {
+			t.Fatal(err)
+		}
+		if (path == "") != empty {
+			t.Errorf("empty=%v: path = %q", empty, path)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/demod"
+	"github.com/art-injener/satwatch-go/internal/rig"
+	"github.com/art-injener/satwatch-go/internal/tlefetch"
+)
+
+// Форматы журнала.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// Validate проверяет значения параметров и возвращает все найденные
+// ошибки, обёрнутые в ErrInvalidConfig.
+func (c *Config) Validate() error {
+	if errs := c.problems(); len(errs) > 0 {
+		return fmt.Errorf("%w:\n%w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+// problems возвращает ошибки значений параметров в порядке полей Config.
+// Пределы совпадают с проверками конструкторов, которым передаются
+// параметры, чтобы все ошибки находились до запуска.
+func (c *Config) problems() []error {
+	v := checker{fields: c.fields()}
+
+	port, err := strconv.Atoi(c.Port)
+	v.check(&c.Port, err == nil && port >= 1 && port <= 65535, "must be a port number within 1..65535")
+	v.check(&c.ObserverLat, within(c.ObserverLat, -90, 90), "must be within -90..90°")
+	v.check(&c.ObserverLon, within(c.ObserverLon, -180, 180), "must be within -180..180°")
+	v.check(&c.ObserverName, strings.TrimSpace(c.ObserverName) != "", "must not be empty")
+
+	v.check(&c.PassMinElevation, c.PassMinElevation >= 0 && c.PassMinElevation < 90, "must be within 0..90°")
+	v.check(&c.PassLookaheadHours, c.PassLookaheadHours > 0, "must be positive")
+	v.check(&c.TLEUpdateHours, duration(c.TLEUpdateHours, time.Hour) >= tlefetch.MinInterval,
+		fmt.Sprintf("must be at least %s", tlefetch.MinInterval))
+
+	maxElevation := 90.0
+	if c.RotatorFlip {
+		maxElevation = 180
+	}
+	v.check(&c.RotatorParkAzimuth, within(c.RotatorParkAzimuth, 0, c.RotatorMaxAzimuth), "must be within 0..rotator.max_azimuth")
+	v.check(&c.RotatorParkElevation, within(c.RotatorParkElevation, 0, maxElevation),
+		fmt.Sprintf("must be within 0..%g°", maxElevation))
+	v.check(&c.RotatorAzimuthRate, c.RotatorAzimuthRate > 0, "must be positive")
+	v.check(&c.RotatorElevationRate, c.RotatorElevationRate > 0, "must be positive")
+	v.check(&c.RotatorMaxAzimuth, within(c.RotatorMaxAzimuth, 360, 720), "must be within 360..720°")
+
+	v.check(&c.RigUpdateSeconds, duration(c.RigUpdateSeconds, time.Second) >= rig.MinInterval,
+		fmt.Sprintf("must be at least %s", rig.MinInterval))
+	v.check(&c.RigToleranceHz, c.RigToleranceHz >= 0, "must not be negative")
+
+	v.check(&c.SDRSampleRate, c.SDRSampleRate > 0, "must be positive")
+	v.check(&c.SDRFrequencyHz, c.SDRFrequencyHz > 0, "must be positive")
+	v.check(&c.SDRFFTSize, c.SDRFFTSize >= 64 && c.SDRFFTSize <= 65536 && c.SDRFFTSize&(c.SDRFFTSize-1) == 0,
+		"must be a power of two within 64..65536")
+	v.check(&c.SDRWaterfallFPS, c.SDRWaterfallFPS > 0, "must be positive")
+	_, err = demod.ParseMode(c.SDRMode)
+	v.check(&c.SDRMode, err == nil, "must be fsk, afsk or fm")
+
+	v.check(&c.SatNOGSCallsign, c.SatNOGSURL == "" || strings.TrimSpace(c.SatNOGSCallsign) != "",
+		"must not be empty when satnogs.url is set")
+	v.check(&c.SatNOGSIntervalSeconds, c.SatNOGSIntervalSeconds > 0, "must be positive")
+	v.check(&c.ScheduleLeadSeconds, c.ScheduleLeadSeconds >= 0, "must not be negative")
+
+	var level slog.Level
+	v.check(&c.LogLevel, level.UnmarshalText([]byte(c.LogLevel)) == nil, "must be debug, info, warn or error")
+	v.check(&c.LogFormat, c.LogFormat == LogFormatText || c.LogFormat == LogFormatJSON, "must be text or json")
+	return v.errs
+}
+
+// checker собирает ошибки значений параметров.
+type checker struct {
+	fields []field
+	errs   []error
+}
+
+// check добавляет ошибку параметра value, если условие ok не выполнено.
+func (v *checker) check(value any, ok bool, rule string) {
+	if ok {
+		return
+	}
+	for _, f := range v.fields {
+		if f.value == value {
+			v.errs = append(v.errs, fmt.Errorf("%s: %w %s, %s", f.name(), ErrInvalidValue, f.format(), rule))
+			return
+		}
+	}
+}
+
+// duration переводит значение x в единицах unit в длительность.
+func duration(x float64, unit time.Duration) time.Duration {
+	return time.Duration(x * float64(unit))
+}
+
+// within сообщает, что x лежит в отрезке [lo, hi].
+func within(x, lo, hi float64) bool {
+	return x >= lo && x <= hi
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		// want — ключ ошибки; пустая строка — конфигурация корректна.
+		want string
+	}{
+		{"defaults", func(c *Config) {}, ""},
+		{"port not a number", func(c *Config) { c.Port = "http" }, "server.port"},
+		{"port out of range", func(c *Config) { c.Port = "0" }, "server.port"},
+		{"latitude above 90", func(c *Config) { c.ObserverLat = 90.5 }, "observer.latitude"},
+		{"longitude below -180", func(c *Config) { c.ObserverLon = -181 }, "observer.longitude"},
+		{"no observer name", func(c *Config) { c.ObserverName = "" }, "observer.name"},
+		{"horizon mask at zenith", func(c *Config) { c.PassMinElevation = 90 }, "passes.min_elevation"},
+		{"no lookahead", func(c *Config) { c.PassLookaheadHours = 0 }, "passes.lookahead_hours"},
+		{"overlap rotator", func(c *Config) { c.RotatorMaxAzimuth, c.RotatorParkAzimuth = 450, 400 }, ""},
+		{"park outside azimuth range", func(c *Config) { c.RotatorParkAzimuth = 400 }, "rotator.park_azimuth"},
+		{"park overhead without flip", func(c *Config) { c.RotatorParkElevation = 120 }, "rotator.park_elevation"},
+		{"park overhead with flip", func(c *Config) { c.RotatorParkElevation, c.RotatorFlip = 120, true }, ""},
+		{"stopped rotator", func(c *Config) { c.RotatorElevationRate = 0 }, "rotator.elevation_rate"},
+		{"TLE update below one minute", func(c *Config) { c.TLEUpdateHours = 0.01 }, "tle.update_hours"},
+		{"TLE update at one minute", func(c *Config) { c.TLEUpdateHours = 1.0 / 60 }, ""},
+		{"rig update below 100 ms", func(c *Config) { c.RigUpdateSeconds = 0.05 }, "rig.update_seconds"},
+		{"rig update at 100 ms", func(c *Config) { c.RigUpdateSeconds = 0.1 }, ""},
+		{"negative tolerance", func(c *Config) { c.RigToleranceHz = -1 }, "rig.tolerance_hz"},
+		{"FFT size not a power of two", func(c *Config) { c.SDRFFTSize = 1000 }, "sdr.fft_size"},
+		{"unknown mode", func(c *Config) { c.SDRMode = "bpsk" }, "sdr.mode"},
+		{"zero SatNOGS interval", func(c *Config) { c.SatNOGSIntervalSeconds = 0 }, "satnogs.interval_seconds"},
+		{"SatNOGS upload without callsign", func(c *Config) { c.SatNOGSURL, c.SatNOGSCallsign = "http://localhost/", "" }, "satnogs.callsign"},
+		{"SatNOGS upload with callsign", func(c *Config) { c.SatNOGSURL, c.SatNOGSCallsign = "http://localhost/", "R4UAB" }, ""},
+		{"negative lead", func(c *Config) { c.ScheduleLeadSeconds = -1 }, "schedule.lead_seconds"},
+		{"unknown log level", func(c *Config) { c.LogLevel = "verbose" }, "log.level"},
+		{"unknown log format", func(c *Config) { c.LogFormat = "xml" }, "log.format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaults()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) || !errors.Is(err, ErrInvalidValue) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package demod
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/art-injener/satwatch-go/internal/dsp"
+)
+
+// Параметры AFSK 1200 (Bell 202).
+const (
+	afskBaud      = 1200
+	afskMark      = 1200 // Гц, 1
+	afskSpace     = 2200 // Гц, 0
+	afskDeviation = 3000 // девиация ЧМ-передатчика, Гц
+	afskChannel   = 6000 // половина полосы канала, Гц
+	afskRate      = 24000
+)
+
+// AFSK1200 демодулирует AFSK 1200 бод поверх узкополосной ЧМ: выделение
+// канала ±6 кГц с децимацией до ~24 кГц, частотный дискриминатор,
+// некогерентное сравнение энергии тонов 1200 и 2200 Гц на интервале
+// символа, восстановление тактовой частоты и декодирование NRZI.
+type AFSK1200 struct {
+	dec   *dsp.Decimator
+	fm    *FMDiscriminator
+	mark  toneDetector
+	space toneDetector
+	clock clockRecovery
+	nrzi  nrzi
+
+	iq    []complex64
+	audio []float32
+}
+
+// NewAFSK1200 создаёт демодулятор для частоты дискретизации sampleRate;
+// после децимации на символ должно приходиться не меньше 8 отсчётов.
+func NewAFSK1200(sampleRate float64) (*AFSK1200, error) {
+	if sampleRate < 8*afskBaud {
+		return nil, fmt.Errorf("%w: %.0f Hz for AFSK 1200", ErrSampleRate, sampleRate)
+	}
+	dec := dsp.NewDecimator(decimation(sampleRate, afskRate), afskChannel/sampleRate)
+	rate := sampleRate / float64(dec.Factor())
+	window := int(math.Round(rate / afskBaud))
+	return &AFSK1200{
+		dec:   dec,
+		fm:    NewFMDiscriminator(rate, afskDeviation),
+		mark:  newToneDetector(rate, afskMark, window),
+		space: newToneDetector(rate, afskSpace, window),
+		clock: newClockRecovery(rate, afskBaud),
+	}, nil
+}
+
+// Demodulate дописывает к dst биты, извлечённые из iq.
+func (a *AFSK1200) Demodulate(dst []byte, iq []complex64) []byte {
+	a.iq = a.dec.Process(a.iq[:0], iq)
+	a.audio = a.fm.Demodulate(a.audio[:0], a.iq)
+	for _, v := range a.audio {
+		d := a.mark.process(v) - a.space.process(v)
+		if bit, ok := a.clock.process(d); ok {
+			dst = append(dst, a.nrzi.decode(bit))
+		}
+	}
+	return dst
+}
+
+// toneDetector оценивает энергию тона на скользящем окне: сигнал
+// переносится на нулевую частоту и суммируется за window отсчётов.
+type toneDetector struct {
+	osc   complex128 // текущий отсчёт гетеродина
+	rot   complex128 // поворот гетеродина за отсчёт
+	ring  []complex128
+	pos   int
+	sum   complex128
+	count int
+}
+
+func newToneDetector(sampleRate, freq float64, window int) toneDetector {
+	s, c := math.Sincos(-2 * math.Pi * freq / sampleRate)
+	return toneDetector{osc: 1, rot: complex(c, s), ring: make([]complex128, window)}
+}
+
+// process добавляет отсчёт v и возвращает энергию тона в окне.
+func (t *toneDetector) process(v float32) float32 {
+	p := complex(float64(v), 0) * t.osc
+	t.sum += p - t.ring[t.pos]
+	t.ring[t.pos] = p
+	t.pos = (t.pos + 1) % len(t.ring)
+
+	t.osc *= t.rot
+	// Периодически нормируем гетеродин и пересчитываем сумму, чтобы
+	// ошибки округления не накапливались.
+	if t.count++; t.count == 1<<16 {
+		t.count = 0
+		t.osc /= complex(math.Hypot(real(t.osc), imag(t.osc)), 0)
+		t.sum = 0
+		for _, x := range t.ring {
+			t.sum += x
+		}
+	}
+	return float32(real(t.sum)*real(t.sum) + imag(t.sum)*imag(t.sum))
+}
@@ -0,0 +1,41 @@
+package demod
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAFSK1200(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   float64
+		snr    float64 // дБ во всей полосе дискретизации
+		maxBER float64
+	}{
+		{name: "clean 48k", rate: 48000, snr: 60, maxBER: 0},
+		{name: "decimated 240k", rate: 240000, snr: 30, maxBER: 0},
+		{name: "noisy 48k", rate: 48000, snr: 3, maxBER: 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			bits := randomBits(rng, 2000)
+			iq := fmModulate(afskAudio(nrziEncode(bits), tt.rate), tt.rate, afskDeviation)
+			addNoise(rng, iq, tt.snr)
+
+			d, err := NewAFSK1200(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []byte
+			for len(iq) > 0 {
+				n := min(len(iq), 4096)
+				got = d.Demodulate(got, iq[:n])
+				iq = iq[n:]
+			}
+			if ber := bitErrorRate(bits, got); ber > tt.maxBER {
+				t.Errorf("BER = %.4f, want <= %v (%d bits received)", ber, tt.maxBER, len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package demod
+
+// clockGain — доля ошибки фазы, исправляемая на каждом переходе.
+const clockGain = 0.15
+
+// clockRecovery восстанавливает тактовую частоту символов цифровой ФАПЧ:
+// переходы сигнала через ноль подстраивают фазу к границе символа,
+// решение принимается в его середине.
+type clockRecovery struct {
+	step  float64 // приращение фазы за отсчёт, символов
+	phase float64 // 0 — середина символа, 0,5 — граница
+	prev  float32
+}
+
+func newClockRecovery(sampleRate, baud float64) clockRecovery {
+	return clockRecovery{step: baud / sampleRate}
+}
+
+// process обрабатывает отсчёт v; ok — в этом отсчёте принято решение.
+func (c *clockRecovery) process(v float32) (bit byte, ok bool) {
+	c.phase += c.step
+	if (v >= 0) != (c.prev >= 0) {
+		// Уточняем момент перехода линейной интерполяцией.
+		at := c.phase - c.step*float64(v/(v-c.prev))
+		c.phase -= clockGain * (at - 0.5)
+	}
+	c.prev = v
+	if c.phase < 1 {
+		return 0, false
+	}
+	c.phase--
+	if v >= 0 {
+		return 1, true
+	}
+	return 0, true
+}
+
+// nrzi декодирует NRZI: отсутствие смены уровня — 1, смена — 0.
+type nrzi struct {
+	last byte
+}
+
+func (n *nrzi) decode(bit byte) byte {
+	out := 1 ^ bit ^ n.last
+	n.last = bit
+	return out
+}
+
+// descrambler — самосинхронизирующийся дескремблер G3RUH с полиномом
+// x^17 + x^12 + 1.
+type descrambler struct {
+	reg uint32
+}
+
+func (d *descrambler) decode(bit byte) byte {
+	out := bit ^ byte(d.reg>>11&1) ^ byte(d.reg>>16&1)
+	d.reg = d.reg<<1 | uint32(bit)
+	return out
+}
@@ -0,0 +1,71 @@
+// Package demod извлекает поток бит из комплексных отсчётов приёмника:
+// частотный дискриминатор, AFSK 1200 бод (Bell 202) и GFSK 9600 бод
+// (G3RUH). Биты выдаются по одному в байте (0 или 1) уже после
+// декодирования NRZI и дескремблирования — в том виде, в котором их
+// принимает уровень HDLC.
+package demod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ошибки демодуляторов.
+var (
+	ErrUnknownMode = errors.New("demod: unknown mode")
+	ErrNotDigital  = errors.New("demod: mode does not produce bits")
+	ErrSampleRate  = errors.New("demod: sample rate is too low")
+)
+
+// Mode — режим демодуляции, как в списке на странице приёмника.
+type Mode string
+
+// Режимы демодуляции.
+const (
+	ModeFSK  Mode = "fsk"  // GFSK 9600 бод, G3RUH
+	ModeAFSK Mode = "afsk" // AFSK 1200 бод, Bell 202
+	ModeFM   Mode = "fm"   // частотная модуляция, звук
+)
+
+// ParseMode разбирает название режима без учёта регистра.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case ModeFSK, ModeAFSK, ModeFM:
+		return m, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownMode, s)
+}
+
+// Digital сообщает, выдаёт ли режим поток бит.
+func (m Mode) Digital() bool {
+	return m == ModeFSK || m == ModeAFSK
+}
+
+// Demodulator преобразует комплексные отсчёты в биты; состояние
+// сохраняется между вызовами, так что отсчёты можно подавать блоками
+// любой длины.
+type Demodulator interface {
+	// Demodulate дописывает к dst биты, извлечённые из iq.
+	Demodulate(dst []byte, iq []complex64) []byte
+}
+
+// New создаёт демодулятор режима mode для частоты дискретизации
+// sampleRate. Режим FM не даёт битов и возвращает ErrNotDigital.
+func New(mode Mode, sampleRate float64) (Demodulator, error) {
+	switch mode {
+	case ModeFSK:
+		return NewG3RUH(sampleRate)
+	case ModeAFSK:
+		return NewAFSK1200(sampleRate)
+	case ModeFM:
+		return nil, fmt.Errorf("%w: %s", ErrNotDigital, mode)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownMode, mode)
+}
+
+// decimation возвращает коэффициент децимации, приближающий частоту
+// sampleRate к target сверху.
+func decimation(sampleRate, target float64) int {
+	return max(int(sampleRate/target), 1)
+}
@@ -0,0 +1,203 @@
+package demod
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Генераторы синтетических сигналов для тестов демодуляторов.
+
+func randomBits(rng *rand.Rand, n int) []byte {
+	bits := make([]byte, n)
+	for i := range bits {
+		bits[i] = byte(rng.Intn(2))
+	}
+	return bits
+}
+
+// nrziEncode кодирует NRZI: 1 — уровень сохраняется, 0 — меняется.
+func nrziEncode(bits []byte) []byte {
+	out := make([]byte, len(bits))
+	var level byte
+	for i, b := range bits {
+		if b == 0 {
+			level ^= 1
+		}
+		out[i] = level
+	}
+	return out
+}
+
+// scramble — скремблер G3RUH x^17 + x^12 + 1.
+func scramble(bits []byte) []byte {
+	out := make([]byte, len(bits))
+	var reg uint32
+	for i, b := range bits {
+		out[i] = b ^ byte(reg>>11&1) ^ byte(reg>>16&1)
+		reg = reg<<1 | uint32(out[i])
+	}
+	return out
+}
+
+// fmModulate формирует ЧМ-сигнал единичной амплитуды: audio = 1
+// соответствует отклонению deviation.
+func fmModulate(audio []float64, rate, deviation float64) []complex64 {
+	iq := make([]complex64, len(audio))
+	var phase float64
+	for i, a := range audio {
+		phase += 2 * math.Pi * deviation * a / rate
+		s, c := math.Sincos(phase)
+		iq[i] = complex(float32(c), float32(s))
+	}
+	return iq
+}
+
+// addNoise добавляет комплексный гауссов шум: snr — отношение мощности
+// сигнала к мощности шума во всей полосе дискретизации, дБ.
+func addNoise(rng *rand.Rand, iq []complex64, snr float64) {
+	sigma := math.Sqrt(math.Pow(10, -snr/10) / 2)
+	for i := range iq {
+		iq[i] += complex(float32(sigma*rng.NormFloat64()), float32(sigma*rng.NormFloat64()))
+	}
+}
+
+// samples возвращает число отсчётов для n символов.
+func samples(n int, rate, baud float64) int {
+	return int(float64(n) * rate / baud)
+}
+
+// afskAudio формирует звук AFSK 1200 с непрерывной фазой по уровням
+// линии (1 — mark, 0 — space); амплитуда 1.
+func afskAudio(levels []byte, rate float64) []float64 {
+	audio := make([]float64, samples(len(levels), rate, afskBaud))
+	var phase float64
+	for i := range audio {
+		freq := float64(afskSpace)
+		if levels[int(float64(i)*afskBaud/rate)] == 1 {
+			freq = afskMark
+		}
+		phase += 2 * math.Pi * freq / rate
+		audio[i] = math.Sin(phase)
+	}
+	return audio
+}
+
+// gfskAudio формирует модулирующий сигнал GFSK: NRZ ±1 через гауссов
+// фильтр с BT = 0,5.
+func gfskAudio(levels []byte, rate, baud float64) []float64 {
+	nrz := make([]float64, samples(len(levels), rate, baud))
+	for i := range nrz {
+		nrz[i] = -1
+		if levels[int(float64(i)*baud/rate)] == 1 {
+			nrz[i] = 1
+		}
+	}
+
+	const bt = 0.5
+	perSymbol := rate / baud
+	sigma := math.Sqrt(math.Log(2)) / (2 * math.Pi * bt) * perSymbol
+	half := int(3 * sigma)
+	taps := make([]float64, 2*half+1)
+	var sum float64
+	for i := range taps {
+		x := float64(i - half)
+		taps[i] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += taps[i]
+	}
+
+	audio := make([]float64, len(nrz))
+	for i := range audio {
+		for k, h := range taps {
+			if j := i + k - half; j >= 0 && j < len(nrz) {
+				audio[i] += h / sum * nrz[j]
+			}
+		}
+	}
+	return audio
+}
+
+// bitErrorRate сравнивает принятые биты с переданными при лучшем сдвиге,
+// пропуская биты захвата синхронизации в начале и конце.
+func bitErrorRate(want, got []byte) float64 {
+	const margin = 100
+	best := 1.0
+	for off := -20; off <= 50; off++ {
+		var errs, total int
+		for i := margin; i < len(want)-margin; i++ {
+			j := i + off
+			if j < 0 || j >= len(got) {
+				continue
+			}
+			total++
+			if got[j] != want[i] {
+				errs++
+			}
+		}
+		if total > len(want)/2 {
+			best = min(best, float64(errs)/float64(total))
+		}
+	}
+	return best
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{in: "fsk", want: ModeFSK},
+		{in: " AFSK ", want: ModeAFSK},
+		{in: "fm", want: ModeFM},
+		{in: "bpsk", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMode(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(ModeFM, 48000); !errors.Is(err, ErrNotDigital) {
+		t.Errorf("New(fm) error = %v, want ErrNotDigital", err)
+	}
+	if _, err := New(ModeFSK, 19200); !errors.Is(err, ErrSampleRate) {
+		t.Errorf("New(fsk, 19200) error = %v, want ErrSampleRate", err)
+	}
+	if _, err := New(Mode("ssb"), 48000); !errors.Is(err, ErrUnknownMode) {
+		t.Errorf("New(ssb) error = %v, want ErrUnknownMode", err)
+	}
+	for _, mode := range []Mode{ModeAFSK, ModeFSK} {
+		if _, err := New(mode, 1.024e6); err != nil {
+			t.Errorf("New(%s) error = %v", mode, err)
+		}
+	}
+}
+
+func TestFMDiscriminator(t *testing.T) {
+	const rate = 48000
+	audio := make([]float64, 1000)
+	for i := range audio {
+		audio[i] = 0.5
+		if i >= 500 {
+			audio[i] = -1
+		}
+	}
+	iq := fmModulate(audio, rate, 5000)
+
+	d := NewFMDiscriminator(rate, 5000)
+	out := d.Demodulate(nil, iq[:300])
+	out = d.Demodulate(out, iq[300:])
+	if len(out) != len(iq) {
+		t.Fatalf("len(out) = %d, want %d", len(out), len(iq))
+	}
+	for _, i := range []int{1, 299, 300, 499, 501, 999} {
+		if math.Abs(float64(out[i])-audio[i]) > 1e-3 {
+			t.Errorf("out[%d] = %v, want %v", i, out[i], audio[i])
+		}
+	}
+}
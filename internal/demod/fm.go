@@ -0,0 +1,28 @@
+package demod
+
+import "math"
+
+// FMDiscriminator — квадратурный частотный дискриминатор: мгновенная
+// частота вычисляется как аргумент произведения отсчёта на сопряжённый
+// предыдущий. Выход нормирован так, что отклонение deviation даёт 1.
+type FMDiscriminator struct {
+	gain float64
+	prev complex64
+}
+
+// NewFMDiscriminator создаёт дискриминатор для частоты дискретизации
+// sampleRate и девиации deviation, Гц.
+func NewFMDiscriminator(sampleRate, deviation float64) *FMDiscriminator {
+	return &FMDiscriminator{gain: sampleRate / (2 * math.Pi * deviation), prev: 1}
+}
+
+// Demodulate дописывает к dst мгновенную частоту каждого отсчёта iq.
+func (d *FMDiscriminator) Demodulate(dst []float32, iq []complex64) []float32 {
+	for _, x := range iq {
+		p := x * complex(real(d.prev), -imag(d.prev))
+		d.prev = x
+		phase := math.Atan2(float64(imag(p)), float64(real(p)))
+		dst = append(dst, float32(phase*d.gain))
+	}
+	return dst
+}
@@ -0,0 +1,86 @@
+package demod
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/art-injener/satwatch-go/internal/dsp"
+)
+
+// Параметры GFSK 9600 (G3RUH).
+const (
+	g3ruhBaud      = 9600
+	g3ruhDeviation = 3000 // Гц
+	g3ruhChannel   = 9000 // половина полосы канала, Гц
+	g3ruhRate      = 96000
+)
+
+// G3RUH демодулирует GFSK 9600 бод: выделение канала ±9 кГц с децимацией
+// до ~96 кГц, частотный дискриминатор, сглаживание скользящим средним
+// на половину символа, удаление постоянной составляющей (ухода частоты),
+// восстановление тактовой частоты, дескремблирование x^17 + x^12 + 1
+// и декодирование NRZI.
+type G3RUH struct {
+	dec   *dsp.Decimator
+	fm    *FMDiscriminator
+	avg   movingAverage
+	dc    float32
+	alpha float32
+	clock clockRecovery
+	desc  descrambler
+	nrzi  nrzi
+
+	iq    []complex64
+	audio []float32
+}
+
+// NewG3RUH создаёт демодулятор для частоты дискретизации sampleRate;
+// после децимации на символ должно приходиться не меньше 4 отсчётов.
+func NewG3RUH(sampleRate float64) (*G3RUH, error) {
+	if sampleRate < 4*g3ruhBaud {
+		return nil, fmt.Errorf("%w: %.0f Hz for G3RUH 9600", ErrSampleRate, sampleRate)
+	}
+	dec := dsp.NewDecimator(decimation(sampleRate, g3ruhRate), g3ruhChannel/sampleRate)
+	rate := sampleRate / float64(dec.Factor())
+	perSymbol := rate / g3ruhBaud
+	return &G3RUH{
+		dec: dec,
+		fm:  NewFMDiscriminator(rate, g3ruhDeviation),
+		avg: newMovingAverage(max(int(math.Round(perSymbol/2)), 1)),
+		// Постоянная составляющая усредняется примерно по 100 символам.
+		alpha: float32(1 / (100 * perSymbol)),
+		clock: newClockRecovery(rate, g3ruhBaud),
+	}, nil
+}
+
+// Demodulate дописывает к dst биты, извлечённые из iq.
+func (g *G3RUH) Demodulate(dst []byte, iq []complex64) []byte {
+	g.iq = g.dec.Process(g.iq[:0], iq)
+	g.audio = g.fm.Demodulate(g.audio[:0], g.iq)
+	for _, v := range g.audio {
+		v = g.avg.process(v)
+		g.dc += g.alpha * (v - g.dc)
+		if bit, ok := g.clock.process(v - g.dc); ok {
+			dst = append(dst, g.nrzi.decode(g.desc.decode(bit)))
+		}
+	}
+	return dst
+}
+
+// movingAverage — скользящее среднее по фиксированному окну.
+type movingAverage struct {
+	ring []float32
+	pos  int
+	sum  float64
+}
+
+func newMovingAverage(n int) movingAverage {
+	return movingAverage{ring: make([]float32, n)}
+}
+
+func (m *movingAverage) process(v float32) float32 {
+	m.sum += float64(v) - float64(m.ring[m.pos])
+	m.ring[m.pos] = v
+	m.pos = (m.pos + 1) % len(m.ring)
+	return float32(m.sum / float64(len(m.ring)))
+}
@@ -0,0 +1,47 @@
+package demod
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestG3RUH(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   float64
+		offset float64 // уход частоты, доля девиации
+		snr    float64 // дБ во всей полосе дискретизации
+		maxBER float64
+	}{
+		{name: "clean 96k", rate: 96000, snr: 60, maxBER: 0},
+		{name: "decimated 1.024M", rate: 1.024e6, snr: 40, maxBER: 0},
+		{name: "frequency offset", rate: 96000, offset: 0.2, snr: 60, maxBER: 0},
+		{name: "noisy 96k", rate: 96000, snr: 5, maxBER: 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			bits := randomBits(rng, 4000)
+			audio := gfskAudio(scramble(nrziEncode(bits)), tt.rate, g3ruhBaud)
+			for i := range audio {
+				audio[i] += tt.offset
+			}
+			iq := fmModulate(audio, tt.rate, g3ruhDeviation)
+			addNoise(rng, iq, tt.snr)
+
+			d, err := NewG3RUH(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []byte
+			for len(iq) > 0 {
+				n := min(len(iq), 10000)
+				got = d.Demodulate(got, iq[:n])
+				iq = iq[n:]
+			}
+			if ber := bitErrorRate(bits, got); ber > tt.maxBER {
+				t.Errorf("BER = %.4f, want <= %v (%d bits received)", ber, tt.maxBER, len(got))
+			}
+		})
+	}
+}
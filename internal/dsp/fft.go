@@ -0,0 +1,64 @@
+// Package dsp содержит примитивы цифровой обработки сигналов: быстрое
+// преобразование Фурье, оконные функции и усреднённый спектр мощности.
+package dsp
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrFFTSize возвращается для размера БПФ, не являющегося степенью двойки.
+var ErrFFTSize = errors.New("dsp: FFT size must be a power of two")
+
+// FFT — быстрое преобразование Фурье по основанию 2 с заранее
+// рассчитанными поворачивающими множителями.
+type FFT struct {
+	n       int
+	twiddle []complex64 // exp(-2πik/n), k < n/2
+	rev     []int       // перестановка с обратным порядком битов
+}
+
+// NewFFT создаёт преобразование размера n (степень двойки, не меньше 2).
+func NewFFT(n int) (*FFT, error) {
+	if n < 2 || n&(n-1) != 0 {
+		return nil, ErrFFTSize
+	}
+
+	f := &FFT{n: n, twiddle: make([]complex64, n/2), rev: make([]int, n)}
+	for k := range f.twiddle {
+		s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+		f.twiddle[k] = complex(float32(c), float32(s))
+	}
+	shift := bits.UintSize - bits.TrailingZeros(uint(n))
+	for i := range f.rev {
+		f.rev[i] = int(bits.Reverse(uint(i)) >> shift)
+	}
+	return f, nil
+}
+
+// Size возвращает размер преобразования.
+func (f *FFT) Size() int {
+	return f.n
+}
+
+// Transform выполняет прямое преобразование x на месте; len(x) должна
+// совпадать с размером преобразования.
+func (f *FFT) Transform(x []complex64) {
+	x = x[:f.n]
+	for i, j := range f.rev {
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= f.n; size <<= 1 {
+		half, step := size/2, f.n/size
+		for start := 0; start < f.n; start += size {
+			for k := range half {
+				a, b := start+k, start+k+half
+				t := x[b] * f.twiddle[k*step]
+				x[a], x[b] = x[a]+t, x[a]-t
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package dsp
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// dft — прямое вычисление ДПФ для сверки.
+func dft(x []complex64) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		for i, v := range x {
+			out[k] += complex128(v) * cmplx.Exp(complex(0, -2*math.Pi*float64(k*i)/float64(n)))
+		}
+	}
+	return out
+}
+
+func TestFFT_Transform(t *testing.T) {
+	for _, n := range []int{2, 8, 64} {
+		x := make([]complex64, n)
+		for i := range x {
+			x[i] = complex(float32(math.Sin(float64(i)*0.7)), float32(i%3)-1)
+		}
+		want := dft(x)
+
+		f, err := NewFFT(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Transform(x)
+		for k := range x {
+			if d := cmplx.Abs(complex128(x[k]) - want[k]); d > 1e-4 {
+				t.Fatalf("n=%d: bin %d = %v, want %v", n, k, x[k], want[k])
+			}
+		}
+	}
+}
+
+func TestNewFFT_Size(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 1000} {
+		if _, err := NewFFT(n); !errors.Is(err, ErrFFTSize) {
+			t.Errorf("NewFFT(%d) error = %v, want ErrFFTSize", n, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package dsp
+
+import "math"
+
+// LowPass возвращает коэффициенты КИХ-фильтра нижних частот длины taps
+// с частотой среза cutoff в долях частоты дискретизации (0 < cutoff < 0,5):
+// sinc с окном Блэкмана — Харриса, усиление на нулевой частоте — 1.
+func LowPass(cutoff float64, taps int) []float32 {
+	// Периодическое окно длины taps+1 без первого отсчёта симметрично.
+	window := cosineWindow(taps+1, 0.35875, 0.48829, 0.14128, 0.01168)[1:]
+	h := make([]float32, taps)
+	center := float64(taps-1) / 2
+	var sum float64
+	for i := range h {
+		x := float64(i) - center
+		v := 2 * cutoff
+		if x != 0 {
+			v = math.Sin(2*math.Pi*cutoff*x) / (math.Pi * x)
+		}
+		v *= float64(window[i])
+		h[i] = float32(v)
+		sum += v
+	}
+	for i := range h {
+		h[i] = float32(float64(h[i]) / sum)
+	}
+	return h
+}
+
+// Decimator понижает частоту дискретизации комплексного сигнала в целое
+// число раз с предварительной фильтрацией нижних частот: фильтр защищает
+// от наложения спектров и заодно выделяет канал, ограничивая шум.
+type Decimator struct {
+	factor int
+	taps   []float32
+	hist   []complex64
+	next   int // индекс в hist последнего отсчёта окна следующего выхода
+}
+
+// NewDecimator создаёт дециматор с коэффициентом factor (1 — только
+// фильтрация) и частотой среза cutoff в долях входной частоты
+// дискретизации. Срез ограничивается 80 % новой полосы Найквиста;
+// cutoff <= 0 выбирает это значение.
+func NewDecimator(factor int, cutoff float64) *Decimator {
+	factor = max(factor, 1)
+	if limit := 0.4 / float64(factor); cutoff <= 0 || cutoff > limit {
+		cutoff = limit
+	}
+	// Ширина переходной полосы окна Блэкмана — Харриса — около 4/taps.
+	taps := LowPass(cutoff, 2*int(math.Round(2/cutoff))+1)
+	return &Decimator{
+		factor: factor,
+		taps:   taps,
+		hist:   make([]complex64, len(taps)-1),
+		next:   len(taps) - 1,
+	}
+}
+
+// Factor возвращает коэффициент децимации.
+func (d *Decimator) Factor() int {
+	return d.factor
+}
+
+// Process фильтрует src и дописывает к dst каждый factor-й отсчёт;
+// состояние фильтра сохраняется между вызовами.
+func (d *Decimator) Process(dst, src []complex64) []complex64 {
+	d.hist = append(d.hist, src...)
+	n := len(d.taps)
+	i := d.next
+	for ; i < len(d.hist); i += d.factor {
+		var re, im float32
+		for k, v := range d.hist[i-n+1 : i+1] {
+			re += d.taps[k] * real(v)
+			im += d.taps[k] * imag(v)
+		}
+		dst = append(dst, complex(re, im))
+	}
+
+	// Сохраняем хвост длины фильтра для следующего вызова.
+	keep := len(d.hist) - (n - 1)
+	d.hist = append(d.hist[:0], d.hist[keep:]...)
+	d.next = i - keep
+	return dst
+}
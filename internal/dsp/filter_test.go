@@ -0,0 +1,62 @@
+package dsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestLowPass(t *testing.T) {
+	h := LowPass(0.1, 63)
+	response := func(f float64) float64 {
+		var sum complex128
+		for i, v := range h {
+			sum += complex(float64(v), 0) * cmplx.Exp(complex(0, -2*math.Pi*f*float64(i)))
+		}
+		return cmplx.Abs(sum)
+	}
+
+	for i := range h {
+		if h[i] != h[len(h)-1-i] {
+			t.Fatalf("taps are not symmetric at %d", i)
+		}
+	}
+	if g := response(0); math.Abs(g-1) > 1e-6 {
+		t.Errorf("DC gain = %v, want 1", g)
+	}
+	if g := response(0.05); math.Abs(g-1) > 0.01 {
+		t.Errorf("passband gain = %v, want 1", g)
+	}
+	if g := 20 * math.Log10(response(0.2)); g > -60 {
+		t.Errorf("stopband gain = %.1f dB, want below -60", g)
+	}
+}
+
+func TestDecimator(t *testing.T) {
+	const factor = 4
+	// Тон в полосе пропускания и тон, который после децимации наложился бы
+	// на нулевую частоту.
+	in := tone(4096, 64, 1)
+	alias := tone(4096, 1024, 1)
+	for i := range in {
+		in[i] += alias[i]
+	}
+
+	d := NewDecimator(factor, 0)
+	var out []complex64
+	// Неравные куски проверяют сохранение состояния между вызовами.
+	for _, n := range []int{1, 7, 1000, 3088} {
+		out = d.Process(out, in[:n])
+		in = in[n:]
+	}
+	if len(out) != 4096/factor {
+		t.Fatalf("len(out) = %d, want %d", len(out), 4096/factor)
+	}
+
+	// После переходного процесса остаётся только тон амплитуды 1.
+	for i := 100; i < len(out); i++ {
+		if a := cmplx.Abs(complex128(out[i])); math.Abs(a-1) > 0.01 {
+			t.Fatalf("out[%d] amplitude = %v, want 1", i, a)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package dsp
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrFrameSize возвращается, если длина кадра не совпадает с размером БПФ.
+var ErrFrameSize = errors.New("dsp: frame length does not match FFT size")
+
+// minPower ограничивает мощность снизу, чтобы пустой канал давал
+// конечное значение в дБ.
+const minPower = 1e-20
+
+// Spectrum накапливает спектр мощности по кадрам длины Size с окном
+// Блэкмана — Харриса и выдаёт среднее по Averages кадрам в дБ
+// относительно полной шкалы (тон амплитуды 1 — 0 дБFS). Нулевая частота
+// находится в середине результата.
+type Spectrum struct {
+	fft      *FFT
+	window   []float32
+	norm     float64
+	buf      []complex64
+	acc      []float64
+	count    int
+	averages int
+}
+
+// NewSpectrum создаёт накопитель для БПФ размера size с усреднением
+// по averages кадрам (не меньше одного).
+func NewSpectrum(size, averages int) (*Spectrum, error) {
+	fft, err := NewFFT(size)
+	if err != nil {
+		return nil, err
+	}
+	window := BlackmanHarris(size)
+	gain := coherentGain(window)
+	return &Spectrum{
+		fft:      fft,
+		window:   window,
+		norm:     gain * gain,
+		buf:      make([]complex64, size),
+		acc:      make([]float64, size),
+		averages: max(averages, 1),
+	}, nil
+}
+
+// Size возвращает размер БПФ.
+func (s *Spectrum) Size() int {
+	return s.fft.Size()
+}
+
+// Averages возвращает число усредняемых кадров.
+func (s *Spectrum) Averages() int {
+	return s.averages
+}
+
+// SetAverages меняет число усредняемых кадров; накопленное среднее
+// сбрасывается.
+func (s *Spectrum) SetAverages(n int) {
+	s.averages = max(n, 1)
+	s.Reset()
+}
+
+// Reset сбрасывает накопленное среднее.
+func (s *Spectrum) Reset() {
+	clear(s.acc)
+	s.count = 0
+}
+
+// Add добавляет кадр и, если набрано Averages кадров, записывает средний
+// спектр в dst (длина не меньше Size) и возвращает true.
+func (s *Spectrum) Add(frame []complex64, dst []float32) (bool, error) {
+	n := s.fft.Size()
+	if len(frame) != n || len(dst) < n {
+		return false, ErrFrameSize
+	}
+
+	for i, v := range frame {
+		s.buf[i] = v * complex(s.window[i], 0)
+	}
+	s.fft.Transform(s.buf)
+	for i, v := range s.buf {
+		re, im := float64(real(v)), float64(imag(v))
+		s.acc[i] += re*re + im*im
+	}
+	s.count++
+	if s.count < s.averages {
+		return false, nil
+	}
+
+	// Перестановка половин: отрицательные частоты слева от нулевой.
+	scale := 1 / (s.norm * float64(s.count))
+	for i, p := range s.acc {
+		j := (i + n/2) % n
+		dst[j] = float32(10 * math.Log10(math.Max(p*scale, minPower)))
+	}
+	s.Reset()
+	return true, nil
+}
+
+// Decimate уменьшает спектр до width точек, выбирая максимум в каждой
+// группе: узкие сигналы не теряются при прореживании. Если width не
+// меньше длины спектра, возвращается копия.
+func Decimate(db []float32, width int) []float32 {
+	if width <= 0 || width >= len(db) {
+		return append([]float32(nil), db...)
+	}
+	out := make([]float32, width)
+	for i := range out {
+		lo, hi := i*len(db)/width, (i+1)*len(db)/width
+		m := db[lo]
+		for _, v := range db[lo+1 : hi] {
+			m = max(m, v)
+		}
+		out[i] = m
+	}
+	return out
+}
+
+// Quantize переводит значения в дБ в диапазон 0–255: floor и ниже — 0,
+// ceil и выше — 255.
+func Quantize(db []float32, floor, ceil float32) []uint8 {
+	out := make([]uint8, len(db))
+	span := ceil - floor
+	if span <= 0 {
+		return out
+	}
+	for i, v := range db {
+		x := (v - floor) / span * 255
+		out[i] = uint8(math.Round(float64(min(max(x, 0), 255))))
+	}
+	return out
+}
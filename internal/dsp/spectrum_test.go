@@ -0,0 +1,109 @@
+package dsp
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// tone возвращает n отсчётов комплексного тона амплитуды amp на частоте
+// bin (в единицах разрешения БПФ размера n).
+func tone(n int, bin, amp float64) []complex64 {
+	x := make([]complex64, n)
+	for i := range x {
+		s, c := math.Sincos(2 * math.Pi * bin * float64(i) / float64(n))
+		x[i] = complex(float32(amp*c), float32(amp*s))
+	}
+	return x
+}
+
+func TestSpectrum_Tone(t *testing.T) {
+	const size = 256
+	s, err := NewSpectrum(size, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]float32, size)
+	frame := tone(size, 32, 0.5)
+	for i := 0; i < 3; i++ {
+		if ready, err := s.Add(frame, dst); ready || err != nil {
+			t.Fatalf("frame %d: ready = %v, err = %v", i, ready, err)
+		}
+	}
+	if ready, err := s.Add(frame, dst); !ready || err != nil {
+		t.Fatalf("4th frame: ready = %v, err = %v", ready, err)
+	}
+
+	// Тон +32 бина после перестановки оказывается в 128+32.
+	peak := 0
+	for i := range dst {
+		if dst[i] > dst[peak] {
+			peak = i
+		}
+	}
+	if peak != size/2+32 {
+		t.Errorf("peak at bin %d, want %d", peak, size/2+32)
+	}
+	// Амплитуда 0,5 — −6 дБFS; уровень вдали от тона — на пределе окна.
+	if math.Abs(float64(dst[peak])+6.02) > 0.1 {
+		t.Errorf("peak level = %.2f dB, want -6.02", dst[peak])
+	}
+	if dst[10] > -90 {
+		t.Errorf("level far from tone = %.2f dB, want below -90", dst[10])
+	}
+}
+
+func TestSpectrum_FrameSize(t *testing.T) {
+	s, err := NewSpectrum(64, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Add(make([]complex64, 32), make([]float32, 64)); !errors.Is(err, ErrFrameSize) {
+		t.Errorf("Add() error = %v, want ErrFrameSize", err)
+	}
+	if _, err := NewSpectrum(100, 1); !errors.Is(err, ErrFFTSize) {
+		t.Errorf("NewSpectrum(100) error = %v, want ErrFFTSize", err)
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	db := []float32{-100, -20, -100, -100, -90, -100, -100, -100}
+
+	tests := []struct {
+		name  string
+		width int
+		want  []float32
+	}{
+		{"max per group", 4, []float32{-20, -100, -90, -100}},
+		{"uneven groups", 3, []float32{-20, -90, -100}},
+		{"no decimation", 16, db},
+		{"zero width", 0, db},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Decimate(db, tt.width)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Decimate() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Decimate() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	got := Quantize([]float32{-130, -120, -70, -20, 0}, -120, -20)
+	want := []uint8{0, 0, 128, 255, 255}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Quantize() = %v, want %v", got, want)
+			break
+		}
+	}
+}
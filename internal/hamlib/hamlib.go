@@ -0,0 +1,151 @@
+// Package hamlib реализует транспорт текстового протокола сетевых служб
+// Hamlib (rotctld, rigctld): команды построчно по TCP, ответ — строки
+// данных или код "RPRT n".
+package hamlib
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCommandTimeout ограничивает обмен одной командой, если
+// контекст не задаёт более ранний срок.
+const defaultCommandTimeout = 5 * time.Second
+
+// ErrProtocol возвращается при ответе, не соответствующем протоколу.
+var ErrProtocol = errors.New("hamlib: unexpected response")
+
+// ReplyError — отрицательный код RPRT, которым служба сообщает об ошибке
+// (коды Hamlib: -1 неверный параметр, -8 ошибка протокола и т. д.).
+type ReplyError struct {
+	Command string
+	Code    int
+}
+
+func (e *ReplyError) Error() string {
+	return fmt.Sprintf("hamlib: %s: RPRT %d", e.Command, e.Code)
+}
+
+// Conn — соединение со службой Hamlib. Устанавливается при первой
+// команде и после ошибки ввода-вывода открывается заново. Методы
+// безопасны для конкурентного использования: команды выполняются
+// по очереди.
+type Conn struct {
+	addr   string
+	dialer net.Dialer
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewConn создаёт соединение со службой по адресу "host:port".
+func NewConn(addr string) *Conn {
+	return &Conn{addr: addr}
+}
+
+// Addr возвращает адрес службы.
+func (c *Conn) Addr() string {
+	return c.addr
+}
+
+// Command отправляет строку и читает ответ: n строк данных для запросов
+// или "RPRT 0" для команд без данных (n == 0). В любом случае ответ
+// может оказаться строкой "RPRT <код>" с ошибкой.
+func (c *Conn) Command(ctx context.Context, line string, n int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.connectLocked(ctx); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(defaultCommandTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, c.fail(err)
+	}
+
+	if _, err := c.conn.Write([]byte(line + "\n")); err != nil {
+		return nil, c.fail(err)
+	}
+
+	cmd, _, _ := strings.Cut(line, " ")
+	want := max(n, 1)
+	lines := make([]string, 0, want)
+	for len(lines) < want {
+		reply, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, c.fail(err)
+		}
+		reply = strings.TrimSpace(reply)
+
+		if code, ok := strings.CutPrefix(reply, "RPRT "); ok {
+			rc, err := strconv.Atoi(code)
+			switch {
+			case err != nil:
+				return nil, c.fail(fmt.Errorf("%w: %s: %q", ErrProtocol, cmd, reply))
+			case rc != 0:
+				return nil, &ReplyError{Command: cmd, Code: rc}
+			case n == 0:
+				return nil, nil
+			default:
+				// Успешный RPRT вместо данных — рассинхронизация протокола.
+				return nil, c.fail(fmt.Errorf("%w: %s: %q", ErrProtocol, cmd, reply))
+			}
+		}
+		if n == 0 {
+			return nil, c.fail(fmt.Errorf("%w: %s: %q", ErrProtocol, cmd, reply))
+		}
+		lines = append(lines, reply)
+	}
+	return lines, nil
+}
+
+// Close закрывает соединение.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+func (c *Conn) connectLocked(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultCommandTimeout)
+	defer cancel()
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("hamlib: connect %s: %w", c.addr, err)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	return nil
+}
+
+// fail закрывает соединение после ошибки обмена: состояние потока
+// неизвестно, следующая команда откроет новое соединение.
+func (c *Conn) fail(err error) error {
+	_ = c.closeLocked()
+	return err
+}
+
+func (c *Conn) closeLocked() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.reader = nil, nil
+	return err
+}
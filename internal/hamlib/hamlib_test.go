@@ -0,0 +1,179 @@
+package hamlib
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lineServer отвечает на каждую строку результатом handle.
+type lineServer struct {
+	ln     net.Listener
+	handle func(line string) string
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newLineServer(t *testing.T, handle func(line string) string) *lineServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &lineServer{ln: ln, handle: handle}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() {
+		ln.Close()
+		s.drop()
+	})
+	return s
+}
+
+func (s *lineServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		if _, err := conn.Write([]byte(s.handle(scanner.Text()))); err != nil {
+			return
+		}
+	}
+}
+
+// drop разрывает открытые соединения.
+func (s *lineServer) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func TestConn_Command(t *testing.T) {
+	replies := map[string]string{
+		"f":     "145800000\n",
+		"p":     "180.000000\n45.000000\n",
+		"F 1":   "RPRT 0\n",
+		"F -1":  "RPRT -1\n",
+		"bad":   "RPRT x\n",
+		"early": "RPRT 0\n",
+		"noisy": "42\n",
+	}
+	srv := newLineServer(t, func(line string) string { return replies[line] })
+	conn := NewConn(srv.ln.Addr().String())
+	defer conn.Close()
+
+	tests := []struct {
+		name     string
+		line     string
+		n        int
+		want     []string
+		code     int
+		protoErr bool
+	}{
+		{name: "one line", line: "f", n: 1, want: []string{"145800000"}},
+		{name: "two lines", line: "p", n: 2, want: []string{"180.000000", "45.000000"}},
+		{name: "report success", line: "F 1", n: 0},
+		{name: "report error", line: "F -1", n: 0, code: -1},
+		{name: "malformed report", line: "bad", n: 0, protoErr: true},
+		{name: "report instead of data", line: "early", n: 1, protoErr: true},
+		{name: "data instead of report", line: "noisy", n: 0, protoErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conn.Command(context.Background(), tt.line, tt.n)
+			var replyErr *ReplyError
+			switch {
+			case tt.protoErr:
+				if !errors.Is(err, ErrProtocol) {
+					t.Errorf("error = %v, want ErrProtocol", err)
+				}
+			case tt.code != 0:
+				if !errors.As(err, &replyErr) || replyErr.Code != tt.code || replyErr.Command != "F" {
+					t.Errorf("error = %v, want RPRT %d", err, tt.code)
+				}
+			case err != nil:
+				t.Fatalf("Command() error = %v", err)
+			case len(got) != len(tt.want):
+				t.Errorf("Command() = %q, want %q", got, tt.want)
+			default:
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Errorf("Command() = %q, want %q", got, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConn_Reconnect(t *testing.T) {
+	srv := newLineServer(t, func(string) string { return "RPRT 0\n" })
+	conn := NewConn(srv.ln.Addr().String())
+	defer conn.Close()
+	ctx := context.Background()
+
+	if _, err := conn.Command(ctx, "S", 0); err != nil {
+		t.Fatalf("Command() error = %v", err)
+	}
+	srv.drop()
+
+	// Первая команда после разрыва завершается ошибкой, следующая
+	// выполняется по новому соединению.
+	if _, err := conn.Command(ctx, "S", 0); err == nil {
+		t.Fatal("Command() after disconnect: expected error")
+	}
+	if _, err := conn.Command(ctx, "S", 0); err != nil {
+		t.Fatalf("Command() after reconnect error = %v", err)
+	}
+}
+
+func TestConn_Timeout(t *testing.T) {
+	// Сервер не отвечает: команда завершается по сроку контекста.
+	srv := newLineServer(t, func(string) string { return "" })
+	conn := NewConn(srv.ln.Addr().String())
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := conn.Command(ctx, "p", 2); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("command took %v, want context deadline", elapsed)
+	}
+}
+
+func TestConn_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	conn := NewConn(addr)
+	if _, err := conn.Command(context.Background(), "p", 2); err == nil {
+		t.Fatal("expected connection error")
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/art-injener/satwatch-go/internal/config"
+	"github.com/art-injener/satwatch-go/internal/station"
 )
 
 const (
@@ -14,13 +14,13 @@ const (
 
 // APIHandler обрабатывает REST API запросы.
 type APIHandler struct {
-	config *config.Config
+	stations *station.Registry
 }
 
 // NewAPIHandler создаёт новый API обработчик.
-func NewAPIHandler(cfg *config.Config) *APIHandler {
+func NewAPIHandler(stations *station.Registry) *APIHandler {
 	return &APIHandler{
-		config: cfg,
+		stations: stations,
 	}
 }
 
@@ -33,6 +33,25 @@ func writeJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// writeError записывает JSON ответ с описанием ошибки.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{
+		"error": msg,
+	})
+}
+
+// decodeJSON разбирает тело запроса в v; неизвестные поля считаются ошибкой.
+// При ошибке записывает ответ 400 и возвращает false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
 // HealthCheck возвращает статус работоспособности сервера.
 func (h *APIHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{
@@ -40,13 +59,17 @@ func (h *APIHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetConfig возвращает текущую конфигурацию.
+// GetConfig возвращает текущую конфигурацию: местоположение активной
+// станции и список станций.
 func (h *APIHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	active := h.stations.Active()
 	writeJSON(w, http.StatusOK, map[string]any{
 		"observer": map[string]float64{
-			"lat": h.config.ObserverLat,
-			"lon": h.config.ObserverLon,
-			"alt": h.config.ObserverAlt,
+			"lat": active.Latitude,
+			"lon": active.Longitude,
+			"alt": active.Altitude,
 		},
+		"station":  active,
+		"stations": h.stations.List(),
 	})
 }
@@ -5,32 +5,23 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-
-	"github.com/art-injener/satwatch-go/internal/config"
 )
 
 func TestNewAPIHandler(t *testing.T) {
-	cfg := &config.Config{
-		Port:        "8080",
-		ObserverLat: 47.315813,
-		ObserverLon: 39.788243,
-		ObserverAlt: 70.0,
-	}
-
-	handler := NewAPIHandler(cfg)
+	stations := newTestStations(t)
+	handler := NewAPIHandler(stations)
 
 	if handler == nil {
 		t.Fatal("NewAPIHandler returned nil")
 	}
 
-	if handler.config != cfg {
-		t.Error("NewAPIHandler did not store config correctly")
+	if handler.stations != stations {
+		t.Error("NewAPIHandler did not store stations correctly")
 	}
 }
 
 func TestAPIHandler_HealthCheck(t *testing.T) {
-	cfg := &config.Config{Port: "8080"}
-	handler := NewAPIHandler(cfg)
+	handler := NewAPIHandler(newTestStations(t))
 
 	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
 	w := httptest.NewRecorder()
@@ -60,13 +51,11 @@ func TestAPIHandler_HealthCheck(t *testing.T) {
 }
 
 func TestAPIHandler_GetConfig(t *testing.T) {
-	cfg := &config.Config{
-		Port:        "8080",
-		ObserverLat: 51.5074,
-		ObserverLon: -0.1278,
-		ObserverAlt: 11.0,
+	stations := newTestStations(t)
+	if _, err := stations.SetActive("santiago"); err != nil {
+		t.Fatal(err)
 	}
-	handler := NewAPIHandler(cfg)
+	handler := NewAPIHandler(stations)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
 	w := httptest.NewRecorder()
@@ -95,16 +84,24 @@ func TestAPIHandler_GetConfig(t *testing.T) {
 		t.Fatal("Expected observer object in response")
 	}
 
-	if lat := observer["lat"].(float64); lat != cfg.ObserverLat {
-		t.Errorf("Expected lat %f, got %f", cfg.ObserverLat, lat)
+	if lat := observer["lat"].(float64); lat != -33.45 {
+		t.Errorf("Expected lat %f, got %f", -33.45, lat)
+	}
+
+	if lon := observer["lon"].(float64); lon != -70.66 {
+		t.Errorf("Expected lon %f, got %f", -70.66, lon)
+	}
+
+	if alt := observer["alt"].(float64); alt != 570.0 {
+		t.Errorf("Expected alt %f, got %f", 570.0, alt)
 	}
 
-	if lon := observer["lon"].(float64); lon != cfg.ObserverLon {
-		t.Errorf("Expected lon %f, got %f", cfg.ObserverLon, lon)
+	if st, ok := body["station"].(map[string]any); !ok || st["id"] != "santiago" {
+		t.Errorf("Expected active station santiago, got %v", body["station"])
 	}
 
-	if alt := observer["alt"].(float64); alt != cfg.ObserverAlt {
-		t.Errorf("Expected alt %f, got %f", cfg.ObserverAlt, alt)
+	if list, ok := body["stations"].([]any); !ok || len(list) != 2 {
+		t.Errorf("Expected 2 stations, got %v", body["stations"])
 	}
 }
 
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/station"
+)
+
+var errNoUpcomingPass = errors.New("no upcoming pass within the prediction window")
+
+// ClockHandler управляет общими модельными часами сервера.
+type ClockHandler struct {
+	clock    *simclock.Clock
+	store    catalog.Store
+	stations *station.Registry
+}
+
+// NewClockHandler создаёт обработчик управления часами; store и stations
+// нужны для перехода к ближайшему пролёту.
+func NewClockHandler(clock *simclock.Clock, store catalog.Store, stations *station.Registry) *ClockHandler {
+	return &ClockHandler{
+		clock:    clock,
+		store:    store,
+		stations: stations,
+	}
+}
+
+// rateRequest — тело запроса изменения скорости.
+type rateRequest struct {
+	Rate float64 `json:"rate"`
+}
+
+// jumpRequest — тело запроса перехода к моменту времени (RFC 3339).
+type jumpRequest struct {
+	Time time.Time `json:"time"`
+}
+
+// jumpPassResponse — ответ перехода к ближайшему пролёту.
+type jumpPassResponse struct {
+	Clock simclock.State `json:"clock"`
+	Pass  satellitePass  `json:"pass"`
+}
+
+// State возвращает состояние часов.
+func (h *ClockHandler) State(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
+
+// SetRate задаёт множитель скорости модельного времени.
+func (h *ClockHandler) SetRate(w http.ResponseWriter, r *http.Request) {
+	var req rateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.clock.SetRate(req.Rate); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
+
+// Pause останавливает модельное время.
+func (h *ClockHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.clock.Pause()
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
+
+// Resume продолжает ход модельного времени.
+func (h *ClockHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.clock.Resume()
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
+
+// Jump переводит часы на заданный момент.
+func (h *ClockHandler) Jump(w http.ResponseWriter, r *http.Request) {
+	var req jumpRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.clock.Jump(req.Time); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
+
+// JumpNextAOS переводит часы на начало ближайшего пролёта, который
+// ещё не начался. Параметр sat задаёт номер NORAD; по умолчанию
+// выбирается ближайший пролёт среди всех спутников каталога с TLE.
+// Параметр station задаёт станцию (по умолчанию активную).
+func (h *ClockHandler) JumpNextAOS(w http.ResponseWriter, r *http.Request) {
+	_, predictor, status, err := resolveStation(h.stations, r)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	sats, status, err := satellitesWithTLE(h.store, r.URL.Query().Get("sat"))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	now := h.clock.Now()
+	var (
+		next  satellitePass
+		found bool
+	)
+	for _, sat := range sats {
+		passes, err := predictSatellite(predictor, sat, now)
+		if err != nil {
+			slog.Warn("pass prediction failed", "norad_id", sat.NoradID, slogKeyError, err)
+			continue
+		}
+		for _, p := range passes {
+			// Текущий пролёт пропускается: переход только вперёд.
+			if !p.AOS.After(now) {
+				continue
+			}
+			if !found || p.AOS.Before(next.AOS) {
+				next, found = p, true
+			}
+			break
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, errNoUpcomingPass.Error())
+		return
+	}
+
+	if err := h.clock.Jump(next.AOS); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, jumpPassResponse{Clock: h.clock.State(), Pass: next})
+}
+
+// Reset возвращает часы к реальному времени.
+func (h *ClockHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	h.clock.Reset()
+	writeJSON(w, http.StatusOK, h.clock.State())
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/simclock"
+)
+
+func newClockMux(t *testing.T) (*http.ServeMux, *simclock.Clock) {
+	t.Helper()
+
+	clock := newTestClock(t)
+	h := NewClockHandler(clock, newPassStore(t), newTestStations(t))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/clock", h.State)
+	mux.HandleFunc("POST /api/clock/rate", h.SetRate)
+	mux.HandleFunc("POST /api/clock/pause", h.Pause)
+	mux.HandleFunc("POST /api/clock/resume", h.Resume)
+	mux.HandleFunc("POST /api/clock/jump", h.Jump)
+	mux.HandleFunc("POST /api/clock/next-aos", h.JumpNextAOS)
+	mux.HandleFunc("POST /api/clock/reset", h.Reset)
+	return mux, clock
+}
+
+func TestClockHandler_Control(t *testing.T) {
+	mux, clock := newClockMux(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"state", http.MethodGet, "/api/clock", "", http.StatusOK},
+		{"rate", http.MethodPost, "/api/clock/rate", `{"rate":60}`, http.StatusOK},
+		{"rate out of range", http.MethodPost, "/api/clock/rate", `{"rate":0}`, http.StatusBadRequest},
+		{"rate unknown field", http.MethodPost, "/api/clock/rate", `{"speed":2}`, http.StatusBadRequest},
+		{"jump", http.MethodPost, "/api/clock/jump", `{"time":"2008-09-21T00:00:00Z"}`, http.StatusOK},
+		{"jump invalid time", http.MethodPost, "/api/clock/jump", `{"time":"tomorrow"}`, http.StatusBadRequest},
+		{"jump without time", http.MethodPost, "/api/clock/jump", `{}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+		})
+	}
+
+	st := clock.State()
+	want := time.Date(2008, time.September, 21, 0, 0, 0, 0, time.UTC)
+	if !st.Time.Equal(want) || st.Rate != 60 || !st.Paused {
+		t.Errorf("clock = %+v, want paused at %v with rate 60", st, want)
+	}
+
+	for _, path := range []string{"/api/clock/resume", "/api/clock/reset"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		var got simclock.State
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Paused {
+			t.Errorf("%s: clock is still paused", path)
+		}
+	}
+	if st := clock.State(); st.Rate != 1 || st.Offset > 1 || st.Offset < -1 {
+		t.Errorf("clock after reset = %+v, want real time", st)
+	}
+}
+
+func TestClockHandler_JumpNextAOS(t *testing.T) {
+	mux, clock := newClockMux(t)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/clock/next-aos?sat=25544", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp jumpPassResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pass.NoradID != 25544 || !resp.Pass.AOS.After(testEpoch) {
+		t.Fatalf("pass = %+v, want ISS pass after %v", resp.Pass, testEpoch)
+	}
+	if now := clock.Now(); !now.Equal(resp.Pass.AOS) || !resp.Clock.Time.Equal(now) {
+		t.Errorf("clock = %v, response clock %v, want AOS %v", now, resp.Clock.Time, resp.Pass.AOS)
+	}
+
+	// Повторный переход ведёт к следующему пролёту, а не к текущему.
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/clock/next-aos", nil))
+	var second jumpPassResponse
+	if err := json.NewDecoder(w.Body).Decode(&second); err != nil {
+		t.Fatal(err)
+	}
+	if !second.Pass.AOS.After(resp.Pass.LOS) {
+		t.Errorf("second AOS %v is not after first LOS %v", second.Pass.AOS, resp.Pass.LOS)
+	}
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"?sat=x", http.StatusBadRequest},
+		{"?sat=1", http.StatusNotFound},
+		{"?sat=99999", http.StatusUnprocessableEntity},
+		{"?station=kazan", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/clock/next-aos"+tt.query, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.query, w.Code, tt.status)
+		}
+	}
+}
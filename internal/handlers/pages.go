@@ -5,7 +5,10 @@ import (
 	"log/slog"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"sync"
+
+	"github.com/art-injener/satwatch-go/internal/station"
 )
 
 const (
@@ -22,14 +25,17 @@ type PageHandler struct {
 	mu        sync.RWMutex
 	devMode   bool
 	tmplDir   string
+	stations  *station.Registry
 }
 
-// NewPageHandler создаёт новый обработчик страниц.
+// NewPageHandler создаёт новый обработчик страниц; страницы показывают
+// активную станцию реестра stations (nil — без станции).
 // Если devMode равен true, шаблоны перезагружаются при каждом запросе.
-func NewPageHandler(tmplDir string, devMode bool) (*PageHandler, error) {
+func NewPageHandler(tmplDir string, devMode bool, stations *station.Registry) (*PageHandler, error) {
 	h := &PageHandler{
-		devMode: devMode,
-		tmplDir: tmplDir,
+		devMode:  devMode,
+		tmplDir:  tmplDir,
+		stations: stations,
 	}
 
 	if err := h.loadTemplates(); err != nil {
@@ -95,10 +101,39 @@ func (h *PageHandler) render(w http.ResponseWriter, name string, data any) {
 	}
 }
 
+// fragment выполняет шаблон name в строку — для фрагментов, которые
+// передаются в событиях SSE.
+func (h *PageHandler) fragment(name string, data any) (string, error) {
+	h.mu.RLock()
+	tmpl := h.templates
+	h.mu.RUnlock()
+
+	var sb strings.Builder
+	if err := tmpl.ExecuteTemplate(&sb, name, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
 // PageData содержит общие данные для рендеринга страниц.
 type PageData struct {
 	Title     string
 	ActiveTab string
+	Station   station.Station
+	Stations  []station.Station
+}
+
+// page возвращает данные страницы с активной станцией.
+func (h *PageHandler) page(title, tab string) PageData {
+	data := PageData{
+		Title:     title,
+		ActiveTab: tab,
+	}
+	if h.stations != nil {
+		data.Station = h.stations.Active()
+		data.Stations = h.stations.List()
+	}
+	return data
 }
 
 // Index перенаправляет на страницу отслеживания.
@@ -108,27 +143,15 @@ func (h *PageHandler) Index(w http.ResponseWriter, r *http.Request) {
 
 // Tracking рендерит страницу отслеживания (вкладка 1).
 func (h *PageHandler) Tracking(w http.ResponseWriter, r *http.Request) {
-	data := PageData{
-		Title:     "Отслеживание - SatWatch",
-		ActiveTab: "tracking",
-	}
-	h.render(w, templateBaseName, data)
+	h.render(w, templateBaseName, h.page("Отслеживание - SatWatch", "tracking"))
 }
 
 // Receiver рендерит страницу приёмника (вкладка 2).
 func (h *PageHandler) Receiver(w http.ResponseWriter, r *http.Request) {
-	data := PageData{
-		Title:     "Приёмник - SatWatch",
-		ActiveTab: "receiver",
-	}
-	h.render(w, templateBaseName, data)
+	h.render(w, templateBaseName, h.page("Приёмник - SatWatch", "receiver"))
 }
 
 // Simulation рендерит страницу имитации (вкладка 3).
 func (h *PageHandler) Simulation(w http.ResponseWriter, r *http.Request) {
-	data := PageData{
-		Title:     "Имитация - SatWatch",
-		ActiveTab: "simulation",
-	}
-	h.render(w, templateBaseName, data)
+	h.render(w, templateBaseName, h.page("Имитация - SatWatch", "simulation"))
 }
@@ -32,7 +32,7 @@ func TestNewPageHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	handler, err := NewPageHandler(tmpDir, false)
+	handler, err := NewPageHandler(tmpDir, false, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -51,7 +51,7 @@ func TestNewPageHandler(t *testing.T) {
 }
 
 func TestNewPageHandler_InvalidDirectory(t *testing.T) {
-	_, err := NewPageHandler("/nonexistent/directory", false)
+	_, err := NewPageHandler("/nonexistent/directory", false, nil)
 	if err == nil {
 		t.Error("Expected error for nonexistent directory, got nil")
 	}
@@ -70,7 +70,7 @@ func TestPageHandler_Index(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	handler, err := NewPageHandler(tmpDir, false)
+	handler, err := NewPageHandler(tmpDir, false, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -95,7 +95,7 @@ func TestPageHandler_Index(t *testing.T) {
 
 func TestPageHandler_Tracking(t *testing.T) {
 	tmpDir := setupTestTemplates(t)
-	handler, err := NewPageHandler(tmpDir, false)
+	handler, err := NewPageHandler(tmpDir, false, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -120,7 +120,7 @@ func TestPageHandler_Tracking(t *testing.T) {
 
 func TestPageHandler_Receiver(t *testing.T) {
 	tmpDir := setupTestTemplates(t)
-	handler, err := NewPageHandler(tmpDir, false)
+	handler, err := NewPageHandler(tmpDir, false, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -140,7 +140,7 @@ func TestPageHandler_Receiver(t *testing.T) {
 
 func TestPageHandler_Simulation(t *testing.T) {
 	tmpDir := setupTestTemplates(t)
-	handler, err := NewPageHandler(tmpDir, false)
+	handler, err := NewPageHandler(tmpDir, false, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -160,7 +160,7 @@ func TestPageHandler_Simulation(t *testing.T) {
 
 func TestPageHandler_DevMode(t *testing.T) {
 	tmpDir := setupTestTemplates(t)
-	handler, err := NewPageHandler(tmpDir, true)
+	handler, err := NewPageHandler(tmpDir, true, nil)
 	if err != nil {
 		t.Fatalf("NewPageHandler failed: %v", err)
 	}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/station"
+)
+
+const (
+	// maxPassHours ограничивает окно прогноза, запрашиваемое через API.
+	maxPassHours = 7 * 24
+
+	passesTemplate = "passes-table"
+
+	// Форматы времени в таблице пролётов (UTC).
+	passDateTimeLayout = "02.01 15:04:05"
+	passTimeLayout     = "15:04:05"
+)
+
+var (
+	errNoTLE        = errors.New("satellite has no TLE")
+	errInvalidID    = errors.New("invalid NORAD ID")
+	errInternal     = errors.New("internal error")
+	errInvalidHours = fmt.Errorf("hours must be a number in (0, %d]", maxPassHours)
+)
+
+// PassHandler отдаёт прогноз пролётов спутников каталога.
+type PassHandler struct {
+	store    catalog.Store
+	pages    *PageHandler
+	stations *station.Registry
+	now      func() time.Time
+}
+
+// NewPassHandler создаёт обработчик прогноза пролётов для станций реестра.
+// Прогноз строится от времени clock.
+func NewPassHandler(store catalog.Store, pages *PageHandler, stations *station.Registry, clock *simclock.Clock) *PassHandler {
+	return &PassHandler{
+		store:    store,
+		pages:    pages,
+		stations: stations,
+		now:      clock.Now,
+	}
+}
+
+// satellitePass — пролёт вместе с параметрами спутника.
+type satellitePass struct {
+	NoradID       int     `json:"norad_id"`
+	SatelliteName string  `json:"satellite_name"`
+	Frequency     float64 `json:"frequency_mhz"`
+	Modulation    string  `json:"modulation"`
+	pass.Pass
+}
+
+// passRow — строка шаблона passes-table.
+type passRow struct {
+	SatelliteName string
+	AOS           string
+	TCA           string
+	LOS           string
+	MaxElevation  string
+	Frequency     string
+	Modulation    string
+}
+
+// List возвращает пролёты в формате JSON.
+// Параметры запроса: sat — номер NORAD (по умолчанию все спутники с TLE),
+// hours — окно прогноза в часах, station — станция (по умолчанию активная).
+func (h *PassHandler) List(w http.ResponseWriter, r *http.Request) {
+	passes, status, err := h.predict(r)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, passes)
+}
+
+// Partial рендерит таблицу пролётов для HTMX.
+func (h *PassHandler) Partial(w http.ResponseWriter, r *http.Request) {
+	passes, status, err := h.predict(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	rows := make([]passRow, 0, len(passes))
+	for _, p := range passes {
+		rows = append(rows, passRow{
+			SatelliteName: p.SatelliteName,
+			AOS:           p.AOS.UTC().Format(passDateTimeLayout),
+			TCA:           p.TCA.UTC().Format(passTimeLayout),
+			LOS:           p.LOS.UTC().Format(passTimeLayout),
+			MaxElevation:  strconv.FormatFloat(p.MaxElevation, 'f', 1, 64),
+			Frequency:     strconv.FormatFloat(p.Frequency, 'f', 3, 64),
+			Modulation:    p.Modulation,
+		})
+	}
+	h.pages.render(w, passesTemplate, map[string]any{
+		"Passes": rows,
+	})
+}
+
+// predict разбирает параметры запроса и считает пролёты, отсортированные по AOS.
+func (h *PassHandler) predict(r *http.Request) ([]satellitePass, int, error) {
+	_, predictor, status, err := resolveStation(h.stations, r)
+	if err != nil {
+		return nil, status, err
+	}
+	if raw := r.URL.Query().Get("hours"); raw != "" {
+		hours, err := strconv.ParseFloat(raw, 64)
+		if err != nil || hours <= 0 || hours > maxPassHours {
+			return nil, http.StatusBadRequest, errInvalidHours
+		}
+		opts := predictor.Options()
+		opts.Lookahead = time.Duration(hours * float64(time.Hour))
+		if predictor, err = pass.NewPredictor(predictor.Observer(), opts); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+
+	sats, status, err := satellitesWithTLE(h.store, r.URL.Query().Get("sat"))
+	if err != nil {
+		return nil, status, err
+	}
+
+	from := h.now()
+	single := len(sats) == 1 && r.URL.Query().Get("sat") != ""
+	result := []satellitePass{}
+	for _, sat := range sats {
+		passes, err := predictSatellite(predictor, sat, from)
+		if err != nil {
+			if single {
+				return nil, http.StatusUnprocessableEntity, err
+			}
+			slog.Warn("pass prediction failed", "norad_id", sat.NoradID, slogKeyError, err)
+			continue
+		}
+		result = append(result, passes...)
+	}
+
+	slices.SortFunc(result, func(a, b satellitePass) int {
+		return a.AOS.Compare(b.AOS)
+	})
+	return result, http.StatusOK, nil
+}
+
+// satellitesWithTLE возвращает спутники для прогноза: один по номеру NORAD
+// или все спутники каталога, у которых есть TLE.
+func satellitesWithTLE(store catalog.Store, rawID string) ([]catalog.Satellite, int, error) {
+	if rawID != "" {
+		sat, status, err := satelliteWithTLE(store, rawID)
+		if err != nil {
+			return nil, status, err
+		}
+		return []catalog.Satellite{sat}, http.StatusOK, nil
+	}
+
+	all, err := store.List()
+	if err != nil {
+		slog.Error("catalog store failure", slogKeyError, err)
+		return nil, http.StatusInternalServerError, errInternal
+	}
+	return slices.DeleteFunc(all, func(sat catalog.Satellite) bool {
+		_, ok := sat.LatestTLE()
+		return !ok
+	}), http.StatusOK, nil
+}
+
+// predictSatellite считает пролёты одного спутника по последнему TLE.
+func predictSatellite(predictor *pass.Predictor, sat catalog.Satellite, from time.Time) ([]satellitePass, error) {
+	set, _ := sat.LatestTLE()
+	prop, err := orbit.New(set.Elements())
+	if err != nil {
+		return nil, err
+	}
+	passes, err := predictor.Passes(prop, from)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]satellitePass, 0, len(passes))
+	for _, p := range passes {
+		result = append(result, satellitePass{
+			NoradID:       sat.NoradID,
+			SatelliteName: sat.Name,
+			Frequency:     sat.Downlink,
+			Modulation:    sat.Modulation,
+			Pass:          p,
+		})
+	}
+	return result, nil
+}
+
+// satelliteWithTLE возвращает спутник по номеру NORAD из запроса;
+// спутник должен иметь хотя бы один набор элементов.
+func satelliteWithTLE(store catalog.Store, rawID string) (catalog.Satellite, int, error) {
+	id, err := strconv.Atoi(rawID)
+	if err != nil || id <= 0 {
+		return catalog.Satellite{}, http.StatusBadRequest, errInvalidID
+	}
+	sat, err := store.Get(id)
+	if errors.Is(err, catalog.ErrNotFound) {
+		return catalog.Satellite{}, http.StatusNotFound, err
+	}
+	if err != nil {
+		slog.Error("catalog store failure", slogKeyError, err)
+		return catalog.Satellite{}, http.StatusInternalServerError, errInternal
+	}
+	if _, ok := sat.LatestTLE(); !ok {
+		return catalog.Satellite{}, http.StatusUnprocessableEntity, errNoTLE
+	}
+	return sat, http.StatusOK, nil
+}
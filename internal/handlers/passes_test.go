@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// testEpoch — эпоха тестового TLE МКС.
+var testEpoch = time.Date(2008, time.September, 20, 12, 25, 40, 0, time.UTC)
+
+// newTestClock возвращает модельные часы, остановленные на testEpoch.
+func newTestClock(t *testing.T) *simclock.Clock {
+	t.Helper()
+
+	clock := simclock.New()
+	if err := clock.Jump(testEpoch); err != nil {
+		t.Fatal(err)
+	}
+	clock.Pause()
+	return clock
+}
+
+func newPassMux(t *testing.T, store catalog.Store) *http.ServeMux {
+	t.Helper()
+
+	tmpDir := setupTestTemplates(t)
+	partialsDir := filepath.Join(tmpDir, "partials")
+	if err := os.MkdirAll(partialsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	partial := `{{define "passes-table"}}{{range .Passes}}<tr><td>{{.SatelliteName}}</td>` +
+		`<td>{{.AOS}}</td><td>{{.MaxElevation}}</td><td>{{.Frequency}}</td></tr>{{end}}{{end}}`
+	if err := os.WriteFile(filepath.Join(partialsDir, "passes_table.html"), []byte(partial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pages, err := NewPageHandler(tmpDir, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewPassHandler(store, pages, newTestStations(t), newTestClock(t))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/passes", h.List)
+	mux.HandleFunc("GET /partials/passes", h.Partial)
+	return mux
+}
+
+func newPassStore(t *testing.T) catalog.Store {
+	t.Helper()
+
+	set, err := tle.ParseTLE("ISS", testTLELine1, testTLELine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := catalog.NewMemoryStore()
+	sats := []catalog.Satellite{
+		{NoradID: 25544, Name: "ISS", Downlink: 145.8, Modulation: "fm", TLEHistory: []tle.ElementSet{set}},
+		{NoradID: 99999, Name: "NO TLE"},
+	}
+	for _, sat := range sats {
+		if err := store.Create(sat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func TestPassHandler_List(t *testing.T) {
+	mux := newPassMux(t, newPassStore(t))
+
+	resp := doRequest(t, mux, http.MethodGet, "/api/passes?sat=25544&hours=12", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var passes []satellitePass
+	if err := json.NewDecoder(resp.Body).Decode(&passes); err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) == 0 {
+		t.Fatal("expected passes in 12 hours")
+	}
+	end := testEpoch.Add(12 * time.Hour)
+	for i, p := range passes {
+		if p.NoradID != 25544 || p.SatelliteName != "ISS" || p.Frequency != 145.8 {
+			t.Errorf("pass %d = %+v, want ISS metadata", i, p)
+		}
+		if p.LOS.After(end) {
+			t.Errorf("pass %d LOS %v after window end %v", i, p.LOS, end)
+		}
+		if i > 0 && p.AOS.Before(passes[i-1].AOS) {
+			t.Errorf("passes not sorted by AOS")
+		}
+	}
+}
+
+func TestPassHandler_ListErrors(t *testing.T) {
+	mux := newPassMux(t, newPassStore(t))
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"unknown satellite", "?sat=1", http.StatusNotFound},
+		{"invalid id", "?sat=abc", http.StatusBadRequest},
+		{"no TLE", "?sat=99999", http.StatusUnprocessableEntity},
+		{"zero hours", "?hours=0", http.StatusBadRequest},
+		{"too many hours", "?hours=1000", http.StatusBadRequest},
+		{"all satellites", "?hours=2", http.StatusOK},
+		{"other station", "?station=santiago", http.StatusOK},
+		{"unknown station", "?station=kazan", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, http.MethodGet, "/api/passes"+tt.query, "")
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestPassHandler_Station(t *testing.T) {
+	mux := newPassMux(t, newPassStore(t))
+
+	// Пролёты над станциями в разных полушариях не совпадают.
+	aos := make(map[string]time.Time)
+	for _, id := range []string{"rostov", "santiago"} {
+		resp := doRequest(t, mux, http.MethodGet, "/api/passes?sat=25544&station="+id, "")
+		var passes []satellitePass
+		if err := json.NewDecoder(resp.Body).Decode(&passes); err != nil {
+			t.Fatal(err)
+		}
+		if len(passes) == 0 {
+			t.Fatalf("%s: no passes", id)
+		}
+		aos[id] = passes[0].AOS
+	}
+	if aos["rostov"].Equal(aos["santiago"]) {
+		t.Errorf("first AOS %v is the same for both stations", aos["rostov"])
+	}
+
+	// По умолчанию — активная станция.
+	resp := doRequest(t, mux, http.MethodGet, "/api/passes?sat=25544", "")
+	var passes []satellitePass
+	if err := json.NewDecoder(resp.Body).Decode(&passes); err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) == 0 || !passes[0].AOS.Equal(aos["rostov"]) {
+		t.Errorf("default station passes = %+v, want rostov", passes)
+	}
+}
+
+func TestPassHandler_Partial(t *testing.T) {
+	mux := newPassMux(t, newPassStore(t))
+
+	resp := doRequest(t, mux, http.MethodGet, "/partials/passes", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(body)
+	if !strings.Contains(html, "<td>ISS</td>") || !strings.Contains(html, "<td>145.800</td>") {
+		t.Errorf("partial does not contain formatted ISS rows:\n%s", html)
+	}
+	if strings.Contains(html, "NO TLE") {
+		t.Error("satellite without TLE must be skipped")
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/receiver"
+)
+
+const eventWaterfall = "waterfall"
+
+var (
+	errInvalidGain  = errors.New("invalid gain")
+	errInvalidWidth = errors.New("invalid waterfall width")
+	errInvalidFPS   = errors.New("invalid waterfall frame rate")
+)
+
+// ReceiverHandler управляет приёмником SDR и передаёт строки водопада.
+type ReceiverHandler struct {
+	rx        *receiver.Receiver
+	waterfall *receiver.Waterfall
+	heartbeat time.Duration
+}
+
+// NewReceiverHandler создаёт обработчик приёмника.
+func NewReceiverHandler(rx *receiver.Receiver, waterfall *receiver.Waterfall) *ReceiverHandler {
+	return &ReceiverHandler{
+		rx:        rx,
+		waterfall: waterfall,
+		heartbeat: defaultStreamHeartbeat,
+	}
+}
+
+// Status возвращает состояние источника отсчётов.
+func (h *ReceiverHandler) Status(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.rx.Status())
+}
+
+// Tune перестраивает приёмник: параметр frequency задаёт частоту в МГц,
+// gain — усиление в дБ (отрицательное — автоматическое).
+func (h *ReceiverHandler) Tune(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	freq, ok := frequencyParam(query.Get("frequency"), 0)
+	if !ok {
+		writeError(w, http.StatusBadRequest, errInvalidFrequency.Error())
+		return
+	}
+	var gain *float64
+	if raw := query.Get("gain"); raw != "" {
+		g, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidGain.Error())
+			return
+		}
+		gain = &g
+	}
+
+	var err error
+	if freq > 0 {
+		err = h.rx.Tune(freq * 1e6)
+	}
+	if err == nil && gain != nil {
+		err = h.rx.SetGain(*gain)
+	}
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, h.rx.Status())
+	case errors.Is(err, receiver.ErrNotConnected):
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, receiver.ErrNotTunable):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		slog.Warn("receiver tuning failed", slogKeyError, err)
+		writeError(w, http.StatusBadGateway, err.Error())
+	}
+}
+
+// Waterfall передаёт событие "waterfall" с каждой строкой и "heartbeat"
+// с состоянием приёмника. Параметр width ограничивает число точек строки
+// (прореживание по максимуму), fps — частоту строк: клиент на медленном
+// канале запрашивает меньшие значения. Непереданная строка заменяется
+// свежей, очередь на сервере не растёт.
+func (h *ReceiverHandler) Waterfall(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	width, err := strconv.Atoi(cmp.Or(query.Get("width"), "0"))
+	if err != nil || width < 0 {
+		writeError(w, http.StatusBadRequest, errInvalidWidth.Error())
+		return
+	}
+	fps, err := strconv.ParseFloat(cmp.Or(query.Get("fps"), "0"), 64)
+	if err != nil || fps < 0 {
+		writeError(w, http.StatusBadRequest, errInvalidFPS.Error())
+		return
+	}
+
+	rc, ok := beginStream(w)
+	if !ok {
+		return
+	}
+
+	rows, unsubscribe := h.waterfall.Subscribe(width, fps)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	name, data := eventHeartbeat, any(h.rx.Status())
+	for {
+		if err := writeEvent(rc, w, name, data); err != nil {
+			slog.Debug("waterfall client disconnected", slogKeyError, err)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case row := <-rows:
+			name, data = eventWaterfall, row
+		case <-heartbeat.C:
+			name, data = eventHeartbeat, h.rx.Status()
+		}
+	}
+}
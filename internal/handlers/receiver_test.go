@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/receiver"
+	"github.com/art-injener/satwatch-go/internal/sdr"
+)
+
+// toneSource — неперестраиваемый источник с постоянным тоном.
+type toneSource struct{}
+
+func (toneSource) ReadIQ(buf []complex64) (int, error) {
+	time.Sleep(time.Millisecond)
+	for i := range buf {
+		buf[i] = complex(0.1, 0)
+	}
+	return len(buf), nil
+}
+
+func (toneSource) SampleRate() float64      { return 48000 }
+func (toneSource) CenterFrequency() float64 { return 145.8e6 }
+func (toneSource) Close() error             { return nil }
+
+func newReceiverServer(t *testing.T) (*httptest.Server, *receiver.Receiver) {
+	t.Helper()
+
+	cfg := receiver.DefaultWaterfallConfig()
+	cfg.FFTSize = 256
+	cfg.FrameRate = 50
+	wf, err := receiver.NewWaterfall(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rx := receiver.NewReceiver(func(context.Context) (sdr.IQSource, error) {
+		return toneSource{}, nil
+	}, receiver.Config{BlockSize: 1024}, wf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go rx.Run(ctx)
+
+	h := NewReceiverHandler(rx, wf)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/receiver", h.Status)
+	mux.HandleFunc("POST /api/receiver/tune", h.Tune)
+	mux.HandleFunc("GET /api/stream/waterfall", h.Waterfall)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, rx
+}
+
+func TestReceiverHandler_Control(t *testing.T) {
+	srv, rx := newReceiverServer(t)
+	deadline := time.Now().Add(2 * time.Second)
+	for !rx.Status().Connected && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"status", http.MethodGet, "/api/receiver", http.StatusOK},
+		{"tune recording", http.MethodPost, "/api/receiver/tune?frequency=437.8", http.StatusConflict},
+		{"invalid frequency", http.MethodPost, "/api/receiver/tune?frequency=abc", http.StatusBadRequest},
+		{"invalid gain", http.MethodPost, "/api/receiver/tune?gain=max", http.StatusBadRequest},
+		{"invalid width", http.MethodGet, "/api/stream/waterfall?width=-1", http.StatusBadRequest},
+		{"invalid fps", http.MethodGet, "/api/stream/waterfall?fps=fast", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestReceiverHandler_Waterfall(t *testing.T) {
+	srv, _ := newReceiverServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/stream/waterfall?width=64&fps=20", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	if ev := readEvent(t, reader); ev.name != eventHeartbeat {
+		t.Fatalf("first event = %q, want %q", ev.name, eventHeartbeat)
+	}
+
+	ev := readEvent(t, reader)
+	for ev.name != eventWaterfall {
+		ev = readEvent(t, reader)
+	}
+	var row receiver.Row
+	if err := json.Unmarshal([]byte(ev.data), &row); err != nil {
+		t.Fatal(err)
+	}
+	// Постоянная составляющая −20 дБFS — в центре строки.
+	if len(row.Bins) != 64 || row.Bins[32] < 200 || row.Frequency != 145.8e6 || row.Span != 48000 {
+		t.Errorf("row = %d bins, center %d, frequency %v, span %v", len(row.Bins), row.Bins[32], row.Frequency, row.Span)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/rig"
+)
+
+var (
+	errInvalidFrequency = errors.New("invalid frequency")
+	errNoFrequency      = errors.New("satellite has no downlink or uplink frequency")
+)
+
+// RigHandler управляет доплеровской подстройкой радиостанции.
+type RigHandler struct {
+	tuner *rig.Tuner
+	store catalog.Store
+}
+
+// NewRigHandler создаёт обработчик управления радиостанцией.
+func NewRigHandler(tuner *rig.Tuner, store catalog.Store) *RigHandler {
+	return &RigHandler{
+		tuner: tuner,
+		store: store,
+	}
+}
+
+// Status возвращает номинальные, целевые и текущие частоты.
+func (h *RigHandler) Status(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.tuner.Status())
+}
+
+// Track начинает подстройку под спутник, заданный параметром sat.
+// Частоты берутся из каталога; параметры downlink и uplink (МГц)
+// заменяют их, нулевое значение отключает подстройку направления.
+func (h *RigHandler) Track(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	sat, status, err := satelliteWithTLE(h.store, query.Get("sat"))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	downlink, ok := frequencyParam(query.Get("downlink"), sat.Downlink)
+	if !ok {
+		writeError(w, http.StatusBadRequest, errInvalidFrequency.Error())
+		return
+	}
+	uplink, ok := frequencyParam(query.Get("uplink"), sat.Uplink)
+	if !ok {
+		writeError(w, http.StatusBadRequest, errInvalidFrequency.Error())
+		return
+	}
+	if downlink == 0 && uplink == 0 {
+		writeError(w, http.StatusUnprocessableEntity, errNoFrequency.Error())
+		return
+	}
+
+	h.tuner.Track(sat.NoradID, downlink*1e6, uplink*1e6)
+	writeJSON(w, http.StatusOK, h.tuner.Status())
+}
+
+// Stop прекращает подстройку.
+func (h *RigHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	h.tuner.Stop()
+	writeJSON(w, http.StatusOK, h.tuner.Status())
+}
+
+// frequencyParam разбирает частоту в МГц; пустая строка означает def.
+func frequencyParam(raw string, def float64) (float64, bool) {
+	if raw == "" {
+		return def, true
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return f, true
+}
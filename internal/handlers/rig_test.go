@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/rig"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// fakeRig — радиостанция без сети.
+type fakeRig struct{}
+
+func (fakeRig) SetFrequency(context.Context, float64) error   { return nil }
+func (fakeRig) Frequency(context.Context) (float64, error)    { return 145.8e6, nil }
+func (fakeRig) SetTXFrequency(context.Context, float64) error { return nil }
+func (fakeRig) TXFrequency(context.Context) (float64, error)  { return 145.8e6, nil }
+
+func newRigMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	store := newPassStore(t)
+	observer := orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+	predictor, err := pass.NewPredictor(observer, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := newTestClock(t)
+	tuner, err := rig.NewTuner(fakeRig{}, tracking.NewTracker(store, predictor), clock.Now, rig.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewRigHandler(tuner, store)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/rig", h.Status)
+	mux.HandleFunc("POST /api/rig/track", h.Track)
+	mux.HandleFunc("POST /api/rig/stop", h.Stop)
+	return mux
+}
+
+func TestRigHandler_Control(t *testing.T) {
+	mux := newRigMux(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		mode   rig.Mode
+	}{
+		{"status", http.MethodGet, "/api/rig", http.StatusOK, rig.ModeStopped},
+		{"track catalog frequency", http.MethodPost, "/api/rig/track?sat=25544", http.StatusOK, rig.ModeTracking},
+		{"track custom frequencies", http.MethodPost, "/api/rig/track?sat=25544&downlink=437.8&uplink=145.99", http.StatusOK, rig.ModeTracking},
+		{"stop", http.MethodPost, "/api/rig/stop", http.StatusOK, rig.ModeStopped},
+		{"invalid id", http.MethodPost, "/api/rig/track?sat=abc", http.StatusBadRequest, ""},
+		{"without TLE", http.MethodPost, "/api/rig/track?sat=99999", http.StatusUnprocessableEntity, ""},
+		{"invalid frequency", http.MethodPost, "/api/rig/track?sat=25544&downlink=vhf", http.StatusBadRequest, ""},
+		{"negative frequency", http.MethodPost, "/api/rig/track?sat=25544&uplink=-1", http.StatusBadRequest, ""},
+		{"no frequencies", http.MethodPost, "/api/rig/track?sat=25544&downlink=0", http.StatusUnprocessableEntity, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, tt.method, tt.path, "")
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.mode == "" {
+				return
+			}
+			var st rig.Status
+			if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+				t.Fatal(err)
+			}
+			if st.Mode != tt.mode {
+				t.Errorf("mode = %s, want %s", st.Mode, tt.mode)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/rotator"
+)
+
+var errNoRotatorPlan = errors.New("rotator has no planned pass")
+
+// RotatorHandler управляет поворотным устройством антенны.
+type RotatorHandler struct {
+	ctrl  *rotator.Controller
+	store catalog.Store
+}
+
+// NewRotatorHandler создаёт обработчик управления поворотным устройством.
+func NewRotatorHandler(ctrl *rotator.Controller, store catalog.Store) *RotatorHandler {
+	return &RotatorHandler{
+		ctrl:  ctrl,
+		store: store,
+	}
+}
+
+// Status возвращает режим, уставку и фактическое положение антенны.
+func (h *RotatorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.ctrl.Status())
+}
+
+// Plan возвращает траекторию устройства на текущий или ближайший пролёт
+// сопровождаемого спутника.
+func (h *RotatorHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	plan, ok := h.ctrl.Plan()
+	if !ok {
+		writeError(w, http.StatusNotFound, errNoRotatorPlan.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// Track начинает сопровождение спутника, заданного параметром sat.
+func (h *RotatorHandler) Track(w http.ResponseWriter, r *http.Request) {
+	sat, status, err := satelliteWithTLE(h.store, r.URL.Query().Get("sat"))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	h.ctrl.Track(sat.NoradID)
+	writeJSON(w, http.StatusOK, h.ctrl.Status())
+}
+
+// Park переводит антенну в положение стоянки.
+func (h *RotatorHandler) Park(w http.ResponseWriter, r *http.Request) {
+	h.ctrl.Park()
+	writeJSON(w, http.StatusOK, h.ctrl.Status())
+}
+
+// Stop останавливает движение антенны.
+func (h *RotatorHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	if err := h.ctrl.Stop(r.Context()); err != nil {
+		slog.Warn("rotator stop failed", slogKeyError, err)
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, h.ctrl.Status())
+}
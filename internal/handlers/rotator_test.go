@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/rotator"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// fakeRotator — поворотное устройство без сети.
+type fakeRotator struct {
+	stopErr error
+}
+
+func (f *fakeRotator) SetPosition(context.Context, rotator.Position) error { return nil }
+
+func (f *fakeRotator) Position(context.Context) (rotator.Position, error) {
+	return rotator.Position{}, nil
+}
+
+func (f *fakeRotator) Stop(context.Context) error { return f.stopErr }
+
+func newRotatorMux(t *testing.T, rot rotator.Rotator) (*http.ServeMux, *rotator.Controller) {
+	t.Helper()
+
+	store := newPassStore(t)
+	observer := orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+	predictor, err := pass.NewPredictor(observer, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := newTestClock(t)
+	ctrl, err := rotator.NewController(rot, tracking.NewTracker(store, predictor), clock.Now, rotator.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewRotatorHandler(ctrl, store)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/rotator", h.Status)
+	mux.HandleFunc("GET /api/rotator/plan", h.Plan)
+	mux.HandleFunc("POST /api/rotator/track", h.Track)
+	mux.HandleFunc("POST /api/rotator/park", h.Park)
+	mux.HandleFunc("POST /api/rotator/stop", h.Stop)
+	return mux, ctrl
+}
+
+func TestRotatorHandler_Control(t *testing.T) {
+	mux, _ := newRotatorMux(t, &fakeRotator{})
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		status  int
+		mode    rotator.Mode
+		noradID int
+	}{
+		{"status", http.MethodGet, "/api/rotator", http.StatusOK, rotator.ModeStopped, 0},
+		{"track", http.MethodPost, "/api/rotator/track?sat=25544", http.StatusOK, rotator.ModeTracking, 25544},
+		{"park", http.MethodPost, "/api/rotator/park", http.StatusOK, rotator.ModeParked, 0},
+		{"stop", http.MethodPost, "/api/rotator/stop", http.StatusOK, rotator.ModeStopped, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, tt.method, tt.path, "")
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			var st rotator.Status
+			if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+				t.Fatal(err)
+			}
+			if st.Mode != tt.mode || st.NoradID != tt.noradID {
+				t.Errorf("mode = %s/%d, want %s/%d", st.Mode, st.NoradID, tt.mode, tt.noradID)
+			}
+		})
+	}
+}
+
+func TestRotatorHandler_Errors(t *testing.T) {
+	mux, _ := newRotatorMux(t, &fakeRotator{stopErr: errors.New("connection refused")})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"track invalid id", "/api/rotator/track?sat=abc", http.StatusBadRequest},
+		{"track missing id", "/api/rotator/track", http.StatusBadRequest},
+		{"track unknown satellite", "/api/rotator/track?sat=12345", http.StatusNotFound},
+		{"track without TLE", "/api/rotator/track?sat=99999", http.StatusUnprocessableEntity},
+		{"stop unavailable", "/api/rotator/stop", http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, http.MethodPost, tt.path, "")
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestRotatorHandler_Plan(t *testing.T) {
+	mux, ctrl := newRotatorMux(t, &fakeRotator{})
+
+	if resp := doRequest(t, mux, http.MethodGet, "/api/rotator/plan", ""); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status without tracking = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ctrl.Run(ctx)
+	doRequest(t, mux, http.MethodPost, "/api/rotator/track?sat=25544", "")
+
+	// Траектория рассчитывается на первом такте после смены режима.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := ctrl.Plan(); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("plan was not computed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp := doRequest(t, mux, http.MethodGet, "/api/rotator/plan", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var plan rotator.Plan
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Points) == 0 || !plan.LOS.After(plan.AOS) || plan.Preposition.After(plan.AOS) {
+		t.Errorf("plan = %v..%v, preposition %v, %d points", plan.AOS, plan.LOS, plan.Preposition, len(plan.Points))
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// maxRequestBody ограничивает размер тела JSON запросов.
+const maxRequestBody = 1 << 20
+
+var errTLEMismatch = errors.New("tle norad id does not match satellite")
+
+// SatelliteHandler обрабатывает CRUD запросы каталога спутников.
+type SatelliteHandler struct {
+	store catalog.Store
+}
+
+// NewSatelliteHandler создаёт обработчик каталога спутников.
+func NewSatelliteHandler(store catalog.Store) *SatelliteHandler {
+	return &SatelliteHandler{
+		store: store,
+	}
+}
+
+// satelliteRequest — тело запросов создания и изменения спутника.
+// Поле TLE принимает набор элементов в любом формате, поддерживаемом tle.Parse;
+// он добавляется в историю элементов спутника.
+type satelliteRequest struct {
+	NoradID    int     `json:"norad_id"`
+	Name       string  `json:"name"`
+	Downlink   float64 `json:"downlink_mhz"`
+	Uplink     float64 `json:"uplink_mhz"`
+	Modulation string  `json:"modulation"`
+	Callsign   string  `json:"callsign"`
+	Schema     string  `json:"telemetry_schema"`
+	SatNOGS    bool    `json:"satnogs_upload"`
+	Schedule   bool    `json:"schedule"`
+	Priority   int     `json:"priority"`
+	MinEl      float64 `json:"min_elevation"`
+	MinContact float64 `json:"min_contact_seconds"`
+	TLE        string  `json:"tle,omitempty"`
+}
+
+// List возвращает все спутники каталога.
+func (h *SatelliteHandler) List(w http.ResponseWriter, r *http.Request) {
+	sats, err := h.store.List()
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sats)
+}
+
+// Get возвращает спутник по номеру NORAD.
+func (h *SatelliteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathNoradID(w, r)
+	if !ok {
+		return
+	}
+
+	sat, err := h.store.Get(id)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sat)
+}
+
+// Create добавляет спутник в каталог.
+func (h *SatelliteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeSatelliteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	sat := catalog.Satellite{NoradID: req.NoradID}
+	if err := req.apply(&sat); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.store.Create(sat); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, sat)
+}
+
+// Update изменяет параметры спутника. История TLE сохраняется,
+// новый набор элементов из запроса добавляется к ней.
+func (h *SatelliteHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathNoradID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeSatelliteRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.NoradID != 0 && req.NoradID != id {
+		writeError(w, http.StatusBadRequest, "norad_id in body does not match URL")
+		return
+	}
+
+	var errApply error
+	sat, err := h.store.Modify(id, func(sat *catalog.Satellite) error {
+		errApply = req.apply(sat)
+		return errApply
+	})
+	switch {
+	case errApply != nil:
+		writeError(w, http.StatusBadRequest, errApply.Error())
+		return
+	case err != nil:
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sat)
+}
+
+// Delete удаляет спутник из каталога.
+func (h *SatelliteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathNoradID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.Delete(id); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apply переносит поля запроса в спутник и разбирает TLE.
+func (req *satelliteRequest) apply(sat *catalog.Satellite) error {
+	sat.Name = strings.TrimSpace(req.Name)
+	sat.Downlink = req.Downlink
+	sat.Uplink = req.Uplink
+	sat.Modulation = strings.ToLower(strings.TrimSpace(req.Modulation))
+	sat.Callsign = strings.ToUpper(strings.TrimSpace(req.Callsign))
+	sat.TelemetrySchema = strings.TrimSpace(req.Schema)
+	sat.SatNOGSUpload = req.SatNOGS
+	sat.Schedule = req.Schedule
+	sat.Priority = req.Priority
+	sat.MinElevation = req.MinEl
+	sat.MinContactSeconds = req.MinContact
+
+	if strings.TrimSpace(req.TLE) == "" {
+		return nil
+	}
+	sets, err := tle.Parse(strings.NewReader(req.TLE))
+	if err != nil {
+		return err
+	}
+	for _, set := range sets {
+		if set.NoradID != sat.NoradID {
+			return errTLEMismatch
+		}
+		if sat.Name == "" {
+			sat.Name = set.Name
+		}
+		sat.TLEHistory = append(sat.TLEHistory, set)
+	}
+	return nil
+}
+
+func decodeSatelliteRequest(w http.ResponseWriter, r *http.Request) (satelliteRequest, bool) {
+	var req satelliteRequest
+	ok := decodeJSON(w, r, &req)
+	return req, ok
+}
+
+// pathNoradID извлекает номер NORAD из пути запроса.
+func pathNoradID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid NORAD ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// writeStoreError переводит ошибку каталога в HTTP ответ.
+func writeStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, catalog.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, catalog.ErrExists):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, catalog.ErrInvalid):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error("catalog store failure", slogKeyError, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+	}
+}
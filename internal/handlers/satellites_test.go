@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+)
+
+const (
+	testTLELine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	testTLELine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+// newSatelliteMux собирает маршруты каталога так же, как в cmd/server.
+func newSatelliteMux(store catalog.Store) *http.ServeMux {
+	h := NewSatelliteHandler(store)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/satellites", h.List)
+	mux.HandleFunc("POST /api/satellites", h.Create)
+	mux.HandleFunc("GET /api/satellites/{id}", h.Get)
+	mux.HandleFunc("PUT /api/satellites/{id}", h.Update)
+	mux.HandleFunc("DELETE /api/satellites/{id}", h.Delete)
+	return mux
+}
+
+func doRequest(t *testing.T, h http.Handler, method, path, body string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestSatelliteHandler_CRUD(t *testing.T) {
+	mux := newSatelliteMux(catalog.NewMemoryStore())
+
+	tleText, _ := json.Marshal(testTLELine1 + "\n" + testTLELine2)
+	body := `{"norad_id":25544,"name":"ISS","downlink_mhz":145.8,"modulation":"FM","tle":` + string(tleText) + `}`
+
+	resp := doRequest(t, mux, http.MethodPost, "/api/satellites", body)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST status = %d, want 201", resp.StatusCode)
+	}
+
+	resp = doRequest(t, mux, http.MethodPost, "/api/satellites", body)
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate POST status = %d, want 409", resp.StatusCode)
+	}
+
+	resp = doRequest(t, mux, http.MethodGet, "/api/satellites/25544", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET status = %d, want 200", resp.StatusCode)
+	}
+	var sat catalog.Satellite
+	if err := json.NewDecoder(resp.Body).Decode(&sat); err != nil {
+		t.Fatal(err)
+	}
+	if sat.Modulation != "fm" || len(sat.TLEHistory) != 1 {
+		t.Errorf("GET = %+v, want modulation fm and one TLE", sat)
+	}
+
+	resp = doRequest(t, mux, http.MethodPut, "/api/satellites/25544", `{"name":"ISS (ZARYA)","uplink_mhz":145.99,"satnogs_upload":true,"schedule":true}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT status = %d, want 200", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&sat); err != nil {
+		t.Fatal(err)
+	}
+	if sat.Name != "ISS (ZARYA)" || sat.Uplink != 145.99 || !sat.SatNOGSUpload || !sat.Schedule || len(sat.TLEHistory) != 1 {
+		t.Errorf("PUT = %+v, want renamed satellite with SatNOGS upload and preserved TLE history", sat)
+	}
+
+	resp = doRequest(t, mux, http.MethodGet, "/api/satellites", "")
+	var list []catalog.Satellite
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Errorf("GET list returned %d satellites, want 1", len(list))
+	}
+
+	resp = doRequest(t, mux, http.MethodDelete, "/api/satellites/25544", "")
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE status = %d, want 204", resp.StatusCode)
+	}
+	resp = doRequest(t, mux, http.MethodGet, "/api/satellites/25544", "")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET after DELETE status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestSatelliteHandler_BadRequests(t *testing.T) {
+	mux := newSatelliteMux(catalog.NewMemoryStore())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"malformed json", http.MethodPost, "/api/satellites", "{", http.StatusBadRequest},
+		{"unknown field", http.MethodPost, "/api/satellites", `{"norad_id":1,"name":"x","foo":1}`, http.StatusBadRequest},
+		{"missing name", http.MethodPost, "/api/satellites", `{"norad_id":1}`, http.StatusBadRequest},
+		{"bad tle", http.MethodPost, "/api/satellites", `{"norad_id":25544,"name":"x","tle":"1 25544U"}`, http.StatusBadRequest},
+		{"tle of another satellite", http.MethodPost, "/api/satellites",
+			`{"norad_id":1,"name":"x","tle":"` + testTLELine1 + `\n` + testTLELine2 + `"}`, http.StatusBadRequest},
+		{"bad id", http.MethodGet, "/api/satellites/abc", "", http.StatusBadRequest},
+		{"update missing", http.MethodPut, "/api/satellites/7", `{"name":"x"}`, http.StatusNotFound},
+		{"id mismatch", http.MethodPut, "/api/satellites/7", `{"norad_id":8,"name":"x"}`, http.StatusBadRequest},
+		{"delete missing", http.MethodDelete, "/api/satellites/7", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, tt.method, tt.path, tt.body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantStatus >= http.StatusBadRequest {
+				var body map[string]string
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body["error"] == "" {
+					t.Errorf("expected JSON error body, got %v (%v)", body, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/art-injener/satwatch-go/internal/schedule"
+)
+
+const (
+	scheduleTemplate = "schedule-table"
+	planTemplate     = "passes-plan"
+)
+
+// Подписи состояний заданий.
+var scheduleStateLabels = map[schedule.State]string{
+	schedule.StatePending:   "Ожидает",
+	schedule.StatePreparing: "Наведение",
+	schedule.StateActive:    "Приём",
+	schedule.StateCompleted: "Завершено",
+	schedule.StateCancelled: "Отменено",
+	schedule.StateSkipped:   "Пропущено",
+	schedule.StateMissed:    "Упущено",
+}
+
+// ScheduleHandler показывает задания автоматического приёма пролётов
+// и отменяет их.
+type ScheduleHandler struct {
+	sched *schedule.Scheduler
+	pages *PageHandler
+}
+
+// NewScheduleHandler создаёт обработчик расписания станции.
+func NewScheduleHandler(sched *schedule.Scheduler, pages *PageHandler) *ScheduleHandler {
+	return &ScheduleHandler{
+		sched: sched,
+		pages: pages,
+	}
+}
+
+// scheduleRow — строка шаблона schedule-table.
+type scheduleRow struct {
+	ID           string
+	Satellite    string
+	AOS          string
+	LOS          string
+	MaxElevation string
+	State        string
+	Active       bool
+	Finished     bool
+	Recording    string
+	Error        string
+}
+
+// planRow — строка шаблона passes-plan.
+type planRow struct {
+	passRow
+	Priority int
+	Score    string
+	Selected bool
+	Reason   string
+}
+
+// List возвращает задания в формате JSON, упорядоченные по AOS.
+func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.sched.Jobs())
+}
+
+// Cancel отменяет задание {id}. На запросы HTMX отвечает обновлённой
+// таблицей заданий, иначе — заданием в формате JSON.
+func (h *ScheduleHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.sched.Cancel(r.PathValue("id"))
+	if r.Header.Get("HX-Request") != "" {
+		h.renderTable(w, err)
+		return
+	}
+
+	switch {
+	case errors.Is(err, schedule.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, schedule.ErrFinished):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeJSON(w, http.StatusOK, job)
+	}
+}
+
+// Plan возвращает план станции в формате JSON: все пролёты отмеченных
+// спутников с отметкой выбранных и причинами исключения остальных.
+func (h *ScheduleHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.sched.Plan())
+}
+
+// PlanPartial рендерит план станции для HTMX.
+func (h *ScheduleHandler) PlanPartial(w http.ResponseWriter, r *http.Request) {
+	plan := h.sched.Plan()
+	rows := make([]planRow, 0, len(plan.Entries))
+	for _, e := range plan.Entries {
+		rows = append(rows, planRow{
+			passRow: passRow{
+				SatelliteName: e.Satellite,
+				AOS:           e.AOS.UTC().Format(passDateTimeLayout),
+				TCA:           e.TCA.UTC().Format(passTimeLayout),
+				LOS:           e.LOS.UTC().Format(passTimeLayout),
+				MaxElevation:  strconv.FormatFloat(e.MaxElevation, 'f', 1, 64),
+			},
+			Priority: e.Priority,
+			Score:    strconv.FormatFloat(e.Score, 'f', 1, 64),
+			Selected: e.Selected,
+			Reason:   e.Reason,
+		})
+	}
+	h.pages.render(w, planTemplate, map[string]any{
+		"Entries": rows,
+	})
+}
+
+// Partial рендерит таблицу заданий для HTMX.
+func (h *ScheduleHandler) Partial(w http.ResponseWriter, r *http.Request) {
+	h.renderTable(w, nil)
+}
+
+// renderTable рендерит таблицу заданий с ошибкой последнего действия.
+func (h *ScheduleHandler) renderTable(w http.ResponseWriter, err error) {
+	jobs := h.sched.Jobs()
+	rows := make([]scheduleRow, 0, len(jobs))
+	for _, job := range jobs {
+		rows = append(rows, scheduleRow{
+			ID:           job.ID,
+			Satellite:    job.Satellite,
+			AOS:          job.AOS.UTC().Format(passDateTimeLayout),
+			LOS:          job.LOS.UTC().Format(passTimeLayout),
+			MaxElevation: strconv.FormatFloat(job.MaxElevation, 'f', 1, 64),
+			State:        scheduleStateLabels[job.State],
+			Active:       job.State == schedule.StateActive,
+			Finished:     job.State.Finished(),
+			Recording:    job.Recording,
+			Error:        job.Error,
+		})
+	}
+
+	data := map[string]any{"Jobs": rows}
+	if err != nil {
+		data["Error"] = err.Error()
+	}
+	h.pages.render(w, scheduleTemplate, data)
+}
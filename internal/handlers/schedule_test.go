@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/schedule"
+)
+
+// testSchedule — сохранённые задания: ожидающее и завершённое.
+const testSchedule = `{"jobs":[
+	{"id":"25544-20080920T125000Z","norad_id":25544,"satellite":"ISS","aos":"2008-09-20T12:50:00Z","los":"2008-09-20T12:58:00Z","max_elevation":41.5,"state":"completed","recording":"data/recordings/25544-20080920T125000Z.sigmf-meta"},
+	{"id":"25544-20080920T142500Z","norad_id":25544,"satellite":"ISS","aos":"2008-09-20T14:25:00Z","los":"2008-09-20T14:34:00Z","max_elevation":63.2,"state":"pending"}
+]}`
+
+func newScheduleMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	if err := os.WriteFile(path, []byte(testSchedule), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	predictor, err := pass.NewPredictor(orbit.Observer{Latitude: 47.315813, Longitude: 39.788243}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, err := schedule.NewScheduler(newPassStore(t), predictor, schedule.Devices{}, path, newTestClock(t).Now, schedule.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, err := NewPageHandler("../../templates", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewScheduleHandler(sched, pages)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/schedule", h.List)
+	mux.HandleFunc("POST /api/schedule/{id}/cancel", h.Cancel)
+	mux.HandleFunc("GET /partials/schedule", h.Partial)
+	return mux
+}
+
+func TestScheduleHandler_ListAndCancel(t *testing.T) {
+	mux := newScheduleMux(t)
+
+	resp := doRequest(t, mux, http.MethodGet, "/api/schedule", "")
+	var jobs []schedule.Job
+	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs[0].State != schedule.StateCompleted || jobs[1].State != schedule.StatePending {
+		t.Fatalf("jobs = %+v, want completed and pending", jobs)
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"pending", "25544-20080920T142500Z", http.StatusOK},
+		{"already cancelled", "25544-20080920T142500Z", http.StatusConflict},
+		{"completed", "25544-20080920T125000Z", http.StatusConflict},
+		{"unknown", "25544-20080921T000000Z", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, http.MethodPost, "/api/schedule/"+tt.id+"/cancel", "")
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestScheduleHandler_Partial(t *testing.T) {
+	mux := newScheduleMux(t)
+
+	resp := doRequest(t, mux, http.MethodGet, "/partials/schedule", "")
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(data)
+	for _, want := range []string{`id="schedule-table"`, "20.09 14:25:00", "Ожидает", "Завершено", "/api/schedule/25544-20080920T142500Z/cancel"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("partial does not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "/api/schedule/25544-20080920T125000Z/cancel") {
+		t.Error("partial offers cancelling a completed job")
+	}
+
+	// HTMX получает таблицу с ошибкой вместо кода 4xx.
+	code, body := postForm(t, mux, "/api/schedule/25544-20080920T125000Z/cancel", url.Values{}, true)
+	if code != http.StatusOK || !strings.Contains(body, "already finished") {
+		t.Errorf("HTMX cancel = %d:\n%s", code, body)
+	}
+}
+
+func TestScheduleHandler_Plan(t *testing.T) {
+	store := newPassStore(t)
+	iss, err := store.Get(25544)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss.Schedule, iss.MinElevation = true, 30
+	if err := store.Update(iss); err != nil {
+		t.Fatal(err)
+	}
+	predictor, err := pass.NewPredictor(orbit.Observer{Latitude: 47.315813, Longitude: 39.788243}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, err := schedule.NewScheduler(store, predictor, schedule.Devices{}, "", newTestClock(t).Now, schedule.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go sched.Run(ctx)
+
+	pages, err := NewPageHandler("../../templates", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewScheduleHandler(sched, pages)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/schedule/plan", h.Plan)
+	mux.HandleFunc("GET /partials/schedule/plan", h.PlanPartial)
+
+	deadline := time.Now().Add(time.Second)
+	for sched.Plan().Created.IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("plan is not built")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	resp := doRequest(t, mux, http.MethodGet, "/api/schedule/plan", "")
+	var plan schedule.Plan
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Entries) == 0 {
+		t.Fatal("plan has no entries")
+	}
+
+	resp = doRequest(t, mux, http.MethodGet, "/partials/schedule/plan", "")
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(data)
+	for _, want := range []string{`id="passes-plan"`, "В плане", `class="dropped"`, "max elevation"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("partial does not contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/simulation"
+)
+
+const (
+	simulationFragments = "simulation-fragments"
+	simulationStatus    = "sim-status"
+	simulationTLE       = "generated-tle"
+
+	// Формат поля datetime-local формы (время в UTC).
+	datetimeLocalLayout = "2006-01-02T15:04"
+)
+
+// Подписи состояний имитации.
+var simulationStateLabels = map[simulation.State]string{
+	simulation.StateStopped: "Остановлен",
+	simulation.StateRunning: "Работает",
+	simulation.StatePaused:  "Пауза",
+}
+
+// SimulationHandler управляет имитацией пролёта с вкладки "Имитация".
+// Ответы — фрагменты HTML для #sim-status и #generated-tle (hx-swap-oob).
+type SimulationHandler struct {
+	sim   *simulation.Simulator
+	pages *PageHandler
+}
+
+// NewSimulationHandler создаёт обработчик управления имитацией.
+func NewSimulationHandler(sim *simulation.Simulator, pages *PageHandler) *SimulationHandler {
+	return &SimulationHandler{
+		sim:   sim,
+		pages: pages,
+	}
+}
+
+// simulationView — данные фрагментов имитации.
+type simulationView struct {
+	OOB     bool
+	Running bool
+	State   string
+	Time    string
+	NextAOS string
+	Doppler string
+	Error   string
+	TLE     *simulation.GeneratedTLE
+}
+
+// Config применяет параметры из формы. Поля, отсутствующие в форме, не меняются.
+func (h *SimulationHandler) Config(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.configure(w, r))
+}
+
+// GenerateTLE применяет параметры из формы и синтезирует TLE.
+func (h *SimulationHandler) GenerateTLE(w http.ResponseWriter, r *http.Request) {
+	err := h.configure(w, r)
+	if err == nil {
+		_, err = h.sim.GenerateTLE()
+	}
+	h.respond(w, r, err)
+}
+
+// Start запускает или продолжает имитацию.
+func (h *SimulationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.sim.Start())
+}
+
+// Stop приостанавливает имитацию.
+func (h *SimulationHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.sim.Stop())
+}
+
+// Reset останавливает имитацию и возвращает модельное время к началу.
+func (h *SimulationHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	h.sim.Reset()
+	h.respond(w, r, nil)
+}
+
+// Status рендерит #sim-status для опроса и #generated-tle как внеполосный фрагмент.
+func (h *SimulationHandler) Status(w http.ResponseWriter, r *http.Request) {
+	view := h.view(nil)
+	h.pages.render(w, simulationStatus, view)
+	view.OOB = true
+	h.pages.render(w, simulationTLE, view)
+}
+
+// configure разбирает форму запроса и применяет её к параметрам имитации.
+func (h *SimulationHandler) configure(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	if len(r.PostForm) == 0 {
+		return nil
+	}
+
+	p := h.sim.Params()
+	if err := applySimulationForm(&p, r.PostForm); err != nil {
+		return err
+	}
+	return h.sim.Configure(p)
+}
+
+// respond рендерит фрагменты имитации. HTMX не выполняет подстановку
+// для ответов 4xx, поэтому на его запросы ошибка возвращается с кодом 200
+// внутри #sim-status.
+func (h *SimulationHandler) respond(w http.ResponseWriter, r *http.Request, err error) {
+	view := h.view(err)
+	view.OOB = true
+
+	if err != nil && r.Header.Get("HX-Request") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(simulationErrorStatus(err))
+	}
+	h.pages.render(w, simulationFragments, view)
+}
+
+// view собирает данные фрагментов из состояния имитации.
+func (h *SimulationHandler) view(err error) simulationView {
+	st, statusErr := h.sim.Status()
+	if err == nil {
+		err = statusErr
+	}
+
+	view := simulationView{
+		Running: st.State == simulation.StateRunning,
+		State:   simulationStateLabels[st.State],
+		Time:    st.Time.UTC().Format(time.DateTime),
+		NextAOS: "--:--:--",
+		Doppler: "-- Hz",
+		TLE:     st.TLE,
+	}
+	if st.NextAOS != nil {
+		view.NextAOS = st.NextAOS.UTC().Format(passTimeLayout)
+	}
+	if st.Look != nil {
+		view.Doppler = fmt.Sprintf("%+.0f Hz", st.Doppler)
+	}
+	if err != nil {
+		view.Error = err.Error()
+	}
+	return view
+}
+
+// simulationErrorStatus переводит ошибку имитации в код HTTP.
+func simulationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, simulation.ErrInvalidTransition), errors.Is(err, simulation.ErrRunning):
+		return http.StatusConflict
+	case errors.Is(err, simulation.ErrNoGenerator):
+		return http.StatusNotImplemented
+	case errors.Is(err, simulation.ErrInvalidParams):
+		return http.StatusBadRequest
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+// applySimulationForm переносит поля формы в параметры имитации.
+func applySimulationForm(p *simulation.Params, form url.Values) error {
+	floats := []struct {
+		key string
+		dst *float64
+	}{
+		{"pass_lat", &p.Orbit.PassLat},
+		{"pass_lon", &p.Orbit.PassLon},
+		{"altitude", &p.Orbit.Altitude},
+		{"inclination", &p.Orbit.Inclination},
+		{"downlink_freq", &p.Radio.Downlink},
+		{"deviation", &p.Radio.Deviation},
+	}
+	for _, f := range floats {
+		if !form.Has(f.key) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(form.Get(f.key)), 64)
+		if err != nil {
+			return &simulation.ParamsError{Field: f.key, Reason: "invalid number"}
+		}
+		*f.dst = v
+	}
+
+	if form.Has("pass_time") {
+		t, err := parseFormTime(form.Get("pass_time"))
+		if err != nil {
+			return &simulation.ParamsError{Field: "pass_time", Reason: "invalid time"}
+		}
+		p.Orbit.PassTime = t
+	}
+	if form.Has("direction") {
+		d, err := simulation.ParseDirection(form.Get("direction"))
+		if err != nil {
+			return err
+		}
+		p.Orbit.Direction = d
+	}
+	if form.Has("modulation") {
+		p.Radio.Modulation = strings.ToLower(strings.TrimSpace(form.Get("modulation")))
+	}
+	if form.Has("baud_rate") {
+		v, err := strconv.Atoi(strings.TrimSpace(form.Get("baud_rate")))
+		if err != nil {
+			return &simulation.ParamsError{Field: "baud_rate", Reason: "invalid number"}
+		}
+		p.Radio.BaudRate = v
+	}
+	if form.Has("telemetry_interval") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(form.Get("telemetry_interval")), 64)
+		if err != nil {
+			return &simulation.ParamsError{Field: "telemetry_interval", Reason: "invalid number"}
+		}
+		p.Radio.TelemetryInterval = time.Duration(v * float64(time.Second))
+	}
+	return nil
+}
+
+// parseFormTime разбирает время из поля datetime-local (UTC) или RFC 3339.
+func parseFormTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{datetimeLocalLayout, "2006-01-02T15:04:05", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, &time.ParseError{Layout: datetimeLocalLayout, Value: s}
+}
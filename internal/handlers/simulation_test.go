@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/simulation"
+)
+
+func newSimulationMux(t *testing.T, gen simulation.Generator) (*http.ServeMux, *simulation.Simulator) {
+	t.Helper()
+
+	// Реальные шаблоны проверяют синтаксис фрагментов.
+	pages, err := NewPageHandler("../../templates", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	predictor, err := pass.NewPredictor(orbit.Observer{Latitude: 47.315813, Longitude: 39.788243}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := simulation.NewSimulator(predictor, gen, newTestClock(t).Now)
+	h := NewSimulationHandler(sim, pages)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/simulation/config", h.Config)
+	mux.HandleFunc("POST /api/simulation/generate-tle", h.GenerateTLE)
+	mux.HandleFunc("POST /api/simulation/start", h.Start)
+	mux.HandleFunc("POST /api/simulation/stop", h.Stop)
+	mux.HandleFunc("POST /api/simulation/reset", h.Reset)
+	mux.HandleFunc("GET /partials/simulation/status", h.Status)
+	return mux, sim
+}
+
+// postForm отправляет форму так же, как HTMX (с заголовком HX-Request, если htmx).
+func postForm(t *testing.T, h http.Handler, path string, form url.Values, htmx bool) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if htmx {
+		req.Header.Set("HX-Request", "true")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	body, err := io.ReadAll(w.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, string(body)
+}
+
+func TestSimulationHandler_Lifecycle(t *testing.T) {
+	gen := func(simulation.Orbit) (string, string, string, error) {
+		return "SIM-1", testTLELine1, testTLELine2, nil
+	}
+	mux, sim := newSimulationMux(t, gen)
+
+	form := url.Values{
+		"pass_lat":           {"47.3"},
+		"pass_lon":           {"39.8"},
+		"pass_time":          {"2008-09-20T13:00"},
+		"altitude":           {"420"},
+		"inclination":        {"51.6"},
+		"direction":          {"descending"},
+		"downlink_freq":      {"437.5"},
+		"modulation":         {"AFSK"},
+		"baud_rate":          {"9600"},
+		"deviation":          {"3500"},
+		"telemetry_interval": {"5"},
+	}
+	status, body := postForm(t, mux, "/api/simulation/config", form, true)
+	if status != http.StatusOK || strings.Contains(body, "status-error") {
+		t.Fatalf("config: status %d, body:\n%s", status, body)
+	}
+	p := sim.Params()
+	if p.Orbit.Direction != simulation.Descending || p.Radio.Modulation != "afsk" ||
+		p.Radio.TelemetryInterval != 5*time.Second ||
+		!p.Orbit.PassTime.Equal(time.Date(2008, time.September, 20, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("params = %+v", p)
+	}
+
+	_, body = postForm(t, mux, "/api/simulation/generate-tle", nil, true)
+	for _, want := range []string{`id="sim-status"`, `id="generated-tle"`, `hx-swap-oob="true"`, testTLELine1, "SIM-1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("generate-tle response missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "-- Hz") {
+		t.Error("Doppler not computed after TLE generation")
+	}
+
+	_, body = postForm(t, mux, "/api/simulation/start", nil, true)
+	if !strings.Contains(body, "Работает") || !strings.Contains(body, `hx-trigger="every 1s"`) {
+		t.Errorf("start response does not show running state with polling:\n%s", body)
+	}
+
+	_, body = postForm(t, mux, "/api/simulation/stop", nil, true)
+	if !strings.Contains(body, "Пауза") || strings.Contains(body, "every 1s") {
+		t.Errorf("stop response does not show paused state:\n%s", body)
+	}
+
+	_, body = postForm(t, mux, "/api/simulation/reset", nil, true)
+	if !strings.Contains(body, "Остановлен") || !strings.Contains(body, "2008-09-20 12:50:00") {
+		t.Errorf("reset response does not show rewound clock:\n%s", body)
+	}
+
+	resp := doRequest(t, mux, http.MethodGet, "/partials/simulation/status", "")
+	raw, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(raw), `id="sim-status"`) {
+		t.Errorf("status partial: %d\n%s", resp.StatusCode, raw)
+	}
+}
+
+func TestSimulationHandler_Errors(t *testing.T) {
+	mux, _ := newSimulationMux(t, nil)
+
+	tests := []struct {
+		name   string
+		path   string
+		form   url.Values
+		status int
+	}{
+		{"stop while stopped", "/api/simulation/stop", nil, http.StatusConflict},
+		{"bad number", "/api/simulation/config", url.Values{"altitude": {"high"}}, http.StatusBadRequest},
+		{"out of range", "/api/simulation/config", url.Values{"inclination": {"200"}}, http.StatusBadRequest},
+		{"bad direction", "/api/simulation/config", url.Values{"direction": {"up"}}, http.StatusBadRequest},
+		{"no generator", "/api/simulation/generate-tle", nil, http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := postForm(t, mux, tt.path, tt.form, false)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if !strings.Contains(body, "status-error") {
+				t.Errorf("body has no error message:\n%s", body)
+			}
+
+			// Для HTMX ошибка приходит с кодом 200, иначе фрагмент не подставится.
+			status, _ = postForm(t, mux, tt.path, tt.form, true)
+			if status != http.StatusOK {
+				t.Errorf("htmx status = %d, want 200", status)
+			}
+		})
+	}
+}
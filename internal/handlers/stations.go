@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/station"
+)
+
+// horizonPolygonStep — шаг контура маски горизонта по азимуту, градусы.
+const horizonPolygonStep = 2
+
+// StationHandler показывает профили станций и переключает активную.
+type StationHandler struct {
+	stations *station.Registry
+}
+
+// NewStationHandler создаёт обработчик реестра станций.
+func NewStationHandler(stations *station.Registry) *StationHandler {
+	return &StationHandler{
+		stations: stations,
+	}
+}
+
+// List возвращает станции и идентификатор активной в формате JSON.
+func (h *StationHandler) List(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"active":   h.stations.Active().ID,
+		"stations": h.stations.List(),
+	})
+}
+
+// SetActive делает активной станцию из параметра id (поле формы или
+// запроса). Запросы HTMX получают заголовок HX-Refresh: страница
+// перезагружается с новой станцией. Станция с другим оборудованием
+// отклоняется с 409: устройства подключены к станции запуска.
+func (h *StationHandler) SetActive(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+	st, err := h.stations.SetActive(r.FormValue("id"))
+	switch {
+	case errors.Is(err, station.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, station.ErrHardwareBound):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		slog.Error("failed to save stations", slogKeyError, err)
+		writeError(w, http.StatusInternalServerError, errInternal.Error())
+		return
+	}
+
+	slog.Info("active station changed", "station", st.ID)
+	if r.Header.Get("HX-Request") != "" {
+		w.Header().Set("HX-Refresh", "true")
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+// Horizon возвращает маску горизонта станции: точки профиля и контур
+// для отрисовки на карте неба.
+func (h *StationHandler) Horizon(w http.ResponseWriter, r *http.Request) {
+	st, err := h.stations.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeHorizon(w, st)
+}
+
+// ImportHorizon заменяет маску горизонта станции файлом из тела запроса.
+// Параметр format задаёт формат: csv (по умолчанию) или panorama;
+// пустой файл открывает горизонт.
+func (h *StationHandler) ImportHorizon(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = horizon.FormatCSV
+	}
+	mask, err := horizon.Parse(http.MaxBytesReader(w, r.Body, maxRequestBody), format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	st, err := h.stations.SetHorizon(r.PathValue("id"), mask)
+	switch {
+	case errors.Is(err, station.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, station.ErrInvalidStation):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		slog.Error("failed to save stations", slogKeyError, err)
+		writeError(w, http.StatusInternalServerError, errInternal.Error())
+		return
+	}
+
+	slog.Info("station horizon imported", "station", st.ID, "points", len(st.Horizon))
+	writeHorizon(w, st)
+}
+
+func writeHorizon(w http.ResponseWriter, st station.Station) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"station": st.ID,
+		"points":  st.Horizon.Polygon(0),
+		"polygon": st.Horizon.Polygon(horizonPolygonStep),
+	})
+}
+
+// resolveStation возвращает станцию из параметра запроса station
+// (по умолчанию — активную) и прогноз пролётов для неё.
+func resolveStation(stations *station.Registry, r *http.Request) (station.Station, *pass.Predictor, int, error) {
+	st, predictor, err := stations.Resolve(r.URL.Query().Get("station"))
+	if err != nil {
+		return station.Station{}, nil, http.StatusNotFound, err
+	}
+	return st, predictor, http.StatusOK, nil
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/station"
+)
+
+// testStationsFile — станции тестов: Ростов-на-Дону (активная) и Сантьяго.
+const testStationsFile = `{"active": "rostov", "stations": [
+	{"id": "rostov", "name": "Ростов-на-Дону", "lat": 47.315813, "lon": 39.788243, "alt": 70},
+	{"id": "santiago", "name": "Сантьяго", "lat": -33.45, "lon": -70.66, "alt": 570}
+]}`
+
+// newTestStations возвращает реестр тестовых станций в файле.
+func newTestStations(t *testing.T) *station.Registry {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stations.json")
+	if err := os.WriteFile(path, []byte(testStationsFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stations, err := station.NewRegistry(path, station.Station{}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stations
+}
+
+func TestStationHandler(t *testing.T) {
+	stations := newTestStations(t)
+	h := NewStationHandler(stations)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/stations", h.List)
+	mux.HandleFunc("POST /api/stations/active", h.SetActive)
+
+	resp := doRequest(t, mux, http.MethodGet, "/api/stations", "")
+	var list struct {
+		Active   string            `json:"active"`
+		Stations []station.Station `json:"stations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if list.Active != "rostov" || len(list.Stations) != 2 {
+		t.Fatalf("stations = %+v, want rostov active of 2", list)
+	}
+
+	// Выбор из формы HTMX перезагружает страницу.
+	req := httptest.NewRequest(http.MethodPost, "/api/stations/active", strings.NewReader(url.Values{"id": {"santiago"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("HX-Refresh") != "true" || stations.Active().ID != "santiago" {
+		t.Errorf("HTMX select = %d, HX-Refresh %q, active %q", w.Code, w.Header().Get("HX-Refresh"), stations.Active().ID)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantActive string
+	}{
+		{"query parameter", "/api/stations/active?id=rostov", http.StatusOK, "rostov"},
+		{"unknown station", "/api/stations/active?id=kazan", http.StatusNotFound, "rostov"},
+		{"no id", "/api/stations/active", http.StatusNotFound, "rostov"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, http.MethodPost, tt.path, "")
+			if resp.StatusCode != tt.wantStatus || stations.Active().ID != tt.wantActive {
+				t.Errorf("status = %d, active %q; want %d, %q", resp.StatusCode, stations.Active().ID, tt.wantStatus, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestStationHandler_HardwareBound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stations.json")
+	content := `{"active": "rostov", "stations": [
+		{"id": "rostov", "name": "Ростов-на-Дону", "lat": 47.315813, "lon": 39.788243},
+		{"id": "remote", "name": "Удалённая", "lat": -33.45, "lon": -70.66, "hardware": {"rotator_addr": "remote:4533"}}
+	]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stations, err := station.NewRegistry(path, station.Station{}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stations.BindHardware(station.Hardware{RotatorAddr: "localhost:4533"})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/stations/active", NewStationHandler(stations).SetActive)
+
+	// Устройства подключены к станции запуска: станция с другим
+	// поворотным устройством не выбирается.
+	resp := doRequest(t, mux, http.MethodPost, "/api/stations/active?id=remote", "")
+	if resp.StatusCode != http.StatusConflict || stations.Active().ID != "rostov" {
+		t.Errorf("status = %d, active %q; want 409, rostov", resp.StatusCode, stations.Active().ID)
+	}
+}
+
+func TestStationHandler_Horizon(t *testing.T) {
+	stations := newTestStations(t)
+	h := NewStationHandler(stations)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/stations/{id}/horizon", h.Horizon)
+	mux.HandleFunc("PUT /api/stations/{id}/horizon", h.ImportHorizon)
+
+	type horizonResponse struct {
+		Station string          `json:"station"`
+		Points  []horizon.Point `json:"points"`
+		Polygon []horizon.Point `json:"polygon"`
+	}
+	get := func(t *testing.T, path string) (int, horizonResponse) {
+		t.Helper()
+		resp := doRequest(t, mux, http.MethodGet, path, "")
+		var body horizonResponse
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return resp.StatusCode, body
+	}
+
+	// Без маски горизонт открыт: пустые массивы, а не null.
+	code, body := get(t, "/api/stations/rostov/horizon")
+	if code != http.StatusOK || body.Points == nil || len(body.Points) != 0 || len(body.Polygon) != 0 {
+		t.Fatalf("open horizon = %d, %+v", code, body)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantPoints int
+	}{
+		{"csv", "/api/stations/rostov/horizon", "az,el\n0,20\n90,0\n270,0\n", http.StatusOK, 3},
+		{"panorama", "/api/stations/santiago/horizon?format=panorama", "10 0 0 5", http.StatusOK, 4},
+		{"unknown format", "/api/stations/rostov/horizon?format=kml", "0,20", http.StatusBadRequest, 0},
+		{"invalid mask", "/api/stations/rostov/horizon", "0,120", http.StatusBadRequest, 0},
+		{"unknown station", "/api/stations/kazan/horizon", "0,20", http.StatusNotFound, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, mux, http.MethodPut, tt.path, tt.body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var body horizonResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Points) != tt.wantPoints || len(body.Polygon) != 360/horizonPolygonStep {
+				t.Errorf("imported %d points, polygon of %d", len(body.Points), len(body.Polygon))
+			}
+		})
+	}
+
+	// Маска сохранена в профиле и меняет прогноз станции.
+	code, body = get(t, "/api/stations/rostov/horizon")
+	if code != http.StatusOK || body.Station != "rostov" || len(body.Points) != 3 {
+		t.Errorf("rostov horizon = %d, %+v", code, body)
+	}
+	if _, predictor, _ := stations.Resolve("rostov"); predictor.Horizon(0) != 20 {
+		t.Errorf("rostov horizon at north = %v, want 20", predictor.Horizon(0))
+	}
+	if code, _ := get(t, "/api/stations/kazan/horizon"); code != http.StatusNotFound {
+		t.Errorf("unknown station status = %d, want 404", code)
+	}
+}
+
+func TestPageHandler_StationFooter(t *testing.T) {
+	pages, err := NewPageHandler("../../templates", false, newTestStations(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	pages.Tracking(w, httptest.NewRequest(http.MethodGet, "/tracking", nil))
+	body := w.Body.String()
+	for _, want := range []string{"Ростов-на-Дону, 47.32°N, 39.79°E", `class="station-select"`, `value="santiago"`, `data-station="rostov"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("tracking page does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/station"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// Параметры потока SSE по умолчанию.
+const (
+	defaultStreamTick      = time.Second
+	defaultStreamHeartbeat = 15 * time.Second
+
+	// streamWriteTimeout ограничивает запись одного события: клиент,
+	// не принимающий данные, отключается, не влияя на остальных.
+	streamWriteTimeout = 10 * time.Second
+
+	eventTracking  = "tracking"
+	eventClock     = "clock"
+	eventHeartbeat = "heartbeat"
+)
+
+// StreamHandler передаёт клиентам текущее положение спутника через SSE.
+type StreamHandler struct {
+	store     catalog.Store
+	stations  *station.Registry
+	clock     *simclock.Clock
+	tick      time.Duration
+	heartbeat time.Duration
+
+	mu       sync.Mutex
+	trackers map[string]stationTracker // по идентификатору станции
+}
+
+// stationTracker — расчёт положения спутников с прогнозом станции.
+type stationTracker struct {
+	predictor *pass.Predictor
+	tracker   *tracking.Tracker
+}
+
+// NewStreamHandler создаёт обработчик потоков SSE для станций реестра;
+// снимки вычисляются на модельное время clock.
+func NewStreamHandler(store catalog.Store, stations *station.Registry, clock *simclock.Clock) *StreamHandler {
+	return &StreamHandler{
+		store:     store,
+		stations:  stations,
+		clock:     clock,
+		tick:      defaultStreamTick,
+		heartbeat: defaultStreamHeartbeat,
+		trackers:  make(map[string]stationTracker),
+	}
+}
+
+// Tracking передаёт событие "tracking" на каждом такте, "clock" при
+// подключении и при каждом изменении модельных часов, "heartbeat"
+// с периодом heartbeat. Параметр sat задаёт номер NORAD; по умолчанию
+// выбирается первый спутник каталога с TLE. Параметр station задаёт
+// станцию (по умолчанию активную).
+//
+// Снимки вычисляются в отдельной горутине клиента и передаются через буфер
+// на одно значение: если клиент не успевает принимать, устаревший снимок
+// заменяется свежим. Общий WriteTimeout сервера для потока отключается,
+// вместо него действует тайм-аут на запись каждого события.
+func (h *StreamHandler) Tracking(w http.ResponseWriter, r *http.Request) {
+	st, _, status, err := resolveStation(h.stations, r)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	id, status, err := h.satellite(r.URL.Query().Get("sat"))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	rc, ok := beginStream(w)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	snapshots := make(chan tracking.Snapshot, 1)
+	go h.produce(ctx, st.ID, id, snapshots)
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	changed := h.clock.Changed()
+	name, data := eventClock, any(h.clock.State())
+	for {
+		if err := writeEvent(rc, w, name, data); err != nil {
+			slog.Debug("stream client disconnected", "norad_id", id, slogKeyError, err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case snap := <-snapshots:
+			name, data = eventTracking, snap
+		case <-changed:
+			changed = h.clock.Changed()
+			name, data = eventClock, h.clock.State()
+		case <-heartbeat.C:
+			name, data = eventHeartbeat, h.clock.State()
+		}
+	}
+}
+
+// produce вычисляет снимки над станцией stationID на каждом такте и сразу
+// после изменения модельных часов до отмены ctx. Расчёт берётся заново
+// на каждом такте, поэтому новая маска горизонта действует и для
+// открытых потоков.
+func (h *StreamHandler) produce(ctx context.Context, stationID string, id int, out chan tracking.Snapshot) {
+	ticker := time.NewTicker(h.tick)
+	defer ticker.Stop()
+
+	for {
+		changed := h.clock.Changed()
+		tracker, err := h.tracker(stationID)
+		var snap tracking.Snapshot
+		if err == nil {
+			snap, err = tracker.Snapshot(id, h.clock.Now())
+		}
+		if err != nil {
+			slog.Warn("tracking snapshot failed", "norad_id", id, slogKeyError, err)
+		} else {
+			// Заменяем непрочитанный снимок свежим.
+			select {
+			case <-out:
+			default:
+			}
+			out <- snap
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-changed:
+		}
+	}
+}
+
+// tracker возвращает общий для клиентов расчёт положения спутников
+// над станцией stationID. Реестр заменяет прогноз станции при смене маски
+// горизонта: расчёт со старым прогнозом заменяется новым.
+func (h *StreamHandler) tracker(stationID string) (*tracking.Tracker, error) {
+	_, predictor, err := h.stations.Resolve(stationID)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t, ok := h.trackers[stationID]
+	if !ok || t.predictor != predictor {
+		t = stationTracker{predictor: predictor, tracker: tracking.NewTracker(h.store, predictor)}
+		h.trackers[stationID] = t
+	}
+	return t.tracker, nil
+}
+
+// satellite определяет отслеживаемый спутник.
+func (h *StreamHandler) satellite(rawID string) (int, int, error) {
+	if rawID != "" {
+		sat, status, err := satelliteWithTLE(h.store, rawID)
+		return sat.NoradID, status, err
+	}
+
+	sats, err := h.store.List()
+	if err != nil {
+		slog.Error("catalog store failure", slogKeyError, err)
+		return 0, http.StatusInternalServerError, errInternal
+	}
+	for _, sat := range sats {
+		if _, ok := sat.LatestTLE(); ok {
+			return sat.NoradID, http.StatusOK, nil
+		}
+	}
+	return 0, http.StatusNotFound, errNoTLE
+}
+
+// beginStream снимает общий WriteTimeout сервера и отправляет заголовки
+// потока SSE; false — запись потоком не поддерживается.
+func beginStream(w http.ResponseWriter) (*http.ResponseController, bool) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Warn("stream write deadline is not supported", slogKeyError, err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		slog.Error("streaming is not supported", slogKeyError, err)
+		return nil, false
+	}
+	return rc, true
+}
+
+// writeEvent записывает одно событие SSE и сбрасывает буфер.
+func writeEvent(rc *http.ResponseController, w http.ResponseWriter, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// Ошибка установки тайм-аута не критична: запись всё равно выполняется.
+	_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// writeHTMLEvent записывает событие SSE с фрагментом HTML для расширения
+// htmx SSE: каждая строка фрагмента передаётся отдельным полем data.
+func writeHTMLEvent(rc *http.ResponseController, w http.ResponseWriter, name, html string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "event: %s\n", name)
+	for line := range strings.SplitSeq(html, "\n") {
+		fmt.Fprintf(&sb, "data: %s\n", line)
+	}
+	sb.WriteByte('\n')
+
+	_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
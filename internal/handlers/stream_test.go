@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/simclock"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// sseEvent — разобранное событие SSE.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvent читает следующее событие из потока.
+func readEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			return ev
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			// Несколько полей data объединяются через перевод строки.
+			if ev.data != "" {
+				ev.data += "\n"
+			}
+			ev.data += strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func newStreamServer(t *testing.T, done chan<- struct{}) (*httptest.Server, *simclock.Clock) {
+	t.Helper()
+
+	clock := newTestClock(t)
+	h := NewStreamHandler(newPassStore(t), newTestStations(t), clock)
+	h.tick = 10 * time.Millisecond
+	h.heartbeat = 25 * time.Millisecond
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/stream/tracking", func(w http.ResponseWriter, r *http.Request) {
+		h.Tracking(w, r)
+		if done != nil {
+			done <- struct{}{}
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, clock
+}
+
+func TestStreamHandler_Tracking(t *testing.T) {
+	done := make(chan struct{}, 1)
+	srv, _ := newStreamServer(t, done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/stream/tracking", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	if ev := readEvent(t, reader); ev.name != eventClock {
+		t.Fatalf("first event = %q, want %q", ev.name, eventClock)
+	}
+	seen := map[string]int{}
+	for seen[eventTracking] < 3 || seen[eventHeartbeat] < 1 {
+		ev := readEvent(t, reader)
+		seen[ev.name]++
+
+		if ev.name != eventTracking {
+			continue
+		}
+		var snap tracking.Snapshot
+		if err := json.Unmarshal([]byte(ev.data), &snap); err != nil {
+			t.Fatalf("decode snapshot: %v", err)
+		}
+		if snap.NoradID != 25544 || snap.Altitude < 300 || snap.Range == 0 || snap.NextAOS == nil {
+			t.Errorf("snapshot = %+v, want ISS position with next pass", snap)
+		}
+	}
+
+	// Отключение клиента завершает обработчик.
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not return after client disconnect")
+	}
+}
+
+func TestStreamHandler_TrackerPerStation(t *testing.T) {
+	stations := newTestStations(t)
+	h := NewStreamHandler(newPassStore(t), stations, newTestClock(t))
+
+	first, err := h.tracker("rostov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := h.tracker("rostov"); again != first {
+		t.Error("tracker is not shared between clients of one station")
+	}
+
+	// Новая маска горизонта заменяет расчёт, а не добавляет его.
+	if _, err := stations.SetHorizon("rostov", horizon.Mask{{Azimuth: 0, Elevation: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if replaced, _ := h.tracker("rostov"); replaced == first {
+		t.Error("tracker kept the predictor of the old mask")
+	}
+	if _, err := h.tracker("kazan"); err == nil {
+		t.Error("tracker(unknown) succeeded, want error")
+	}
+	if len(h.trackers) != 1 {
+		t.Errorf("trackers = %d, want 1", len(h.trackers))
+	}
+}
+
+func TestStreamHandler_ClockJump(t *testing.T) {
+	srv, clock := newStreamServer(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/stream/tracking", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	readEvent(t, reader)
+
+	target := testEpoch.Add(time.Hour)
+	if err := clock.Jump(target); err != nil {
+		t.Fatal(err)
+	}
+
+	// После перехода клиент получает событие clock и снимки на новое время.
+	var gotClock, gotSnapshot bool
+	for !gotClock || !gotSnapshot {
+		ev := readEvent(t, reader)
+		switch ev.name {
+		case eventClock:
+			var st simclock.State
+			if err := json.Unmarshal([]byte(ev.data), &st); err != nil {
+				t.Fatalf("decode clock: %v", err)
+			}
+			if !st.Time.Equal(target) || !st.Paused {
+				t.Errorf("clock = %+v, want paused at %v", st, target)
+			}
+			gotClock = true
+		case eventTracking:
+			var snap tracking.Snapshot
+			if err := json.Unmarshal([]byte(ev.data), &snap); err != nil {
+				t.Fatalf("decode snapshot: %v", err)
+			}
+			gotSnapshot = snap.Time.Equal(target)
+		}
+	}
+}
+
+func TestStreamHandler_Errors(t *testing.T) {
+	srv, _ := newStreamServer(t, nil)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"unknown satellite", "?sat=1", http.StatusNotFound},
+		{"invalid id", "?sat=x", http.StatusBadRequest},
+		{"no TLE", "?sat=99999", http.StatusUnprocessableEntity},
+		{"unknown station", "?station=kazan", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/api/stream/tracking" + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/telemetry"
+)
+
+const (
+	eventTelemetry = "telemetry"
+
+	// telemetryRowsTemplate — строки таблицы телеметрии на странице
+	// приёмника.
+	telemetryRowsTemplate = "telemetry-rows"
+
+	// defaultTelemetryRange — интервал ряда, если from не задан.
+	defaultTelemetryRange = 24 * time.Hour
+)
+
+var (
+	errInvalidTime = errors.New("invalid time, want RFC 3339")
+	errInvalidStep = errors.New("invalid step, want duration such as 10m")
+)
+
+// TelemetryHandler передаёт декодированную телеметрию странице приёмника
+// и отвечает на запросы рядов из архива.
+type TelemetryHandler struct {
+	monitor   *telemetry.Monitor
+	archive   *telemetry.Archive
+	pages     *PageHandler
+	heartbeat time.Duration
+}
+
+// NewTelemetryHandler создаёт обработчик телеметрии; archive может быть
+// nil, если архив отключён.
+func NewTelemetryHandler(monitor *telemetry.Monitor, archive *telemetry.Archive, pages *PageHandler) *TelemetryHandler {
+	return &TelemetryHandler{
+		monitor:   monitor,
+		archive:   archive,
+		pages:     pages,
+		heartbeat: defaultStreamHeartbeat,
+	}
+}
+
+// Query возвращает временной ряд поля телеметрии из архива. Параметры:
+// sat — номер NORAD, field — имя поля схемы, from и to — границы
+// в RFC 3339 (по умолчанию последние сутки), step — интервал усреднения
+// (например, 1h; по умолчанию точки без прореживания).
+func (h *TelemetryHandler) Query(w http.ResponseWriter, r *http.Request) {
+	if h.archive == nil {
+		writeError(w, http.StatusNotFound, "telemetry archive is disabled")
+		return
+	}
+
+	query := r.URL.Query()
+	id, err := strconv.Atoi(query.Get("sat"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, errInvalidID.Error())
+		return
+	}
+	q := telemetry.Query{NoradID: id, Field: query.Get("field"), To: time.Now().UTC()}
+	if raw := query.Get("to"); raw != "" {
+		if q.To, err = time.Parse(time.RFC3339, raw); err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidTime.Error())
+			return
+		}
+	}
+	q.From = q.To.Add(-defaultTelemetryRange)
+	if raw := query.Get("from"); raw != "" {
+		if q.From, err = time.Parse(time.RFC3339, raw); err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidTime.Error())
+			return
+		}
+	}
+	if raw := query.Get("step"); raw != "" {
+		if q.Step, err = time.ParseDuration(raw); err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidStep.Error())
+			return
+		}
+	}
+
+	series, err := h.archive.Series(q)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, series)
+	case errors.Is(err, telemetry.ErrInvalidQuery):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error("telemetry archive failure", slogKeyError, err)
+		writeError(w, http.StatusInternalServerError, errInternal.Error())
+	}
+}
+
+// telemetryRowsView — данные шаблона строк: одна строка на значение.
+type telemetryRowsView struct {
+	Time      string
+	Satellite string
+	Values    []telemetry.Value
+}
+
+// Stream передаёт событие "telemetry" с HTML-строками таблицы для каждой
+// декодированной записи (расширение htmx SSE вставляет их в начало
+// таблицы) и "heartbeat" с периодом heartbeat.
+func (h *TelemetryHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	rc, ok := beginStream(w)
+	if !ok {
+		return
+	}
+
+	records, unsubscribe := h.monitor.Subscribe()
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case rec := <-records:
+			if len(rec.Values) == 0 {
+				continue
+			}
+			var html string
+			html, err = h.pages.fragment(telemetryRowsTemplate, telemetryRowsView{
+				Time:      rec.Time.UTC().Format(time.TimeOnly),
+				Satellite: rec.Satellite,
+				Values:    rec.Values,
+			})
+			if err != nil {
+				slog.Error("failed to render telemetry rows", slogKeyError, err)
+				continue
+			}
+			err = writeHTMLEvent(rc, w, eventTelemetry, html)
+		case <-heartbeat.C:
+			err = writeEvent(rc, w, eventHeartbeat, map[string]time.Time{"time": time.Now().UTC()})
+		}
+		if err != nil {
+			slog.Debug("telemetry client disconnected", slogKeyError, err)
+			return
+		}
+	}
+}
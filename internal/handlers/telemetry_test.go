@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/ax25"
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/telemetry"
+)
+
+// voltageSchema — схема из одного поля: напряжение в мВ.
+type voltageSchema struct{}
+
+func (voltageSchema) Decode(payload []byte) ([]telemetry.Value, error) {
+	raw := uint64(payload[0])<<8 | uint64(payload[1])
+	return []telemetry.Value{{Name: "battery_voltage", Value: float64(raw) / 1000, Unit: "V", Raw: raw}}, nil
+}
+
+func TestTelemetryHandler_Stream(t *testing.T) {
+	// Строки таблицы рендерятся настоящим шаблоном.
+	tmpDir := setupTestTemplates(t)
+	partial, err := os.ReadFile(filepath.Join("..", "..", "templates", "partials", "telemetry_rows.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "partials"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "partials", "telemetry_rows.html"), partial, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pages, err := NewPageHandler(tmpDir, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := catalog.NewMemoryStore()
+	if err := store.Create(catalog.Satellite{NoradID: 44909, Name: "RS40S", Callsign: "RS40S", TelemetrySchema: "voltage"}); err != nil {
+		t.Fatal(err)
+	}
+	registry := telemetry.NewRegistry()
+	registry.Register("voltage", voltageSchema{})
+	monitor := telemetry.NewMonitor(store, registry, nil)
+
+	h := NewTelemetryHandler(monitor, nil, pages)
+	h.heartbeat = 25 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(h.Stream))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// Подписка оформляется после заголовков; первое сердцебиение
+	// гарантирует, что запись не опубликована раньше.
+	if ev := readEvent(t, reader); ev.name != eventHeartbeat {
+		t.Fatalf("first event = %q, want heartbeat", ev.name)
+	}
+	frame := ax25.NewUI(ax25.Address{Call: "CQ"}, ax25.Address{Call: "RS40S"}, []byte{0x1C, 0xE8})
+	when := time.Date(2026, 10, 16, 12, 30, 5, 0, time.UTC)
+	if _, err := monitor.Handle(when, frame, telemetry.Signal{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ev sseEvent
+	for ev = readEvent(t, reader); ev.name == eventHeartbeat; ev = readEvent(t, reader) {
+	}
+	if ev.name != eventTelemetry {
+		t.Fatalf("event = %q, want %q", ev.name, eventTelemetry)
+	}
+	for _, want := range []string{"<tr>", "12:30:05", "RS40S: battery_voltage", "7.4 V", "0x1CE8"} {
+		if !strings.Contains(ev.data, want) {
+			t.Errorf("rows %q do not contain %q", ev.data, want)
+		}
+	}
+}
+
+func TestTelemetryHandler_Query(t *testing.T) {
+	archive, err := telemetry.OpenArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for i, volts := range []float64{7.0, 7.2, 7.4, 7.6} {
+		rec := telemetry.Record{
+			Time:    start.Add(time.Duration(i) * 20 * time.Minute),
+			NoradID: 44909,
+			PassID:  "20261016T115500Z",
+			Values:  []telemetry.Value{{Name: "battery_voltage", Value: volts, Unit: "V"}},
+		}
+		if err := archive.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := NewTelemetryHandler(nil, archive, nil)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPoints int
+	}{
+		{"raw points", "sat=44909&field=battery_voltage&from=2026-10-16T00:00:00Z&to=2026-10-17T00:00:00Z", http.StatusOK, 4},
+		{"hourly", "sat=44909&field=battery_voltage&from=2026-10-16T00:00:00Z&to=2026-10-17T00:00:00Z&step=1h", http.StatusOK, 2},
+		{"default range ends now", "sat=44909&field=battery_voltage&to=2026-10-16T12:30:00Z", http.StatusOK, 2},
+		{"invalid satellite", "sat=abc&field=battery_voltage", http.StatusBadRequest, 0},
+		{"missing field", "sat=44909", http.StatusBadRequest, 0},
+		{"invalid from", "sat=44909&field=battery_voltage&from=yesterday", http.StatusBadRequest, 0},
+		{"invalid step", "sat=44909&field=battery_voltage&step=hour", http.StatusBadRequest, 0},
+		{"too many points", "sat=44909&field=battery_voltage&step=1s", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/telemetry?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.Query(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var series telemetry.Series
+			if err := json.NewDecoder(w.Body).Decode(&series); err != nil {
+				t.Fatal(err)
+			}
+			if series.Unit != "V" || len(series.Points) != tt.wantPoints {
+				t.Errorf("series = %+v, want %d points", series, tt.wantPoints)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package horizon описывает маску горизонта станции: наименьший угол
+// места, на котором спутник виден из-за зданий и рельефа, в зависимости
+// от азимута. Между точками маски угол места интерполируется линейно
+// по азимуту, после последней точки — к первой через север.
+package horizon
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Ошибки маски горизонта.
+var (
+	ErrInvalidMask   = errors.New("horizon: invalid mask")
+	ErrUnknownFormat = errors.New("horizon: unknown format")
+)
+
+// Point — угол места горизонта на азимуте.
+type Point struct {
+	Azimuth   float64 `json:"az"` // градусы от севера по часовой стрелке
+	Elevation float64 `json:"el"` // градусы
+}
+
+// Mask — точки маски горизонта по возрастанию азимута. Пустая маска
+// соответствует открытому горизонту.
+type Mask []Point
+
+// New создаёт маску из точек в любом порядке. Азимуты приводятся
+// к [0, 360).
+func New(points []Point) (Mask, error) {
+	m := make(Mask, len(points))
+	for i, p := range points {
+		m[i] = Point{Azimuth: normalize(p.Azimuth), Elevation: p.Elevation}
+	}
+	slices.SortFunc(m, func(a, b Point) int {
+		return cmp.Compare(a.Azimuth, b.Azimuth)
+	})
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate проверяет маску: азимуты в [0, 360) строго возрастают,
+// углы места в [0, 90).
+func (m Mask) Validate() error {
+	for i, p := range m {
+		switch {
+		case math.IsNaN(p.Azimuth) || p.Azimuth < 0 || p.Azimuth >= 360:
+			return fmt.Errorf("%w: azimuth %v must be within 0..360°", ErrInvalidMask, p.Azimuth)
+		case math.IsNaN(p.Elevation) || p.Elevation < 0 || p.Elevation >= 90:
+			return fmt.Errorf("%w: elevation %v at azimuth %v must be within 0..90°", ErrInvalidMask, p.Elevation, p.Azimuth)
+		case i > 0 && p.Azimuth <= m[i-1].Azimuth:
+			return fmt.Errorf("%w: azimuth %v is duplicated or out of order", ErrInvalidMask, p.Azimuth)
+		}
+	}
+	return nil
+}
+
+// Elevation возвращает угол места горизонта на азимуте az.
+func (m Mask) Elevation(az float64) float64 {
+	switch len(m) {
+	case 0:
+		return 0
+	case 1:
+		return m[0].Elevation
+	}
+
+	az = normalize(az)
+	next, found := m.find(az)
+	if found {
+		return m[next].Elevation
+	}
+	prev := (next + len(m) - 1) % len(m)
+	next %= len(m)
+
+	span := normalize(m[next].Azimuth - m[prev].Azimuth)
+	frac := normalize(az-m[prev].Azimuth) / span
+	return m[prev].Elevation + frac*(m[next].Elevation-m[prev].Elevation)
+}
+
+// Polygon возвращает контур маски для отрисовки: угол места с шагом step
+// градусов по азимуту вместе с точками самой маски. При step <= 0
+// возвращаются только точки маски; результат не бывает nil.
+func (m Mask) Polygon(step float64) []Point {
+	points := append([]Point{}, m...)
+	if len(m) == 0 || step <= 0 {
+		return points
+	}
+	for az := 0.0; az < 360; az += step {
+		if _, found := m.find(az); !found {
+			points = append(points, Point{Azimuth: az, Elevation: m.Elevation(az)})
+		}
+	}
+	slices.SortFunc(points, func(a, b Point) int {
+		return cmp.Compare(a.Azimuth, b.Azimuth)
+	})
+	return points
+}
+
+// find ищет точку с азимутом az; если её нет, возвращает индекс
+// первой точки с большим азимутом.
+func (m Mask) find(az float64) (int, bool) {
+	return slices.BinarySearchFunc(m, az, func(p Point, az float64) int {
+		return cmp.Compare(p.Azimuth, az)
+	})
+}
+
+// normalize приводит угол к [0, 360).
+func normalize(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
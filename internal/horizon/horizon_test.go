@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []Point
+		want    Mask
+		wantErr bool
+	}{
+		{name: "empty", want: Mask{}},
+		{
+			name:   "sorted and normalized",
+			points: []Point{{Azimuth: 360, Elevation: 20}, {Azimuth: -90, Elevation: 5}, {Azimuth: 90, Elevation: 10}},
+			want:   Mask{{Azimuth: 0, Elevation: 20}, {Azimuth: 90, Elevation: 10}, {Azimuth: 270, Elevation: 5}},
+		},
+		{name: "duplicate azimuth", points: []Point{{Azimuth: 0, Elevation: 1}, {Azimuth: 360, Elevation: 2}}, wantErr: true},
+		{name: "negative elevation", points: []Point{{Azimuth: 10, Elevation: -1}}, wantErr: true},
+		{name: "elevation at zenith", points: []Point{{Azimuth: 10, Elevation: 90}}, wantErr: true},
+		{name: "NaN azimuth", points: []Point{{Azimuth: math.NaN(), Elevation: 1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.points)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMask) {
+					t.Fatalf("New() error = %v, want ErrInvalidMask", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertMask(t, m, tt.want)
+		})
+	}
+}
+
+func TestMask_Elevation(t *testing.T) {
+	// Дома на севере: 20° от 330° до 30°, открытый горизонт на юге.
+	m := Mask{{Azimuth: 30, Elevation: 20}, {Azimuth: 90, Elevation: 0}, {Azimuth: 270, Elevation: 0}, {Azimuth: 330, Elevation: 20}}
+
+	tests := []struct {
+		az, want float64
+	}{
+		{30, 20},
+		{60, 10},
+		{180, 0},
+		{300, 10},
+		{0, 20},
+		{360, 20},
+		{-15, 20},
+		{720 + 60, 10},
+	}
+	for _, tt := range tests {
+		if got := m.Elevation(tt.az); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Elevation(%v) = %v, want %v", tt.az, got, tt.want)
+		}
+	}
+
+	if got := (Mask{}).Elevation(123); got != 0 {
+		t.Errorf("empty mask Elevation() = %v, want 0", got)
+	}
+	if got := (Mask{{Azimuth: 100, Elevation: 7}}).Elevation(300); got != 7 {
+		t.Errorf("single point Elevation() = %v, want 7", got)
+	}
+	// Две точки: интерполяция в обе стороны через север.
+	two := Mask{{Azimuth: 0, Elevation: 10}, {Azimuth: 180, Elevation: 0}}
+	if got := two.Elevation(270); math.Abs(got-5) > 1e-9 {
+		t.Errorf("two points Elevation(270) = %v, want 5", got)
+	}
+}
+
+func TestMask_Polygon(t *testing.T) {
+	m := Mask{{Azimuth: 45.5, Elevation: 10}, {Azimuth: 90, Elevation: 0}}
+
+	got := m.Polygon(30)
+	// Отсчёты 0, 30, ..., 330 и точка 45.5; 90 уже есть среди отсчётов.
+	if len(got) != 13 {
+		t.Fatalf("Polygon() has %d points: %v", len(got), got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Azimuth <= got[i-1].Azimuth {
+			t.Fatalf("Polygon() is not sorted: %v", got)
+		}
+	}
+	if got[2] != m[0] {
+		t.Errorf("Polygon()[2] = %v, want mask point %v", got[2], m[0])
+	}
+
+	if got := (Mask{}).Polygon(1); got == nil || len(got) != 0 {
+		t.Errorf("empty mask Polygon() = %#v, want empty slice", got)
+	}
+}
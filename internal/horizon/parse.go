@@ -0,0 +1,97 @@
+package horizon
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Форматы файлов маски горизонта.
+const (
+	// FormatCSV — строки "азимут,угол места"; допускаются строка заголовка
+	// и комментарии, начинающиеся с '#'.
+	FormatCSV = "csv"
+	// FormatPanorama — углы места горизонта через равные интервалы
+	// азимута, начиная с севера по часовой стрелке: 36 значений задают
+	// горизонт через 10°. Значения разделяются пробелами, запятыми или
+	// переводами строк; '#' начинает комментарий до конца строки.
+	FormatPanorama = "panorama"
+)
+
+// Parse разбирает маску горизонта в формате format.
+func Parse(r io.Reader, format string) (Mask, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatPanorama:
+		return ParsePanorama(r)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// ParseCSV разбирает маску горизонта в формате CSV.
+func ParseCSV(r io.Reader) (Mask, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var points []Point
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidMask, err)
+		}
+		line, _ := cr.FieldPos(0)
+		if len(row) < 2 {
+			return nil, fmt.Errorf("%w: line %d: want azimuth and elevation", ErrInvalidMask, line)
+		}
+
+		az, errAz := strconv.ParseFloat(strings.TrimSpace(row[0]), 64)
+		el, errEl := strconv.ParseFloat(strings.TrimSpace(row[1]), 64)
+		if errAz != nil && first {
+			continue // заголовок
+		}
+		if errAz != nil || errEl != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMask, line, errors.Join(errAz, errEl))
+		}
+		points = append(points, Point{Azimuth: az, Elevation: el})
+	}
+	return New(points)
+}
+
+// ParsePanorama разбирает маску горизонта в формате панорамы.
+func ParsePanorama(r io.Reader) (Mask, error) {
+	var elevations []float64
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\r'
+		})
+		for _, f := range fields {
+			el, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMask, line, err)
+			}
+			elevations = append(elevations, el)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, len(elevations))
+	step := 360 / float64(len(elevations))
+	for i, el := range elevations {
+		points[i] = Point{Azimuth: float64(i) * step, Elevation: el}
+	}
+	return New(points)
+}
@@ -0,0 +1,101 @@
+package horizon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Mask
+		wantErr bool
+	}{
+		{
+			name:  "header and comments",
+			input: "# крыша\nazimuth,elevation\n0,15\n 90, 2.5\n# юг открыт\n180,0\n",
+			want:  Mask{{Azimuth: 0, Elevation: 15}, {Azimuth: 90, Elevation: 2.5}, {Azimuth: 180, Elevation: 0}},
+		},
+		{
+			name:  "unsorted with extra column",
+			input: "270,5,дом\n10,1,\n",
+			want:  Mask{{Azimuth: 10, Elevation: 1}, {Azimuth: 270, Elevation: 5}},
+		},
+		{name: "empty", input: "", want: Mask{}},
+		{name: "one column", input: "10\n", wantErr: true},
+		{name: "bad elevation", input: "10,high\n", wantErr: true},
+		{name: "text after header", input: "az,el\nnorth,10\n", wantErr: true},
+		{name: "elevation out of range", input: "10,95\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse(strings.NewReader(tt.input), FormatCSV)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMask) {
+					t.Fatalf("ParseCSV() error = %v, want ErrInvalidMask", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertMask(t, m, tt.want)
+		})
+	}
+}
+
+func TestParsePanorama(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Mask
+		wantErr bool
+	}{
+		{
+			name:  "four directions",
+			input: "# север, восток, юг, запад\n20 5\n0, 10\n",
+			want:  Mask{{Azimuth: 0, Elevation: 20}, {Azimuth: 90, Elevation: 5}, {Azimuth: 180, Elevation: 0}, {Azimuth: 270, Elevation: 10}},
+		},
+		{name: "single value", input: "3", want: Mask{{Azimuth: 0, Elevation: 3}}},
+		{name: "empty", input: "# нет данных\n", want: Mask{}},
+		{name: "not a number", input: "10 x 20", wantErr: true},
+		{name: "negative", input: "10 -1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse(strings.NewReader(tt.input), FormatPanorama)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMask) {
+					t.Fatalf("ParsePanorama() error = %v, want ErrInvalidMask", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertMask(t, m, tt.want)
+		})
+	}
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	if _, err := Parse(strings.NewReader("0,10"), "xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Parse() error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func assertMask(t *testing.T, got, want Mask) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("mask = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("mask[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package kiss передаёт принятые кадры AX.25 внешним программам по
+// протоколу KISS поверх TCP, как Direwolf на порту 8001.
+package kiss
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// Специальные байты KISS.
+const (
+	FEND  = 0xC0 // граница кадра
+	FESC  = 0xDB // экранирование
+	TFEND = 0xDC // экранированный FEND
+	TFESC = 0xDD // экранированный FESC
+
+	// CmdData — команда «кадр данных» в младшей тетраде байта команды.
+	CmdData = 0x00
+)
+
+// DefaultPort — порт KISS TCP по соглашению Direwolf.
+const DefaultPort = 8001
+
+// maxFrameLen ограничивает длину кадра, принимаемого от клиента.
+const maxFrameLen = 4096
+
+// ErrFrameTooLong возвращается при превышении длины кадра от клиента.
+var ErrFrameTooLong = errors.New("kiss: frame is too long")
+
+// Encode дописывает к dst кадр данных для порта TNC port (0–15).
+func Encode(dst []byte, port int, frame []byte) []byte {
+	dst = append(dst, FEND, byte(port&0x0F)<<4|CmdData)
+	for _, b := range frame {
+		switch b {
+		case FEND:
+			dst = append(dst, FESC, TFEND)
+		case FESC:
+			dst = append(dst, FESC, TFESC)
+		default:
+			dst = append(dst, b)
+		}
+	}
+	return append(dst, FEND)
+}
+
+// Decoder читает кадры KISS из потока.
+type Decoder struct {
+	r *bufio.Reader
+}
+
+// NewDecoder создаёт декодер, читающий из r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Next возвращает следующий непустой кадр: порт, команду и данные.
+// Пустые кадры (подряд идущие FEND) пропускаются.
+func (d *Decoder) Next() (port int, cmd byte, data []byte, err error) {
+	var frame []byte
+	inFrame, escaped := false, false
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		switch {
+		case b == FEND:
+			if len(frame) > 0 {
+				return int(frame[0] >> 4), frame[0] & 0x0F, frame[1:], nil
+			}
+			inFrame = true
+			continue
+		case !inFrame:
+			continue
+		case escaped:
+			escaped = false
+			switch b {
+			case TFEND:
+				b = FEND
+			case TFESC:
+				b = FESC
+			}
+		case b == FESC:
+			escaped = true
+			continue
+		}
+		if len(frame) == maxFrameLen {
+			return 0, 0, nil, ErrFrameTooLong
+		}
+		frame = append(frame, b)
+	}
+}
@@ -0,0 +1,42 @@
+package kiss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	got := Encode(nil, 1, []byte{0x01, FEND, 0x02, FESC})
+	want := []byte{FEND, 0x10, 0x01, FESC, TFEND, 0x02, FESC, TFESC, FEND}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Encode() = %x, want %x", got, want)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	frame := []byte{0x01, FEND, FESC, 0x7E}
+	// Мусор до первого FEND и пустые кадры пропускаются.
+	stream := append([]byte{0x55, FEND, FEND}, Encode(nil, 2, frame)...)
+	stream = append(stream, Encode(nil, 0, []byte{0x42})...)
+
+	dec := NewDecoder(bytes.NewReader(stream))
+	port, cmd, data, err := dec.Next()
+	if err != nil || port != 2 || cmd != CmdData || !bytes.Equal(data, frame) {
+		t.Fatalf("Next() = %d, %d, %x, %v", port, cmd, data, err)
+	}
+	if _, _, data, err = dec.Next(); err != nil || !bytes.Equal(data, []byte{0x42}) {
+		t.Fatalf("second Next() = %x, %v", data, err)
+	}
+	if _, _, _, err = dec.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() at end = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoder_TooLong(t *testing.T) {
+	stream := append([]byte{FEND, 0x00}, make([]byte, maxFrameLen+1)...)
+	if _, _, _, err := NewDecoder(bytes.NewReader(stream)).Next(); !errors.Is(err, ErrFrameTooLong) {
+		t.Errorf("Next() = %v, want ErrFrameTooLong", err)
+	}
+}
@@ -0,0 +1,134 @@
+package kiss
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// clientQueue — число кадров в очереди клиента; при переполнении
+	// новые кадры клиенту не передаются.
+	clientQueue = 64
+	// writeTimeout ограничивает запись одного кадра клиенту.
+	writeTimeout = 10 * time.Second
+)
+
+// client — подключённая программа и её очередь кадров.
+type client struct {
+	conn net.Conn
+	out  chan []byte
+}
+
+// Server рассылает принятые кадры всем подключённым клиентам KISS TCP.
+// Кадры от клиентов (передача) читаются и отбрасываются: SatWatch
+// только принимает.
+type Server struct {
+	mu      sync.Mutex
+	clients map[*client]struct{}
+}
+
+// NewServer создаёт сервер KISS.
+func NewServer() *Server {
+	return &Server{clients: make(map[*client]struct{})}
+}
+
+// Serve принимает подключения на ln до отмены ctx; при отмене слушатель
+// и все подключения закрываются.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handle(ctx, conn)
+		}()
+	}
+}
+
+// Broadcast отправляет кадр AX.25 без FCS всем клиентам на порту TNC 0.
+// Вызов не блокируется.
+func (s *Server) Broadcast(frame []byte) {
+	data := Encode(nil, 0, frame)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		select {
+		case c.out <- data:
+		default:
+			slog.Warn("KISS client queue is full, frame dropped", "client", c.conn.RemoteAddr().String())
+		}
+	}
+}
+
+// Clients возвращает число подключённых клиентов.
+func (s *Server) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// handle обслуживает клиента до отключения или отмены ctx.
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	c := &client{conn: conn, out: make(chan []byte, clientQueue)}
+	remote := conn.RemoteAddr().String()
+	slog.Info("KISS client connected", "client", remote)
+
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		conn.Close()
+		slog.Info("KISS client disconnected", "client", remote)
+	}()
+
+	// Чтение обнаруживает отключение клиента; закрытие conn его прерывает.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		dec := NewDecoder(conn)
+		for {
+			port, cmd, data, err := dec.Next()
+			if err != nil {
+				return
+			}
+			slog.Debug("KISS frame from client ignored", "client", remote, "port", port, "command", cmd, "len", len(data))
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case data := <-c.out:
+			// Ошибка установки тайм-аута не критична: запись всё равно выполняется.
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := conn.Write(data); err != nil {
+				slog.Warn("KISS client write failed", "client", remote, "error", err)
+				return
+			}
+		}
+	}
+}
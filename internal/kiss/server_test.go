@@ -0,0 +1,60 @@
+package kiss
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServer_Broadcast(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, ln) }()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for srv.Clients() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Кадр от клиента игнорируется и не разрывает соединение.
+	if _, err := conn.Write(Encode(nil, 0, []byte("ignored"))); err != nil {
+		t.Fatal(err)
+	}
+	frame := []byte{0x86, 0xA2, FEND, 0x03, 0xF0}
+	srv.Broadcast(frame)
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, cmd, data, err := NewDecoder(conn).Next()
+	if err != nil || cmd != CmdData || !bytes.Equal(data, frame) {
+		t.Fatalf("received %d, %x, %v; want %x", cmd, data, err, frame)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve() = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+	if srv.Clients() != 0 {
+		t.Errorf("Clients() = %d after shutdown", srv.Clients())
+	}
+}
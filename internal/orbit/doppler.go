@@ -0,0 +1,16 @@
+package orbit
+
+// SpeedOfLight — скорость света в вакууме, км/с.
+const SpeedOfLight = 299792.458
+
+// DopplerShift возвращает доплеровский сдвиг (Гц) для несущей freq (Гц)
+// при скорости изменения дальности rangeRate (км/с). При сближении
+// (rangeRate < 0) сдвиг положительный.
+func DopplerShift(freq, rangeRate float64) float64 {
+	return -freq * rangeRate / SpeedOfLight
+}
+
+// Doppler возвращает доплеровский сдвиг частоты freq (Гц) для направления l.
+func (l Look) Doppler(freq float64) float64 {
+	return DopplerShift(freq, l.RangeRate)
+}
@@ -0,0 +1,72 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDopplerShift(t *testing.T) {
+	tests := []struct {
+		name      string
+		freq      float64
+		rangeRate float64
+		want      float64
+	}{
+		{"approaching VHF", 145.8e6, -7, 3404.37},
+		{"receding UHF", 437.5e6, 7, -10215.5},
+		{"no radial motion", 437.5e6, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DopplerShift(tt.freq, tt.rangeRate); math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("DopplerShift(%v, %v) = %.2f, want %.2f", tt.freq, tt.rangeRate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLook_DopplerDuringPass(t *testing.T) {
+	prop, err := New(Elements{
+		Epoch:        time.Date(2008, time.September, 20, 12, 25, 40, 104192000, time.UTC),
+		Inclination:  51.6416,
+		RAAN:         247.4627,
+		Eccentricity: 0.0006703,
+		ArgPerigee:   130.536,
+		MeanAnomaly:  325.0288,
+		MeanMotion:   15.72125391,
+		BStar:        -0.11606e-4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs := Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+
+	// Сдвиг на 145.8 МГц для НОО не превышает ~3.7 кГц и согласован
+	// с численной производной дальности.
+	start := prop.Elements().Epoch
+	for at := start; at.Before(start.Add(6 * time.Hour)); at = at.Add(7 * time.Minute) {
+		st, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		look := obs.Look(st)
+		if shift := look.Doppler(145.8e6); math.Abs(shift) > 3700 {
+			t.Errorf("Doppler at %v = %.0f Hz, exceeds LEO bound", at, shift)
+		}
+
+		next, err := prop.Propagate(at.Add(time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		prev, err := prop.Propagate(at.Add(-time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		numeric := (obs.Look(next).Range - obs.Look(prev).Range) / 2
+		if math.Abs(numeric-look.RangeRate) > 1e-3 {
+			t.Errorf("RangeRate at %v = %.5f, numeric %.5f", at, look.RangeRate, numeric)
+		}
+	}
+}
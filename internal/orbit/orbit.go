@@ -0,0 +1,76 @@
+// Package orbit реализует модели распространения орбит SGP4/SDP4.
+//
+// Реализация следует отчёту Vallado et al. "Revisiting Spacetrack Report #3"
+// (AIAA 2006-6753) в режиме "improved" и использует гравитационную модель
+// WGS-72, с которой согласованы элементы NORAD. Результаты выдаются в системе
+// TEME (True Equator, Mean Equinox).
+package orbit
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Ошибки распространения (соответствуют кодам ошибок SGP4).
+var (
+	ErrEccentricity          = errors.New("orbit: mean eccentricity out of range")
+	ErrMeanMotion            = errors.New("orbit: mean motion is not positive")
+	ErrPerturbedEccentricity = errors.New("orbit: perturbed eccentricity out of range")
+	ErrSemiLatusRectum       = errors.New("orbit: semi-latus rectum is negative")
+	ErrDecayed               = errors.New("orbit: satellite has decayed")
+)
+
+// Elements содержит средние элементы орбиты в формате NORAD (TLE/OMM).
+type Elements struct {
+	Epoch        time.Time
+	Inclination  float64 // градусы
+	RAAN         float64 // долгота восходящего узла, градусы
+	Eccentricity float64
+	ArgPerigee   float64 // аргумент перигея, градусы
+	MeanAnomaly  float64 // градусы
+	MeanMotion   float64 // оборотов в сутки
+	BStar        float64 // баллистический коэффициент, 1/радиус Земли
+}
+
+// Period возвращает период обращения по среднему движению.
+func (e Elements) Period() time.Duration {
+	if e.MeanMotion <= 0 {
+		return 0
+	}
+	return time.Duration(float64(24*time.Hour) / e.MeanMotion)
+}
+
+// Vector — трёхмерный вектор в декартовой системе координат.
+type Vector struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// Norm возвращает длину вектора.
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Sub возвращает разность векторов v - u.
+func (v Vector) Sub(u Vector) Vector {
+	return Vector{X: v.X - u.X, Y: v.Y - u.Y, Z: v.Z - u.Z}
+}
+
+// Dot возвращает скалярное произведение векторов.
+func (v Vector) Dot(u Vector) float64 {
+	return v.X*u.X + v.Y*u.Y + v.Z*u.Z
+}
+
+// State содержит положение (км) и скорость (км/с) спутника в системе TEME.
+type State struct {
+	Time     time.Time `json:"time"`
+	Position Vector    `json:"position"`
+	Velocity Vector    `json:"velocity"`
+}
+
+// Propagator вычисляет состояние спутника на произвольный момент времени.
+type Propagator interface {
+	Propagate(t time.Time) (State, error)
+}
@@ -0,0 +1,457 @@
+package orbit
+
+import (
+	"math"
+	"time"
+)
+
+// Константы гравитационной модели WGS-72.
+const (
+	earthRadiusKm = 6378.135
+	earthMu       = 398600.8 // км³/с²
+	j2            = 0.001082616
+	j3            = -0.00000253881
+	j4            = -0.00000165597
+	j3oj2         = j3 / j2
+
+	twoPi       = 2 * math.Pi
+	deg2rad     = math.Pi / 180
+	x2o3        = 2.0 / 3.0
+	minPerDay   = 1440.0
+	deepPeriod  = 225.0 // минут, граница между SGP4 и SDP4
+	jd1950Epoch = 2433281.5
+)
+
+var (
+	// xke — sqrt(mu) в единицах радиус Земли^1.5 / минута.
+	xke = 60.0 / math.Sqrt(earthRadiusKm*earthRadiusKm*earthRadiusKm/earthMu)
+	// vkmpersec — множитель перевода скорости в км/с.
+	vkmpersec = earthRadiusKm * xke / 60.0
+	// epoch1950 — начало отсчёта эпохи SGP4 (1949-12-31 00:00 UTC).
+	epoch1950 = time.Date(1949, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// SGP4 распространяет орбиту по моделям SGP4 (период < 225 мин) и SDP4
+// (глубокий космос). Выбор модели выполняется автоматически в New.
+// После инициализации значение неизменяемо и безопасно для конкурентного
+// использования.
+type SGP4 struct {
+	elements Elements
+	deep     bool
+	isimp    bool
+
+	// Средние элементы на эпоху (радианы, радианы в минуту).
+	epoch    float64 // дни от 1950.0
+	ecco     float64
+	inclo    float64
+	nodeo    float64
+	argpo    float64
+	mo       float64
+	noKozai  float64
+	noUnkoz  float64
+	bstar    float64
+	gsto     float64
+	eccsq    float64
+	sinio    float64
+	cosio    float64
+	con41    float64
+	x1mth2   float64
+	x7thm1   float64
+	xlcof    float64
+	aycof    float64
+	eta      float64
+	delmo    float64
+	sinmao   float64
+	cc1      float64
+	cc4      float64
+	cc5      float64
+	d2       float64
+	d3       float64
+	d4       float64
+	t2cof    float64
+	t3cof    float64
+	t4cof    float64
+	t5cof    float64
+	mdot     float64
+	argpdot  float64
+	nodedot  float64
+	omgcof   float64
+	xmcof    float64
+	nodecf   float64
+	deepTerm deepSpace
+}
+
+// New инициализирует модель по средним элементам орбиты.
+func New(el Elements) (*SGP4, error) {
+	s := &SGP4{
+		elements: el,
+		epoch:    el.Epoch.Sub(epoch1950).Hours() / 24,
+		ecco:     el.Eccentricity,
+		inclo:    el.Inclination * deg2rad,
+		nodeo:    el.RAAN * deg2rad,
+		argpo:    el.ArgPerigee * deg2rad,
+		mo:       el.MeanAnomaly * deg2rad,
+		noKozai:  el.MeanMotion * twoPi / minPerDay,
+		bstar:    el.BStar,
+	}
+	if s.ecco < 0 || s.ecco >= 1 {
+		return nil, ErrEccentricity
+	}
+	if s.noKozai <= 0 {
+		return nil, ErrMeanMotion
+	}
+
+	s.init()
+
+	// Проверка начального состояния, как в оригинальной sgp4init.
+	if _, err := s.PropagateMinutes(0); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Elements возвращает исходные элементы орбиты.
+func (s *SGP4) Elements() Elements {
+	return s.elements
+}
+
+// DeepSpace сообщает, используется ли модель SDP4.
+func (s *SGP4) DeepSpace() bool {
+	return s.deep
+}
+
+// Propagate вычисляет состояние спутника на момент t.
+func (s *SGP4) Propagate(t time.Time) (State, error) {
+	st, err := s.PropagateMinutes(t.Sub(s.elements.Epoch).Minutes())
+	st.Time = t
+	return st, err
+}
+
+// PropagateMinutes вычисляет состояние спутника через tsince минут от эпохи.
+func (s *SGP4) PropagateMinutes(tsince float64) (State, error) {
+	st := State{Time: s.elements.Epoch.Add(time.Duration(tsince * float64(time.Minute)))}
+
+	// Вековые возмущения от гравитации и сопротивления атмосферы.
+	xmdf := s.mo + s.mdot*tsince
+	argpdf := s.argpo + s.argpdot*tsince
+	nodedf := s.nodeo + s.nodedot*tsince
+	argpm := argpdf
+	mm := xmdf
+	t2 := tsince * tsince
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1 - s.cc1*tsince
+	tempe := s.bstar * s.cc4 * tsince
+	templ := s.t2cof * t2
+
+	if !s.isimp {
+		delomg := s.omgcof * tsince
+		delmtemp := 1 + s.eta*math.Cos(xmdf)
+		delm := s.xmcof * (delmtemp*delmtemp*delmtemp - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * tsince
+		t4 := t3 * tsince
+		tempa = tempa - s.d2*t2 - s.d3*t3 - s.d4*t4
+		tempe += s.bstar * s.cc5 * (math.Sin(mm) - s.sinmao)
+		templ += s.t3cof*t3 + t4*(s.t4cof+tsince*s.t5cof)
+	}
+
+	nm := s.noUnkoz
+	em := s.ecco
+	inclm := s.inclo
+	if s.deep {
+		em, argpm, inclm, mm, nodem, nm = s.deepTerm.dspace(s, tsince, em, argpm, inclm, mm, nodem)
+	}
+
+	if nm <= 0 {
+		return st, ErrMeanMotion
+	}
+	am := math.Pow(xke/nm, x2o3) * tempa * tempa
+	nm = xke / math.Pow(am, 1.5)
+	em -= tempe
+	if em >= 1 || em < -0.001 {
+		return st, ErrEccentricity
+	}
+	if em < 1e-6 {
+		em = 1e-6
+	}
+	mm += s.noUnkoz * templ
+	xlm := mm + argpm + nodem
+
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+
+	// Долгопериодические возмущения от Луны и Солнца.
+	ep := em
+	xincp := inclm
+	argpp := argpm
+	nodep := nodem
+	mp := mm
+	sinip := math.Sin(inclm)
+	cosip := math.Cos(inclm)
+	con41, x1mth2, x7thm1 := s.con41, s.x1mth2, s.x7thm1
+	aycof, xlcof := s.aycof, s.xlcof
+
+	if s.deep {
+		ep, xincp, nodep, argpp, mp = s.deepTerm.dpper(tsince, ep, xincp, nodep, argpp, mp)
+		if xincp < 0 {
+			xincp = -xincp
+			nodep += math.Pi
+			argpp -= math.Pi
+		}
+		if ep < 0 || ep > 1 {
+			return st, ErrPerturbedEccentricity
+		}
+
+		sinip = math.Sin(xincp)
+		cosip = math.Cos(xincp)
+		aycof = -0.5 * j3oj2 * sinip
+		xlcof = longPeriodCoef(sinip, cosip)
+
+		cosisq := cosip * cosip
+		con41 = 3*cosisq - 1
+		x1mth2 = 1 - cosisq
+		x7thm1 = 7*cosisq - 1
+	}
+
+	// Долгопериодические периодические члены.
+	axnl := ep * math.Cos(argpp)
+	temp := 1 / (am * (1 - ep*ep))
+	aynl := ep*math.Sin(argpp) + temp*aycof
+	xl := mp + argpp + nodep + temp*xlcof*axnl
+
+	// Решение уравнения Кеплера.
+	u := math.Mod(xl-nodep, twoPi)
+	eo1 := u
+	tem5 := 9999.9
+	var sineo1, coseo1 float64
+	for ktr := 1; math.Abs(tem5) >= 1e-12 && ktr <= 10; ktr++ {
+		sineo1 = math.Sin(eo1)
+		coseo1 = math.Cos(eo1)
+		tem5 = 1 - coseo1*axnl - sineo1*aynl
+		tem5 = (u - aynl*coseo1 + axnl*sineo1 - eo1) / tem5
+		if math.Abs(tem5) >= 0.95 {
+			tem5 = math.Copysign(0.95, tem5)
+		}
+		eo1 += tem5
+	}
+
+	// Короткопериодические возмущения.
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+	if pl < 0 {
+		return st, ErrSemiLatusRectum
+	}
+
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * j2 * temp
+	temp2 := temp1 * temp
+
+	mrt := rl*(1-1.5*temp2*betal*con41) + 0.5*temp1*x1mth2*cos2u
+	su -= 0.25 * temp2 * x7thm1 * sin2u
+	xnode := nodep + 1.5*temp2*cosip*sin2u
+	xinc := xincp + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*x1mth2*sin2u/xke
+	rvdot := rvdotl + nm*temp1*(x1mth2*cos2u+1.5*con41)/xke
+
+	// Ориентирующие векторы.
+	sinsu, cossu := math.Sincos(su)
+	snod, cnod := math.Sincos(xnode)
+	sini, cosi := math.Sincos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	st.Position = Vector{
+		X: mrt * ux * earthRadiusKm,
+		Y: mrt * uy * earthRadiusKm,
+		Z: mrt * uz * earthRadiusKm,
+	}
+	st.Velocity = Vector{
+		X: (mvt*ux + rvdot*vx) * vkmpersec,
+		Y: (mvt*uy + rvdot*vy) * vkmpersec,
+		Z: (mvt*uz + rvdot*vz) * vkmpersec,
+	}
+
+	if mrt < 1 {
+		return st, ErrDecayed
+	}
+	return st, nil
+}
+
+// unKozai переводит среднее движение TLE (по Козаи) в среднее движение
+// Брауэра, используемое SGP4; значения в радианах в минуту.
+func unKozai(noKozai, ecco, cosio float64) float64 {
+	omeosq := 1 - ecco*ecco
+	ak := math.Pow(xke/noKozai, x2o3)
+	d1 := 0.75 * j2 * (3*cosio*cosio - 1) / (math.Sqrt(omeosq) * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1.0/3.0+134*del*del/81))
+	del = d1 / (adel * adel)
+	return noKozai / (1 + del)
+}
+
+// MeanMotionForAltitude возвращает среднее движение TLE (об/сутки), при котором
+// средняя большая полуось SGP4 равна экваториальному радиусу Земли плюс
+// altitude (км). Наклонение — в градусах.
+func MeanMotionForAltitude(altitude, inclination, eccentricity float64) float64 {
+	a := 1 + altitude/earthRadiusKm
+	target := xke / math.Sqrt(a*a*a)
+	cosio := math.Cos(inclination * deg2rad)
+
+	// Обращение unKozai простой итерацией: поправка мала (~1e-3).
+	no := target
+	for range 20 {
+		no *= target / unKozai(no, eccentricity, cosio)
+	}
+	return no * minPerDay / twoPi
+}
+
+// init вычисляет постоянные модели (аналог sgp4init и initl).
+//
+//nolint:funlen // прямой перенос алгоритма, разбиение ухудшает сверку с эталоном
+func (s *SGP4) init() {
+	const ss = 78.0/earthRadiusKm + 1.0
+	qzms2t := math.Pow((120.0-78.0)/earthRadiusKm, 4)
+
+	// initl: восстановление среднего движения (un-Kozai).
+	s.eccsq = s.ecco * s.ecco
+	omeosq := 1 - s.eccsq
+	rteosq := math.Sqrt(omeosq)
+	s.cosio = math.Cos(s.inclo)
+	cosio2 := s.cosio * s.cosio
+
+	s.noUnkoz = unKozai(s.noKozai, s.ecco, s.cosio)
+
+	ao := math.Pow(xke/s.noUnkoz, x2o3)
+	s.sinio = math.Sin(s.inclo)
+	po := ao * omeosq
+	con42 := 1 - 5*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1 - s.ecco)
+	s.gsto = gstime(s.epoch + jd1950Epoch)
+
+	// sgp4init: вековые коэффициенты.
+	s.isimp = rp < 220/earthRadiusKm+1
+
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1) * earthRadiusKm
+	if perige < 156 {
+		sfour = perige - 78
+		if perige < 98 {
+			sfour = 20
+		}
+		qzms24 = math.Pow((120-sfour)/earthRadiusKm, 4)
+		sfour = sfour/earthRadiusKm + 1
+	}
+	pinvsq := 1 / posq
+
+	tsi := 1 / (ao - sfour)
+	s.eta = ao * s.ecco * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecco * s.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * s.noUnkoz * (ao*(1+1.5*etasq+eeta*(4+etasq)) +
+		0.375*j2*tsi/psisq*s.con41*(8+3*etasq*(8+etasq)))
+	s.cc1 = s.bstar * cc2
+	cc3 := 0.0
+	if s.ecco > 1e-4 {
+		cc3 = -2 * coef * tsi * j3oj2 * s.noUnkoz * s.sinio / s.ecco
+	}
+	s.x1mth2 = 1 - cosio2
+	s.cc4 = 2 * s.noUnkoz * coef1 * ao * omeosq *
+		(s.eta*(2+0.5*etasq) + s.ecco*(0.5+2*etasq) -
+			j2*tsi/(ao*psisq)*(-3*s.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+				0.75*s.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*s.argpo)))
+	s.cc5 = 2 * coef1 * ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * j2 * pinvsq * s.noUnkoz
+	temp2 := 0.5 * temp1 * j2 * pinvsq
+	temp3 := -0.46875 * j4 * pinvsq * pinvsq * s.noUnkoz
+	s.mdot = s.noUnkoz + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) +
+		temp3*(3-36*cosio2+49*cosio4)
+	xhdot1 := -temp1 * s.cosio
+	s.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*s.cosio
+	xpidot := s.argpdot + s.nodedot
+	s.omgcof = s.bstar * cc3 * math.Cos(s.argpo)
+	s.xmcof = 0
+	if s.ecco > 1e-4 {
+		s.xmcof = -x2o3 * coef * s.bstar / eeta
+	}
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+	s.xlcof = longPeriodCoef(s.sinio, s.cosio)
+	s.aycof = -0.5 * j3oj2 * s.sinio
+	delmotemp := 1 + s.eta*math.Cos(s.mo)
+	s.delmo = delmotemp * delmotemp * delmotemp
+	s.sinmao = math.Sin(s.mo)
+	s.x7thm1 = 7*cosio2 - 1
+
+	// Глубокий космос: инициализация резонансных и лунно-солнечных членов.
+	if twoPi/s.noUnkoz >= deepPeriod {
+		s.deep = true
+		s.isimp = true
+		s.deepTerm.init(s, xpidot)
+	}
+
+	if !s.isimp {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4 * ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3
+		s.d3 = (17*ao + sfour) * temp
+		s.d4 = 0.5 * temp * ao * tsi * (221*ao + 31*sfour) * s.cc1
+		s.t3cof = s.d2 + 2*cc1sq
+		s.t4cof = 0.25 * (3*s.d3 + s.cc1*(12*s.d2+10*cc1sq))
+		s.t5cof = 0.2 * (3*s.d4 + 12*s.cc1*s.d3 + 6*s.d2*s.d2 + 15*cc1sq*(2*s.d2+cc1sq))
+	}
+}
+
+// longPeriodCoef вычисляет коэффициент xlcof с защитой от деления на ноль
+// при наклонении 180°.
+func longPeriodCoef(sini, cosi float64) float64 {
+	const temp4 = 1.5e-12
+	den := 1 + cosi
+	if math.Abs(den) <= temp4 {
+		den = temp4
+	}
+	return -0.25 * j3oj2 * sini * (3 + 5*cosi) / den
+}
+
+// gstime возвращает гринвичское среднее звёздное время (IAU-82) в радианах
+// для юлианской даты jdut1.
+func gstime(jdut1 float64) float64 {
+	tut1 := (jdut1 - 2451545.0) / 36525.0
+	temp := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 +
+		(876600.0*3600+8640184.812866)*tut1 + 67310.54841
+	temp = math.Mod(temp*deg2rad/240.0, twoPi)
+	if temp < 0 {
+		temp += twoPi
+	}
+	return temp
+}
@@ -0,0 +1,259 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// tleEpoch переводит эпоху TLE (год, день года с дробной частью) в time.Time.
+func tleEpoch(year int, day float64) time.Time {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((day - 1) * float64(24*time.Hour)))
+}
+
+// Эталонные векторы из набора проверки Vallado (SGP4-VER.TLE, tcppver.out).
+func TestSGP4_VerificationVectors(t *testing.T) {
+	type point struct {
+		tsince float64
+		r      Vector
+		v      Vector
+	}
+
+	tests := []struct {
+		name   string
+		el     Elements
+		deep   bool
+		points []point
+	}{
+		{
+			// 1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753
+			// 2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667
+			name: "00005 near earth",
+			el: Elements{
+				Epoch:        tleEpoch(2000, 179.78495062),
+				Inclination:  34.2682,
+				RAAN:         348.7242,
+				Eccentricity: 0.1859667,
+				ArgPerigee:   331.7664,
+				MeanAnomaly:  19.3264,
+				MeanMotion:   10.82419157,
+				BStar:        0.28098e-4,
+			},
+			points: []point{
+				{0, Vector{7022.46529266, -1400.08296755, 0.03995155}, Vector{1.893841015, 6.405893759, 4.534807250}},
+				{360, Vector{-7154.03120202, -3783.17682504, -3536.19412294}, Vector{4.741887409, -4.151817765, -2.093935425}},
+				{720, Vector{-7134.59340119, 6531.68641334, 3260.27186483}, Vector{-4.113793027, -2.911922039, -2.557327851}},
+				{1080, Vector{5568.53901181, 4492.06992591, 3863.87641983}, Vector{-4.209106476, 5.159719888, 2.744852980}},
+			},
+		},
+		{
+			// 1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813
+			// 2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656
+			name: "08195 Molniya 12h resonance",
+			el: Elements{
+				Epoch:        tleEpoch(2006, 176.33215444),
+				Inclination:  64.1586,
+				RAAN:         279.0717,
+				Eccentricity: 0.6877146,
+				ArgPerigee:   264.7651,
+				MeanAnomaly:  20.2257,
+				MeanMotion:   2.00491383,
+				BStar:        0.11873e-3,
+			},
+			deep: true,
+			points: []point{
+				{0, Vector{2349.89483350, -14785.93811562, 0.02119378}, Vector{2.721488096, -3.256811655, 4.498416672}},
+				{120, Vector{15223.91713658, -17852.95881713, 25280.39558224}, Vector{1.079041732, 0.875187372, 2.485682813}},
+			},
+		},
+		{
+			// 1 11801U          80230.29629788  .01431103  00000-0  14311-1      13
+			// 2 11801  46.7916 230.4354 7318036  47.4722  10.4117  2.28537848    13
+			name: "11801 deep space",
+			el: Elements{
+				Epoch:        tleEpoch(1980, 230.29629788),
+				Inclination:  46.7916,
+				RAAN:         230.4354,
+				Eccentricity: 0.7318036,
+				ArgPerigee:   47.4722,
+				MeanAnomaly:  10.4117,
+				MeanMotion:   2.28537848,
+				BStar:        0.14311e-1,
+			},
+			deep: true,
+			points: []point{
+				{0, Vector{7473.37102491, 428.94748312, 5828.74846783}, Vector{5.107155391, 6.444680305, -0.186133297}},
+				{720, Vector{14271.29083858, 24110.44309009, -4725.76320143}, Vector{-0.320504528, 2.679841539, -2.084054355}},
+			},
+		},
+		{
+			// 1 28626U 05008A   06176.46683397 -.00000205  00000-0  10000-3 0  2190
+			// 2 28626   0.0019 286.9433 0000335  13.7918  55.6504  1.00270176  4589
+			name: "28626 geosynchronous 24h resonance",
+			el: Elements{
+				Epoch:        tleEpoch(2006, 176.46683397),
+				Inclination:  0.0019,
+				RAAN:         286.9433,
+				Eccentricity: 0.0000335,
+				ArgPerigee:   13.7918,
+				MeanAnomaly:  55.6504,
+				MeanMotion:   1.00270176,
+				BStar:        0.1e-3,
+			},
+			deep: true,
+			points: []point{
+				{0, Vector{42080.71852213, -2646.86387436, 0.81851294}, Vector{0.193105177, 3.068688251, 0.000438449}},
+				{120, Vector{37740.00085593, 18802.76872802, 3.45512584}, Vector{-1.371035206, 2.752105932, 0.000336883}},
+			},
+		},
+	}
+
+	const (
+		posTol = 1e-6 // км
+		velTol = 1e-8 // км/с
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.el)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			if p.DeepSpace() != tt.deep {
+				t.Errorf("DeepSpace() = %v, want %v", p.DeepSpace(), tt.deep)
+			}
+
+			for _, pt := range tt.points {
+				st, err := p.PropagateMinutes(pt.tsince)
+				if err != nil {
+					t.Fatalf("PropagateMinutes(%v) error: %v", pt.tsince, err)
+				}
+				if d := st.Position.Sub(pt.r).Norm(); d > posTol {
+					t.Errorf("t=%v: position %+v, want %+v (diff %g km)", pt.tsince, st.Position, pt.r, d)
+				}
+				if d := st.Velocity.Sub(pt.v).Norm(); d > velTol {
+					t.Errorf("t=%v: velocity %+v, want %+v (diff %g km/s)", pt.tsince, st.Velocity, pt.v, d)
+				}
+			}
+		})
+	}
+}
+
+func TestSGP4_PropagateTime(t *testing.T) {
+	el := Elements{
+		Epoch:        tleEpoch(2000, 179.78495062),
+		Inclination:  34.2682,
+		RAAN:         348.7242,
+		Eccentricity: 0.1859667,
+		ArgPerigee:   331.7664,
+		MeanAnomaly:  19.3264,
+		MeanMotion:   10.82419157,
+		BStar:        0.28098e-4,
+	}
+	p, err := New(el)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := el.Epoch.Add(360 * time.Minute)
+	byTime, err := p.Propagate(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byMinutes, err := p.PropagateMinutes(360)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !byTime.Time.Equal(at) {
+		t.Errorf("State.Time = %v, want %v", byTime.Time, at)
+	}
+	if d := byTime.Position.Sub(byMinutes.Position).Norm(); d > 1e-9 {
+		t.Errorf("Propagate and PropagateMinutes differ by %g km", d)
+	}
+
+	var _ Propagator = p
+}
+
+func TestNew_InvalidElements(t *testing.T) {
+	tests := []struct {
+		name    string
+		el      Elements
+		wantErr error
+	}{
+		{
+			name:    "negative eccentricity",
+			el:      Elements{Eccentricity: -0.1, MeanMotion: 15},
+			wantErr: ErrEccentricity,
+		},
+		{
+			name:    "eccentricity above one",
+			el:      Elements{Eccentricity: 1.2, MeanMotion: 15},
+			wantErr: ErrEccentricity,
+		},
+		{
+			name:    "zero mean motion",
+			el:      Elements{Eccentricity: 0.001},
+			wantErr: ErrMeanMotion,
+		},
+		{
+			name:    "perigee below surface",
+			el:      Elements{Eccentricity: 0.5, MeanMotion: 16},
+			wantErr: ErrDecayed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.el); !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestElements_Period(t *testing.T) {
+	el := Elements{MeanMotion: 15.5}
+	want := 24 * 60 / 15.5
+	if got := el.Period().Minutes(); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Period() = %v min, want %v min", got, want)
+	}
+
+	if got := (Elements{}).Period(); got != 0 {
+		t.Errorf("Period() for zero mean motion = %v, want 0", got)
+	}
+}
+
+func TestGstime(t *testing.T) {
+	// Vallado, пример 3-5: 1992-08-20 12:14 UT1 -> GMST 152.578787886°.
+	jd := 2448855.009722
+	got := gstime(jd) / deg2rad
+	if math.Abs(got-152.578787886) > 1e-4 {
+		t.Errorf("gstime() = %v°, want 152.578787886°", got)
+	}
+}
+
+func TestMeanMotionForAltitude(t *testing.T) {
+	tests := []struct {
+		altitude    float64
+		inclination float64
+	}{
+		{400, 51.6},
+		{800, 98.6},
+		{35786, 0.1},
+	}
+
+	for _, tt := range tests {
+		n := MeanMotionForAltitude(tt.altitude, tt.inclination, 0)
+		s, err := New(Elements{Epoch: time.Now(), Inclination: tt.inclination, MeanMotion: n})
+		if err != nil {
+			t.Fatalf("New() error: %v", err)
+		}
+		a := math.Pow(xke/s.noUnkoz, x2o3) * earthRadiusKm
+		if math.Abs(a-earthRadiusKm-tt.altitude) > 1e-6 {
+			t.Errorf("altitude %v: mean semi-major axis = %.6f km, want %.6f", tt.altitude, a, earthRadiusKm+tt.altitude)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package orbit
+
+import (
+	"math"
+	"time"
+)
+
+// Параметры эллипсоида WGS-84.
+const (
+	wgs84A  = 6378.137 // большая полуось, км
+	wgs84F  = 1 / 298.257223563
+	wgs84E2 = wgs84F * (2 - wgs84F)
+
+	rad2deg   = 180 / math.Pi
+	jdUnixEra = 2440587.5 // юлианская дата 1970-01-01 00:00 UTC
+
+	// earthRotation — угловая скорость вращения Земли, рад/с.
+	earthRotation = 7.292115146706979e-5
+)
+
+// Observer — точка наблюдения на поверхности Земли.
+type Observer struct {
+	Latitude  float64 // градусы, север положительный
+	Longitude float64 // градусы, восток положительный
+	Altitude  float64 // метры над эллипсоидом
+}
+
+// Look — направление с наблюдателя на спутник.
+type Look struct {
+	Azimuth   float64 `json:"azimuth"`    // градусы от севера по часовой стрелке
+	Elevation float64 `json:"elevation"`  // градусы над горизонтом
+	Range     float64 `json:"range"`      // наклонная дальность, км
+	RangeRate float64 `json:"range_rate"` // скорость изменения дальности, км/с
+}
+
+// Geodetic — геодезические координаты точки относительно WGS-84.
+type Geodetic struct {
+	Latitude  float64 `json:"lat"` // градусы
+	Longitude float64 `json:"lon"` // градусы, [-180, 180)
+	Altitude  float64 `json:"alt"` // км над эллипсоидом
+}
+
+// JulianDate возвращает юлианскую дату момента t (UTC).
+func JulianDate(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + jdUnixEra
+}
+
+// GMST возвращает гринвичское среднее звёздное время (IAU-82) в радианах.
+func GMST(t time.Time) float64 {
+	return gstime(JulianDate(t))
+}
+
+// TEMEToECEF поворачивает вектор из TEME во вращающуюся систему ECEF
+// (движение полюса не учитывается).
+func TEMEToECEF(r Vector, t time.Time) Vector {
+	sin, cos := math.Sincos(GMST(t))
+	return Vector{
+		X: cos*r.X + sin*r.Y,
+		Y: -sin*r.X + cos*r.Y,
+		Z: r.Z,
+	}
+}
+
+// TEMEToECEFVelocity переводит скорость из TEME в ECEF с учётом
+// вращения Земли; r — положение в TEME.
+func TEMEToECEFVelocity(r, v Vector, t time.Time) Vector {
+	rot := TEMEToECEF(v, t)
+	pos := TEMEToECEF(r, t)
+	return Vector{
+		X: rot.X + earthRotation*pos.Y,
+		Y: rot.Y - earthRotation*pos.X,
+		Z: rot.Z,
+	}
+}
+
+// ECEFToGeodetic переводит положение ECEF (км) в геодезические координаты
+// итерационным методом.
+func ECEFToGeodetic(r Vector) Geodetic {
+	p := math.Hypot(r.X, r.Y)
+	lon := math.Atan2(r.Y, r.X)
+	lat := math.Atan2(r.Z, p*(1-wgs84E2))
+
+	var n float64
+	for range 10 {
+		sinLat := math.Sin(lat)
+		n = wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+		next := math.Atan2(r.Z+n*wgs84E2*sinLat, p)
+		if math.Abs(next-lat) < 1e-12 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+
+	sinLat, cosLat := math.Sincos(lat)
+	n = wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+	var alt float64
+	if math.Abs(cosLat) > 1e-10 {
+		alt = p/cosLat - n
+	} else {
+		alt = math.Abs(r.Z) - n*(1-wgs84E2)
+	}
+
+	lonDeg := lon * rad2deg
+	if lonDeg >= 180 {
+		lonDeg -= 360
+	}
+	return Geodetic{
+		Latitude:  lat * rad2deg,
+		Longitude: lonDeg,
+		Altitude:  alt,
+	}
+}
+
+// SubPoint возвращает подспутниковую точку и высоту спутника.
+func SubPoint(st State) Geodetic {
+	return ECEFToGeodetic(TEMEToECEF(st.Position, st.Time))
+}
+
+// ECEF возвращает положение наблюдателя в системе ECEF, км.
+func (o Observer) ECEF() Vector {
+	sinLat, cosLat := math.Sincos(o.Latitude / rad2deg)
+	sinLon, cosLon := math.Sincos(o.Longitude / rad2deg)
+	h := o.Altitude / 1000
+	n := wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+	return Vector{
+		X: (n + h) * cosLat * cosLon,
+		Y: (n + h) * cosLat * sinLon,
+		Z: (n*(1-wgs84E2) + h) * sinLat,
+	}
+}
+
+// Look вычисляет азимут, угол места и дальность до спутника.
+func (o Observer) Look(st State) Look {
+	rho := TEMEToECEF(st.Position, st.Time).Sub(o.ECEF())
+	vel := TEMEToECEFVelocity(st.Position, st.Velocity, st.Time)
+
+	sinLat, cosLat := math.Sincos(o.Latitude / rad2deg)
+	sinLon, cosLon := math.Sincos(o.Longitude / rad2deg)
+
+	// Топоцентрическая система SEZ (юг, восток, зенит).
+	south := sinLat*cosLon*rho.X + sinLat*sinLon*rho.Y - cosLat*rho.Z
+	east := -sinLon*rho.X + cosLon*rho.Y
+	zenith := cosLat*cosLon*rho.X + cosLat*sinLon*rho.Y + sinLat*rho.Z
+
+	rng := rho.Norm()
+	az := math.Atan2(east, -south) * rad2deg
+	if az < 0 {
+		az += 360
+	}
+	return Look{
+		Azimuth:   az,
+		Elevation: math.Asin(zenith/rng) * rad2deg,
+		Range:     rng,
+		RangeRate: rho.Dot(vel) / rng,
+	}
+}
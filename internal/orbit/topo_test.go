@@ -0,0 +1,163 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestObserver_ECEF(t *testing.T) {
+	tests := []struct {
+		name string
+		obs  Observer
+		want Vector
+	}{
+		{"equator prime meridian", Observer{}, Vector{X: wgs84A}},
+		{"north pole", Observer{Latitude: 90}, Vector{Z: 6356.752314245}},
+		{"equator 90E with altitude", Observer{Longitude: 90, Altitude: 1000}, Vector{Y: wgs84A + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := tt.obs.ECEF().Sub(tt.want).Norm(); d > 1e-6 {
+				t.Errorf("ECEF() = %+v, want %+v", tt.obs.ECEF(), tt.want)
+			}
+		})
+	}
+}
+
+func TestObserver_LookOverhead(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	obs := Observer{Latitude: 47.3, Longitude: 39.8}
+
+	// Спутник на 500 км строго над наблюдателем: переводим ECEF в TEME.
+	up := obs.ECEF()
+	scale := (up.Norm() + 500) / up.Norm()
+	ecef := Vector{X: up.X * scale, Y: up.Y * scale, Z: up.Z * scale}
+	sin, cos := math.Sincos(GMST(at))
+	teme := Vector{X: cos*ecef.X - sin*ecef.Y, Y: sin*ecef.X + cos*ecef.Y, Z: ecef.Z}
+
+	look := obs.Look(State{Time: at, Position: teme})
+	// Радиус-вектор отличается от геодезической нормали на ~0.2°.
+	if look.Elevation < 89.5 {
+		t.Errorf("Elevation = %v, want ~90", look.Elevation)
+	}
+	if math.Abs(look.Range-500) > 0.5 {
+		t.Errorf("Range = %v, want ~500", look.Range)
+	}
+}
+
+func TestObserver_LookAzimuth(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	sin, cos := math.Sincos(GMST(at))
+	toTEME := func(v Vector) Vector {
+		return Vector{X: cos*v.X - sin*v.Y, Y: sin*v.X + cos*v.Y, Z: v.Z}
+	}
+	obs := Observer{}
+
+	tests := []struct {
+		name string
+		ecef Vector
+		az   float64
+	}{
+		{"north", Vector{X: wgs84A, Z: 1000}, 0},
+		{"east", Vector{X: wgs84A, Y: 1000}, 90},
+		{"south", Vector{X: wgs84A, Z: -1000}, 180},
+		{"west", Vector{X: wgs84A, Y: -1000}, 270},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			look := obs.Look(State{Time: at, Position: toTEME(tt.ecef)})
+			if math.Abs(look.Azimuth-tt.az) > 1e-6 {
+				t.Errorf("Azimuth = %v, want %v", look.Azimuth, tt.az)
+			}
+			if math.Abs(look.Elevation) > 1e-6 {
+				t.Errorf("Elevation = %v, want 0", look.Elevation)
+			}
+		})
+	}
+}
+
+func TestECEFToGeodetic_RoundTrip(t *testing.T) {
+	observers := []Observer{
+		{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70},
+		{Latitude: -33.9, Longitude: -70.7, Altitude: 500_000},
+		{Latitude: 89.99, Longitude: 179.5, Altitude: 0},
+		{Latitude: 0, Longitude: -120, Altitude: 35_786_000},
+	}
+
+	for _, obs := range observers {
+		got := ECEFToGeodetic(obs.ECEF())
+		if math.Abs(got.Latitude-obs.Latitude) > 1e-9 ||
+			math.Abs(got.Longitude-obs.Longitude) > 1e-9 ||
+			math.Abs(got.Altitude*1000-obs.Altitude) > 1e-5 {
+			t.Errorf("ECEFToGeodetic(%+v) = %+v", obs, got)
+		}
+	}
+}
+
+func TestObserver_LookRangeRate(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 6, 0, 0, 0, time.UTC)
+	obs := Observer{Latitude: 47.3, Longitude: 39.8}
+
+	// Объект, неподвижный относительно Земли: скорость в TEME равна ω × r.
+	fixed := Observer{Latitude: 10, Longitude: 40, Altitude: 35_786_000}.ECEF()
+	sin, cos := math.Sincos(GMST(at))
+	r := Vector{X: cos*fixed.X - sin*fixed.Y, Y: sin*fixed.X + cos*fixed.Y, Z: fixed.Z}
+	corotating := Vector{X: -earthRotation * r.Y, Y: earthRotation * r.X}
+
+	look := obs.Look(State{Time: at, Position: r, Velocity: corotating})
+	if math.Abs(look.RangeRate) > 1e-9 {
+		t.Errorf("RangeRate for Earth-fixed object = %v, want 0", look.RangeRate)
+	}
+
+	// Дополнительная радиальная скорость 1 км/с вдоль луча зрения.
+	rho := r.Sub(Vector{
+		X: cos*obs.ECEF().X - sin*obs.ECEF().Y,
+		Y: sin*obs.ECEF().X + cos*obs.ECEF().Y,
+		Z: obs.ECEF().Z,
+	})
+	n := rho.Norm()
+	receding := Vector{X: corotating.X + rho.X/n, Y: corotating.Y + rho.Y/n, Z: corotating.Z + rho.Z/n}
+	look = obs.Look(State{Time: at, Position: r, Velocity: receding})
+	if math.Abs(look.RangeRate-1) > 1e-9 {
+		t.Errorf("RangeRate for receding object = %v, want 1", look.RangeRate)
+	}
+}
+
+// Vallado D.A., Crawford P. et al. "Revisiting Spacetrack Report #3",
+// AIAA 2006-6753: пример перехода TEME -> PEF. Поворот выполняется
+// по времени UT1 (dUT1 = -0.4399619 с).
+func TestTEMEToECEF_Vallado2006(t *testing.T) {
+	utc := time.Date(2004, time.April, 6, 7, 51, 28, 386009000, time.UTC)
+	ut1 := utc.Add(time.Duration(-0.4399619 * float64(time.Second)))
+
+	r := Vector{X: 5094.18016210, Y: 6127.64465950, Z: 6380.34453270}
+	v := Vector{X: -4.746131487, Y: 0.785818041, Z: 5.531931288}
+
+	wantR := Vector{X: -1033.4750312, Y: 7901.3055856, Z: 6380.3445328}
+	wantV := Vector{X: -3.225632747, Y: -2.872442511, Z: 5.531931288}
+
+	if d := TEMEToECEF(r, ut1).Sub(wantR).Norm(); d > 1e-6 {
+		t.Errorf("TEMEToECEF() = %+v, want %+v (diff %.3g km)", TEMEToECEF(r, ut1), wantR, d)
+	}
+	if d := TEMEToECEFVelocity(r, v, ut1).Sub(wantV).Norm(); d > 1e-7 {
+		t.Errorf("TEMEToECEFVelocity() = %+v, want %+v (diff %.3g km/s)", TEMEToECEFVelocity(r, v, ut1), wantV, d)
+	}
+}
+
+// Vallado D.A. "Fundamentals of Astrodynamics and Applications", пример 3-3.
+func TestECEFToGeodetic_Vallado(t *testing.T) {
+	got := ECEFToGeodetic(Vector{X: 6524.834, Y: 6862.875, Z: 6448.296})
+
+	if math.Abs(got.Latitude-34.352496) > 1e-5 {
+		t.Errorf("Latitude = %.6f, want 34.352496", got.Latitude)
+	}
+	if math.Abs(got.Longitude-46.4464) > 1e-4 {
+		t.Errorf("Longitude = %.6f, want 46.4464", got.Longitude)
+	}
+	if math.Abs(got.Altitude-5085.22) > 0.01 {
+		t.Errorf("Altitude = %.3f, want 5085.22", got.Altitude)
+	}
+}
@@ -0,0 +1,295 @@
+// Package pass предсказывает пролёты спутников над наблюдателем:
+// моменты AOS (восход), TCA (максимальное сближение) и LOS (заход).
+package pass
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// Значения по умолчанию для поиска пролётов.
+const (
+	DefaultLookahead = 24 * time.Hour
+	DefaultStep      = 30 * time.Second
+
+	// Точность уточнения AOS/LOS и TCA.
+	edgeTolerance = 100 * time.Millisecond
+	peakTolerance = time.Second
+
+	// maxBacktrack ограничивает поиск AOS пролёта, идущего в начале окна.
+	maxBacktrack = 6 * time.Hour
+)
+
+// ErrInvalidOptions возвращается при некорректных параметрах поиска.
+var ErrInvalidOptions = errors.New("pass: invalid prediction options")
+
+// Options задаёт параметры поиска пролётов. Спутник виден, когда его угол
+// места выше и MinElevation, и маски горизонта на его азимуте.
+type Options struct {
+	MinElevation float64       // маска по углу места, градусы
+	Horizon      horizon.Mask  // маска горизонта станции
+	Lookahead    time.Duration // длина окна поиска
+	Step         time.Duration // шаг грубого перебора
+}
+
+// DefaultOptions возвращает параметры по умолчанию: маска 0°, окно 24 часа.
+func DefaultOptions() Options {
+	return Options{
+		Lookahead: DefaultLookahead,
+		Step:      DefaultStep,
+	}
+}
+
+// Pass описывает один пролёт спутника.
+type Pass struct {
+	AOS          time.Time `json:"aos"`
+	TCA          time.Time `json:"tca"`
+	LOS          time.Time `json:"los"`
+	MaxElevation float64   `json:"max_elevation"` // градусы
+	AOSAzimuth   float64   `json:"aos_azimuth"`   // градусы
+	LOSAzimuth   float64   `json:"los_azimuth"`   // градусы
+}
+
+// Duration возвращает длительность пролёта.
+func (p Pass) Duration() time.Duration {
+	return p.LOS.Sub(p.AOS)
+}
+
+// Predictor ищет пролёты для заданного наблюдателя.
+type Predictor struct {
+	observer orbit.Observer
+	opts     Options
+}
+
+// NewPredictor создаёт предсказатель пролётов. Нулевые Lookahead и Step
+// заменяются значениями по умолчанию.
+func NewPredictor(observer orbit.Observer, opts Options) (*Predictor, error) {
+	if opts.Lookahead == 0 {
+		opts.Lookahead = DefaultLookahead
+	}
+	if opts.Step == 0 {
+		opts.Step = DefaultStep
+	}
+	if opts.Lookahead < 0 || opts.Step < 0 || opts.MinElevation < -90 || opts.MinElevation >= 90 {
+		return nil, ErrInvalidOptions
+	}
+	if err := opts.Horizon.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOptions, err)
+	}
+
+	return &Predictor{
+		observer: observer,
+		opts:     opts,
+	}, nil
+}
+
+// Options возвращает параметры поиска.
+func (p *Predictor) Options() Options {
+	return p.opts
+}
+
+// Observer возвращает наблюдателя.
+func (p *Predictor) Observer() orbit.Observer {
+	return p.observer
+}
+
+// Horizon возвращает наименьший угол места, на котором спутник виден
+// на азимуте az, с учётом маски горизонта.
+func (p *Predictor) Horizon(az float64) float64 {
+	return math.Max(p.opts.MinElevation, p.opts.Horizon.Elevation(az))
+}
+
+// Look вычисляет направление на спутник в момент t.
+func (p *Predictor) Look(prop orbit.Propagator, t time.Time) (orbit.Look, error) {
+	st, err := prop.Propagate(t)
+	if err != nil {
+		return orbit.Look{}, err
+	}
+	return p.observer.Look(st), nil
+}
+
+// search хранит состояние одного поиска и кэширует ошибку распространения.
+type search struct {
+	p    *Predictor
+	prop orbit.Propagator
+	err  error
+}
+
+// f возвращает превышение угла места над маской.
+func (s *search) f(t time.Time) float64 {
+	look, ok := s.look(t)
+	if !ok {
+		return math.Inf(-1)
+	}
+	return look.Elevation - s.p.Horizon(look.Azimuth)
+}
+
+// elevation возвращает угол места спутника.
+func (s *search) elevation(t time.Time) float64 {
+	look, ok := s.look(t)
+	if !ok {
+		return math.Inf(-1)
+	}
+	return look.Elevation
+}
+
+func (s *search) look(t time.Time) (orbit.Look, bool) {
+	if s.err != nil {
+		return orbit.Look{}, false
+	}
+	look, err := s.p.Look(s.prop, t)
+	if err != nil {
+		s.err = err
+		return orbit.Look{}, false
+	}
+	return look, true
+}
+
+// Passes возвращает пролёты, начинающиеся или идущие в окне
+// [from, from+Lookahead]. Пролёт, идущий в момент from, включается
+// с реальным AOS (если он найден не далее maxBacktrack назад).
+// Пролёт, не закончившийся к концу окна, обрезается по его границе.
+func (p *Predictor) Passes(prop orbit.Propagator, from time.Time) ([]Pass, error) {
+	s := &search{p: p, prop: prop}
+	end := from.Add(p.opts.Lookahead)
+
+	var (
+		passes []Pass
+		aos    time.Time
+	)
+
+	prev := from
+	fPrev := s.f(prev)
+	inPass := fPrev > 0
+	if inPass {
+		aos = s.backtrackAOS(from)
+	}
+	// Два предыдущих отсчёта нужны для поиска коротких пролётов между шагами.
+	prevPrev, fPrevPrev := prev, fPrev
+
+	for t := from.Add(p.opts.Step); ; t = t.Add(p.opts.Step) {
+		if t.After(end) {
+			t = end
+		}
+		ft := s.f(t)
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		switch {
+		case !inPass && ft > 0:
+			aos = s.bisect(prev, t)
+			inPass = true
+		case inPass && ft <= 0:
+			passes = append(passes, s.makePass(aos, s.bisect(prev, t)))
+			inPass = false
+		case !inPass && fPrev > fPrevPrev && fPrev > ft:
+			// Локальный максимум ниже маски на сетке: пролёт мог уместиться между отсчётами.
+			tMax := s.peak(prevPrev, t, s.f)
+			if s.f(tMax) > 0 {
+				passes = append(passes, s.makePass(s.bisect(prevPrev, tMax), s.bisect(t, tMax)))
+			}
+		}
+
+		prevPrev, fPrevPrev = prev, fPrev
+		prev, fPrev = t, ft
+		if !t.Before(end) {
+			break
+		}
+	}
+
+	if inPass {
+		passes = append(passes, s.makePass(aos, end))
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return passes, nil
+}
+
+// NextPass возвращает ближайший пролёт, начинающийся после from
+// (или идущий в момент from).
+func (p *Predictor) NextPass(prop orbit.Propagator, from time.Time) (Pass, bool, error) {
+	passes, err := p.Passes(prop, from)
+	if err != nil || len(passes) == 0 {
+		return Pass{}, false, err
+	}
+	return passes[0], true, nil
+}
+
+// backtrackAOS ищет начало пролёта, идущего в момент t.
+func (s *search) backtrackAOS(t time.Time) time.Time {
+	step := s.p.opts.Step
+	limit := t.Add(-maxBacktrack)
+	for cur := t; cur.After(limit); cur = cur.Add(-step) {
+		before := cur.Add(-step)
+		if s.f(before) <= 0 {
+			return s.bisect(before, cur)
+		}
+	}
+	return t
+}
+
+// bisect находит момент пересечения маски между a и b
+// (значения f в a и b имеют разные знаки).
+func (s *search) bisect(a, b time.Time) time.Time {
+	fa := s.f(a)
+	for b.Sub(a).Abs() > edgeTolerance {
+		mid := a.Add(b.Sub(a) / 2)
+		if (s.f(mid) > 0) == (fa > 0) {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	return a.Add(b.Sub(a) / 2)
+}
+
+// peak находит момент максимума f на [a, b] методом золотого сечения.
+func (s *search) peak(a, b time.Time, f func(time.Time) float64) time.Time {
+	invPhi := (math.Sqrt(5) - 1) / 2
+	span := b.Sub(a)
+	c := b.Add(-time.Duration(float64(span) * invPhi))
+	d := a.Add(time.Duration(float64(span) * invPhi))
+	fc, fd := f(c), f(d)
+
+	for b.Sub(a) > peakTolerance {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b.Add(-time.Duration(float64(b.Sub(a)) * invPhi))
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a.Add(time.Duration(float64(b.Sub(a)) * invPhi))
+			fd = f(d)
+		}
+	}
+	return a.Add(b.Sub(a) / 2)
+}
+
+// makePass уточняет TCA и заполняет параметры пролёта. TCA — момент
+// наибольшего угла места, а не превышения над маской горизонта.
+func (s *search) makePass(aos, los time.Time) Pass {
+	tca := s.peak(aos, los, s.elevation)
+	pass := Pass{
+		AOS: aos.Round(time.Second),
+		TCA: tca.Round(time.Second),
+		LOS: los.Round(time.Second),
+	}
+
+	if look, err := s.p.Look(s.prop, tca); err == nil {
+		pass.MaxElevation = look.Elevation
+	}
+	if look, err := s.p.Look(s.prop, aos); err == nil {
+		pass.AOSAzimuth = look.Azimuth
+	}
+	if look, err := s.p.Look(s.prop, los); err == nil {
+		pass.LOSAzimuth = look.Azimuth
+	}
+	return pass
+}
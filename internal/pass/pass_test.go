@@ -0,0 +1,239 @@
+package pass
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+const (
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+var rostov = orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+
+func issPropagator(t *testing.T) (*orbit.SGP4, time.Time) {
+	t.Helper()
+	set, err := tle.ParseTLE("ISS", issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prop, err := orbit.New(set.Elements())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prop, set.Epoch
+}
+
+func elevation(t *testing.T, p *Predictor, prop orbit.Propagator, at time.Time) float64 {
+	t.Helper()
+	look, err := p.Look(prop, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return look.Elevation
+}
+
+func TestPredictor_Passes(t *testing.T) {
+	prop, epoch := issPropagator(t)
+	p, err := NewPredictor(rostov, Options{MinElevation: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passes, err := p.Passes(prop, epoch)
+	if err != nil {
+		t.Fatalf("Passes() error: %v", err)
+	}
+	if len(passes) < 3 {
+		t.Fatalf("got %d passes in 24h, want at least 3", len(passes))
+	}
+
+	for i, ps := range passes {
+		if !ps.AOS.Before(ps.TCA) || !ps.TCA.Before(ps.LOS) {
+			t.Errorf("pass %d: order AOS %v, TCA %v, LOS %v", i, ps.AOS, ps.TCA, ps.LOS)
+		}
+		if ps.Duration() > 15*time.Minute {
+			t.Errorf("pass %d: duration %v too long for LEO", i, ps.Duration())
+		}
+		if i > 0 && !passes[i-1].LOS.Before(ps.AOS) {
+			t.Errorf("pass %d overlaps previous", i)
+		}
+
+		// На границах пролёта угол места совпадает с маской.
+		for _, edge := range []time.Time{ps.AOS, ps.LOS} {
+			if el := elevation(t, p, prop, edge); math.Abs(el-5) > 0.1 {
+				t.Errorf("pass %d: elevation at edge %v = %.3f, want 5", i, edge, el)
+			}
+		}
+
+		// TCA — локальный максимум.
+		if ps.MaxElevation < 5 {
+			t.Errorf("pass %d: MaxElevation = %.2f below mask", i, ps.MaxElevation)
+		}
+		for _, d := range []time.Duration{-10 * time.Second, 10 * time.Second} {
+			if el := elevation(t, p, prop, ps.TCA.Add(d)); el > ps.MaxElevation {
+				t.Errorf("pass %d: elevation at TCA%+v = %.3f exceeds max %.3f", i, d, el, ps.MaxElevation)
+			}
+		}
+	}
+}
+
+func TestPredictor_MatchesBruteForce(t *testing.T) {
+	prop, epoch := issPropagator(t)
+	p, err := NewPredictor(rostov, Options{Lookahead: 12 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passes, err := p.Passes(prop, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Перебор с шагом 5 секунд считает восходы над горизонтом.
+	want := 0
+	above := elevation(t, p, prop, epoch) > 0
+	if above {
+		want++
+	}
+	for at := epoch; at.Before(epoch.Add(12 * time.Hour)); at = at.Add(5 * time.Second) {
+		now := elevation(t, p, prop, at) > 0
+		if now && !above {
+			want++
+		}
+		above = now
+	}
+
+	if len(passes) != want {
+		t.Errorf("got %d passes, brute force found %d", len(passes), want)
+	}
+}
+
+func TestPredictor_PassInProgress(t *testing.T) {
+	prop, epoch := issPropagator(t)
+	p, err := NewPredictor(rostov, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passes, err := p.Passes(prop, epoch)
+	if err != nil || len(passes) == 0 {
+		t.Fatalf("Passes() = %v, %v", passes, err)
+	}
+	first := passes[0]
+
+	// Начинаем поиск в середине пролёта: AOS должен остаться прежним.
+	mid := first.TCA
+	again, err := p.Passes(prop, mid)
+	if err != nil || len(again) == 0 {
+		t.Fatalf("Passes(mid) = %v, %v", again, err)
+	}
+	if d := again[0].AOS.Sub(first.AOS).Abs(); d > time.Second {
+		t.Errorf("AOS = %v, want %v", again[0].AOS, first.AOS)
+	}
+
+	// Окно, заканчивающееся в середине пролёта, обрезает LOS.
+	clipped, err := NewPredictor(rostov, Options{Lookahead: first.TCA.Sub(epoch)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut, err := clipped.Passes(prop, epoch)
+	if err != nil || len(cut) == 0 {
+		t.Fatalf("clipped Passes() = %v, %v", cut, err)
+	}
+	if last := cut[len(cut)-1]; !last.LOS.Equal(first.TCA) {
+		t.Errorf("clipped LOS = %v, want %v", last.LOS, first.TCA)
+	}
+}
+
+func TestPredictor_Horizon(t *testing.T) {
+	prop, epoch := issPropagator(t)
+	open, err := NewPredictor(rostov, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Дома закрывают север до 25°, юг открыт.
+	mask := horizon.Mask{{Azimuth: 0, Elevation: 25}, {Azimuth: 90, Elevation: 0}, {Azimuth: 270, Elevation: 0}}
+	masked, err := NewPredictor(rostov, Options{MinElevation: 2, Horizon: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := masked.Horizon(0); got != 25 {
+		t.Errorf("Horizon(0) = %v, want 25", got)
+	}
+	if got := masked.Horizon(180); got != 2 {
+		t.Errorf("Horizon(180) = %v, want MinElevation 2", got)
+	}
+
+	all, err := open.Passes(prop, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passes, err := masked.Passes(prop, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passes) == 0 || len(passes) > len(all) {
+		t.Fatalf("got %d passes with mask, %d without", len(passes), len(all))
+	}
+
+	shortened := 0
+	for i, ps := range passes {
+		// На границах угол места совпадает с маской на азимуте спутника.
+		for _, edge := range []time.Time{ps.AOS, ps.LOS} {
+			look, err := masked.Look(prop, edge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := masked.Horizon(look.Azimuth); math.Abs(look.Elevation-want) > 0.2 {
+				t.Errorf("pass %d: elevation at edge %v = %.2f at az %.1f, want %.2f", i, edge, look.Elevation, look.Azimuth, want)
+			}
+		}
+
+		// Пролёт с маской — часть пролёта над открытым горизонтом с тем же TCA.
+		j := slices.IndexFunc(all, func(p Pass) bool { return !p.LOS.Before(ps.AOS) && !p.AOS.After(ps.LOS) })
+		if j < 0 {
+			t.Fatalf("pass %d has no counterpart without mask", i)
+		}
+		if ps.AOS.Before(all[j].AOS) || ps.LOS.After(all[j].LOS) {
+			t.Errorf("pass %d %v..%v exceeds open horizon pass %v..%v", i, ps.AOS, ps.LOS, all[j].AOS, all[j].LOS)
+		}
+		if ps.AOS.Sub(all[j].AOS) > time.Minute {
+			shortened++
+		}
+		// TCA — максимум угла места, а не превышения над маской.
+		if !all[j].TCA.Before(ps.AOS) && !all[j].TCA.After(ps.LOS) && all[j].TCA.Sub(ps.TCA).Abs() > 2*time.Second {
+			t.Errorf("pass %d: TCA = %v, want %v", i, ps.TCA, all[j].TCA)
+		}
+	}
+	if shortened == 0 {
+		t.Error("mask did not delay any AOS")
+	}
+}
+
+func TestNewPredictor_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"negative lookahead", Options{Lookahead: -time.Hour}},
+		{"negative step", Options{Step: -time.Second}},
+		{"mask at zenith", Options{MinElevation: 90}},
+		{"invalid horizon", Options{Horizon: horizon.Mask{{Azimuth: 10, Elevation: -5}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPredictor(rostov, tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
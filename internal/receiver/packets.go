@@ -0,0 +1,75 @@
+package receiver
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/ax25"
+	"github.com/art-injener/satwatch-go/internal/demod"
+)
+
+// Packet — принятый кадр AX.25.
+type Packet struct {
+	Time      time.Time  `json:"time"`
+	Frequency float64    `json:"frequency"` // центральная частота приёмника, Гц
+	Mode      demod.Mode `json:"mode"`
+	Frame     ax25.Frame `json:"frame"`
+	// Raw — кадр без FCS в том виде, в котором он передаётся по KISS.
+	Raw []byte `json:"-"`
+}
+
+// PacketDecoder — обработчик блоков, демодулирующий сигнал и выделяющий
+// кадры AX.25. Демодулятор пересоздаётся при смене частоты дискретизации.
+type PacketDecoder struct {
+	mode   demod.Mode
+	handle func(Packet)
+
+	rate     float64
+	demod    demod.Demodulator
+	deframer ax25.Deframer
+	bits     []byte
+	frames   [][]byte
+}
+
+// NewPacketDecoder создаёт декодер режима mode; handle вызывается для
+// каждого кадра из горутины приёмника и не должен блокироваться.
+func NewPacketDecoder(mode demod.Mode, handle func(Packet)) (*PacketDecoder, error) {
+	if !mode.Digital() {
+		return nil, fmt.Errorf("%w: %q", demod.ErrNotDigital, mode)
+	}
+	return &PacketDecoder{mode: mode, handle: handle}, nil
+}
+
+// Process демодулирует блок и передаёт кадры обработчику.
+func (p *PacketDecoder) Process(b Block) {
+	if b.SampleRate != p.rate {
+		p.rate = b.SampleRate
+		d, err := demod.New(p.mode, b.SampleRate)
+		if err != nil {
+			slog.Warn("packet demodulator is unavailable", "mode", p.mode, "error", err)
+		}
+		p.demod, p.deframer = d, ax25.Deframer{}
+	}
+	if p.demod == nil {
+		return
+	}
+
+	p.bits = p.demod.Demodulate(p.bits[:0], b.Samples)
+	p.frames = p.deframer.Process(p.frames[:0], p.bits)
+	for _, raw := range p.frames {
+		frame, err := ax25.Parse(raw)
+		if err != nil {
+			slog.Debug("AX.25 frame rejected", "error", err)
+			continue
+		}
+		slog.Info("AX.25 frame received", "frame", frame.String())
+		p.handle(Packet{
+			Time:      b.Time,
+			Frequency: b.Frequency,
+			Mode:      p.mode,
+			Frame:     frame,
+			Raw:       raw,
+		})
+	}
+}
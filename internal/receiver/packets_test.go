@@ -0,0 +1,90 @@
+package receiver
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/ax25"
+	"github.com/art-injener/satwatch-go/internal/demod"
+)
+
+// afskSignal формирует ЧМ-сигнал AFSK 1200 с кадром HDLC: преамбула
+// из флагов, кадр с FCS и вставкой нулей, NRZI, девиация 3 кГц.
+func afskSignal(frame []byte, rate float64) []complex64 {
+	var bits []byte
+	flags := func(n int) {
+		for range n {
+			for i := range 8 {
+				bits = append(bits, 0x7E>>i&1)
+			}
+		}
+	}
+	flags(20)
+	fcs := ax25.FCS(frame)
+	ones := 0
+	for _, b := range append(append([]byte(nil), frame...), byte(fcs), byte(fcs>>8)) {
+		for i := range 8 {
+			bit := b >> i & 1
+			bits = append(bits, bit)
+			if ones = (ones + int(bit)) * int(bit); ones == 5 {
+				bits = append(bits, 0)
+				ones = 0
+			}
+		}
+	}
+	flags(4)
+
+	// NRZI: на нуле уровень меняется, на единице сохраняется.
+	levels := make([]byte, len(bits))
+	var level byte
+	for i, bit := range bits {
+		level ^= 1 - bit
+		levels[i] = level
+	}
+
+	var phase, audioPhase float64
+	iq := make([]complex64, int(float64(len(bits))*rate/1200))
+	for i := range iq {
+		tone := 2200.0
+		if levels[int(float64(i)*1200/rate)] == 1 {
+			tone = 1200
+		}
+		audioPhase += 2 * math.Pi * tone / rate
+		phase += 2 * math.Pi * 3000 * math.Sin(audioPhase) / rate
+		s, c := math.Sincos(phase)
+		iq[i] = complex(float32(c), float32(s))
+	}
+	return iq
+}
+
+func TestPacketDecoder(t *testing.T) {
+	if _, err := NewPacketDecoder(demod.ModeFM, nil); !errors.Is(err, demod.ErrNotDigital) {
+		t.Errorf("NewPacketDecoder(fm) error = %v, want ErrNotDigital", err)
+	}
+
+	src, _ := ax25.ParseAddress("RS40S")
+	want := ax25.NewUI(ax25.Address{Call: "CQ"}, src, []byte("telemetry")).Encode()
+
+	var got []Packet
+	d, err := NewPacketDecoder(demod.ModeAFSK, func(p Packet) { got = append(got, p) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	const rate = 48000
+	samples := afskSignal(want, rate)
+	for len(samples) > 0 {
+		n := min(len(samples), 4096)
+		d.Process(Block{Time: time.Now(), Frequency: 145.8e6, SampleRate: rate, Samples: samples[:n]})
+		samples = samples[n:]
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d packets, want 1", len(got))
+	}
+	if p := got[0]; !bytes.Equal(p.Raw, want) || p.Frame.Source != src || p.Mode != demod.ModeAFSK || p.Frequency != 145.8e6 {
+		t.Errorf("packet = %+v", p)
+	}
+}
@@ -0,0 +1,205 @@
+// Package receiver читает отсчёты из источника SDR и передаёт их блоками
+// обработчикам: водопаду, демодуляторам.
+package receiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/sdr"
+)
+
+// Значения по умолчанию.
+const (
+	// DefaultBlockSize — число отсчётов в блоке, передаваемом обработчикам.
+	DefaultBlockSize = 16384
+	// DefaultRetryInterval — пауза перед повторным открытием источника.
+	DefaultRetryInterval = 5 * time.Second
+)
+
+// Ошибки управления приёмником.
+var (
+	ErrNotConnected = errors.New("receiver: SDR is not connected")
+	ErrNotTunable   = errors.New("receiver: SDR source cannot be tuned")
+	ErrInvalidValue = errors.New("receiver: invalid value")
+)
+
+// Opener открывает источник отсчётов; вызывается при запуске и после
+// каждой ошибки чтения.
+type Opener func(ctx context.Context) (sdr.IQSource, error)
+
+// Block — блок отсчётов источника. Samples действителен только на время
+// вызова Process: обработчик не должен сохранять срез.
+type Block struct {
+	Time       time.Time // момент чтения блока
+	Frequency  float64   // центральная частота, Гц
+	SampleRate float64   // отсчётов/с
+	Samples    []complex64
+}
+
+// Sink обрабатывает блоки отсчётов. Process вызывается последовательно
+// из горутины Run и не должен блокироваться надолго.
+type Sink interface {
+	Process(b Block)
+}
+
+// Config — параметры приёмника.
+type Config struct {
+	BlockSize     int
+	RetryInterval time.Duration
+}
+
+// DefaultConfig возвращает параметры по умолчанию.
+func DefaultConfig() Config {
+	return Config{BlockSize: DefaultBlockSize, RetryInterval: DefaultRetryInterval}
+}
+
+// Status — состояние приёмника.
+type Status struct {
+	Connected  bool      `json:"connected"`
+	Tunable    bool      `json:"tunable"`
+	Frequency  float64   `json:"frequency,omitempty"`   // Гц
+	SampleRate float64   `json:"sample_rate,omitempty"` // отсчётов/с
+	Error      string    `json:"error,omitempty"`
+	Updated    time.Time `json:"updated"`
+}
+
+// Receiver читает отсчёты из источника и передаёт их обработчикам.
+// Потеря источника отражается в Status, источник открывается заново
+// через RetryInterval.
+type Receiver struct {
+	open  Opener
+	cfg   Config
+	sinks []Sink
+
+	mu     sync.Mutex
+	src    sdr.IQSource
+	status Status
+}
+
+// NewReceiver создаёт приёмник, передающий блоки обработчикам sinks.
+func NewReceiver(open Opener, cfg Config, sinks ...Sink) *Receiver {
+	if cfg.BlockSize <= 0 {
+		cfg.BlockSize = DefaultBlockSize
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	return &Receiver{open: open, cfg: cfg, sinks: sinks}
+}
+
+// Status возвращает состояние приёмника.
+func (r *Receiver) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Tune перестраивает источник на частоту hz.
+func (r *Receiver) Tune(hz float64) error {
+	if hz <= 0 {
+		return fmt.Errorf("%w: frequency %.0f Hz", ErrInvalidValue, hz)
+	}
+	return r.withTuner(func(t sdr.Tuner) error { return t.SetFrequency(hz) })
+}
+
+// SetGain задаёт усиление, дБ; отрицательное — автоматическое.
+func (r *Receiver) SetGain(db float64) error {
+	return r.withTuner(func(t sdr.Tuner) error { return t.SetGain(db) })
+}
+
+// withTuner выполняет f для текущего источника, если он перестраивается.
+func (r *Receiver) withTuner(f func(sdr.Tuner) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.src == nil {
+		return ErrNotConnected
+	}
+	t, ok := r.src.(sdr.Tuner)
+	if !ok {
+		return ErrNotTunable
+	}
+	if err := f(t); err != nil {
+		return err
+	}
+	r.status.Frequency = t.CenterFrequency()
+	return nil
+}
+
+// Run читает источник до отмены ctx.
+func (r *Receiver) Run(ctx context.Context) {
+	for {
+		if err := r.session(ctx); err != nil && ctx.Err() == nil {
+			r.fail(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.RetryInterval):
+		}
+	}
+}
+
+// session открывает источник и читает блоки до ошибки или отмены ctx.
+func (r *Receiver) session(ctx context.Context) error {
+	src, err := r.open(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Закрытие источника прерывает ожидающий ReadIQ.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		src.Close()
+	}()
+
+	_, tunable := src.(sdr.Tuner)
+	status := Status{
+		Connected:  true,
+		Tunable:    tunable,
+		Frequency:  src.CenterFrequency(),
+		SampleRate: src.SampleRate(),
+		Updated:    time.Now(),
+	}
+	r.mu.Lock()
+	r.src, r.status = src, status
+	r.mu.Unlock()
+	slog.Info("SDR source connected", "frequency", status.Frequency, "sample_rate", status.SampleRate)
+
+	buf := make([]complex64, r.cfg.BlockSize)
+	for {
+		n, err := src.ReadIQ(buf)
+		if n > 0 {
+			block := Block{Time: time.Now(), Samples: buf[:n], SampleRate: src.SampleRate(), Frequency: src.CenterFrequency()}
+			for _, s := range r.sinks {
+				s.Process(block)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fail отражает потерю источника.
+func (r *Receiver) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status.Connected || r.status.Error != err.Error() {
+		slog.Warn("SDR source is unavailable", "error", err)
+	}
+	r.src = nil
+	r.status = Status{Error: err.Error(), Updated: time.Now()}
+}
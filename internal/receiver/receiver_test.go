@@ -0,0 +1,190 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/sdr"
+)
+
+// fakeSource выдаёт samples и затем io.EOF.
+type fakeSource struct {
+	mu      sync.Mutex
+	samples []complex64
+	freq    float64
+	gain    float64
+	closed  bool
+}
+
+func (f *fakeSource) ReadIQ(buf []complex64) (int, error) {
+	if len(f.samples) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(buf, f.samples)
+	f.samples = f.samples[n:]
+	return n, nil
+}
+
+func (f *fakeSource) SampleRate() float64 { return 48000 }
+
+func (f *fakeSource) CenterFrequency() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.freq
+}
+
+func (f *fakeSource) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeSource) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+// fakeTuner — перестраиваемый источник, блокирующийся до закрытия.
+type fakeTuner struct {
+	fakeSource
+	done chan struct{}
+}
+
+func (f *fakeTuner) ReadIQ([]complex64) (int, error) {
+	<-f.done
+	return 0, io.EOF
+}
+
+func (f *fakeTuner) Close() error {
+	close(f.done)
+	return nil
+}
+
+func (f *fakeTuner) SetFrequency(hz float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.freq = hz
+	return nil
+}
+
+func (f *fakeTuner) SetSampleRate(float64) error { return nil }
+
+func (f *fakeTuner) SetGain(db float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gain = db
+	return nil
+}
+
+// recordSink запоминает длины полученных блоков.
+type recordSink struct {
+	mu     sync.Mutex
+	blocks []int
+}
+
+func (s *recordSink) Process(b Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks = append(s.blocks, len(b.Samples))
+}
+
+func (s *recordSink) lengths() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.blocks...)
+}
+
+// waitFor ожидает выполнения условия.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestReceiver_Blocks(t *testing.T) {
+	src := &fakeSource{samples: make([]complex64, 10), freq: 145.8e6}
+	sink := &recordSink{}
+	opened := 0
+	r := NewReceiver(func(context.Context) (sdr.IQSource, error) {
+		opened++
+		if opened > 1 {
+			return nil, errors.New("recording is over")
+		}
+		return src, nil
+	}, Config{BlockSize: 4, RetryInterval: time.Millisecond}, sink)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+
+	waitFor(t, "source failure", func() bool { return r.Status().Error == "recording is over" })
+	cancel()
+
+	if got := sink.lengths(); len(got) != 3 || got[0] != 4 || got[2] != 2 {
+		t.Errorf("block lengths = %v, want [4 4 2]", got)
+	}
+	waitFor(t, "source close after EOF", src.isClosed)
+	if st := r.Status(); st.Connected {
+		t.Errorf("status = %+v, want disconnected", st)
+	}
+}
+
+func TestReceiver_Tune(t *testing.T) {
+	tuner := &fakeTuner{fakeSource: fakeSource{freq: 145.8e6}, done: make(chan struct{})}
+	r := NewReceiver(func(context.Context) (sdr.IQSource, error) { return tuner, nil }, DefaultConfig())
+
+	if err := r.Tune(437e6); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Tune() before connect = %v, want ErrNotConnected", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+	waitFor(t, "connection", func() bool { return r.Status().Connected })
+
+	if err := r.Tune(437.8e6); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetGain(20); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Tune(-1); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Tune(-1) = %v, want ErrInvalidValue", err)
+	}
+	tuner.mu.Lock()
+	gain := tuner.gain
+	tuner.mu.Unlock()
+	if st := r.Status(); !st.Tunable || st.Frequency != 437.8e6 || gain != 20 {
+		t.Errorf("status = %+v, gain = %v", st, gain)
+	}
+
+	cancel()
+	waitFor(t, "source close", func() bool {
+		select {
+		case <-tuner.done:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func TestReceiver_NotTunable(t *testing.T) {
+	src := &fakeSource{}
+	r := NewReceiver(func(context.Context) (sdr.IQSource, error) { return src, nil }, DefaultConfig())
+	r.src = src
+	if err := r.Tune(145.8e6); !errors.Is(err, ErrNotTunable) {
+		t.Errorf("Tune() = %v, want ErrNotTunable", err)
+	}
+}
@@ -0,0 +1,98 @@
+package receiver
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/art-injener/satwatch-go/internal/sdr"
+)
+
+// recordingFormat — формат отсчётов записей: родной формат rtl_tcp,
+// вчетверо компактнее cf32.
+const recordingFormat = sdr.FormatCU8
+
+// Ошибки записи.
+var (
+	ErrRecording    = errors.New("receiver: recording is already in progress")
+	ErrNotRecording = errors.New("receiver: recording is not in progress")
+)
+
+// Recorder — обработчик блоков, записывающий отсчёты в SigMF между
+// вызовами Start и Stop. Файлы создаются при первом блоке после Start,
+// когда известны частота дискретизации и центральная частота.
+type Recorder struct {
+	dir string
+
+	mu     sync.Mutex
+	base   string // путь записи без расширения; "" — запись не идёт
+	writer *sdr.SigMFWriter
+	failed bool // ошибка записи: блоки до Stop пропускаются
+}
+
+// NewRecorder создаёт обработчик, сохраняющий записи в каталог dir.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{dir: dir}
+}
+
+// Start начинает запись name; файлы name.sigmf-meta и name.sigmf-data
+// создаются в каталоге записей.
+func (r *Recorder) Start(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.base != "" {
+		return ErrRecording
+	}
+	if err := os.MkdirAll(r.dir, 0o750); err != nil {
+		return fmt.Errorf("create recordings directory: %w", err)
+	}
+	r.base, r.failed = filepath.Join(r.dir, name), false
+	return nil
+}
+
+// Stop завершает запись и возвращает путь файла метаданных; пустой
+// путь — за время записи не пришло ни одного блока.
+func (r *Recorder) Stop() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.base == "" {
+		return "", ErrNotRecording
+	}
+	base, w := r.base, r.writer
+	r.base, r.writer = "", nil
+	if w == nil {
+		return "", nil
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base + ".sigmf-meta", nil
+}
+
+// Process дописывает блок в текущую запись.
+func (r *Recorder) Process(b Block) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.base == "" || r.failed {
+		return
+	}
+	if r.writer == nil {
+		w, err := sdr.CreateSigMF(r.base, recordingFormat, b.SampleRate, b.Frequency, b.Time)
+		if err != nil {
+			slog.Error("failed to create recording", "path", r.base, "error", err)
+			r.failed = true
+			return
+		}
+		r.writer = w
+	}
+	if err := r.writer.WriteIQ(b.Samples); err != nil {
+		slog.Error("failed to write recording", "path", r.base, "error", err)
+		r.failed = true
+	}
+}
@@ -0,0 +1,57 @@
+package receiver
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/sdr"
+)
+
+func TestRecorder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	rec := NewRecorder(dir)
+	block := Block{Time: time.Now(), Frequency: 145.8e6, SampleRate: 48000, Samples: []complex64{1, -1i, 0.5}}
+
+	// Блоки вне записи пропускаются.
+	rec.Process(block)
+	if _, err := rec.Stop(); !errors.Is(err, ErrNotRecording) {
+		t.Errorf("Stop() before Start error = %v, want ErrNotRecording", err)
+	}
+
+	if err := rec.Start("pass-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Start("pass-2"); !errors.Is(err, ErrRecording) {
+		t.Errorf("second Start() error = %v, want ErrRecording", err)
+	}
+	rec.Process(block)
+	rec.Process(block)
+	path, err := rec.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "pass-1.sigmf-meta") {
+		t.Errorf("path = %q", path)
+	}
+
+	src, err := sdr.OpenFile(path, sdr.FileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	buf := make([]complex64, 16)
+	n, err := src.ReadIQ(buf)
+	if err != nil || n != 6 || src.SampleRate() != 48000 || src.CenterFrequency() != 145.8e6 {
+		t.Errorf("recording: %d samples, %v, rate %v, frequency %v", n, err, src.SampleRate(), src.CenterFrequency())
+	}
+
+	// Запись без блоков не создаёт файлов.
+	if err := rec.Start("empty"); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := rec.Stop(); err != nil || path != "" {
+		t.Errorf("empty Stop() = %q, %v", path, err)
+	}
+}
@@ -0,0 +1,192 @@
+package receiver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/dsp"
+)
+
+// ErrInvalidWaterfall возвращается при недопустимых параметрах водопада.
+var ErrInvalidWaterfall = errors.New("receiver: invalid waterfall config")
+
+// WaterfallConfig — параметры водопада.
+type WaterfallConfig struct {
+	FFTSize int
+	// Averages — минимальное число усредняемых кадров БПФ; при высокой
+	// частоте дискретизации усреднение увеличивается, чтобы строки
+	// появлялись не чаще FrameRate.
+	Averages  int
+	FrameRate float64 // строк/с
+	// Floor и Ceiling — уровни (дБFS), соответствующие 0 и 255.
+	Floor   float32
+	Ceiling float32
+}
+
+// DefaultWaterfallConfig возвращает БПФ на 1024 точки, 10 строк/с
+// и шкалу от −120 до −20 дБFS.
+func DefaultWaterfallConfig() WaterfallConfig {
+	return WaterfallConfig{
+		FFTSize:   1024,
+		Averages:  1,
+		FrameRate: 10,
+		Floor:     -120,
+		Ceiling:   -20,
+	}
+}
+
+// Validate проверяет параметры.
+func (c WaterfallConfig) Validate() error {
+	switch {
+	case c.FFTSize < 64 || c.FFTSize > 65536 || c.FFTSize&(c.FFTSize-1) != 0:
+		return fmt.Errorf("%w: FFT size must be a power of two within 64..65536", ErrInvalidWaterfall)
+	case c.Averages < 1:
+		return fmt.Errorf("%w: averages must be positive", ErrInvalidWaterfall)
+	case c.FrameRate <= 0:
+		return fmt.Errorf("%w: frame rate must be positive", ErrInvalidWaterfall)
+	case c.Ceiling <= c.Floor:
+		return fmt.Errorf("%w: ceiling must be above floor", ErrInvalidWaterfall)
+	}
+	return nil
+}
+
+// Row — строка водопада: уровни в полосе [Frequency − Span/2,
+// Frequency + Span/2], квантованные в 0–255 между Floor и Ceiling.
+// Bins кодируется в JSON как base64.
+type Row struct {
+	Time      time.Time `json:"time"`
+	Frequency float64   `json:"frequency"` // центральная частота, Гц
+	Span      float64   `json:"span"`      // полоса, Гц
+	Floor     float32   `json:"floor"`
+	Ceiling   float32   `json:"ceiling"`
+	Bins      []uint8   `json:"bins"`
+}
+
+// subscription — получатель строк со своей шириной и частотой кадров.
+type subscription struct {
+	ch       chan Row
+	width    int
+	interval time.Duration
+	last     time.Time
+}
+
+// Waterfall — обработчик блоков, вычисляющий усреднённый спектр
+// и рассылающий строки подписчикам.
+type Waterfall struct {
+	cfg      WaterfallConfig
+	spectrum *dsp.Spectrum
+	frame    []complex64
+	fill     int
+	db       []float32
+	rate     float64 // частота дискретизации, под которую подобрано усреднение
+
+	mu   sync.Mutex
+	subs map[*subscription]struct{}
+}
+
+// NewWaterfall создаёт водопад.
+func NewWaterfall(cfg WaterfallConfig) (*Waterfall, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	spectrum, err := dsp.NewSpectrum(cfg.FFTSize, cfg.Averages)
+	if err != nil {
+		return nil, err
+	}
+	return &Waterfall{
+		cfg:      cfg,
+		spectrum: spectrum,
+		frame:    make([]complex64, cfg.FFTSize),
+		db:       make([]float32, cfg.FFTSize),
+		subs:     make(map[*subscription]struct{}),
+	}, nil
+}
+
+// Config возвращает параметры водопада.
+func (w *Waterfall) Config() WaterfallConfig {
+	return w.cfg
+}
+
+// Subscribe регистрирует получателя строк шириной width точек (0 —
+// полный размер БПФ) с частотой не выше fps (0 — FrameRate). Канал
+// хранит одну строку: если получатель не успевает, устаревшая строка
+// заменяется свежей. Возвращаемая функция отменяет подписку.
+func (w *Waterfall) Subscribe(width int, fps float64) (<-chan Row, func()) {
+	if width <= 0 || width > w.cfg.FFTSize {
+		width = w.cfg.FFTSize
+	}
+	if fps <= 0 || fps > w.cfg.FrameRate {
+		fps = w.cfg.FrameRate
+	}
+	sub := &subscription{
+		ch:       make(chan Row, 1),
+		width:    width,
+		interval: time.Duration(float64(time.Second) / fps),
+	}
+
+	w.mu.Lock()
+	w.subs[sub] = struct{}{}
+	w.mu.Unlock()
+
+	return sub.ch, func() {
+		w.mu.Lock()
+		delete(w.subs, sub)
+		w.mu.Unlock()
+	}
+}
+
+// Process накапливает кадры БПФ и при готовности среднего рассылает строку.
+func (w *Waterfall) Process(b Block) {
+	if b.SampleRate != w.rate {
+		w.rate = b.SampleRate
+		// Усреднение подбирается так, чтобы поток источника давал
+		// не больше FrameRate строк в секунду.
+		frames := int(math.Floor(b.SampleRate / (float64(w.cfg.FFTSize) * w.cfg.FrameRate)))
+		w.spectrum.SetAverages(max(w.cfg.Averages, frames))
+		w.fill = 0
+	}
+
+	samples := b.Samples
+	for len(samples) > 0 {
+		n := copy(w.frame[w.fill:], samples)
+		w.fill += n
+		samples = samples[n:]
+		if w.fill < len(w.frame) {
+			break
+		}
+		w.fill = 0
+		if ready, _ := w.spectrum.Add(w.frame, w.db); ready {
+			w.publish(b)
+		}
+	}
+}
+
+// publish рассылает строку подписчикам, для которых истёк их интервал.
+func (w *Waterfall) publish(b Block) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for sub := range w.subs {
+		if !sub.last.IsZero() && b.Time.Sub(sub.last) < sub.interval {
+			continue
+		}
+		sub.last = b.Time
+		row := Row{
+			Time:      b.Time,
+			Frequency: b.Frequency,
+			Span:      b.SampleRate,
+			Floor:     w.cfg.Floor,
+			Ceiling:   w.cfg.Ceiling,
+			Bins:      dsp.Quantize(dsp.Decimate(w.db, sub.width), w.cfg.Floor, w.cfg.Ceiling),
+		}
+		// Заменяем непрочитанную строку свежей.
+		select {
+		case <-sub.ch:
+		default:
+		}
+		sub.ch <- row
+	}
+}
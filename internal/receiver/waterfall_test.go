@@ -0,0 +1,142 @@
+package receiver
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// toneBlock возвращает блок с тоном амплитуды amp на смещении offset Гц.
+func toneBlock(at time.Time, n int, rate, offset, amp float64) Block {
+	samples := make([]complex64, n)
+	for i := range samples {
+		s, c := math.Sincos(2 * math.Pi * offset * float64(i) / rate)
+		samples[i] = complex(float32(amp*c), float32(amp*s))
+	}
+	return Block{Time: at, Frequency: 145.8e6, SampleRate: rate, Samples: samples}
+}
+
+func TestWaterfallConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*WaterfallConfig)
+	}{
+		{"FFT size not a power of two", func(c *WaterfallConfig) { c.FFTSize = 1000 }},
+		{"FFT size too small", func(c *WaterfallConfig) { c.FFTSize = 16 }},
+		{"zero averages", func(c *WaterfallConfig) { c.Averages = 0 }},
+		{"zero frame rate", func(c *WaterfallConfig) { c.FrameRate = 0 }},
+		{"inverted scale", func(c *WaterfallConfig) { c.Floor, c.Ceiling = -20, -120 }},
+	}
+
+	if err := DefaultWaterfallConfig().Validate(); err != nil {
+		t.Fatalf("DefaultWaterfallConfig().Validate() = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultWaterfallConfig()
+			tt.modify(&cfg)
+			if _, err := NewWaterfall(cfg); !errors.Is(err, ErrInvalidWaterfall) {
+				t.Errorf("NewWaterfall() error = %v, want ErrInvalidWaterfall", err)
+			}
+		})
+	}
+}
+
+func TestWaterfall_Row(t *testing.T) {
+	cfg := DefaultWaterfallConfig()
+	cfg.FFTSize = 256
+	w, err := NewWaterfall(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, cancelFull := w.Subscribe(0, 0)
+	defer cancelFull()
+	narrow, cancelNarrow := w.Subscribe(64, 0)
+	defer cancelNarrow()
+
+	// 25,6 кГц при 10 строках/с — 10 кадров БПФ на строку.
+	const rate = 25600
+	now := time.Now()
+	w.Process(toneBlock(now, 1000, rate, 5000, 0.1))
+	select {
+	case <-full:
+		t.Fatal("row published before averaging completed")
+	default:
+	}
+	w.Process(toneBlock(now, 1560, rate, 5000, 0.1))
+
+	row := <-full
+	if len(row.Bins) != 256 || row.Span != rate || row.Frequency != 145.8e6 {
+		t.Fatalf("row = %d bins, span %v, frequency %v", len(row.Bins), row.Span, row.Frequency)
+	}
+	// Тон +5 кГц при разрешении 100 Гц — 50 бинов правее центра.
+	peak := 0
+	for i, v := range row.Bins {
+		if v > row.Bins[peak] {
+			peak = i
+		}
+	}
+	if peak != 128+50 {
+		t.Errorf("peak at bin %d, want %d", peak, 128+50)
+	}
+	// −20 дБFS — верх шкалы.
+	if row.Bins[peak] < 250 {
+		t.Errorf("peak value = %d, want near 255", row.Bins[peak])
+	}
+
+	if nr := <-narrow; len(nr.Bins) != 64 || nr.Bins[(128+50)/4] != row.Bins[peak] {
+		t.Errorf("narrow row = %v", nr.Bins)
+	}
+
+	payload, err := json.Marshal(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded["bins"].(string); !ok {
+		t.Errorf("bins are encoded as %T, want base64 string", decoded["bins"])
+	}
+}
+
+func TestWaterfall_FrameRate(t *testing.T) {
+	cfg := DefaultWaterfallConfig()
+	cfg.FFTSize = 64
+	cfg.FrameRate = 5
+	w, err := NewWaterfall(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, cancel := w.Subscribe(0, 2)
+	defer cancel()
+
+	// Неограниченный источник (запись без темпа): строки одного момента
+	// времени рассылаются один раз, следующая — не раньше чем через 0,5 с.
+	start := time.Now()
+	w.Process(toneBlock(start, 64*10, 320, 0, 0.5))
+	<-rows
+	w.Process(toneBlock(start.Add(100*time.Millisecond), 64*10, 320, 0, 0.5))
+	select {
+	case <-rows:
+		t.Fatal("row published faster than subscriber frame rate")
+	default:
+	}
+	w.Process(toneBlock(start.Add(600*time.Millisecond), 64*10, 320, 0, 0.5))
+	select {
+	case <-rows:
+	default:
+		t.Fatal("row was not published after the subscriber interval")
+	}
+
+	cancel()
+	w.Process(toneBlock(start.Add(2*time.Second), 64*10, 320, 0, 0.5))
+	select {
+	case <-rows:
+		t.Fatal("row published after unsubscribe")
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package rig
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/art-injener/satwatch-go/internal/hamlib"
+)
+
+// Client — клиент протокола Hamlib rigctld.
+type Client struct {
+	conn *hamlib.Conn
+}
+
+// NewClient создаёт клиент rigctld по адресу "host:port" (обычно порт 4532).
+func NewClient(addr string) *Client {
+	return &Client{conn: hamlib.NewConn(addr)}
+}
+
+// Addr возвращает адрес rigctld.
+func (c *Client) Addr() string {
+	return c.conn.Addr()
+}
+
+// SetFrequency устанавливает частоту текущего VFO командой "F", Гц.
+func (c *Client) SetFrequency(ctx context.Context, hz float64) error {
+	_, err := c.conn.Command(ctx, "F "+formatHz(hz), 0)
+	return err
+}
+
+// Frequency возвращает частоту текущего VFO (команда "f"), Гц.
+func (c *Client) Frequency(ctx context.Context) (float64, error) {
+	return c.query(ctx, "f")
+}
+
+// SetTXFrequency устанавливает частоту передачи в режиме разноса
+// командой "I", Гц.
+func (c *Client) SetTXFrequency(ctx context.Context, hz float64) error {
+	_, err := c.conn.Command(ctx, "I "+formatHz(hz), 0)
+	return err
+}
+
+// TXFrequency возвращает частоту передачи в режиме разноса (команда "i"), Гц.
+func (c *Client) TXFrequency(ctx context.Context) (float64, error) {
+	return c.query(ctx, "i")
+}
+
+// Close закрывает соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) query(ctx context.Context, cmd string) (float64, error) {
+	lines, err := c.conn.Command(ctx, cmd, 1)
+	if err != nil {
+		return 0, err
+	}
+	hz, err := strconv.ParseFloat(lines[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %q", hamlib.ErrProtocol, cmd, lines[0])
+	}
+	return hz, nil
+}
+
+// formatHz округляет частоту до целых герц: rigctld не принимает дробных.
+func formatHz(hz float64) string {
+	return strconv.FormatFloat(math.Round(hz), 'f', 0, 64)
+}
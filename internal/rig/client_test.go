@@ -0,0 +1,217 @@
+package rig
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/hamlib"
+)
+
+// fakeRigctld имитирует rigctld: хранит частоты приёма и передачи
+// и записывает полученные команды.
+type fakeRigctld struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	rx, tx   float64
+	commands []string
+	replies  map[string]string // команда → ответ вместо стандартного
+	conns    []net.Conn
+}
+
+func newFakeRigctld(t *testing.T) *fakeRigctld {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeRigctld{ln: ln, rx: 145800000, tx: 145800000, replies: make(map[string]string)}
+	go f.accept()
+	t.Cleanup(f.close)
+	return f
+}
+
+func (f *fakeRigctld) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeRigctld) accept() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+		go f.serve(conn)
+	}
+}
+
+func (f *fakeRigctld) serve(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		if _, err := conn.Write([]byte(f.handle(scanner.Text()))); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRigctld) handle(line string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.commands = append(f.commands, line)
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "RPRT -1\n"
+	}
+	if reply, ok := f.replies[fields[0]]; ok {
+		return reply
+	}
+
+	switch fields[0] {
+	case "F", "I":
+		if len(fields) != 2 {
+			return "RPRT -1\n"
+		}
+		hz, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return "RPRT -1\n"
+		}
+		if fields[0] == "F" {
+			f.rx = hz
+		} else {
+			f.tx = hz
+		}
+		return "RPRT 0\n"
+	case "f":
+		return fmt.Sprintf("%.0f\n", f.rx)
+	case "i":
+		return fmt.Sprintf("%.0f\n", f.tx)
+	default:
+		return "RPRT -4\n"
+	}
+}
+
+// sent возвращает отправленные команды установки частоты cmd ("F" или "I").
+func (f *fakeRigctld) sent(cmd string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []string
+	for _, line := range f.commands {
+		if strings.HasPrefix(line, cmd+" ") {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func (f *fakeRigctld) setReply(cmd, reply string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies[cmd] = reply
+}
+
+// dropConnections разрывает открытые соединения.
+func (f *fakeRigctld) dropConnections() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+}
+
+func (f *fakeRigctld) close() {
+	f.ln.Close()
+	f.dropConnections()
+}
+
+func TestClient_Frequencies(t *testing.T) {
+	fake := newFakeRigctld(t)
+	client := NewClient(fake.addr())
+	defer client.Close()
+	ctx := context.Background()
+
+	if err := client.SetFrequency(ctx, 145802345.6); err != nil {
+		t.Fatalf("SetFrequency() error = %v", err)
+	}
+	if got, err := client.Frequency(ctx); err != nil || got != 145802346 {
+		t.Errorf("Frequency() = %v, %v; want 145802346", got, err)
+	}
+
+	if err := client.SetTXFrequency(ctx, 437799999.4); err != nil {
+		t.Fatalf("SetTXFrequency() error = %v", err)
+	}
+	if got, err := client.TXFrequency(ctx); err != nil || got != 437799999 {
+		t.Errorf("TXFrequency() = %v, %v; want 437799999", got, err)
+	}
+
+	if got := fake.sent("F"); len(got) != 1 || got[0] != "F 145802346" {
+		t.Errorf("F commands = %q", got)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		cmd   string
+		reply string
+		call  func(*Client) error
+		code  int
+	}{
+		{
+			name:  "set frequency rejected",
+			cmd:   "F",
+			reply: "RPRT -1\n",
+			call:  func(c *Client) error { return c.SetFrequency(context.Background(), 1) },
+			code:  -1,
+		},
+		{
+			name:  "split not supported",
+			cmd:   "i",
+			reply: "RPRT -11\n",
+			call:  func(c *Client) error { _, err := c.TXFrequency(context.Background()); return err },
+			code:  -11,
+		},
+		{
+			name:  "malformed frequency",
+			cmd:   "f",
+			reply: "VFOA\n",
+			call:  func(c *Client) error { _, err := c.Frequency(context.Background()); return err },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeRigctld(t)
+			fake.setReply(tt.cmd, tt.reply)
+			client := NewClient(fake.addr())
+			defer client.Close()
+
+			err := tt.call(client)
+			if tt.code == 0 {
+				if !errors.Is(err, hamlib.ErrProtocol) {
+					t.Errorf("error = %v, want ErrProtocol", err)
+				}
+				return
+			}
+			var replyErr *hamlib.ReplyError
+			if !errors.As(err, &replyErr) || replyErr.Code != tt.code {
+				t.Errorf("error = %v, want RPRT %d", err, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package rig подстраивает частоты радиостанции через Hamlib rigctld:
+// во время сопровождения спутника приём и передача смещаются на
+// доплеровскую поправку.
+package rig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// Параметры по умолчанию. Доплеровский сдвиг на 145 МГц для НОО
+// меняется до ~60 Гц/с вблизи кульминации, так что допуск 10 Гц даёт
+// не больше нескольких команд в секунду.
+const (
+	DefaultInterval  = time.Second
+	DefaultTolerance = 10.0 // Гц
+	MinInterval      = 100 * time.Millisecond
+)
+
+// ErrInvalidConfig возвращается при некорректных параметрах.
+var ErrInvalidConfig = errors.New("rig: invalid config")
+
+// Config — параметры подстройки.
+type Config struct {
+	// Interval — период пересчёта поправки.
+	Interval time.Duration `json:"interval"`
+	// Tolerance — наименьшее изменение частоты, ради которого
+	// отправляется команда, Гц.
+	Tolerance float64 `json:"tolerance_hz"`
+}
+
+// DefaultConfig возвращает параметры по умолчанию.
+func DefaultConfig() Config {
+	return Config{
+		Interval:  DefaultInterval,
+		Tolerance: DefaultTolerance,
+	}
+}
+
+// Validate проверяет параметры.
+func (c Config) Validate() error {
+	switch {
+	case c.Interval < MinInterval:
+		return fmt.Errorf("%w: interval must be at least %s", ErrInvalidConfig, MinInterval)
+	case c.Tolerance < 0:
+		return fmt.Errorf("%w: tolerance must not be negative", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// DownlinkFrequency возвращает частоту приёма сигнала, излучаемого
+// спутником на nominal (Гц), при радиальной скорости rangeRate (км/с).
+func DownlinkFrequency(nominal, rangeRate float64) float64 {
+	return nominal + orbit.DopplerShift(nominal, rangeRate)
+}
+
+// UplinkFrequency возвращает частоту передачи, которую спутник
+// при радиальной скорости rangeRate (км/с) примет на nominal (Гц).
+func UplinkFrequency(nominal, rangeRate float64) float64 {
+	return nominal - orbit.DopplerShift(nominal, rangeRate)
+}
+
+// changed сообщает, отличается ли target от отправленной частоты sent
+// не меньше чем на tolerance; nil — частота ещё не отправлялась.
+func changed(sent *float64, target, tolerance float64) bool {
+	return sent == nil || math.Abs(target-*sent) >= math.Max(tolerance, 1)
+}
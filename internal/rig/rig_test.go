@@ -0,0 +1,56 @@
+package rig
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default", DefaultConfig(), false},
+		{"zero tolerance", Config{Interval: time.Second}, false},
+		{"interval too short", Config{Interval: 10 * time.Millisecond, Tolerance: 10}, true},
+		{"negative tolerance", Config{Interval: time.Second, Tolerance: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDopplerFrequencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		nominal   float64
+		rangeRate float64
+		downlink  float64
+		uplink    float64
+	}{
+		// При сближении со скоростью 7 км/с сдвиг на 145.8 МГц ≈ +3404 Гц.
+		{"approaching", 145.8e6, -7, 145.8e6 + 3404.4, 145.8e6 - 3404.4},
+		{"receding", 145.8e6, 7, 145.8e6 - 3404.4, 145.8e6 + 3404.4},
+		{"culmination", 437.8e6, 0, 437.8e6, 437.8e6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DownlinkFrequency(tt.nominal, tt.rangeRate); math.Abs(got-tt.downlink) > 0.1 {
+				t.Errorf("DownlinkFrequency() = %.1f, want %.1f", got, tt.downlink)
+			}
+			if got := UplinkFrequency(tt.nominal, tt.rangeRate); math.Abs(got-tt.uplink) > 0.1 {
+				t.Errorf("UplinkFrequency() = %.1f, want %.1f", got, tt.uplink)
+			}
+		})
+	}
+}
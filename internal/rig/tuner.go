@@ -0,0 +1,282 @@
+package rig
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// Mode — режим работы подстройки.
+type Mode string
+
+// Режимы подстройки.
+const (
+	// ModeStopped — частоты не меняются, только читается текущая частота.
+	ModeStopped Mode = "stopped"
+	// ModeTracking — частоты смещаются на доплеровскую поправку спутника.
+	ModeTracking Mode = "tracking"
+)
+
+// Rig — радиостанция; реализуется Client.
+type Rig interface {
+	SetFrequency(ctx context.Context, hz float64) error
+	Frequency(ctx context.Context) (float64, error)
+	SetTXFrequency(ctx context.Context, hz float64) error
+	TXFrequency(ctx context.Context) (float64, error)
+}
+
+// Tracker вычисляет положение спутника; реализуется tracking.Tracker.
+type Tracker interface {
+	Snapshot(noradID int, at time.Time) (tracking.Snapshot, error)
+}
+
+// Channel — частоты одного направления связи, Гц.
+type Channel struct {
+	Nominal float64 `json:"nominal_hz"`           // частота спутника без поправки
+	Target  float64 `json:"target_hz,omitempty"`  // с доплеровской поправкой
+	Current float64 `json:"current_hz,omitempty"` // по данным rigctld
+	Doppler float64 `json:"doppler_hz"`           // поправка Target − Nominal
+}
+
+// Status — состояние подстройки.
+type Status struct {
+	Mode      Mode      `json:"mode"`
+	NoradID   int       `json:"norad_id,omitempty"`
+	Connected bool      `json:"connected"`
+	Visible   bool      `json:"visible"`
+	Downlink  *Channel  `json:"downlink,omitempty"`
+	Uplink    *Channel  `json:"uplink,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// Tuner подстраивает частоты: на каждом такте вычисляет доплеровскую
+// поправку по модельному времени и отправляет частоту, если она
+// изменилась больше чем на Tolerance. Приём настраивается на текущем
+// VFO, передача — на частоте разноса.
+type Tuner struct {
+	rig     Rig
+	tracker Tracker
+	now     func() time.Time
+	wall    func() time.Time
+	cfg     Config
+	wake    chan struct{}
+
+	mu       sync.Mutex
+	mode     Mode
+	noradID  int
+	downlink float64 // Гц; ноль — приём не подстраивается
+	uplink   float64 // Гц; ноль — передача не подстраивается
+	status   Status
+	sentDown *float64
+	sentUp   *float64
+}
+
+// NewTuner создаёт подстройку в режиме ModeStopped; now задаёт
+// модельное время расчёта поправки.
+func NewTuner(rig Rig, tracker Tracker, now func() time.Time, cfg Config) (*Tuner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Tuner{
+		rig:     rig,
+		tracker: tracker,
+		now:     now,
+		wall:    time.Now,
+		cfg:     cfg,
+		wake:    make(chan struct{}, 1),
+		mode:    ModeStopped,
+		status:  Status{Mode: ModeStopped},
+	}, nil
+}
+
+// Config возвращает параметры подстройки.
+func (t *Tuner) Config() Config {
+	return t.cfg
+}
+
+// Status возвращает состояние подстройки на последнем такте.
+func (t *Tuner) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// Track начинает подстройку под спутник noradID с частотами
+// downlink и uplink (Гц); нулевая частота не подстраивается.
+func (t *Tuner) Track(noradID int, downlink, uplink float64) {
+	t.mu.Lock()
+	t.mode, t.noradID = ModeTracking, noradID
+	t.downlink, t.uplink = downlink, uplink
+	t.sentDown, t.sentUp = nil, nil
+	t.status = Status{Mode: ModeTracking, NoradID: noradID, Connected: t.status.Connected, Updated: t.status.Updated}
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Stop прекращает подстройку; радиостанция остаётся на последней частоте.
+func (t *Tuner) Stop() {
+	t.mu.Lock()
+	t.mode, t.noradID = ModeStopped, 0
+	t.downlink, t.uplink = 0, 0
+	t.sentDown, t.sentUp = nil, nil
+	t.status = Status{Mode: ModeStopped, Connected: t.status.Connected, Updated: t.status.Updated}
+	t.mu.Unlock()
+	t.notify()
+}
+
+func (t *Tuner) notify() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run выполняет такты с периодом Interval и сразу после смены режима
+// до отмены ctx. Ошибки обмена с rigctld отражаются в Status и не
+// прерывают работу.
+func (t *Tuner) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		t.step(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-t.wake:
+		}
+	}
+}
+
+// step выполняет один такт подстройки. Цели вычисляются по копии
+// состояния, обмен с rigctld идёт без блокировки, чтобы медленная
+// радиостанция не задерживала Status и смену режима. Результат такта
+// не записывается, если за время обмена сменились режим или спутник.
+func (t *Tuner) step(ctx context.Context) {
+	current, err := t.rig.Frequency(ctx)
+	if err != nil {
+		t.fail(ctx, err)
+		return
+	}
+
+	t.mu.Lock()
+	t.status.Connected = true
+	t.status.Error = ""
+	t.status.Updated = t.wall()
+	if t.mode == ModeStopped {
+		t.status.Downlink = &Channel{Current: current}
+		t.mu.Unlock()
+		return
+	}
+	r := tuning{mode: t.mode, noradID: t.noradID, downlink: t.downlink, uplink: t.uplink}
+	sentDown, sentUp := t.sentDown, t.sentUp
+	t.mu.Unlock()
+
+	r.run(ctx, t, current, sentDown, sentUp)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.mode != r.mode || t.noradID != r.noradID || t.downlink != r.downlink || t.uplink != r.uplink {
+		return
+	}
+	if r.err != nil {
+		t.status.Error = r.err.Error()
+	}
+	if r.snapped {
+		t.status.Visible = r.visible
+	}
+	if r.down != nil {
+		t.status.Downlink = r.down
+	}
+	if r.up != nil {
+		t.status.Uplink = r.up
+	}
+	if r.sentDown != nil {
+		t.sentDown = r.sentDown
+	}
+	if r.sentUp != nil {
+		t.sentUp = r.sentUp
+	}
+}
+
+// tuning — такт подстройки, выполняемый без блокировки: режим и частоты
+// на его начало и результаты обмена с rigctld.
+type tuning struct {
+	mode     Mode
+	noradID  int
+	downlink float64
+	uplink   float64
+
+	snapped  bool
+	visible  bool
+	down, up *Channel
+	sentDown *float64 // отправленная частота приёма; nil — не отправлялась
+	sentUp   *float64
+	err      error
+}
+
+// run вычисляет поправку и отправляет частоты, изменившиеся относительно
+// sentDown и sentUp больше чем на Tolerance.
+func (r *tuning) run(ctx context.Context, t *Tuner, current float64, sentDown, sentUp *float64) {
+	snap, err := t.tracker.Snapshot(r.noradID, t.now())
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.snapped, r.visible = true, snap.Visible
+
+	if r.downlink > 0 {
+		ch := &Channel{Nominal: r.downlink, Target: DownlinkFrequency(r.downlink, snap.RangeRate), Current: current}
+		ch.Doppler = ch.Target - ch.Nominal
+		r.down = ch
+		if changed(sentDown, ch.Target, t.cfg.Tolerance) {
+			if err := t.rig.SetFrequency(ctx, ch.Target); err != nil {
+				r.err = err
+				slog.Warn("rig downlink tuning failed", "error", err)
+				return
+			}
+			r.sentDown = &ch.Target
+		}
+	}
+
+	if r.uplink > 0 {
+		ch := &Channel{Nominal: r.uplink, Target: UplinkFrequency(r.uplink, snap.RangeRate)}
+		ch.Doppler = ch.Target - ch.Nominal
+		r.up = ch
+		if changed(sentUp, ch.Target, t.cfg.Tolerance) {
+			if err := t.rig.SetTXFrequency(ctx, ch.Target); err != nil {
+				r.err = err
+				slog.Warn("rig uplink tuning failed", "error", err)
+				return
+			}
+			r.sentUp = &ch.Target
+		}
+		if tx, err := t.rig.TXFrequency(ctx); err == nil {
+			ch.Current = tx
+		}
+	}
+}
+
+// fail отражает потерю связи с rigctld. После восстановления частоты
+// отправляются заново.
+func (t *Tuner) fail(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.status.Connected || t.status.Error != err.Error() {
+		slog.Warn("rig is unavailable", "error", err)
+	}
+	t.status.Connected = false
+	t.status.Error = err.Error()
+	t.status.Updated = t.wall()
+	t.sentDown, t.sentUp = nil, nil
+}
@@ -0,0 +1,215 @@
+package rig
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+var testEpoch = time.Date(2008, 9, 20, 12, 0, 0, 0, time.UTC)
+
+// fakeTracker возвращает заданную радиальную скорость спутника.
+type fakeTracker struct {
+	rangeRate float64
+	visible   bool
+	err       error
+}
+
+func (f *fakeTracker) Snapshot(noradID int, at time.Time) (tracking.Snapshot, error) {
+	if f.err != nil {
+		return tracking.Snapshot{}, f.err
+	}
+	return tracking.Snapshot{
+		Time:    at,
+		NoradID: noradID,
+		Look:    orbit.Look{RangeRate: f.rangeRate},
+		Visible: f.visible,
+	}, nil
+}
+
+func newTestTuner(t *testing.T, fake *fakeRigctld, tracker Tracker) *Tuner {
+	t.Helper()
+
+	client := NewClient(fake.addr())
+	t.Cleanup(func() { client.Close() })
+
+	tuner, err := NewTuner(client, tracker, func() time.Time { return testEpoch }, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tuner
+}
+
+func TestTuner_Stopped(t *testing.T) {
+	fake := newFakeRigctld(t)
+	tuner := newTestTuner(t, fake, &fakeTracker{})
+
+	tuner.step(context.Background())
+
+	st := tuner.Status()
+	if st.Mode != ModeStopped || !st.Connected {
+		t.Errorf("status = %+v", st)
+	}
+	if st.Downlink == nil || st.Downlink.Current != 145800000 {
+		t.Errorf("downlink = %+v, want current frequency", st.Downlink)
+	}
+	if got := fake.sent("F"); len(got) != 0 {
+		t.Errorf("stopped tuner sent %q", got)
+	}
+}
+
+func TestTuner_Doppler(t *testing.T) {
+	fake := newFakeRigctld(t)
+	tracker := &fakeTracker{rangeRate: -7, visible: true}
+	tuner := newTestTuner(t, fake, tracker)
+	ctx := context.Background()
+
+	tuner.Track(25544, 145.8e6, 437.8e6)
+	tuner.step(ctx)
+
+	// Сдвиг меньше допуска не отправляется, больший — отправляется.
+	tracker.rangeRate = -6.99 // downlink: −4.9 Гц, uplink: +14.6 Гц
+	tuner.step(ctx)
+	tracker.rangeRate = -6.9
+	tuner.step(ctx)
+
+	wantRX := []string{"F 145803404", "F 145803356"}
+	if got := fake.sent("F"); strings.Join(got, ";") != strings.Join(wantRX, ";") {
+		t.Errorf("F commands = %q, want %q", got, wantRX)
+	}
+	wantTX := []string{"I 437789778", "I 437789792", "I 437789924"}
+	if got := fake.sent("I"); strings.Join(got, ";") != strings.Join(wantTX, ";") {
+		t.Errorf("I commands = %q, want %q", got, wantTX)
+	}
+
+	st := tuner.Status()
+	if st.Mode != ModeTracking || st.NoradID != 25544 || !st.Visible {
+		t.Errorf("status = %+v", st)
+	}
+	if st.Downlink == nil || math.Abs(st.Downlink.Target-145803355.9) > 1 || st.Downlink.Current != 145803404 {
+		t.Errorf("downlink = %+v", st.Downlink)
+	}
+	if st.Uplink == nil || math.Abs(st.Uplink.Doppler+10076.4) > 1 || st.Uplink.Current != 437789924 {
+		t.Errorf("uplink = %+v", st.Uplink)
+	}
+}
+
+func TestTuner_DownlinkOnly(t *testing.T) {
+	fake := newFakeRigctld(t)
+	tuner := newTestTuner(t, fake, &fakeTracker{rangeRate: 3})
+
+	tuner.Track(25544, 145.8e6, 0)
+	tuner.step(context.Background())
+
+	if st := tuner.Status(); st.Uplink != nil || st.Downlink == nil || st.Visible {
+		t.Errorf("status = %+v", st)
+	}
+	if got := fake.sent("I"); len(got) != 0 {
+		t.Errorf("uplink commands without uplink frequency: %q", got)
+	}
+}
+
+func TestTuner_Errors(t *testing.T) {
+	fake := newFakeRigctld(t)
+	tracker := &fakeTracker{err: errors.New("satellite has no TLE")}
+	tuner := newTestTuner(t, fake, tracker)
+	ctx := context.Background()
+
+	tuner.Track(25544, 145.8e6, 0)
+	tuner.step(ctx)
+	if st := tuner.Status(); st.Error == "" || !st.Connected {
+		t.Errorf("status after tracking error = %+v", st)
+	}
+
+	tracker.err = nil
+	tuner.step(ctx)
+	fake.dropConnections()
+	tuner.step(ctx)
+	if st := tuner.Status(); st.Connected || st.Error == "" {
+		t.Errorf("status after disconnect = %+v", st)
+	}
+
+	// После восстановления связи частота отправляется заново.
+	tuner.step(ctx)
+	if got := fake.sent("F"); len(got) != 2 {
+		t.Errorf("F commands = %q, want resend after reconnect", got)
+	}
+}
+
+func TestTuner_Stop(t *testing.T) {
+	fake := newFakeRigctld(t)
+	tuner := newTestTuner(t, fake, &fakeTracker{rangeRate: 5})
+	ctx := context.Background()
+
+	tuner.Track(25544, 145.8e6, 0)
+	tuner.step(ctx)
+	tuner.Stop()
+	tuner.step(ctx)
+
+	st := tuner.Status()
+	if st.Mode != ModeStopped || st.NoradID != 0 || st.Uplink != nil {
+		t.Errorf("status = %+v", st)
+	}
+	if st.Downlink == nil || st.Downlink.Target != 0 || st.Downlink.Current == 145800000 {
+		t.Errorf("downlink = %+v, want the last tuned frequency", st.Downlink)
+	}
+}
+
+// slowRig не отвечает на установку частоты до закрытия release.
+type slowRig struct {
+	sent    chan float64
+	release chan struct{}
+}
+
+func (r *slowRig) SetFrequency(_ context.Context, hz float64) error {
+	r.sent <- hz
+	<-r.release
+	return nil
+}
+func (r *slowRig) Frequency(context.Context) (float64, error)    { return 145.8e6, nil }
+func (r *slowRig) SetTXFrequency(context.Context, float64) error { return nil }
+func (r *slowRig) TXFrequency(context.Context) (float64, error)  { return 0, nil }
+
+func TestTuner_SlowRig(t *testing.T) {
+	rig := &slowRig{sent: make(chan float64, 1), release: make(chan struct{})}
+	tuner, err := NewTuner(rig, &fakeTracker{rangeRate: -7}, func() time.Time { return testEpoch }, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tuner.Track(25544, 145.8e6, 0)
+	stepped := make(chan struct{})
+	go func() {
+		tuner.step(context.Background())
+		close(stepped)
+	}()
+	<-rig.sent
+
+	// Пока rigctld не ответил, состояние читается и подстройка меняется.
+	done := make(chan Status)
+	go func() {
+		tuner.Track(43017, 435.35e6, 0)
+		done <- tuner.Status()
+	}()
+	select {
+	case st := <-done:
+		if st.NoradID != 43017 {
+			t.Errorf("norad_id = %d, want 43017", st.NoradID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Status() blocked by rigctld")
+	}
+
+	// Результат такта для прежнего спутника не записывается.
+	close(rig.release)
+	<-stepped
+	if st := tuner.Status(); st.Downlink != nil || tuner.sentDown != nil {
+		t.Errorf("status after retune = %+v", st)
+	}
+}
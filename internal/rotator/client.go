@@ -0,0 +1,65 @@
+package rotator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/art-injener/satwatch-go/internal/hamlib"
+)
+
+// Client — клиент протокола Hamlib rotctld.
+type Client struct {
+	conn *hamlib.Conn
+}
+
+// NewClient создаёт клиент rotctld по адресу "host:port" (обычно порт 4533).
+func NewClient(addr string) *Client {
+	return &Client{conn: hamlib.NewConn(addr)}
+}
+
+// Addr возвращает адрес rotctld.
+func (c *Client) Addr() string {
+	return c.conn.Addr()
+}
+
+// SetPosition отправляет команду "P az el".
+func (c *Client) SetPosition(ctx context.Context, p Position) error {
+	line := "P " + strconv.FormatFloat(p.Azimuth, 'f', 2, 64) + " " + strconv.FormatFloat(p.Elevation, 'f', 2, 64)
+	_, err := c.conn.Command(ctx, line, 0)
+	return err
+}
+
+// Position запрашивает текущее положение командой "p".
+func (c *Client) Position(ctx context.Context) (Position, error) {
+	lines, err := c.conn.Command(ctx, "p", 2)
+	if err != nil {
+		return Position{}, err
+	}
+	az, errAz := strconv.ParseFloat(lines[0], 64)
+	el, errEl := strconv.ParseFloat(lines[1], 64)
+	if errAz != nil || errEl != nil {
+		return Position{}, fmt.Errorf("%w: p: %q", hamlib.ErrProtocol, lines)
+	}
+	return Position{Azimuth: az, Elevation: el}, nil
+}
+
+// Stop останавливает движение командой "S".
+func (c *Client) Stop(ctx context.Context) error {
+	_, err := c.conn.Command(ctx, "S", 0)
+	return err
+}
+
+// Info возвращает описание модели поворотного устройства (команда "_").
+func (c *Client) Info(ctx context.Context) (string, error) {
+	lines, err := c.conn.Command(ctx, "_", 1)
+	if err != nil {
+		return "", err
+	}
+	return lines[0], nil
+}
+
+// Close закрывает соединение.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,244 @@
+package rotator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/hamlib"
+)
+
+// fakeRotctld имитирует rotctld: команда P перемещает устройство
+// мгновенно, p возвращает положение. Ответы на команды можно подменить.
+type fakeRotctld struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	pos      Position
+	commands []string
+	replies  map[string]string // команда → ответ вместо стандартного
+	conns    []net.Conn
+}
+
+func newFakeRotctld(t *testing.T) *fakeRotctld {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeRotctld{ln: ln, replies: make(map[string]string)}
+	go f.accept()
+	t.Cleanup(f.close)
+	return f
+}
+
+func (f *fakeRotctld) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeRotctld) accept() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+		go f.serve(conn)
+	}
+}
+
+func (f *fakeRotctld) serve(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		if _, err := conn.Write([]byte(f.handle(scanner.Text()))); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRotctld) handle(line string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.commands = append(f.commands, line)
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "RPRT -1\n"
+	}
+	if reply, ok := f.replies[fields[0]]; ok {
+		return reply
+	}
+
+	switch fields[0] {
+	case "P":
+		if len(fields) != 3 {
+			return "RPRT -1\n"
+		}
+		az, errAz := strconv.ParseFloat(fields[1], 64)
+		el, errEl := strconv.ParseFloat(fields[2], 64)
+		if errAz != nil || errEl != nil {
+			return "RPRT -1\n"
+		}
+		f.pos = Position{Azimuth: az, Elevation: el}
+		return "RPRT 0\n"
+	case "p":
+		return fmt.Sprintf("%f\n%f\n", f.pos.Azimuth, f.pos.Elevation)
+	case "S":
+		return "RPRT 0\n"
+	case "_":
+		return "Dummy\n"
+	default:
+		return "RPRT -4\n"
+	}
+}
+
+// setPositions возвращает отправленные уставки P.
+func (f *fakeRotctld) setPositions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []string
+	for _, cmd := range f.commands {
+		if strings.HasPrefix(cmd, "P ") {
+			result = append(result, cmd)
+		}
+	}
+	return result
+}
+
+func (f *fakeRotctld) setPosition(p Position) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pos = p
+}
+
+func (f *fakeRotctld) setReply(cmd, reply string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies[cmd] = reply
+}
+
+// dropConnections разрывает открытые соединения.
+func (f *fakeRotctld) dropConnections() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+}
+
+func (f *fakeRotctld) close() {
+	f.ln.Close()
+	f.dropConnections()
+}
+
+func TestClient_Commands(t *testing.T) {
+	fake := newFakeRotctld(t)
+	client := NewClient(fake.addr())
+	defer client.Close()
+	ctx := context.Background()
+
+	info, err := client.Info(ctx)
+	if err != nil || info != "Dummy" {
+		t.Fatalf("Info() = %q, %v; want Dummy", info, err)
+	}
+
+	if err := client.SetPosition(ctx, Position{Azimuth: 123.456, Elevation: 45.5}); err != nil {
+		t.Fatalf("SetPosition() error = %v", err)
+	}
+	if got := fake.setPositions(); len(got) != 1 || got[0] != "P 123.46 45.50" {
+		t.Errorf("commands = %q, want [\"P 123.46 45.50\"]", got)
+	}
+
+	pos, err := client.Position(ctx)
+	if err != nil {
+		t.Fatalf("Position() error = %v", err)
+	}
+	if pos != (Position{Azimuth: 123.46, Elevation: 45.5}) {
+		t.Errorf("Position() = %+v", pos)
+	}
+
+	if err := client.Stop(ctx); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		cmd   string
+		reply string
+		call  func(*Client) error
+		code  int
+		proto bool
+	}{
+		{
+			name:  "set position rejected",
+			cmd:   "P",
+			reply: "RPRT -1\n",
+			call:  func(c *Client) error { return c.SetPosition(context.Background(), Position{}) },
+			code:  -1,
+		},
+		{
+			name:  "get position rejected",
+			cmd:   "p",
+			reply: "RPRT -8\n",
+			call:  func(c *Client) error { _, err := c.Position(context.Background()); return err },
+			code:  -8,
+		},
+		{
+			name:  "malformed position",
+			cmd:   "p",
+			reply: "north\nup\n",
+			call:  func(c *Client) error { _, err := c.Position(context.Background()); return err },
+			proto: true,
+		},
+		{
+			name:  "data instead of report",
+			cmd:   "S",
+			reply: "0.0\n",
+			call:  func(c *Client) error { return c.Stop(context.Background()) },
+			proto: true,
+		},
+		{
+			name:  "success report instead of data",
+			cmd:   "p",
+			reply: "RPRT 0\n",
+			call:  func(c *Client) error { _, err := c.Position(context.Background()); return err },
+			proto: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeRotctld(t)
+			fake.setReply(tt.cmd, tt.reply)
+			client := NewClient(fake.addr())
+			defer client.Close()
+
+			err := tt.call(client)
+			if tt.proto {
+				if !errors.Is(err, hamlib.ErrProtocol) {
+					t.Errorf("error = %v, want ErrProtocol", err)
+				}
+				return
+			}
+			var replyErr *hamlib.ReplyError
+			if !errors.As(err, &replyErr) || replyErr.Code != tt.code {
+				t.Errorf("error = %v, want RPRT %d", err, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package rotator
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+// Mode — режим работы контроллера.
+type Mode string
+
+// Режимы контроллера.
+const (
+	// ModeStopped — команды движения не отправляются, только читается положение.
+	ModeStopped Mode = "stopped"
+	// ModeParked — антенна удерживается в положении стоянки.
+	ModeParked Mode = "parked"
+	// ModeTracking — антенна сопровождает спутник по траектории пролёта,
+	// заранее выходит в её начальную точку, между пролётами стоит
+	// в положении стоянки.
+	ModeTracking Mode = "tracking"
+)
+
+// Rotator — поворотное устройство; реализуется Client.
+type Rotator interface {
+	SetPosition(ctx context.Context, p Position) error
+	Position(ctx context.Context) (Position, error)
+	Stop(ctx context.Context) error
+}
+
+// Tracker вычисляет положение спутника; реализуется tracking.Tracker.
+type Tracker interface {
+	Snapshot(noradID int, at time.Time) (tracking.Snapshot, error)
+	Look(noradID int, at time.Time) (orbit.Look, error)
+}
+
+// Status — состояние контроллера.
+type Status struct {
+	Mode      Mode      `json:"mode"`
+	NoradID   int       `json:"norad_id,omitempty"`
+	Connected bool      `json:"connected"`
+	Target    *Position `json:"target,omitempty"`  // желаемое направление
+	Command   *Position `json:"command,omitempty"` // уставка с учётом скорости поворота
+	Actual    *Position `json:"actual,omitempty"`  // положение по данным rotctld
+	Flip      bool      `json:"flip,omitempty"`    // пролёт идёт в режиме переворота
+	Error     string    `json:"error,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// Controller наводит антенну: на каждом такте берёт положение из
+// траектории текущего пролёта по модельному времени, ограничивает шаг
+// скоростью поворота и отправляет уставку, если она изменилась больше
+// чем на Tolerance.
+type Controller struct {
+	rot     Rotator
+	tracker Tracker
+	now     func() time.Time // модельное время для расчёта направления
+	wall    func() time.Time // реальное время для ограничения скорости
+	cfg     Config
+	wake    chan struct{}
+
+	mu      sync.Mutex
+	mode    Mode
+	noradID int
+	status  Status
+	command *Position // последняя уставка; nil — движение начнётся от фактического положения
+	sent    *Position // последняя отправленная уставка
+	last    time.Time // реальное время последней уставки
+	plan    *Plan     // траектория текущего или ближайшего пролёта
+	gen     uint64    // номер режима; меняется при каждой смене режима
+}
+
+// NewController создаёт контроллер в режиме ModeStopped; now задаёт
+// модельное время расчёта направления.
+func NewController(rot Rotator, tracker Tracker, now func() time.Time, cfg Config) (*Controller, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Controller{
+		rot:     rot,
+		tracker: tracker,
+		now:     now,
+		wall:    time.Now,
+		cfg:     cfg,
+		wake:    make(chan struct{}, 1),
+		mode:    ModeStopped,
+		status:  Status{Mode: ModeStopped},
+	}, nil
+}
+
+// Config возвращает параметры устройства.
+func (c *Controller) Config() Config {
+	return c.cfg
+}
+
+// Status возвращает состояние контроллера на последнем такте.
+func (c *Controller) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// Track начинает сопровождение спутника noradID.
+func (c *Controller) Track(noradID int) {
+	c.setMode(ModeTracking, noradID)
+}
+
+// Park переводит антенну в положение стоянки.
+func (c *Controller) Park() {
+	c.setMode(ModeParked, 0)
+}
+
+// Stop прекращает отправку уставок и останавливает движение устройства.
+func (c *Controller) Stop(ctx context.Context) error {
+	c.setMode(ModeStopped, 0)
+	return c.rot.Stop(ctx)
+}
+
+func (c *Controller) setMode(mode Mode, noradID int) {
+	c.mu.Lock()
+	c.mode, c.noradID = mode, noradID
+	c.gen++
+	c.status.Mode, c.status.NoradID = mode, noradID
+	c.plan, c.status.Flip = nil, false
+	if mode == ModeStopped {
+		c.command, c.sent = nil, nil
+		c.status.Target, c.status.Command = nil, nil
+	}
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run выполняет такты с периодом Interval и сразу после смены режима
+// до отмены ctx. Ошибки обмена с rotctld отражаются в Status и не
+// прерывают работу: соединение восстанавливается на следующем такте.
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.step(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.wake:
+		}
+	}
+}
+
+// step выполняет один такт управления. Состояние копируется под mu,
+// а расчёт траектории и обмен с rotctld выполняются без блокировки,
+// чтобы медленное устройство не задерживало Status и смену режима.
+// Результат такта отбрасывается, если режим сменился за время обмена.
+func (c *Controller) step(ctx context.Context) {
+	actual, err := c.rot.Position(ctx)
+	if err != nil {
+		c.fail(ctx, err)
+		return
+	}
+
+	c.mu.Lock()
+	c.status.Connected = true
+	c.status.Actual = &actual
+	c.status.Error = ""
+	c.status.Updated = c.wall()
+	if c.mode == ModeStopped {
+		c.mu.Unlock()
+		return
+	}
+	mode, noradID, plan, sent, gen := c.mode, c.noradID, c.plan, c.sent, c.gen
+	from := actual
+	dt := c.cfg.Interval
+	if c.command != nil {
+		from = *c.command
+		dt = min(c.wall().Sub(c.last), 2*c.cfg.Interval)
+	}
+	c.mu.Unlock()
+
+	target, plan, planErr := c.target(mode, noradID, plan, from)
+	next := c.cfg.slew(from, target, dt)
+	send := sent == nil || distance(next, *sent) >= c.cfg.Tolerance
+	var sendErr error
+	if send {
+		sendErr = c.rot.SetPosition(ctx, next)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen != gen {
+		return
+	}
+	c.plan, c.status.Flip = plan, plan != nil && plan.Flip
+	c.status.Target = &target
+	if planErr != nil {
+		c.status.Error = planErr.Error()
+	}
+	if sendErr != nil {
+		c.status.Error = sendErr.Error()
+		slog.Warn("rotator command failed", "error", sendErr)
+		return
+	}
+	if send {
+		c.sent = &next
+	}
+	c.command = &next
+	c.last = c.wall()
+	c.status.Command = &next
+}
+
+// target возвращает желаемое положение для режима mode и траекторию
+// пролёта, заменяющую plan. Вызывается без блокировки.
+func (c *Controller) target(mode Mode, noradID int, plan *Plan, from Position) (Position, *Plan, error) {
+	if mode != ModeTracking {
+		return c.cfg.Park, plan, nil
+	}
+	now := c.now()
+	plan, err := c.currentPlan(noradID, plan, now, from)
+	if err != nil {
+		return c.cfg.Park, plan, err
+	}
+	if plan == nil || now.Before(plan.Preposition) {
+		return c.cfg.Park, plan, nil
+	}
+	return plan.At(now), plan, nil
+}
+
+// currentPlan возвращает траекторию текущего или ближайшего пролёта,
+// рассчитывая её вместо cached при смене пролёта или его границ,
+// например после замены маски горизонта; nil — пролёт не найден.
+// При ошибке возвращается cached.
+func (c *Controller) currentPlan(noradID int, cached *Plan, now time.Time, from Position) (*Plan, error) {
+	snap, err := c.tracker.Snapshot(noradID, now)
+	if err != nil {
+		return cached, err
+	}
+	if snap.NextAOS == nil || snap.NextLOS == nil {
+		return nil, nil
+	}
+	if cached != nil && cached.AOS.Equal(*snap.NextAOS) && cached.LOS.Equal(*snap.NextLOS) {
+		return cached, nil
+	}
+
+	// До пролёта антенна стоит в положении стоянки, из него и планируется
+	// выход; при подключении посреди пролёта — из текущего положения.
+	start := c.cfg.Park
+	if !now.Before(*snap.NextAOS) {
+		start = from
+	}
+	plan, err := c.cfg.PlanPass(func(t time.Time) (orbit.Look, error) {
+		return c.tracker.Look(noradID, t)
+	}, *snap.NextAOS, *snap.NextLOS, start)
+	if err != nil {
+		return cached, err
+	}
+	if plan.Unwinds > 0 {
+		slog.Warn("rotator trajectory requires unwinding", "norad_id", noradID, "aos", plan.AOS, "unwinds", plan.Unwinds)
+	}
+	return &plan, nil
+}
+
+// Plan возвращает траекторию текущего или ближайшего пролёта
+// сопровождаемого спутника; false — траектория ещё не рассчитана.
+func (c *Controller) Plan() (Plan, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plan == nil {
+		return Plan{}, false
+	}
+	return *c.plan, true
+}
+
+// fail отражает потерю связи с rotctld. Уставка сбрасывается: после
+// восстановления движение начнётся от фактического положения.
+func (c *Controller) fail(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.status.Connected || c.status.Error != err.Error() {
+		slog.Warn("rotator is unavailable", "error", err)
+	}
+	c.status.Connected = false
+	c.status.Actual = nil
+	c.status.Error = err.Error()
+	c.status.Updated = c.wall()
+	c.command, c.sent = nil, nil
+}
@@ -0,0 +1,338 @@
+package rotator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tracking"
+)
+
+var testEpoch = time.Date(2008, 9, 20, 12, 0, 0, 0, time.UTC)
+
+// fakeTracker возвращает направление на спутник look в момент testEpoch,
+// меняющееся на drift за секунду; пролёт идёт в окне [aos, los],
+// нулевой aos — пролёт не найден.
+type fakeTracker struct {
+	look     orbit.Look
+	drift    orbit.Look
+	aos, los time.Time
+	err      error
+}
+
+// passing возвращает трекер пролёта, идущего в момент testEpoch.
+func passing(az, el float64) *fakeTracker {
+	return &fakeTracker{
+		look: orbit.Look{Azimuth: az, Elevation: el},
+		aos:  testEpoch.Add(-time.Minute),
+		los:  testEpoch.Add(10 * time.Minute),
+	}
+}
+
+func (f *fakeTracker) Snapshot(noradID int, at time.Time) (tracking.Snapshot, error) {
+	if f.err != nil {
+		return tracking.Snapshot{}, f.err
+	}
+	look, _ := f.Look(noradID, at)
+	snap := tracking.Snapshot{Time: at, NoradID: noradID, Look: look}
+	if !f.aos.IsZero() {
+		snap.Visible = !at.Before(f.aos) && at.Before(f.los)
+		snap.NextAOS, snap.NextLOS = &f.aos, &f.los
+	}
+	return snap, nil
+}
+
+func (f *fakeTracker) Look(_ int, at time.Time) (orbit.Look, error) {
+	dt := at.Sub(testEpoch).Seconds()
+	return orbit.Look{
+		Azimuth:   f.look.Azimuth + f.drift.Azimuth*dt,
+		Elevation: f.look.Elevation + f.drift.Elevation*dt,
+	}, f.err
+}
+
+// newTestController создаёт контроллер, у которого модельное и реальное
+// время совпадают и управляются тестом.
+func newTestController(t *testing.T, fake *fakeRotctld, tracker Tracker) (*Controller, *time.Time) {
+	t.Helper()
+
+	client := NewClient(fake.addr())
+	t.Cleanup(func() { client.Close() })
+
+	wall := testEpoch
+	now := func() time.Time { return wall }
+	ctrl, err := NewController(client, tracker, now, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl.wall = now
+	return ctrl, &wall
+}
+
+func TestController_Stopped(t *testing.T) {
+	fake := newFakeRotctld(t)
+	fake.setPosition(Position{Azimuth: 42, Elevation: 7})
+	ctrl, _ := newTestController(t, fake, &fakeTracker{})
+
+	ctrl.step(context.Background())
+
+	st := ctrl.Status()
+	if st.Mode != ModeStopped || !st.Connected {
+		t.Errorf("status = %+v", st)
+	}
+	if st.Actual == nil || *st.Actual != (Position{Azimuth: 42, Elevation: 7}) {
+		t.Errorf("actual = %v, want 42/7", st.Actual)
+	}
+	if got := fake.setPositions(); len(got) != 0 {
+		t.Errorf("stopped controller sent %q", got)
+	}
+}
+
+func TestController_TrackingSlew(t *testing.T) {
+	fake := newFakeRotctld(t)
+	tracker := passing(100, 50)
+	ctrl, wall := newTestController(t, fake, tracker)
+	ctx := context.Background()
+
+	ctrl.Track(25544)
+	want := []string{"P 6.00 3.00", "P 12.00 6.00", "P 24.00 12.00"}
+	steps := []time.Duration{0, time.Second, 2 * time.Second}
+	for _, d := range steps {
+		*wall = wall.Add(d)
+		ctrl.step(ctx)
+	}
+
+	if got := fake.setPositions(); strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+	st := ctrl.Status()
+	if st.Mode != ModeTracking || st.NoradID != 25544 {
+		t.Errorf("mode = %s/%d", st.Mode, st.NoradID)
+	}
+	if st.Target == nil || *st.Target != (Position{Azimuth: 100, Elevation: 50}) {
+		t.Errorf("target = %v", st.Target)
+	}
+	if st.Actual == nil || *st.Actual != (Position{Azimuth: 12, Elevation: 6}) {
+		t.Errorf("actual = %v, want position read before the last command", st.Actual)
+	}
+}
+
+func TestController_ReplansChangedPass(t *testing.T) {
+	fake := newFakeRotctld(t)
+	tracker := passing(100, 50)
+	ctrl, _ := newTestController(t, fake, tracker)
+
+	ctrl.Track(25544)
+	ctrl.step(context.Background())
+	if plan, ok := ctrl.Plan(); !ok || !plan.LOS.Equal(tracker.los) {
+		t.Fatalf("plan = %+v, %v", plan, ok)
+	}
+
+	// Маска горизонта сократила пролёт при том же AOS.
+	tracker.los = tracker.los.Add(-3 * time.Minute)
+	ctrl.step(context.Background())
+	if plan, ok := ctrl.Plan(); !ok || !plan.LOS.Equal(tracker.los) {
+		t.Errorf("plan LOS = %v, want %v", plan.LOS, tracker.los)
+	}
+}
+
+func TestController_Park(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*Controller)
+		tracker *fakeTracker
+	}{
+		{"park mode", func(c *Controller) { c.Park() }, &fakeTracker{}},
+		{"no pass", func(c *Controller) { c.Track(25544) }, &fakeTracker{look: orbit.Look{Azimuth: 200, Elevation: -10}}},
+		{"snapshot error", func(c *Controller) { c.Track(25544) }, &fakeTracker{err: errors.New("no TLE")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeRotctld(t)
+			fake.setPosition(Position{Azimuth: 3, Elevation: 2})
+			ctrl, _ := newTestController(t, fake, tt.tracker)
+
+			tt.setup(ctrl)
+			ctrl.step(context.Background())
+
+			if got := fake.setPositions(); len(got) != 1 || got[0] != "P 0.00 0.00" {
+				t.Errorf("commands = %q, want park", got)
+			}
+		})
+	}
+}
+
+func TestController_Tolerance(t *testing.T) {
+	fake := newFakeRotctld(t)
+	tracker := passing(100, 10)
+	tracker.drift = orbit.Look{Azimuth: 0.3, Elevation: 0.1}
+	fake.setPosition(Position{Azimuth: 100, Elevation: 10})
+	ctrl, wall := newTestController(t, fake, tracker)
+	ctx := context.Background()
+
+	// Смещение меньше допуска не порождает команду, большее — порождает.
+	ctrl.Track(25544)
+	for range 3 {
+		ctrl.step(ctx)
+		*wall = wall.Add(time.Second)
+	}
+
+	want := []string{"P 100.00 10.00", "P 100.60 10.20"}
+	if got := fake.setPositions(); strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
+
+func TestController_Preposition(t *testing.T) {
+	fake := newFakeRotctld(t)
+	tracker := passing(100, 0)
+	tracker.aos = testEpoch.Add(30 * time.Second)
+	ctrl, wall := newTestController(t, fake, tracker)
+	ctx := context.Background()
+
+	// Выход из стоянки в 100° занимает 17 с плюс запас 10 с: до AOS−27 с
+	// антенна стоит в стоянке, затем поворачивается к началу траектории.
+	ctrl.Track(25544)
+	ctrl.step(ctx)
+	*wall = wall.Add(5 * time.Second)
+	ctrl.step(ctx)
+
+	want := []string{"P 0.00 0.00", "P 12.00 0.00"}
+	if got := fake.setPositions(); strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+	plan, ok := ctrl.Plan()
+	if !ok || !plan.AOS.Equal(tracker.aos) || !plan.Preposition.Equal(testEpoch.Add(3*time.Second)) {
+		t.Errorf("plan = %v/%v, preposition %v", ok, plan.AOS, plan.Preposition)
+	}
+}
+
+func TestController_ConnectionLoss(t *testing.T) {
+	fake := newFakeRotctld(t)
+	tracker := passing(100, 50)
+	ctrl, wall := newTestController(t, fake, tracker)
+	ctx := context.Background()
+
+	ctrl.Track(25544)
+	ctrl.step(ctx)
+
+	fake.dropConnections()
+	*wall = wall.Add(time.Second)
+	ctrl.step(ctx)
+	st := ctrl.Status()
+	if st.Connected || st.Error == "" || st.Actual != nil {
+		t.Fatalf("status after disconnect = %+v", st)
+	}
+
+	// Устройство сдвинули вручную: движение продолжается от фактического положения.
+	fake.setPosition(Position{Azimuth: 50, Elevation: 20})
+	*wall = wall.Add(time.Second)
+	ctrl.step(ctx)
+	if st := ctrl.Status(); !st.Connected || st.Error != "" {
+		t.Fatalf("status after reconnect = %+v", st)
+	}
+	got := fake.setPositions()
+	if last := got[len(got)-1]; last != "P 56.00 23.00" {
+		t.Errorf("last command = %q, want P 56.00 23.00", last)
+	}
+}
+
+func TestController_Stop(t *testing.T) {
+	fake := newFakeRotctld(t)
+	ctrl, _ := newTestController(t, fake, &fakeTracker{})
+	ctx := context.Background()
+
+	ctrl.Park()
+	ctrl.step(ctx)
+	if err := ctrl.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	ctrl.step(ctx)
+
+	st := ctrl.Status()
+	if st.Mode != ModeStopped || st.Command != nil || st.Target != nil {
+		t.Errorf("status = %+v", st)
+	}
+	if got := fake.setPositions(); len(got) != 1 {
+		t.Errorf("commands = %q, want only the park command", got)
+	}
+}
+
+// slowRotator не отвечает на уставку до закрытия release.
+type slowRotator struct {
+	sent    chan Position
+	release chan struct{}
+}
+
+func (r *slowRotator) SetPosition(_ context.Context, p Position) error {
+	r.sent <- p
+	<-r.release
+	return nil
+}
+func (r *slowRotator) Position(context.Context) (Position, error) { return Position{}, nil }
+func (r *slowRotator) Stop(context.Context) error                 { return nil }
+
+func TestController_SlowRotator(t *testing.T) {
+	rot := &slowRotator{sent: make(chan Position, 1), release: make(chan struct{})}
+	ctrl, err := NewController(rot, &fakeTracker{}, func() time.Time { return testEpoch }, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl.Park()
+	stepped := make(chan struct{})
+	go func() {
+		ctrl.step(context.Background())
+		close(stepped)
+	}()
+	<-rot.sent
+
+	// Пока rotctld не ответил, состояние читается и режим меняется.
+	done := make(chan Status)
+	go func() {
+		_ = ctrl.Stop(context.Background())
+		done <- ctrl.Status()
+	}()
+	select {
+	case st := <-done:
+		if st.Mode != ModeStopped {
+			t.Errorf("mode = %s, want stopped", st.Mode)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Status() blocked by rotctld")
+	}
+
+	// Уставка такта, начатого до остановки, не записывается.
+	close(rot.release)
+	<-stepped
+	if st := ctrl.Status(); st.Command != nil || st.Target != nil {
+		t.Errorf("status after stop = %+v", st)
+	}
+}
+
+func TestController_Run(t *testing.T) {
+	fake := newFakeRotctld(t)
+	ctrl, _ := newTestController(t, fake, &fakeTracker{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ctrl.Run(ctx)
+		close(done)
+	}()
+
+	// Смена режима применяется сразу, не дожидаясь такта.
+	ctrl.Park()
+	deadline := time.Now().Add(time.Second)
+	for len(fake.setPositions()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("park command was not sent")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,206 @@
+package rotator
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// Параметры планирования траектории.
+const (
+	// PlanStep — шаг выборки траектории; между точками положение
+	// интерполируется линейно.
+	PlanStep = 2 * time.Second
+
+	// prepositionMargin — запас времени на выход в начальную точку до AOS.
+	prepositionMargin = 10 * time.Second
+)
+
+// ErrEmptyPass возвращается для пролёта с LOS не позже AOS.
+var ErrEmptyPass = errors.New("rotator: pass has no duration")
+
+// LookFunc возвращает направление на спутник в момент t.
+type LookFunc func(t time.Time) (orbit.Look, error)
+
+// PlanPoint — точка траектории: положение устройства и направление на
+// спутник в момент Time.
+type PlanPoint struct {
+	Time time.Time `json:"time"`
+	Position
+	Look Position `json:"look"`
+}
+
+// Plan — траектория устройства на один пролёт в координатах устройства:
+// азимут может выходить за [0, 360) в зоне перекрытия, угол места в режиме
+// переворота — превышать 90°.
+type Plan struct {
+	AOS time.Time `json:"aos"`
+	LOS time.Time `json:"los"`
+	// Preposition — момент начала выхода в начальную точку.
+	Preposition time.Time `json:"preposition"`
+	Flip        bool      `json:"flip"`
+	// Unwinds — число разворотов через упор; ноль, если пролёт удалось
+	// провести без размотки.
+	Unwinds int         `json:"unwinds"`
+	Points  []PlanPoint `json:"points"`
+}
+
+// Start возвращает начальное положение траектории.
+func (p Plan) Start() Position {
+	return p.Points[0].Position
+}
+
+// At возвращает положение устройства в момент t: до AOS — начальную
+// точку, после LOS — конечную.
+func (p Plan) At(t time.Time) Position {
+	i, found := slices.BinarySearchFunc(p.Points, t, func(pt PlanPoint, t time.Time) int {
+		return pt.Time.Compare(t)
+	})
+	switch {
+	case found:
+		return p.Points[i].Position
+	case i == 0:
+		return p.Points[0].Position
+	case i == len(p.Points):
+		return p.Points[len(p.Points)-1].Position
+	}
+
+	a, b := p.Points[i-1], p.Points[i]
+	f := float64(t.Sub(a.Time)) / float64(b.Time.Sub(a.Time))
+	return Position{
+		Azimuth:   a.Azimuth + f*(b.Azimuth-a.Azimuth),
+		Elevation: a.Elevation + f*(b.Elevation-a.Elevation),
+	}
+}
+
+// PlanPass рассчитывает траекторию на пролёт [aos, los] для устройства,
+// стоящего перед пролётом в положении from.
+//
+// Азимут спутника развёртывается в непрерывную кривую и сдвигается на
+// 360°·k так, чтобы весь пролёт уместился в диапазон устройства; из
+// допустимых сдвигов выбирается ближайший к from. Если пролёт не
+// умещается (например, пересекает север при диапазоне 0–360°), а режим
+// переворота разрешён, используется переворот. Если не помогает и он,
+// траектория строится по ближайшему допустимому азимуту с разворотами
+// через упор, число которых отражается в Unwinds.
+func (c Config) PlanPass(look LookFunc, aos, los time.Time, from Position) (Plan, error) {
+	if !los.After(aos) {
+		return Plan{}, ErrEmptyPass
+	}
+
+	var looks []PlanPoint
+	for t := aos; ; t = t.Add(PlanStep) {
+		if t.After(los) {
+			t = los
+		}
+		l, err := look(t)
+		if err != nil {
+			return Plan{}, err
+		}
+		looks = append(looks, PlanPoint{Time: t, Look: Position{Azimuth: l.Azimuth, Elevation: l.Elevation}})
+		if t.Equal(los) {
+			break
+		}
+	}
+
+	plan := Plan{AOS: aos, LOS: los, Points: looks}
+	unwrapped := unwrapAzimuth(looks)
+	switch {
+	case c.fitPlan(plan.Points, unwrapped, from, false):
+	case c.Flip && c.fitPlan(plan.Points, unwrapped, from, true):
+		plan.Flip = true
+	default:
+		plan.Unwinds = c.greedyPlan(plan.Points, from)
+	}
+
+	lead := c.slewTime(from, plan.Start()) + prepositionMargin
+	plan.Preposition = aos.Add(-lead)
+	return plan, nil
+}
+
+// unwrapAzimuth возвращает азимуты спутника без скачков через 0°/360°.
+func unwrapAzimuth(points []PlanPoint) []float64 {
+	az := make([]float64, len(points))
+	az[0] = points[0].Look.Azimuth
+	for i := 1; i < len(points); i++ {
+		d := math.Mod(points[i].Look.Azimuth-points[i-1].Look.Azimuth, 360)
+		switch {
+		case d > 180:
+			d -= 360
+		case d < -180:
+			d += 360
+		}
+		az[i] = az[i-1] + d
+	}
+	return az
+}
+
+// fitPlan заполняет положения устройства, если развёрнутая траектория
+// (в режиме переворота — смещённая на 180°) умещается в диапазон азимута.
+func (c Config) fitPlan(points []PlanPoint, unwrapped []float64, from Position, flip bool) bool {
+	offset := 0.0
+	if flip {
+		offset = 180
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, az := range unwrapped {
+		lo, hi = math.Min(lo, az+offset), math.Max(hi, az+offset)
+	}
+
+	kMin := math.Ceil((c.MinAzimuth - lo) / 360)
+	kMax := math.Floor((c.MaxAzimuth - hi) / 360)
+	if kMin > kMax {
+		return false
+	}
+	// Из допустимых сдвигов выбирается ближайший к исходному положению.
+	start := unwrapped[0] + offset
+	k := math.Round((from.Azimuth - start) / 360)
+	k = math.Max(kMin, math.Min(kMax, k))
+
+	for i := range points {
+		el := points[i].Look.Elevation
+		if flip {
+			el = 180 - el
+		}
+		points[i].Position = Position{
+			Azimuth:   unwrapped[i] + offset + 360*k,
+			Elevation: math.Max(c.MinElevation, math.Min(c.MaxElevation, el)),
+		}
+	}
+	return true
+}
+
+// greedyPlan заполняет положения, выбирая на каждом шаге допустимый азимут,
+// ближайший к предыдущему, и возвращает число разворотов через упор.
+func (c Config) greedyPlan(points []PlanPoint, from Position) int {
+	unwinds := 0
+	prev := from
+	for i := range points {
+		pos := c.resolve(points[i].Look, prev)
+		if i > 0 && math.Abs(pos.Azimuth-prev.Azimuth) > 180 {
+			unwinds++
+		}
+		points[i].Position = pos
+		prev = pos
+	}
+	return unwinds
+}
+
+// SlewTime возвращает время перехода из положения from в направление to,
+// приведённое к диапазону устройства.
+func (c Config) SlewTime(from, to Position) time.Duration {
+	return c.slewTime(from, c.resolve(to, from))
+}
+
+// slewTime возвращает время перехода между положениями с предельными
+// скоростями осей.
+func (c Config) slewTime(from, to Position) time.Duration {
+	sec := math.Max(
+		math.Abs(to.Azimuth-from.Azimuth)/c.AzimuthRate,
+		math.Abs(to.Elevation-from.Elevation)/c.ElevationRate,
+	)
+	return time.Duration(sec * float64(time.Second)).Round(time.Second)
+}
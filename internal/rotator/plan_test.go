@@ -0,0 +1,201 @@
+package rotator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// arcPass возвращает пролёт длительностью 10 минут с азимутом, равномерно
+// меняющимся от az0 на sweep градусов, и кульминацией maxEl.
+func arcPass(az0, sweep, maxEl float64) (LookFunc, time.Time, time.Time) {
+	aos := testEpoch
+	los := aos.Add(10 * time.Minute)
+	look := func(t time.Time) (orbit.Look, error) {
+		f := t.Sub(aos).Seconds() / los.Sub(aos).Seconds()
+		az := math.Mod(az0+sweep*f, 360)
+		if az < 0 {
+			az += 360
+		}
+		return orbit.Look{Azimuth: az, Elevation: maxEl * math.Sin(math.Pi*f)}, nil
+	}
+	return look, aos, los
+}
+
+func TestConfig_PlanPass(t *testing.T) {
+	overlap := DefaultConfig()
+	overlap.MaxAzimuth = 450
+
+	flip := DefaultConfig()
+	flip.MaxElevation = 180
+	flip.Flip = true
+
+	tests := []struct {
+		name         string
+		cfg          Config
+		az0, sweep   float64
+		from         Position
+		wantFlip     bool
+		wantUnwinds  int
+		wantStart    float64 // азимут устройства в AOS
+		wantEnd      float64 // азимут устройства в LOS
+		wantMaxElev  float64
+		wantMinAzims float64 // нижняя граница азимутов траектории
+	}{
+		{"no north crossing", DefaultConfig(), 100, 100, Position{}, false, 0, 100, 200, 60, 100},
+		{"north crossing without overlap", DefaultConfig(), 300, 120, Position{}, false, 1, 300, 60, 60, 0},
+		{"north crossing with overlap", overlap, 300, 120, Position{}, false, 0, 300, 420, 60, 300},
+		{"westward crossing with overlap", overlap, 30, -60, Position{}, false, 0, 390, 330, 60, 330},
+		{"overlap nearest to park", overlap, 10, 70, Position{Azimuth: 400}, false, 0, 370, 440, 60, 370},
+		{"north crossing with flip", flip, 300, 120, Position{}, true, 0, 120, 240, 180, 120},
+		{"flip not needed", flip, 100, 100, Position{}, false, 0, 100, 200, 60, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			look, aos, los := arcPass(tt.az0, tt.sweep, 60)
+			plan, err := tt.cfg.PlanPass(look, aos, los, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Flip != tt.wantFlip || plan.Unwinds != tt.wantUnwinds {
+				t.Errorf("flip = %v, unwinds = %d; want %v, %d", plan.Flip, plan.Unwinds, tt.wantFlip, tt.wantUnwinds)
+			}
+
+			first, last := plan.Points[0], plan.Points[len(plan.Points)-1]
+			if !first.Time.Equal(aos) || !last.Time.Equal(los) {
+				t.Errorf("points span %v..%v, want %v..%v", first.Time, last.Time, aos, los)
+			}
+			if math.Abs(first.Azimuth-tt.wantStart) > 1e-6 || math.Abs(last.Azimuth-tt.wantEnd) > 1e-6 {
+				t.Errorf("azimuth %.2f..%.2f, want %.2f..%.2f", first.Azimuth, last.Azimuth, tt.wantStart, tt.wantEnd)
+			}
+
+			minAz, maxEl := math.Inf(1), math.Inf(-1)
+			for i, p := range plan.Points {
+				if !tt.cfg.inRange(p.Position) {
+					t.Fatalf("point %d %+v is outside the rotator limits", i, p.Position)
+				}
+				minAz, maxEl = math.Min(minAz, p.Azimuth), math.Max(maxEl, p.Elevation)
+			}
+			if math.Abs(minAz-tt.wantMinAzims) > 0.5 {
+				t.Errorf("min azimuth = %.2f, want %.2f", minAz, tt.wantMinAzims)
+			}
+			if math.Abs(maxEl-tt.wantMaxElev) > 0.1 && tt.wantMaxElev != 180 {
+				t.Errorf("max elevation = %.2f, want %.2f", maxEl, tt.wantMaxElev)
+			}
+		})
+	}
+}
+
+func TestConfig_PlanPassFlipElevation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxElevation = 180
+	cfg.Flip = true
+
+	look, aos, los := arcPass(300, 120, 60)
+	plan, err := cfg.PlanPass(look, aos, los, Position{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// В режиме переворота угол места отсчитывается через зенит:
+	// спутник на 60° соответствует 120° устройства.
+	mid := plan.At(aos.Add(5 * time.Minute))
+	if math.Abs(mid.Elevation-120) > 0.01 || math.Abs(mid.Azimuth-180) > 0.01 {
+		t.Errorf("culmination position = %+v, want az 180, el 120", mid)
+	}
+}
+
+func TestConfig_PlanPassPreposition(t *testing.T) {
+	cfg := DefaultConfig() // 6 °/с по азимуту
+	look, aos, los := arcPass(300, 120, 60)
+
+	tests := []struct {
+		name string
+		from Position
+		lead time.Duration
+	}{
+		// Выход из 0° в 300° занимает 50 с плюс запас 10 с.
+		{"from park", Position{}, 60 * time.Second},
+		{"already in place", Position{Azimuth: 300}, prepositionMargin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := cfg.PlanPass(look, aos, los, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := aos.Sub(plan.Preposition); got != tt.lead {
+				t.Errorf("preposition lead = %v, want %v", got, tt.lead)
+			}
+		})
+	}
+}
+
+func TestPlan_At(t *testing.T) {
+	plan := Plan{Points: []PlanPoint{
+		{Time: testEpoch, Position: Position{Azimuth: 350, Elevation: 0}},
+		{Time: testEpoch.Add(2 * time.Second), Position: Position{Azimuth: 370, Elevation: 10}},
+	}}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want Position
+	}{
+		{"before AOS", testEpoch.Add(-time.Minute), Position{Azimuth: 350, Elevation: 0}},
+		{"first point", testEpoch, Position{Azimuth: 350, Elevation: 0}},
+		{"interpolated across north", testEpoch.Add(time.Second), Position{Azimuth: 360, Elevation: 5}},
+		{"after LOS", testEpoch.Add(time.Minute), Position{Azimuth: 370, Elevation: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := plan.At(tt.at); got != tt.want {
+				t.Errorf("At() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_PlanPassErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	look, aos, los := arcPass(0, 90, 45)
+
+	if _, err := cfg.PlanPass(look, los, aos, Position{}); !errors.Is(err, ErrEmptyPass) {
+		t.Errorf("reversed pass error = %v, want ErrEmptyPass", err)
+	}
+
+	errProp := errors.New("propagation failed")
+	failing := func(time.Time) (orbit.Look, error) { return orbit.Look{}, errProp }
+	if _, err := cfg.PlanPass(failing, aos, los, Position{}); !errors.Is(err, errProp) {
+		t.Errorf("look error = %v, want %v", err, errProp)
+	}
+}
+
+func TestConfig_SlewTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxAz    float64
+		from, to Position
+		want     time.Duration
+	}{
+		{"azimuth", 360, Position{Azimuth: 0}, Position{Azimuth: 90}, 15 * time.Second},
+		{"elevation dominates", 360, Position{Azimuth: 0}, Position{Azimuth: 30, Elevation: 60}, 20 * time.Second},
+		{"no overlap goes the long way", 360, Position{Azimuth: 350}, Position{Azimuth: 10}, 57 * time.Second},
+		{"overlap takes the short way", 450, Position{Azimuth: 350}, Position{Azimuth: 10}, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MaxAzimuth = tt.maxAz
+			if got := cfg.SlewTime(tt.from, tt.to); got != tt.want {
+				t.Errorf("SlewTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package rotator управляет поворотным устройством антенны через Hamlib
+// rotctld: наведение на спутник во время пролёта с ограничением скорости
+// поворота, парковка и чтение фактического положения.
+package rotator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Параметры по умолчанию (поворотное устройство класса Yaesu G-5500).
+const (
+	DefaultAzimuthRate   = 6.0 // °/с
+	DefaultElevationRate = 3.0 // °/с
+	DefaultTolerance     = 0.5 // °
+	DefaultInterval      = time.Second
+)
+
+// ErrInvalidConfig возвращается при некорректных параметрах.
+var ErrInvalidConfig = errors.New("rotator: invalid config")
+
+// Position — направление антенны в градусах.
+type Position struct {
+	Azimuth   float64 `json:"azimuth"`
+	Elevation float64 `json:"elevation"`
+}
+
+// Config — параметры поворотного устройства.
+type Config struct {
+	// Диапазоны осей. Диапазон азимута может превышать 360°
+	// (например, [-180, 540]), тогда направление выбирается ближайшее
+	// к текущему.
+	MinAzimuth   float64 `json:"min_azimuth"`
+	MaxAzimuth   float64 `json:"max_azimuth"`
+	MinElevation float64 `json:"min_elevation"`
+	MaxElevation float64 `json:"max_elevation"`
+
+	// Flip разрешает режим переворота: азимут смещается на 180°, угол
+	// места отсчитывается через зенит (180° − el). Требует MaxElevation 180°.
+	Flip bool `json:"flip"`
+
+	// Наибольшая скорость поворота по осям, °/с.
+	AzimuthRate   float64 `json:"azimuth_rate"`
+	ElevationRate float64 `json:"elevation_rate"`
+
+	// Tolerance — наименьшее изменение уставки, ради которого
+	// отправляется команда, °.
+	Tolerance float64 `json:"tolerance"`
+
+	// Park — положение стоянки вне пролёта.
+	Park Position `json:"park"`
+
+	// Interval — период обновления уставки.
+	Interval time.Duration `json:"interval"`
+}
+
+// DefaultConfig возвращает параметры устройства с азимутом 0–360°
+// и углом места 0–90°.
+func DefaultConfig() Config {
+	return Config{
+		MinAzimuth:    0,
+		MaxAzimuth:    360,
+		MinElevation:  0,
+		MaxElevation:  90,
+		AzimuthRate:   DefaultAzimuthRate,
+		ElevationRate: DefaultElevationRate,
+		Tolerance:     DefaultTolerance,
+		Park:          Position{Azimuth: 0, Elevation: 0},
+		Interval:      DefaultInterval,
+	}
+}
+
+// Validate проверяет согласованность параметров.
+func (c Config) Validate() error {
+	switch {
+	case c.MaxAzimuth-c.MinAzimuth < 360 || c.MaxAzimuth-c.MinAzimuth > 720:
+		return fmt.Errorf("%w: azimuth range must span 360..720°", ErrInvalidConfig)
+	case c.MinElevation < -90 || c.MaxElevation > 180 || c.MinElevation >= c.MaxElevation:
+		return fmt.Errorf("%w: elevation range must lie within [-90, 180]", ErrInvalidConfig)
+	case c.Flip && c.MaxElevation < 180:
+		return fmt.Errorf("%w: flip mode requires elevation up to 180°", ErrInvalidConfig)
+	case c.AzimuthRate <= 0 || c.ElevationRate <= 0:
+		return fmt.Errorf("%w: slew rates must be positive", ErrInvalidConfig)
+	case c.Tolerance < 0:
+		return fmt.Errorf("%w: tolerance must not be negative", ErrInvalidConfig)
+	case c.Interval <= 0:
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidConfig)
+	case !c.inRange(c.Park):
+		return fmt.Errorf("%w: park position is outside the rotator limits", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func (c Config) inRange(p Position) bool {
+	return p.Azimuth >= c.MinAzimuth && p.Azimuth <= c.MaxAzimuth &&
+		p.Elevation >= c.MinElevation && p.Elevation <= c.MaxElevation
+}
+
+// resolve приводит направление на цель к диапазону устройства:
+// из представлений азимута az+360k выбирается допустимое и ближайшее
+// к текущему положению, угол места ограничивается диапазоном.
+func (c Config) resolve(target, current Position) Position {
+	az := math.Mod(target.Azimuth, 360)
+	if az < 0 {
+		az += 360
+	}
+	best, bestDist := math.NaN(), math.Inf(1)
+	for k := math.Floor((c.MinAzimuth - az) / 360); az+k*360 <= c.MaxAzimuth; k++ {
+		cand := az + k*360
+		if cand < c.MinAzimuth {
+			continue
+		}
+		if d := math.Abs(cand - current.Azimuth); d < bestDist {
+			best, bestDist = cand, d
+		}
+	}
+	return Position{
+		Azimuth:   best,
+		Elevation: math.Max(c.MinElevation, math.Min(c.MaxElevation, target.Elevation)),
+	}
+}
+
+// slew делает шаг от from к to не больше, чем позволяют скорости осей за dt.
+func (c Config) slew(from, to Position, dt time.Duration) Position {
+	step := func(a, b, rate float64) float64 {
+		limit := rate * dt.Seconds()
+		return a + math.Max(-limit, math.Min(limit, b-a))
+	}
+	return Position{
+		Azimuth:   step(from.Azimuth, to.Azimuth, c.AzimuthRate),
+		Elevation: step(from.Elevation, to.Elevation, c.ElevationRate),
+	}
+}
+
+// distance возвращает наибольшее расхождение по осям, °.
+func distance(a, b Position) float64 {
+	return math.Max(math.Abs(a.Azimuth-b.Azimuth), math.Abs(a.Elevation-b.Elevation))
+}
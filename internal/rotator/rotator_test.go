@@ -0,0 +1,93 @@
+package rotator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"azimuth range too small", func(c *Config) { c.MaxAzimuth = 270 }},
+		{"azimuth range too large", func(c *Config) { c.MinAzimuth, c.MaxAzimuth = -360, 450 }},
+		{"inverted elevation", func(c *Config) { c.MinElevation, c.MaxElevation = 90, 0 }},
+		{"elevation beyond flip", func(c *Config) { c.MaxElevation = 181 }},
+		{"flip without elevation range", func(c *Config) { c.Flip = true }},
+		{"zero azimuth rate", func(c *Config) { c.AzimuthRate = 0 }},
+		{"negative elevation rate", func(c *Config) { c.ElevationRate = -1 }},
+		{"negative tolerance", func(c *Config) { c.Tolerance = -0.1 }},
+		{"zero interval", func(c *Config) { c.Interval = 0 }},
+		{"park outside limits", func(c *Config) { c.Park = Position{Azimuth: 400, Elevation: 0} }},
+	}
+
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig().Validate() = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Validate() = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestConfig_Resolve(t *testing.T) {
+	overlap := DefaultConfig()
+	overlap.MaxAzimuth = 450
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		target  Position
+		current Position
+		want    Position
+	}{
+		{"inside range", DefaultConfig(), Position{120, 30}, Position{100, 10}, Position{120, 30}},
+		{"negative azimuth", DefaultConfig(), Position{-10, 30}, Position{0, 0}, Position{350, 30}},
+		{"elevation clamped", DefaultConfig(), Position{10, -5}, Position{0, 0}, Position{10, 0}},
+		{"no wrap without overlap", DefaultConfig(), Position{10, 0}, Position{350, 0}, Position{10, 0}},
+		{"overlap keeps direction", overlap, Position{10, 0}, Position{350, 0}, Position{370, 0}},
+		{"overlap returns to base", overlap, Position{80, 0}, Position{20, 0}, Position{80, 0}},
+		{"zero and full circle", DefaultConfig(), Position{360, 0}, Position{359, 0}, Position{360, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.resolve(tt.target, tt.current)
+			if math.Abs(got.Azimuth-tt.want.Azimuth) > 1e-9 || math.Abs(got.Elevation-tt.want.Elevation) > 1e-9 {
+				t.Errorf("resolve(%+v, %+v) = %+v, want %+v", tt.target, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Slew(t *testing.T) {
+	cfg := DefaultConfig() // 6 °/с по азимуту, 3 °/с по углу места
+
+	tests := []struct {
+		name     string
+		from, to Position
+		dt       time.Duration
+		want     Position
+	}{
+		{"both axes limited", Position{0, 0}, Position{100, 50}, time.Second, Position{6, 3}},
+		{"negative direction", Position{100, 50}, Position{0, 0}, 2 * time.Second, Position{88, 44}},
+		{"target reached", Position{10, 10}, Position{12, 11}, time.Second, Position{12, 11}},
+		{"zero interval", Position{10, 10}, Position{50, 50}, 0, Position{10, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.slew(tt.from, tt.to, tt.dt); got != tt.want {
+				t.Errorf("slew() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
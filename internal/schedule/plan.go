@@ -0,0 +1,204 @@
+package schedule
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/rotator"
+)
+
+// planTimeLayout — время пролёта в объяснениях плана (UTC).
+const planTimeLayout = "15:04:05"
+
+// Entry — пролёт отмеченного спутника в плане станции.
+type Entry struct {
+	NoradID   int    `json:"norad_id"`
+	Satellite string `json:"satellite"`
+	Priority  int    `json:"priority"`
+	// Pass — полезная часть пролёта: выше MinElevation спутника.
+	pass.Pass
+	// Score — вклад пролёта в оценку плана.
+	Score    float64 `json:"score"`
+	Selected bool    `json:"selected"`
+	// Reason — почему пролёт не вошёл в план.
+	Reason string `json:"reason,omitempty"`
+
+	mask  float64 // угол места на границах полезной части, °
+	fixed bool    // задание пролёта уже управляет станцией
+}
+
+// start и end — направления антенны в начале и в конце пролёта.
+func (e Entry) start() rotator.Position {
+	return rotator.Position{Azimuth: e.AOSAzimuth, Elevation: e.mask}
+}
+
+func (e Entry) end() rotator.Position {
+	return rotator.Position{Azimuth: e.LOSAzimuth, Elevation: e.mask}
+}
+
+// score — оценка пролёта: минуты полезного контакта, умноженные
+// на приоритет спутника плюс один.
+func score(e Entry) float64 {
+	return float64(e.Priority+1) * e.Duration().Minutes()
+}
+
+// Plan — план станции на окно прогноза: все пролёты отмеченных спутников,
+// упорядоченные по AOS, с отметкой выбранных.
+type Plan struct {
+	Created time.Time `json:"created"`
+	Entries []Entry   `json:"entries"`
+}
+
+// candidates возвращает пролёты спутника в окне прогноза от now. Пролёты
+// ниже MinElevation спутника и с контактом короче MinContactSeconds
+// получают причину исключения.
+func (s *Scheduler) candidates(sat catalog.Satellite, now time.Time) ([]Entry, error) {
+	set, _ := sat.LatestTLE()
+	prop, err := orbit.New(set.Elements())
+	if err != nil {
+		return nil, err
+	}
+	passes, err := s.predictor.Passes(prop, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Полезная часть пролёта — пролёт для маски спутника.
+	mask := s.predictor.Options().MinElevation
+	var useful []pass.Pass
+	if sat.MinElevation > mask {
+		opts := s.predictor.Options()
+		opts.MinElevation = sat.MinElevation
+		predictor, err := pass.NewPredictor(s.predictor.Observer(), opts)
+		if err != nil {
+			return nil, err
+		}
+		if useful, err = predictor.Passes(prop, now); err != nil {
+			return nil, err
+		}
+	}
+	minContact := time.Duration(sat.MinContactSeconds * float64(time.Second))
+
+	entries := make([]Entry, 0, len(passes))
+	for _, p := range passes {
+		e := Entry{NoradID: sat.NoradID, Satellite: sat.Name, Priority: sat.Priority, Pass: p, mask: mask}
+		if sat.MinElevation > mask {
+			i := slices.IndexFunc(useful, func(u pass.Pass) bool { return overlaps(u, p) })
+			if i < 0 {
+				e.Reason = fmt.Sprintf("max elevation %.1f° is below %.1f°", p.MaxElevation, sat.MinElevation)
+			} else {
+				e.Pass, e.mask = useful[i], sat.MinElevation
+			}
+		}
+		if e.Reason == "" && e.Duration() < minContact {
+			e.Reason = fmt.Sprintf("contact of %v above %.1f° is shorter than %v",
+				e.Duration().Round(time.Second), e.mask, minContact)
+		}
+		e.Score = score(e)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// resolve выбирает из пролётов без причины исключения набор
+// с наибольшей суммарной оценкой, в котором антенна успевает перейти
+// из конца каждого пролёта в начало следующего. Закреплённые пролёты
+// входят в план всегда. Остальные пролёты получают объяснение:
+// с каким выбранным пролётом они пересекаются.
+func resolve(entries []Entry, rot rotator.Config) Plan {
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if c := a.AOS.Compare(b.AOS); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.NoradID, b.NoradID)
+	})
+
+	var fixed []int
+	for i := range entries {
+		if entries[i].fixed {
+			entries[i].Selected = true
+			fixed = append(fixed, i)
+		}
+	}
+	var free []int
+	for i := range entries {
+		if entries[i].fixed || entries[i].Reason != "" {
+			continue
+		}
+		if k := slices.IndexFunc(fixed, func(f int) bool { return conflict(entries[f], entries[i], rot) }); k >= 0 {
+			entries[i].Reason = explain(entries[fixed[k]], entries[i], rot)
+			continue
+		}
+		free = append(free, i)
+	}
+
+	// Наибольшая оценка цепочки пролётов, заканчивающейся k-м свободным
+	// пролётом: пролёты упорядочены по AOS, поэтому цепочка допустима,
+	// если антенна успевает между каждой парой соседних пролётов.
+	best := make([]float64, len(free))
+	prev := make([]int, len(free))
+	last := -1
+	for k, i := range free {
+		best[k], prev[k] = entries[i].Score, -1
+		for m := range k {
+			if sum := best[m] + entries[i].Score; sum > best[k] && !conflict(entries[free[m]], entries[i], rot) {
+				best[k], prev[k] = sum, m
+			}
+		}
+		if last < 0 || best[k] > best[last] {
+			last = k
+		}
+	}
+	for k := last; k >= 0; k = prev[k] {
+		entries[free[k]].Selected = true
+	}
+
+	for _, i := range free {
+		if entries[i].Selected {
+			continue
+		}
+		k := slices.IndexFunc(entries, func(e Entry) bool { return e.Selected && conflict(e, entries[i], rot) })
+		if k < 0 {
+			// Не встречается при положительных оценках: такой пролёт
+			// увеличил бы оценку плана.
+			entries[i].Reason = "lower plan score"
+			continue
+		}
+		entries[i].Reason = explain(entries[k], entries[i], rot)
+	}
+	return Plan{Entries: entries}
+}
+
+// conflict сообщает, что антенна не может принять оба пролёта.
+func conflict(a, b Entry, rot rotator.Config) bool {
+	if b.AOS.Before(a.AOS) {
+		a, b = b, a
+	}
+	return a.LOS.Add(rot.SlewTime(a.end(), b.start())).After(b.AOS)
+}
+
+// explain объясняет исключение пролёта dropped выбранным пролётом kept.
+func explain(kept, dropped Entry, rot rotator.Config) string {
+	when := kept.AOS.UTC().Format(planTimeLayout)
+	if overlaps(kept.Pass, dropped.Pass) {
+		return fmt.Sprintf("overlaps %s pass at %s UTC (priority %d)", kept.Satellite, when, kept.Priority)
+	}
+	first, second := kept, dropped
+	if second.AOS.Before(first.AOS) {
+		first, second = second, first
+	}
+	gap := second.AOS.Sub(first.LOS).Round(time.Second)
+	slew := rot.SlewTime(first.end(), second.start())
+	return fmt.Sprintf("gap of %v to %s pass at %s UTC (priority %d) is shorter than rotator slew of %v",
+		gap, kept.Satellite, when, kept.Priority, slew)
+}
+
+// overlaps сообщает, что пролёты пересекаются по времени.
+func overlaps(a, b pass.Pass) bool {
+	return a.AOS.Before(b.LOS) && b.AOS.Before(a.LOS)
+}
@@ -0,0 +1,162 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/rotator"
+)
+
+// planStart — начало окна плана в тестах.
+var planStart = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// entry создаёт пролёт спутника name от from до to (минуты от planStart)
+// с азимутами начала и конца.
+func entry(name string, priority int, from, to float64, aosAz, losAz float64) Entry {
+	at := func(min float64) time.Time { return planStart.Add(time.Duration(min * float64(time.Minute))) }
+	e := Entry{
+		NoradID:   int(name[0]),
+		Satellite: name,
+		Priority:  priority,
+		Pass:      pass.Pass{AOS: at(from), LOS: at(to), AOSAzimuth: aosAz, LOSAzimuth: losAz},
+	}
+	e.Score = score(e)
+	return e
+}
+
+func TestResolve(t *testing.T) {
+	overlap := rotator.DefaultConfig()
+	overlap.MaxAzimuth = 450
+
+	fixed := entry("A", 0, 0, 10, 0, 0)
+	fixed.fixed = true
+	low := entry("L", 0, 0, 10, 0, 0)
+	low.Reason = "max elevation 12.0° is below 20.0°"
+
+	tests := []struct {
+		name     string
+		rot      rotator.Config
+		entries  []Entry
+		selected string
+		// dropped — ожидаемое начало причины исключения по спутникам.
+		dropped map[string]string
+	}{
+		{
+			name:     "priority outweighs longer contact",
+			entries:  []Entry{entry("A", 0, 0, 10, 0, 0), entry("B", 2, 5, 10, 0, 0)},
+			selected: "B",
+			dropped:  map[string]string{"A": "overlaps B pass at 12:05:00 UTC (priority 2)"},
+		},
+		{
+			name:     "two passes outweigh one of higher priority",
+			entries:  []Entry{entry("A", 0, 0, 10, 0, 0), entry("B", 1, 8, 20, 0, 0), entry("C", 0, 18, 33, 0, 0)},
+			selected: "AC",
+			dropped:  map[string]string{"B": "overlaps A pass"},
+		},
+		{
+			name:     "no time to slew the long way",
+			entries:  []Entry{entry("A", 0, 0, 10, 0, 350), entry("D", 0, 10.5, 15, 10, 90)},
+			selected: "A",
+			dropped:  map[string]string{"D": "gap of 30s to A pass at 12:00:00 UTC (priority 0) is shorter than rotator slew of 57s"},
+		},
+		{
+			name:     "overlap rotator slews the short way",
+			rot:      overlap,
+			entries:  []Entry{entry("A", 0, 0, 10, 0, 350), entry("D", 0, 10.5, 15, 10, 90)},
+			selected: "AD",
+		},
+		{
+			name:     "started job keeps the station",
+			entries:  []Entry{fixed, entry("B", 5, 5, 15, 0, 0)},
+			selected: "A",
+			dropped:  map[string]string{"B": "overlaps A pass"},
+		},
+		{
+			name:     "excluded pass keeps its reason",
+			entries:  []Entry{low, entry("C", 0, 20, 30, 0, 0)},
+			selected: "C",
+			dropped:  map[string]string{"L": "max elevation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rot := tt.rot
+			if rot.AzimuthRate == 0 {
+				rot = rotator.DefaultConfig()
+			}
+			plan := resolve(tt.entries, rot)
+
+			var selected string
+			for _, e := range plan.Entries {
+				if e.Selected {
+					selected += e.Satellite
+					if e.Reason != "" {
+						t.Errorf("selected %s has reason %q", e.Satellite, e.Reason)
+					}
+					continue
+				}
+				if want, ok := tt.dropped[e.Satellite]; !ok || !strings.HasPrefix(e.Reason, want) {
+					t.Errorf("%s reason = %q, want prefix %q", e.Satellite, e.Reason, want)
+				}
+			}
+			if selected != tt.selected {
+				t.Errorf("selected %q, want %q", selected, tt.selected)
+			}
+		})
+	}
+}
+
+func TestScheduler_PlanSettings(t *testing.T) {
+	s, _, _, store := newTestScheduler(t, "")
+	iss, err := store.Get(25544)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss.MinElevation, iss.MinContactSeconds = 30, 120
+	if err := store.Update(iss); err != nil {
+		t.Fatal(err)
+	}
+	copySat, err := store.Get(99998)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copySat.Schedule, copySat.Priority = true, 3
+	if err := store.Update(copySat); err != nil {
+		t.Fatal(err)
+	}
+
+	s.step()
+	plan := s.Plan()
+	jobs := s.Jobs()
+	selected, conflicts := 0, 0
+	for _, e := range plan.Entries {
+		switch {
+		case e.Selected:
+			selected++
+			if e.NoradID != 99998 {
+				t.Errorf("selected %s pass at %v, want copy with higher priority", e.Satellite, e.AOS)
+			}
+		case e.MaxElevation < 30:
+			if !strings.HasPrefix(e.Reason, "max elevation") {
+				t.Errorf("low pass reason = %q", e.Reason)
+			}
+		case e.Duration() < 120*time.Second:
+			if !strings.HasPrefix(e.Reason, "contact of") {
+				t.Errorf("short pass reason = %q", e.Reason)
+			}
+		case !strings.HasPrefix(e.Reason, "overlaps ISS COPY pass"):
+			t.Errorf("conflicting pass reason = %q", e.Reason)
+		default:
+			conflicts++
+		}
+	}
+	if conflicts == 0 {
+		t.Error("no ISS pass lost to the copy")
+	}
+	if selected == 0 || len(jobs) != selected {
+		t.Errorf("%d jobs for %d selected passes", len(jobs), selected)
+	}
+}
@@ -0,0 +1,169 @@
+// Package schedule автоматизирует станцию: по прогнозу пролётов
+// отмеченных спутников каталога создаёт задания и для каждого проводит
+// цикл — до AOS выводит антенну в начальную точку траектории, от AOS
+// до LOS сопровождает спутник, подстраивает радиостанцию, настраивает
+// приёмник и пишет отсчёты, после LOS останавливает запись и паркует
+// антенну.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/rotator"
+)
+
+// Параметры по умолчанию.
+const (
+	DefaultLead      = 2 * time.Minute
+	DefaultReplan    = 10 * time.Minute
+	DefaultRetention = 7 * 24 * time.Hour
+	DefaultInterval  = time.Second
+)
+
+// jobIDLayout — время AOS в идентификаторе задания.
+const jobIDLayout = "20060102T150405Z"
+
+// Ошибки расписания.
+var (
+	ErrInvalidConfig = errors.New("schedule: invalid config")
+	ErrNotFound      = errors.New("schedule: job not found")
+	ErrFinished      = errors.New("schedule: job is already finished")
+)
+
+// State — состояние задания.
+type State string
+
+// Состояния задания.
+const (
+	// StatePending — задание ждёт начала подготовки.
+	StatePending State = "pending"
+	// StatePreparing — антенна выводится в начальную точку до AOS.
+	StatePreparing State = "preparing"
+	// StateActive — идёт пролёт: сопровождение, приём и запись.
+	StateActive State = "active"
+	// StateCompleted — пролёт завершён, антенна запаркована.
+	StateCompleted State = "completed"
+	// StateCancelled — задание отменено оператором.
+	StateCancelled State = "cancelled"
+	// StateSkipped — станция занята другим пролётом.
+	StateSkipped State = "skipped"
+	// StateMissed — пролёт закончился до начала задания (станция была
+	// выключена или модельное время перескочило через пролёт).
+	StateMissed State = "missed"
+)
+
+// Finished сообщает, что задание больше не изменит состояние.
+func (s State) Finished() bool {
+	switch s {
+	case StateCompleted, StateCancelled, StateSkipped, StateMissed:
+		return true
+	}
+	return false
+}
+
+// holdsStation сообщает, что задание управляет оборудованием станции.
+func (s State) holdsStation() bool {
+	return s == StatePreparing || s == StateActive
+}
+
+// Job — задание на один пролёт спутника.
+type Job struct {
+	ID           string    `json:"id"`
+	NoradID      int       `json:"norad_id"`
+	Satellite    string    `json:"satellite"`
+	AOS          time.Time `json:"aos"`
+	TCA          time.Time `json:"tca"`
+	LOS          time.Time `json:"los"`
+	MaxElevation float64   `json:"max_elevation"`
+	Downlink     float64   `json:"downlink_mhz,omitempty"`
+	Uplink       float64   `json:"uplink_mhz,omitempty"`
+	State        State     `json:"state"`
+	// Recording — файл метаданных SigMF записи пролёта.
+	Recording string `json:"recording,omitempty"`
+	// Error — ошибки оборудования во время задания и причина пропуска.
+	Error   string    `json:"error,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// jobID формирует идентификатор задания.
+func jobID(noradID int, aos time.Time) string {
+	return fmt.Sprintf("%d-%s", noradID, aos.UTC().Format(jobIDLayout))
+}
+
+// Config — параметры расписания.
+type Config struct {
+	// Lead — за сколько до AOS антенна начинает выход в начальную точку.
+	Lead time.Duration
+	// Replan — период пересчёта прогноза пролётов.
+	Replan time.Duration
+	// Retention — сколько хранятся завершённые задания.
+	Retention time.Duration
+	// Interval — период проверки заданий.
+	Interval time.Duration
+	// Rotator — поворотное устройство: время перехода антенны между
+	// пролётами учитывается при выборе плана.
+	Rotator rotator.Config
+}
+
+// DefaultConfig возвращает параметры по умолчанию.
+func DefaultConfig() Config {
+	return Config{
+		Lead:      DefaultLead,
+		Replan:    DefaultReplan,
+		Retention: DefaultRetention,
+		Interval:  DefaultInterval,
+		Rotator:   rotator.DefaultConfig(),
+	}
+}
+
+// Validate проверяет параметры.
+func (c Config) Validate() error {
+	switch {
+	case c.Lead < 0:
+		return fmt.Errorf("%w: lead must not be negative", ErrInvalidConfig)
+	case c.Replan <= 0:
+		return fmt.Errorf("%w: replan period must be positive", ErrInvalidConfig)
+	case c.Retention <= 0:
+		return fmt.Errorf("%w: retention must be positive", ErrInvalidConfig)
+	case c.Interval <= 0:
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidConfig)
+	}
+	if err := c.Rotator.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// Antenna — поворотное устройство; реализуется rotator.Controller,
+// который в режиме сопровождения сам выходит в начальную точку пролёта.
+type Antenna interface {
+	Track(noradID int)
+	Park()
+}
+
+// Radio — доплеровская подстройка; реализуется rig.Tuner.
+type Radio interface {
+	Track(noradID int, downlink, uplink float64)
+	Stop()
+}
+
+// Tuner — перестраиваемый приёмник SDR; реализуется receiver.Receiver.
+type Tuner interface {
+	Tune(hz float64) error
+}
+
+// Recorder — запись отсчётов; реализуется receiver.Recorder.
+type Recorder interface {
+	Start(name string) error
+	Stop() (string, error)
+}
+
+// Devices — оборудование станции; отсутствующие устройства равны nil.
+type Devices struct {
+	Antenna  Antenna
+	Radio    Radio
+	Receiver Tuner
+	Recorder Recorder
+}
@@ -0,0 +1,513 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/atomicfile"
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/pass"
+)
+
+// fileFormat — содержимое файла заданий.
+type fileFormat struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// Scheduler ведёт задания: периодически пересчитывает прогноз пролётов
+// спутников с флагом Schedule, выбирает из пересекающихся пролётов план
+// с наибольшей оценкой и на каждом такте переводит задания по состояниям
+// в модельном времени. Станцией в каждый момент управляет не более
+// одного задания: пролёт, начавшийся, пока станция занята, пропускается.
+// Задания записываются в файл при каждом изменении. Команды оборудованию
+// выполняются вне блокировки заданий, поэтому медленное устройство
+// не задерживает чтение расписания.
+type Scheduler struct {
+	store   catalog.Store
+	devices Devices
+	path    string
+	now     func() time.Time
+	cfg     Config
+
+	devMu sync.Mutex // упорядочивает выполнение команд оборудованию
+
+	mu        sync.Mutex
+	predictor *pass.Predictor
+	jobs      map[string]*Job
+	planned   time.Time // модельное время последнего прогноза
+	current   Plan
+	dirty     bool
+	actions   []deviceAction // команды, ожидающие выполнения
+}
+
+// deviceAction — команда оборудованию для задания jobID. Ошибка
+// и путь записи, возвращаемые run, сохраняются в задании.
+type deviceAction struct {
+	jobID string
+	name  string
+	run   func() (recording string, err error)
+}
+
+// NewScheduler создаёт расписание для спутников каталога store;
+// now задаёт модельное время. Задания хранятся в файле path (пустая
+// строка — только в памяти); задания, прерванные перезапуском,
+// возобновляются, если пролёт ещё не закончился.
+func NewScheduler(store catalog.Store, predictor *pass.Predictor, devices Devices, path string, now func() time.Time, cfg Config) (*Scheduler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Scheduler{
+		store:     store,
+		predictor: predictor,
+		devices:   devices,
+		path:      path,
+		now:       now,
+		cfg:       cfg,
+		jobs:      make(map[string]*Job),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schedule: %w", err)
+	}
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode schedule %s: %w", path, err)
+	}
+	for _, job := range f.Jobs {
+		if job.State.holdsStation() {
+			job.State = StatePending
+		}
+		s.jobs[job.ID] = &job
+	}
+	return s, nil
+}
+
+// Jobs возвращает задания, упорядоченные по AOS.
+func (s *Scheduler) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.sorted() {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// Job возвращает задание по идентификатору.
+func (s *Scheduler) Job(id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return *job, nil
+}
+
+// Plan возвращает план станции, построенный при последнем пересчёте
+// прогноза.
+func (s *Scheduler) Plan() Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Plan{Created: s.current.Created, Entries: slices.Clone(s.current.Entries)}
+}
+
+// Cancel отменяет задание; если оно управляет станцией, запись
+// останавливается, а антенна паркуется. Отменённое задание не
+// создаётся заново при пересчёте прогноза.
+func (s *Scheduler) Cancel(id string) (Job, error) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return Job{}, ErrNotFound
+	}
+	if job.State.Finished() {
+		s.mu.Unlock()
+		return *job, ErrFinished
+	}
+	if job.State.holdsStation() {
+		s.release(job)
+	}
+	s.transition(job, StateCancelled, s.now())
+	s.save()
+	s.mu.Unlock()
+
+	s.runDevices()
+	return s.Job(id)
+}
+
+// SetPredictor заменяет прогноз пролётов, например при смене станции
+// или её маски горизонта; план пересчитывается на следующем такте.
+func (s *Scheduler) SetPredictor(predictor *pass.Predictor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.predictor = predictor
+	s.planned = time.Time{}
+}
+
+// Run выполняет такты с периодом Interval до отмены ctx.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.step()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step выполняет один такт: пересчёт прогноза по расписанию, переходы
+// заданий и удаление устаревших.
+func (s *Scheduler) step() {
+	s.mu.Lock()
+	now := s.now()
+	// Прогноз пересчитывается и после перехода модельного времени назад.
+	if s.planned.IsZero() || now.Sub(s.planned) >= s.cfg.Replan || now.Before(s.planned) {
+		s.replan(now)
+	}
+	s.advance(now)
+	s.prune(now)
+	if s.dirty {
+		s.save()
+	}
+	s.mu.Unlock()
+
+	s.runDevices()
+}
+
+// runDevices выполняет накопленные команды оборудованию в порядке
+// постановки без блокировки заданий и записывает их результаты
+// в задания.
+func (s *Scheduler) runDevices() {
+	s.devMu.Lock()
+	defer s.devMu.Unlock()
+
+	s.mu.Lock()
+	actions := s.actions
+	s.actions = nil
+	s.mu.Unlock()
+	if len(actions) == 0 {
+		return
+	}
+
+	type result struct {
+		recording string
+		err       error
+	}
+	results := make([]result, len(actions))
+	for i, a := range actions {
+		results[i].recording, results[i].err = a.run()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, a := range actions {
+		job, ok := s.jobs[a.jobID]
+		if !ok {
+			continue
+		}
+		r := results[i]
+		if r.err != nil {
+			s.addError(job, a.name, r.err)
+		}
+		if r.recording != "" {
+			job.Recording = r.recording
+			s.dirty = true
+		}
+	}
+	if s.dirty {
+		s.save()
+	}
+}
+
+// queue ставит команду оборудованию для задания job; вызывается
+// под блокировкой.
+func (s *Scheduler) queue(job *Job, name string, run func() (string, error)) {
+	s.actions = append(s.actions, deviceAction{jobID: job.ID, name: name, run: run})
+}
+
+// replan строит план станции по пролётам отмеченных спутников в окне
+// прогноза, создаёт задания для выбранных пролётов и удаляет ожидающие
+// задания, не вошедшие в план: спутник снят с расписания, прогноз
+// изменился с новым TLE или пролёт уступил более ценному.
+func (s *Scheduler) replan(now time.Time) {
+	sats, err := s.store.List()
+	if err != nil {
+		slog.Error("schedule planning failed", "error", err)
+		return
+	}
+	s.planned = now
+
+	byID := make(map[int]catalog.Satellite)
+	var entries []Entry
+	for _, sat := range sats {
+		if _, ok := sat.LatestTLE(); !sat.Schedule || !ok {
+			continue
+		}
+		satEntries, err := s.candidates(sat, now)
+		if err != nil {
+			slog.Warn("schedule planning failed", "norad_id", sat.NoradID, "error", err)
+			continue
+		}
+		byID[sat.NoradID] = sat
+		entries = append(entries, satEntries...)
+	}
+
+	// Начатое задание закрепляет свой пролёт; завершённые и отменённые
+	// задания не повторяются.
+	for i := range entries {
+		e := &entries[i]
+		job := s.match(e.NoradID, e.Pass)
+		switch {
+		case job == nil || job.State == StatePending:
+		case job.State.holdsStation():
+			e.fixed = true
+		case e.Reason == "":
+			e.Reason = "job is " + string(job.State)
+		}
+	}
+	s.current = resolve(entries, s.cfg.Rotator)
+	s.current.Created = now
+
+	seen := make(map[string]bool)
+	for _, e := range s.current.Entries {
+		if e.Selected {
+			seen[s.upsert(byID[e.NoradID], e.Pass, now)] = true
+		}
+	}
+	for id, job := range s.jobs {
+		if job.State == StatePending && !seen[id] && job.AOS.After(now) {
+			delete(s.jobs, id)
+			s.dirty = true
+		}
+	}
+}
+
+// match возвращает задание того же спутника, пересекающееся с пролётом
+// p по времени, или nil.
+func (s *Scheduler) match(noradID int, p pass.Pass) *Job {
+	for _, job := range s.jobs {
+		if job.NoradID == noradID && overlaps(p, pass.Pass{AOS: job.AOS, LOS: job.LOS}) {
+			return job
+		}
+	}
+	return nil
+}
+
+// upsert обновляет задание на пролёт p или создаёт новое и возвращает
+// его идентификатор. Задание того же спутника, пересекающееся с p
+// по времени, считается тем же пролётом; у начатых заданий время
+// не меняется.
+func (s *Scheduler) upsert(sat catalog.Satellite, p pass.Pass, now time.Time) string {
+	if job := s.match(sat.NoradID, p); job != nil {
+		if job.State == StatePending && (!job.AOS.Equal(p.AOS) || !job.LOS.Equal(p.LOS)) {
+			job.AOS, job.TCA, job.LOS, job.MaxElevation = p.AOS, p.TCA, p.LOS, p.MaxElevation
+			job.Downlink, job.Uplink = sat.Downlink, sat.Uplink
+			job.Updated = now
+			s.dirty = true
+		}
+		return job.ID
+	}
+
+	job := &Job{
+		ID:           jobID(sat.NoradID, p.AOS),
+		NoradID:      sat.NoradID,
+		Satellite:    sat.Name,
+		AOS:          p.AOS,
+		TCA:          p.TCA,
+		LOS:          p.LOS,
+		MaxElevation: p.MaxElevation,
+		Downlink:     sat.Downlink,
+		Uplink:       sat.Uplink,
+		State:        StatePending,
+		Updated:      now,
+	}
+	s.jobs[job.ID] = job
+	s.dirty = true
+	return job.ID
+}
+
+// advance переводит задания по состояниям на момент now.
+func (s *Scheduler) advance(now time.Time) {
+	jobs := s.sorted()
+
+	// Сначала освобождается станция: следующий пролёт может начаться
+	// на том же такте.
+	var holder *Job
+	for _, job := range jobs {
+		if !job.State.holdsStation() {
+			continue
+		}
+		if now.Before(job.LOS) {
+			holder = job
+			continue
+		}
+		state := StateCompleted
+		if job.State == StatePreparing {
+			state = StateMissed
+		}
+		s.release(job)
+		s.transition(job, state, now)
+	}
+
+	for _, job := range jobs {
+		if job.State != StatePending || now.Before(job.AOS.Add(-s.cfg.Lead)) {
+			continue
+		}
+		switch {
+		case !now.Before(job.LOS):
+			s.transition(job, StateMissed, now)
+		case holder == nil:
+			holder = job
+			if antenna := s.devices.Antenna; antenna != nil {
+				noradID := job.NoradID
+				s.queue(job, "track antenna", func() (string, error) {
+					antenna.Track(noradID)
+					return "", nil
+				})
+			}
+			s.transition(job, StatePreparing, now)
+		case !now.Before(job.AOS):
+			job.Error = "station is busy with " + holder.ID
+			s.transition(job, StateSkipped, now)
+		}
+	}
+
+	if holder != nil && holder.State == StatePreparing && !now.Before(holder.AOS) {
+		s.activate(holder)
+		s.transition(holder, StateActive, now)
+	}
+}
+
+// activate ставит команды настройки оборудования на пролёт.
+func (s *Scheduler) activate(job *Job) {
+	d := s.devices
+	noradID, downlink, uplink := job.NoradID, job.Downlink*1e6, job.Uplink*1e6
+	if d.Radio != nil {
+		s.queue(job, "track radio", func() (string, error) {
+			d.Radio.Track(noradID, downlink, uplink)
+			return "", nil
+		})
+	}
+	if d.Receiver != nil && downlink > 0 {
+		s.queue(job, "tune receiver", func() (string, error) {
+			return "", d.Receiver.Tune(downlink)
+		})
+	}
+	if d.Recorder != nil {
+		id := job.ID
+		s.queue(job, "start recording", func() (string, error) {
+			return "", d.Recorder.Start(id)
+		})
+	}
+}
+
+// release ставит команды остановки записи и подстройки и парковки
+// антенны.
+func (s *Scheduler) release(job *Job) {
+	d := s.devices
+	if d.Recorder != nil && job.State == StateActive {
+		s.queue(job, "stop recording", d.Recorder.Stop)
+	}
+	if d.Radio != nil && job.State == StateActive {
+		s.queue(job, "stop radio", func() (string, error) {
+			d.Radio.Stop()
+			return "", nil
+		})
+	}
+	if d.Antenna != nil {
+		s.queue(job, "park antenna", func() (string, error) {
+			d.Antenna.Park()
+			return "", nil
+		})
+	}
+}
+
+// transition меняет состояние задания.
+func (s *Scheduler) transition(job *Job, state State, now time.Time) {
+	slog.Info("pass job state changed", "id", job.ID, "from", job.State, "to", state)
+	job.State, job.Updated = state, now
+	s.dirty = true
+}
+
+// addError добавляет ошибку оборудования к заданию.
+func (s *Scheduler) addError(job *Job, action string, err error) {
+	slog.Warn("pass job device error", "id", job.ID, "action", action, "error", err)
+	msg := action + ": " + err.Error()
+	if job.Error != "" {
+		msg = job.Error + "; " + msg
+	}
+	job.Error = msg
+	s.dirty = true
+}
+
+// prune удаляет завершённые задания старше Retention.
+func (s *Scheduler) prune(now time.Time) {
+	for id, job := range s.jobs {
+		if job.State.Finished() && now.Sub(job.LOS) > s.cfg.Retention {
+			delete(s.jobs, id)
+			s.dirty = true
+		}
+	}
+}
+
+// sorted возвращает задания, упорядоченные по AOS.
+func (s *Scheduler) sorted() []*Job {
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	slices.SortFunc(jobs, func(a, b *Job) int {
+		if c := a.AOS.Compare(b.AOS); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return jobs
+}
+
+// save записывает задания в файл через временный файл и переименование.
+// Ошибка записи не останавливает расписание: задания остаются в памяти.
+func (s *Scheduler) save() {
+	s.dirty = false
+	if s.path == "" {
+		return
+	}
+
+	f := fileFormat{Jobs: make([]Job, 0, len(s.jobs))}
+	for _, job := range s.sorted() {
+		f.Jobs = append(f.Jobs, *job)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err == nil {
+		err = atomicfile.WriteFile(s.path, data)
+	}
+	if err != nil {
+		slog.Error("failed to save schedule", "path", s.path, "error", err)
+	}
+}
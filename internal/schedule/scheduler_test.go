@@ -0,0 +1,361 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+const (
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+// station записывает команды оборудованию.
+type station struct {
+	calls []string
+	files int
+}
+
+func (s *station) Track(noradID int) {
+	s.calls = append(s.calls, fmt.Sprintf("antenna track %d", noradID))
+}
+func (s *station) Park() { s.calls = append(s.calls, "antenna park") }
+func (s *station) Stop() { s.calls = append(s.calls, "radio stop") }
+
+func (s *station) Tune(hz float64) error {
+	s.calls = append(s.calls, fmt.Sprintf("tune %.0f", hz))
+	return nil
+}
+
+func (s *station) Start(name string) error {
+	s.calls = append(s.calls, "record "+name)
+	return nil
+}
+
+func (s *station) StopRecording() (string, error) {
+	s.calls = append(s.calls, "record stop")
+	s.files++
+	return fmt.Sprintf("rec-%d.sigmf-meta", s.files), nil
+}
+
+// radio и recorder разделяют методы Track и Stop станции.
+type radio struct{ *station }
+
+func (r radio) Track(noradID int, downlink, uplink float64) {
+	r.calls = append(r.calls, fmt.Sprintf("radio track %d %.0f %.0f", noradID, downlink, uplink))
+}
+
+type recorder struct{ *station }
+
+func (r recorder) Stop() (string, error) { return r.StopRecording() }
+
+// testClock — модельное время теста.
+type testClock struct{ t time.Time }
+
+func (c *testClock) Now() time.Time { return c.t }
+
+// newTestScheduler создаёт расписание для МКС и её копии с другим
+// номером NORAD: пролёты обоих спутников совпадают.
+func newTestScheduler(t *testing.T, path string) (*Scheduler, *station, *testClock, catalog.Store) {
+	t.Helper()
+
+	set, err := tle.ParseTLE("ISS", issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copySet := set
+	copySet.NoradID = 99998
+	store := catalog.NewMemoryStore()
+	for _, sat := range []catalog.Satellite{
+		{NoradID: 25544, Name: "ISS", Downlink: 145.8, Schedule: true, TLEHistory: []tle.ElementSet{set}},
+		{NoradID: 99998, Name: "ISS COPY", Downlink: 437.8, TLEHistory: []tle.ElementSet{copySet}},
+		{NoradID: 99999, Name: "NO TLE", Schedule: true},
+	} {
+		if err := store.Create(sat); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	observer := orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+	predictor, err := pass.NewPredictor(observer, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := &station{}
+	clock := &testClock{t: set.Epoch}
+	devices := Devices{Antenna: st, Radio: radio{st}, Receiver: st, Recorder: recorder{st}}
+	s, err := NewScheduler(store, predictor, devices, path, clock.Now, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, st, clock, store
+}
+
+// mustJob возвращает задание по идентификатору.
+func mustJob(t *testing.T, s *Scheduler, id string) Job {
+	t.Helper()
+	job, err := s.Job(id)
+	if err != nil {
+		t.Fatalf("Job(%s) error: %v", id, err)
+	}
+	return job
+}
+
+func TestScheduler_Lifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	s, st, clock, _ := newTestScheduler(t, path)
+
+	s.step()
+	jobs := s.Jobs()
+	if len(jobs) == 0 {
+		t.Fatal("no jobs planned")
+	}
+	for _, job := range jobs {
+		if job.NoradID != 25544 || job.State != StatePending {
+			t.Fatalf("planned %+v, want pending ISS jobs only", job)
+		}
+	}
+	first := jobs[0]
+
+	steps := []struct {
+		at    time.Time
+		state State
+		calls []string
+	}{
+		{first.AOS.Add(-DefaultLead - time.Second), StatePending, nil},
+		{first.AOS.Add(-DefaultLead), StatePreparing, []string{"antenna track 25544"}},
+		{first.AOS, StateActive, []string{"radio track 25544 145800000 0", "tune 145800000", "record " + first.ID}},
+		{first.TCA, StateActive, nil},
+		{first.LOS, StateCompleted, []string{"record stop", "radio stop", "antenna park"}},
+	}
+	for _, step := range steps {
+		clock.t = step.at
+		st.calls = nil
+		s.step()
+
+		if got := mustJob(t, s, first.ID).State; got != step.state {
+			t.Fatalf("at %v state = %s, want %s", step.at, got, step.state)
+		}
+		if !slices.Equal(st.calls, step.calls) {
+			t.Errorf("at %v calls = %q, want %q", step.at, st.calls, step.calls)
+		}
+	}
+	if got := mustJob(t, s, first.ID).Recording; got != "rec-1.sigmf-meta" {
+		t.Errorf("Recording = %q, want rec-1.sigmf-meta", got)
+	}
+
+	// Задания сохраняются между запусками.
+	restored, _, _, _ := newTestScheduler(t, path)
+	if got := mustJob(t, restored, first.ID); got.State != StateCompleted || got.Recording != "rec-1.sigmf-meta" {
+		t.Errorf("restored job = %+v", got)
+	}
+	if got := len(restored.Jobs()); got != len(s.Jobs()) {
+		t.Errorf("restored %d jobs, want %d", got, len(s.Jobs()))
+	}
+}
+
+func TestScheduler_ResumesAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	s, _, clock, _ := newTestScheduler(t, path)
+	s.step()
+	first := s.Jobs()[0]
+	clock.t = first.AOS
+	s.step()
+
+	// Перезапуск посреди пролёта: задание снова занимает станцию.
+	restored, st, clock, _ := newTestScheduler(t, path)
+	clock.t = first.TCA
+	restored.step()
+	if got := mustJob(t, restored, first.ID).State; got != StateActive {
+		t.Fatalf("state after restart = %s, want active", got)
+	}
+	want := []string{"antenna track 25544", "radio track 25544 145800000 0", "tune 145800000", "record " + first.ID}
+	if !slices.Equal(st.calls, want) {
+		t.Errorf("calls = %q, want %q", st.calls, want)
+	}
+}
+
+func TestScheduler_StationBusy(t *testing.T) {
+	// Пересекающиеся задания из файла: план таких не строит, но второе
+	// задание не должно перехватить станцию.
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	aos := time.Date(2008, 9, 20, 13, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(fileFormat{Jobs: []Job{
+		{ID: jobID(25544, aos), NoradID: 25544, AOS: aos, LOS: aos.Add(6 * time.Minute), State: StatePending},
+		{ID: jobID(99998, aos), NoradID: 99998, AOS: aos, LOS: aos.Add(6 * time.Minute), State: StatePending},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _, clock, _ := newTestScheduler(t, path)
+	clock.t = aos
+	s.step()
+
+	if got := mustJob(t, s, jobID(25544, aos)).State; got != StateActive {
+		t.Errorf("first job state = %s, want active", got)
+	}
+	busy := mustJob(t, s, jobID(99998, aos))
+	if busy.State != StateSkipped || busy.Error == "" {
+		t.Errorf("second job = %s (%q), want skipped with reason", busy.State, busy.Error)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	s, st, clock, _ := newTestScheduler(t, "")
+	s.step()
+	jobs := s.Jobs()
+	first, second := jobs[0], jobs[1]
+
+	clock.t = first.AOS
+	s.step()
+	st.calls = nil
+	job, err := s.Cancel(first.ID)
+	if err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+	if job.State != StateCancelled || job.Recording != "rec-1.sigmf-meta" {
+		t.Errorf("cancelled job = %+v", job)
+	}
+	if want := []string{"record stop", "radio stop", "antenna park"}; !slices.Equal(st.calls, want) {
+		t.Errorf("calls = %q, want %q", st.calls, want)
+	}
+	if _, err := s.Cancel(first.ID); !errors.Is(err, ErrFinished) {
+		t.Errorf("second Cancel() error = %v, want ErrFinished", err)
+	}
+	if _, err := s.Cancel("25544-unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Cancel(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	// Отменённое ожидающее задание не возвращается при пересчёте.
+	if _, err := s.Cancel(second.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.t = first.LOS.Add(DefaultReplan)
+	s.step()
+	if got := mustJob(t, s, second.ID).State; got != StateCancelled {
+		t.Errorf("state after replan = %s, want cancelled", got)
+	}
+}
+
+// slowTuner блокирует настройку приёмника до закрытия release.
+type slowTuner struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (t slowTuner) Tune(float64) error {
+	close(t.started)
+	<-t.release
+	return errors.New("tuner timeout")
+}
+
+func TestScheduler_DevicesOutsideLock(t *testing.T) {
+	s, _, clock, _ := newTestScheduler(t, "")
+	tuner := slowTuner{started: make(chan struct{}), release: make(chan struct{})}
+	s.devices.Receiver = tuner
+
+	s.step()
+	first := s.Jobs()[0]
+	clock.t = first.AOS
+	stepped := make(chan struct{})
+	go func() {
+		s.step()
+		close(stepped)
+	}()
+	<-tuner.started
+
+	// Пока приёмник не ответил, задания читаются без ожидания.
+	read := make(chan Job)
+	go func() { read <- mustJob(t, s, first.ID) }()
+	select {
+	case job := <-read:
+		if job.State != StateActive {
+			t.Errorf("state = %s, want %s", job.State, StateActive)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Job() blocked by device I/O")
+	}
+
+	close(tuner.release)
+	<-stepped
+	if got := mustJob(t, s, first.ID).Error; got != "tune receiver: tuner timeout" {
+		t.Errorf("Error = %q", got)
+	}
+}
+
+func TestScheduler_Replan(t *testing.T) {
+	s, _, clock, store := newTestScheduler(t, "")
+	s.step()
+	first := s.Jobs()[0]
+
+	// Модельное время перескочило через пролёт.
+	clock.t = first.LOS.Add(time.Second)
+	s.step()
+	if got := mustJob(t, s, first.ID).State; got != StateMissed {
+		t.Errorf("state = %s, want missed", got)
+	}
+
+	// Снятый с расписания спутник теряет ожидающие задания.
+	iss, err := store.Get(25544)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss.Schedule = false
+	if err := store.Update(iss); err != nil {
+		t.Fatal(err)
+	}
+	clock.t = clock.t.Add(DefaultReplan)
+	s.step()
+	for _, job := range s.Jobs() {
+		if job.State == StatePending {
+			t.Errorf("pending job %s left after unflagging", job.ID)
+		}
+	}
+
+	// Завершённые задания удаляются после Retention.
+	clock.t = first.LOS.Add(DefaultRetention + time.Minute)
+	s.step()
+	if _, err := s.Job(first.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Job() after retention error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"negative lead", func(c *Config) { c.Lead = -time.Second }},
+		{"zero replan", func(c *Config) { c.Replan = 0 }},
+		{"zero retention", func(c *Config) { c.Retention = 0 }},
+		{"zero interval", func(c *Config) { c.Interval = 0 }},
+	}
+
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig().Validate() error: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Validate() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
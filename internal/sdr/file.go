@@ -0,0 +1,195 @@
+package sdr
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Расширения файлов записи SigMF: метаданные и отсчёты.
+const (
+	sigmfMetaExt = ".sigmf-meta"
+	sigmfDataExt = ".sigmf-data"
+)
+
+// FileOptions — параметры воспроизведения записи. Для файлов SigMF
+// формат, частота дискретизации и центральная частота берутся из
+// метаданных, для остальных Format определяется по расширению, если
+// не задан, а SampleRate обязателен.
+type FileOptions struct {
+	Format     Format
+	SampleRate float64 // отсчётов/с
+	Frequency  float64 // центральная частота записи, Гц
+	// Loop — по окончании файла воспроизводить его сначала.
+	Loop bool
+	// Realtime — выдавать отсчёты не быстрее частоты дискретизации.
+	Realtime bool
+}
+
+// FileSource воспроизводит запись IQ из файла.
+type FileSource struct {
+	file   *os.File
+	reader *bufio.Reader
+	opts   FileOptions
+	raw    []byte
+	empty  bool // после перемотки не прочитано ни одного отсчёта
+
+	started time.Time // начало воспроизведения в реальном времени
+	played  int64     // выдано отсчётов с начала воспроизведения
+}
+
+// OpenFile открывает запись path: raw-файл cu8/cs16/cf32 (.cu8, .cs16,
+// .cf32, .cfile) или SigMF (.sigmf-meta либо .sigmf-data).
+func OpenFile(path string, opts FileOptions) (*FileSource, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == sigmfMetaExt || ext == sigmfDataExt {
+		base := strings.TrimSuffix(path, filepath.Ext(path))
+		meta, err := readSigMF(base + sigmfMetaExt)
+		if err != nil {
+			return nil, err
+		}
+		opts.Format, opts.SampleRate, opts.Frequency = meta.format, meta.sampleRate, meta.frequency
+		path = base + sigmfDataExt
+	}
+
+	if opts.Format == "" {
+		f, err := ParseFormat(strings.TrimPrefix(ext, "."))
+		if err != nil {
+			return nil, err
+		}
+		opts.Format = f
+	}
+	if opts.Format.SampleSize() == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, opts.Format)
+	}
+	if opts.SampleRate <= 0 {
+		return nil, ErrNoSampleRate
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSource{
+		file:   file,
+		reader: bufio.NewReaderSize(file, 64<<10),
+		opts:   opts,
+	}, nil
+}
+
+// Format возвращает формат отсчётов записи.
+func (s *FileSource) Format() Format {
+	return s.opts.Format
+}
+
+// SampleRate возвращает частоту дискретизации записи.
+func (s *FileSource) SampleRate() float64 {
+	return s.opts.SampleRate
+}
+
+// CenterFrequency возвращает центральную частоту записи.
+func (s *FileSource) CenterFrequency() float64 {
+	return s.opts.Frequency
+}
+
+// ReadIQ читает до len(buf) отсчётов. Неполный отсчёт в конце файла
+// отбрасывается.
+func (s *FileSource) ReadIQ(buf []complex64) (int, error) {
+	size := len(buf) * s.opts.Format.SampleSize()
+	if cap(s.raw) < size {
+		s.raw = make([]byte, size)
+	}
+	raw := s.raw[:size]
+
+	n, err := io.ReadFull(s.reader, raw)
+	count := s.opts.Format.decode(buf, raw[:n])
+	switch {
+	case err == nil || (count > 0 && errors.Is(err, io.ErrUnexpectedEOF)):
+		s.empty = false
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		// Запись без единого полного отсчёта не зацикливается.
+		if !s.opts.Loop || s.empty {
+			return 0, io.EOF
+		}
+		if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+			return 0, err
+		}
+		s.reader.Reset(s.file)
+		s.empty = true
+		return s.ReadIQ(buf)
+	default:
+		return count, err
+	}
+
+	s.pace(count)
+	return count, nil
+}
+
+// pace задерживает выдачу отсчётов до момента, соответствующего их
+// положению в записи, если включено воспроизведение в реальном времени.
+func (s *FileSource) pace(n int) {
+	if !s.opts.Realtime {
+		return
+	}
+	if s.started.IsZero() {
+		s.started = time.Now()
+	}
+	s.played += int64(n)
+	due := s.started.Add(time.Duration(float64(s.played) / s.opts.SampleRate * float64(time.Second)))
+	if wait := time.Until(due); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// Close закрывает файл записи.
+func (s *FileSource) Close() error {
+	return s.file.Close()
+}
+
+// sigmfMeta — поля метаданных SigMF, необходимые для воспроизведения.
+type sigmfMeta struct {
+	format     Format
+	sampleRate float64
+	frequency  float64
+}
+
+// readSigMF читает формат, частоту дискретизации и частоту первого
+// захвата из файла метаданных SigMF.
+func readSigMF(path string) (sigmfMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sigmfMeta{}, err
+	}
+	var doc struct {
+		Global struct {
+			Datatype    string  `json:"core:datatype"`
+			SampleRate  float64 `json:"core:sample_rate"`
+			NumChannels int     `json:"core:num_channels"`
+		} `json:"global"`
+		Captures []struct {
+			Frequency float64 `json:"core:frequency"`
+		} `json:"captures"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return sigmfMeta{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if doc.Global.NumChannels > 1 {
+		return sigmfMeta{}, fmt.Errorf("%w: %d channels", ErrUnsupportedFormat, doc.Global.NumChannels)
+	}
+
+	format, err := ParseFormat(doc.Global.Datatype)
+	if err != nil {
+		return sigmfMeta{}, err
+	}
+	meta := sigmfMeta{format: format, sampleRate: doc.Global.SampleRate}
+	if len(doc.Captures) > 0 {
+		meta.frequency = doc.Captures[0].Frequency
+	}
+	return meta, nil
+}
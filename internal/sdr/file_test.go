@@ -0,0 +1,193 @@
+package sdr
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeFile создаёт файл name во временном каталоге и возвращает путь.
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// cf32Samples кодирует отсчёты в формате cf32.
+func cf32Samples(samples ...complex64) []byte {
+	raw := make([]byte, 8*len(samples))
+	for i, s := range samples {
+		binary.LittleEndian.PutUint32(raw[8*i:], math.Float32bits(real(s)))
+		binary.LittleEndian.PutUint32(raw[8*i+4:], math.Float32bits(imag(s)))
+	}
+	return raw
+}
+
+// readAll читает из источника все отсчёты блоками по block.
+func readAll(t *testing.T, src IQSource, block int) []complex64 {
+	t.Helper()
+	var all []complex64
+	buf := make([]complex64, block)
+	for {
+		n, err := src.ReadIQ(buf)
+		all = append(all, buf[:n]...)
+		if errors.Is(err, io.EOF) {
+			return all
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOpenFile_Raw(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		path string
+		opts FileOptions
+		want []complex64
+	}{
+		{"cu8 by extension", writeFile(t, dir, "rec.cu8", []byte{255, 0, 0, 255, 255, 255}),
+			FileOptions{SampleRate: 48000}, []complex64{complex(1, -1), complex(-1, 1), complex(1, 1)}},
+		{"cf32 by extension", writeFile(t, dir, "rec.cfile", cf32Samples(0.5, -0.25i)),
+			FileOptions{SampleRate: 48000}, []complex64{0.5, -0.25i}},
+		{"explicit format", writeFile(t, dir, "rec.raw", []byte{0, 0x40, 0, 0xC0}),
+			FileOptions{Format: FormatCS16, SampleRate: 48000}, []complex64{complex(0.5, -0.5)}},
+		{"trailing partial sample", writeFile(t, dir, "partial.cu8", []byte{255, 255, 0}),
+			FileOptions{SampleRate: 48000}, []complex64{complex(1, 1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := OpenFile(tt.path, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+
+			got := readAll(t, src, 2)
+			if len(got) != len(tt.want) {
+				t.Fatalf("read %d samples, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("sample %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpenFile_SigMF(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "noaa.sigmf-data", cf32Samples(1, 1i, -1))
+	writeFile(t, dir, "noaa.sigmf-meta", []byte(`{
+		"global": {"core:datatype": "cf32_le", "core:sample_rate": 250000, "core:version": "1.0.0"},
+		"captures": [{"core:sample_start": 0, "core:frequency": 137100000}],
+		"annotations": []
+	}`))
+
+	// Открыть запись можно по любому из двух файлов.
+	for _, name := range []string{"noaa.sigmf-meta", "noaa.sigmf-data"} {
+		t.Run(name, func(t *testing.T) {
+			src, err := OpenFile(filepath.Join(dir, name), FileOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+
+			if src.Format() != FormatCF32 || src.SampleRate() != 250000 || src.CenterFrequency() != 137.1e6 {
+				t.Errorf("metadata = %s %v %v", src.Format(), src.SampleRate(), src.CenterFrequency())
+			}
+			if got := readAll(t, src, 8); len(got) != 3 || got[1] != 1i {
+				t.Errorf("samples = %v", got)
+			}
+		})
+	}
+}
+
+func TestOpenFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	cu8 := writeFile(t, dir, "rec.cu8", []byte{0, 0})
+	unknown := writeFile(t, dir, "rec.wav", []byte{0, 0})
+	writeFile(t, dir, "be.sigmf-meta", []byte(`{"global": {"core:datatype": "ci16_be", "core:sample_rate": 1}}`))
+	writeFile(t, dir, "broken.sigmf-meta", []byte(`{"global":`))
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    FileOptions
+		wantErr error
+	}{
+		{"no sample rate", cu8, FileOptions{}, ErrNoSampleRate},
+		{"unknown extension", unknown, FileOptions{SampleRate: 1}, ErrUnsupportedFormat},
+		{"unknown explicit format", cu8, FileOptions{Format: "cs8", SampleRate: 1}, ErrUnsupportedFormat},
+		{"big-endian SigMF", filepath.Join(dir, "be.sigmf-data"), FileOptions{}, ErrUnsupportedFormat},
+		{"missing file", filepath.Join(dir, "none.cu8"), FileOptions{SampleRate: 1}, os.ErrNotExist},
+		{"missing SigMF metadata", filepath.Join(dir, "none.sigmf-data"), FileOptions{}, os.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OpenFile(tt.path, tt.opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("OpenFile() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := OpenFile(filepath.Join(dir, "broken.sigmf-meta"), FileOptions{}); err == nil {
+		t.Error("OpenFile() with broken metadata succeeded")
+	}
+}
+
+func TestFileSource_Loop(t *testing.T) {
+	dir := t.TempDir()
+	src, err := OpenFile(writeFile(t, dir, "rec.cu8", []byte{255, 255, 0, 0}), FileOptions{SampleRate: 1000, Loop: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	buf := make([]complex64, 3)
+	for i := 0; i < 3; i++ {
+		n, err := src.ReadIQ(buf)
+		if err != nil || n == 0 {
+			t.Fatalf("read %d: %d, %v", i, n, err)
+		}
+	}
+
+	empty, err := OpenFile(writeFile(t, dir, "empty.cu8", nil), FileOptions{SampleRate: 1000, Loop: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer empty.Close()
+	if _, err := empty.ReadIQ(buf); !errors.Is(err, io.EOF) {
+		t.Errorf("empty looped file error = %v, want io.EOF", err)
+	}
+}
+
+func TestFileSource_Realtime(t *testing.T) {
+	dir := t.TempDir()
+	src, err := OpenFile(writeFile(t, dir, "rec.cu8", make([]byte, 2*100)), FileOptions{SampleRate: 1000, Realtime: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	// 100 отсчётов при 1000 отсчётов/с выдаются не быстрее чем за 100 мс.
+	start := time.Now()
+	readAll(t, src, 25)
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("playback took %v, want at least 100ms", elapsed)
+	}
+}
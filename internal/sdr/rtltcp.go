@@ -0,0 +1,246 @@
+package sdr
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// Команды протокола rtl_tcp: байт команды и параметр uint32 big-endian.
+const (
+	rtlCmdFrequency  = 0x01
+	rtlCmdSampleRate = 0x02
+	rtlCmdGainMode   = 0x03 // 0 — автоматическое усиление, 1 — ручное
+	rtlCmdGain       = 0x04 // десятые доли дБ
+	rtlCmdAGC        = 0x08 // АРУ демодулятора RTL2832
+)
+
+const (
+	// rtlMagic открывает заголовок, который rtl_tcp отправляет при подключении.
+	rtlMagic      = "RTL0"
+	rtlHeaderSize = 12
+
+	defaultDialTimeout = 5 * time.Second
+)
+
+// rtlTuners — названия микросхем тюнера по коду из заголовка rtl_tcp.
+var rtlTuners = map[uint32]string{
+	1: "E4000",
+	2: "FC0012",
+	3: "FC0013",
+	4: "FC2580",
+	5: "R820T",
+	6: "R828D",
+}
+
+// RTLConfig — начальные параметры приёмника rtl_tcp.
+type RTLConfig struct {
+	Frequency  float64 // центральная частота, Гц
+	SampleRate float64 // отсчётов/с
+	Gain       float64 // дБ; отрицательное — автоматическое усиление
+}
+
+// DefaultRTLConfig возвращает параметры для приёма в диапазоне 2 м:
+// 145,8 МГц, 1,024 Мотсч/с, автоматическое усиление.
+func DefaultRTLConfig() RTLConfig {
+	return RTLConfig{
+		Frequency:  145.8e6,
+		SampleRate: 1.024e6,
+		Gain:       -1,
+	}
+}
+
+// RTLTCP — клиент сервера rtl_tcp. Поток отсчётов в формате cu8 читается
+// через ReadIQ; команды настройки можно отправлять из других горутин.
+type RTLTCP struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	tunerType uint32
+	raw       []byte // буфер чтения; используется только в ReadIQ
+
+	mu   sync.Mutex
+	freq float64
+	rate float64
+	gain float64
+}
+
+// DialRTLTCP подключается к rtl_tcp по адресу "host:port", проверяет
+// заголовок и применяет параметры cfg.
+func DialRTLTCP(ctx context.Context, addr string, cfg RTLConfig) (*RTLTCP, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RTLTCP{conn: conn, reader: bufio.NewReaderSize(conn, 64<<10)}
+	if err := c.readHeader(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, apply := range []func() error{
+		func() error { return c.SetSampleRate(cfg.SampleRate) },
+		func() error { return c.SetFrequency(cfg.Frequency) },
+		func() error { return c.SetGain(cfg.Gain) },
+	} {
+		if err := apply(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// readHeader читает заголовок "RTL0", тип тюнера и число ступеней усиления.
+func (c *RTLTCP) readHeader(ctx context.Context) error {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+	var header [rtlHeaderSize]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return fmt.Errorf("read rtl_tcp header: %w", err)
+	}
+	if string(header[:4]) != rtlMagic {
+		return fmt.Errorf("%w: %q", ErrProtocol, header[:4])
+	}
+	c.tunerType = binary.BigEndian.Uint32(header[4:])
+	return c.conn.SetReadDeadline(time.Time{})
+}
+
+// TunerType возвращает название микросхемы тюнера из заголовка rtl_tcp.
+func (c *RTLTCP) TunerType() string {
+	if name, ok := rtlTuners[c.tunerType]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ReadIQ читает len(buf) отсчётов, ожидая их поступления.
+func (c *RTLTCP) ReadIQ(buf []complex64) (int, error) {
+	size := len(buf) * FormatCU8.SampleSize()
+	if cap(c.raw) < size {
+		c.raw = make([]byte, size)
+	}
+	raw := c.raw[:size]
+
+	n, err := io.ReadFull(c.reader, raw)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return FormatCU8.decode(buf, raw[:n]), err
+}
+
+// SampleRate возвращает установленную частоту дискретизации.
+func (c *RTLTCP) SampleRate() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+// CenterFrequency возвращает установленную центральную частоту.
+func (c *RTLTCP) CenterFrequency() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.freq
+}
+
+// Gain возвращает установленное усиление; отрицательное — автоматическое.
+func (c *RTLTCP) Gain() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gain
+}
+
+// SetFrequency перестраивает приёмник на hz.
+func (c *RTLTCP) SetFrequency(hz float64) error {
+	if hz <= 0 || hz > math.MaxUint32 {
+		return fmt.Errorf("sdr: invalid frequency %.0f Hz", hz)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.commandLocked(rtlCmdFrequency, uint32(math.Round(hz))); err != nil {
+		return err
+	}
+	c.freq = hz
+	return nil
+}
+
+// SetSampleRate задаёт частоту дискретизации.
+func (c *RTLTCP) SetSampleRate(rate float64) error {
+	if rate <= 0 || rate > math.MaxUint32 {
+		return fmt.Errorf("sdr: invalid sample rate %.0f", rate)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.commandLocked(rtlCmdSampleRate, uint32(math.Round(rate))); err != nil {
+		return err
+	}
+	c.rate = rate
+	return nil
+}
+
+// SetGain задаёт усиление тюнера, дБ; rtl_tcp выбирает ближайшую
+// поддерживаемую ступень. Отрицательное значение включает
+// автоматическое усиление.
+func (c *RTLTCP) SetGain(db float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if db < 0 {
+		if err := c.commandLocked(rtlCmdGainMode, 0); err != nil {
+			return err
+		}
+		c.gain = -1
+		return nil
+	}
+	if err := c.commandLocked(rtlCmdGainMode, 1); err != nil {
+		return err
+	}
+	if err := c.commandLocked(rtlCmdGain, uint32(math.Round(db*10))); err != nil {
+		return err
+	}
+	c.gain = db
+	return nil
+}
+
+// SetAGC включает или выключает АРУ демодулятора RTL2832.
+func (c *RTLTCP) SetAGC(on bool) error {
+	var v uint32
+	if on {
+		v = 1
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.commandLocked(rtlCmdAGC, v)
+}
+
+// commandLocked отправляет команду rtl_tcp. Вызывается с захваченным mu.
+func (c *RTLTCP) commandLocked(cmd byte, param uint32) error {
+	var msg [5]byte
+	msg[0] = cmd
+	binary.BigEndian.PutUint32(msg[1:], param)
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(defaultDialTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(msg[:]); err != nil {
+		return fmt.Errorf("rtl_tcp command 0x%02x: %w", cmd, err)
+	}
+	return nil
+}
+
+// Close закрывает соединение; ожидающий ReadIQ завершается ошибкой.
+func (c *RTLTCP) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,217 @@
+package sdr
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rtlCommand — команда, принятая фальшивым сервером rtl_tcp.
+type rtlCommand struct {
+	cmd   byte
+	param uint32
+}
+
+func (c rtlCommand) String() string {
+	return fmt.Sprintf("%02x:%d", c.cmd, c.param)
+}
+
+// fakeRTLTCP — сервер rtl_tcp: отправляет заголовок и отсчёты samples,
+// записывает принятые команды.
+type fakeRTLTCP struct {
+	ln      net.Listener
+	header  []byte
+	samples []byte
+
+	mu       sync.Mutex
+	commands []rtlCommand
+}
+
+func newFakeRTLTCP(t *testing.T, samples []byte) *fakeRTLTCP {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := make([]byte, rtlHeaderSize)
+	copy(header, rtlMagic)
+	binary.BigEndian.PutUint32(header[4:], 5)  // R820T
+	binary.BigEndian.PutUint32(header[8:], 29) // ступеней усиления
+
+	f := &fakeRTLTCP{ln: ln, header: header, samples: samples}
+	t.Cleanup(func() { ln.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeRTLTCP) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *fakeRTLTCP) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRTLTCP) handle(conn net.Conn) {
+	defer conn.Close()
+	f.mu.Lock()
+	header := f.header
+	f.mu.Unlock()
+	if _, err := conn.Write(header); err != nil {
+		return
+	}
+	go func() {
+		_, _ = conn.Write(f.samples)
+	}()
+
+	var msg [5]byte
+	for {
+		if _, err := io.ReadFull(conn, msg[:]); err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, rtlCommand{msg[0], binary.BigEndian.Uint32(msg[1:])})
+		f.mu.Unlock()
+	}
+}
+
+// setMagic подменяет сигнатуру заголовка для следующих подключений.
+func (f *fakeRTLTCP) setMagic(magic string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.header = append([]byte(magic), f.header[len(magic):]...)
+}
+
+// received ожидает n команд и возвращает их.
+func (f *fakeRTLTCP) received(t *testing.T, n int) []rtlCommand {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		f.mu.Lock()
+		got := append([]rtlCommand(nil), f.commands...)
+		f.mu.Unlock()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDialRTLTCP(t *testing.T) {
+	fake := newFakeRTLTCP(t, nil)
+
+	c, err := DialRTLTCP(context.Background(), fake.addr(), RTLConfig{Frequency: 437.8e6, SampleRate: 2.048e6, Gain: 29.7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	want := []rtlCommand{
+		{rtlCmdSampleRate, 2048000},
+		{rtlCmdFrequency, 437800000},
+		{rtlCmdGainMode, 1},
+		{rtlCmdGain, 297},
+	}
+	if got := fake.received(t, len(want)); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("commands = %v, want %v", got, want)
+	}
+	if c.TunerType() != "R820T" || c.CenterFrequency() != 437.8e6 || c.SampleRate() != 2.048e6 || c.Gain() != 29.7 {
+		t.Errorf("state = %s %v %v %v", c.TunerType(), c.CenterFrequency(), c.SampleRate(), c.Gain())
+	}
+}
+
+func TestRTLTCP_Commands(t *testing.T) {
+	fake := newFakeRTLTCP(t, nil)
+
+	c, err := DialRTLTCP(context.Background(), fake.addr(), DefaultRTLConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.SetFrequency(145.9e6); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetGain(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetAGC(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetFrequency(0); err == nil {
+		t.Error("SetFrequency(0) succeeded")
+	}
+
+	// Первые три команды — начальные параметры DefaultRTLConfig.
+	want := []rtlCommand{
+		{rtlCmdFrequency, 145900000},
+		{rtlCmdGainMode, 0},
+		{rtlCmdAGC, 1},
+	}
+	got := fake.received(t, 3+len(want))
+	if len(got) != 3+len(want) || fmt.Sprint(got[3:]) != fmt.Sprint(want) {
+		t.Errorf("commands = %v, want initial settings then %v", got, want)
+	}
+	if c.Gain() != -1 {
+		t.Errorf("Gain() = %v, want automatic", c.Gain())
+	}
+}
+
+func TestRTLTCP_ReadIQ(t *testing.T) {
+	fake := newFakeRTLTCP(t, []byte{255, 0, 0, 255, 255, 255})
+
+	c, err := DialRTLTCP(context.Background(), fake.addr(), DefaultRTLConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	buf := make([]complex64, 2)
+	if n, err := c.ReadIQ(buf); n != 2 || err != nil {
+		t.Fatalf("ReadIQ() = %d, %v", n, err)
+	}
+	if buf[0] != complex(1, -1) || buf[1] != complex(-1, 1) {
+		t.Errorf("samples = %v", buf)
+	}
+
+	// Остаток потока — неполный блок; после закрытия чтение завершается ошибкой.
+	c.Close()
+	if _, err := c.ReadIQ(buf); err == nil {
+		t.Error("ReadIQ() after Close succeeded")
+	}
+}
+
+func TestDialRTLTCP_Errors(t *testing.T) {
+	t.Run("bad header", func(t *testing.T) {
+		fake := newFakeRTLTCP(t, nil)
+		fake.setMagic("HTTP")
+		if _, err := DialRTLTCP(context.Background(), fake.addr(), DefaultRTLConfig()); !errors.Is(err, ErrProtocol) {
+			t.Errorf("DialRTLTCP() error = %v, want ErrProtocol", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr := ln.Addr().String()
+		ln.Close()
+		if _, err := DialRTLTCP(context.Background(), addr, DefaultRTLConfig()); err == nil {
+			t.Error("DialRTLTCP() to closed port succeeded")
+		}
+	})
+}
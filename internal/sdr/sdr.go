@@ -0,0 +1,135 @@
+// Package sdr предоставляет источники комплексных отсчётов (IQ):
+// сетевой приёмник rtl_tcp и воспроизведение записей cu8/cs16/cf32
+// и SigMF.
+package sdr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Ошибки источников.
+var (
+	ErrUnsupportedFormat = errors.New("sdr: unsupported sample format")
+	ErrNoSampleRate      = errors.New("sdr: sample rate is not set")
+	ErrProtocol          = errors.New("sdr: unexpected rtl_tcp header")
+)
+
+// IQSource — источник комплексных отсчётов. ReadIQ заполняет buf
+// нормированными к [-1, 1] отсчётами и возвращает их число; по окончании
+// данных возвращает io.EOF.
+type IQSource interface {
+	ReadIQ(buf []complex64) (int, error)
+	// SampleRate возвращает частоту дискретизации, отсчётов/с.
+	SampleRate() float64
+	// CenterFrequency возвращает центральную частоту, Гц; 0 — неизвестна.
+	CenterFrequency() float64
+	Close() error
+}
+
+// Tuner — источник с перестраиваемой частотой, усилением и частотой
+// дискретизации; реализуется RTLTCP.
+type Tuner interface {
+	IQSource
+	SetFrequency(hz float64) error
+	SetSampleRate(rate float64) error
+	// SetGain задаёт усиление, дБ; отрицательное значение включает
+	// автоматическую регулировку.
+	SetGain(db float64) error
+}
+
+// Format — формат отсчётов в потоке или файле.
+type Format string
+
+// Поддерживаемые форматы: компоненты I и Q чередуются.
+const (
+	// FormatCU8 — беззнаковые 8 бит со смещением 127,5 (rtl_tcp, rtl_sdr).
+	FormatCU8 Format = "cu8"
+	// FormatCS16 — знаковые 16 бит, little-endian.
+	FormatCS16 Format = "cs16"
+	// FormatCF32 — float32, little-endian (GNU Radio, SDR#).
+	FormatCF32 Format = "cf32"
+)
+
+// ParseFormat разбирает имя формата; принимаются также имена типов
+// SigMF ("ci16_le", "cf32_le") и расширение GNU Radio "cfile".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "cu8", "cu8_le":
+		return FormatCU8, nil
+	case "cs16", "ci16", "ci16_le", "cs16_le":
+		return FormatCS16, nil
+	case "cf32", "cf32_le", "cfile", "fc32":
+		return FormatCF32, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, s)
+}
+
+// SampleSize возвращает размер одного комплексного отсчёта в байтах.
+func (f Format) SampleSize() int {
+	switch f {
+	case FormatCU8:
+		return 2
+	case FormatCS16:
+		return 4
+	case FormatCF32:
+		return 8
+	}
+	return 0
+}
+
+// decode преобразует len(raw)/SampleSize() отсчётов raw в dst.
+func (f Format) decode(dst []complex64, raw []byte) int {
+	n := min(len(dst), len(raw)/f.SampleSize())
+	switch f {
+	case FormatCU8:
+		for i := range n {
+			dst[i] = complex((float32(raw[2*i])-127.5)/127.5, (float32(raw[2*i+1])-127.5)/127.5)
+		}
+	case FormatCS16:
+		for i := range n {
+			re := int16(binary.LittleEndian.Uint16(raw[4*i:]))
+			im := int16(binary.LittleEndian.Uint16(raw[4*i+2:]))
+			dst[i] = complex(float32(re)/32768, float32(im)/32768)
+		}
+	case FormatCF32:
+		for i := range n {
+			re := math.Float32frombits(binary.LittleEndian.Uint32(raw[8*i:]))
+			im := math.Float32frombits(binary.LittleEndian.Uint32(raw[8*i+4:]))
+			dst[i] = complex(re, im)
+		}
+	}
+	return n
+}
+
+// encode дописывает отсчёты src в формате f к dst; значения вне [-1, 1]
+// в целочисленных форматах ограничиваются.
+func (f Format) encode(dst []byte, src []complex64) []byte {
+	for _, s := range src {
+		re, im := real(s), imag(s)
+		switch f {
+		case FormatCU8:
+			dst = append(dst, quantize(re*127.5+127.5, 0, 255), quantize(im*127.5+127.5, 0, 255))
+		case FormatCS16:
+			dst = binary.LittleEndian.AppendUint16(dst, uint16(int16(clamp(re*32768, -32768, 32767))))
+			dst = binary.LittleEndian.AppendUint16(dst, uint16(int16(clamp(im*32768, -32768, 32767))))
+		case FormatCF32:
+			dst = binary.LittleEndian.AppendUint32(dst, math.Float32bits(re))
+			dst = binary.LittleEndian.AppendUint32(dst, math.Float32bits(im))
+		}
+	}
+	return dst
+}
+
+// quantize округляет v до байта в пределах [lo, hi].
+func quantize(v, lo, hi float32) byte {
+	return byte(clamp(v, lo, hi))
+}
+
+// clamp округляет v и ограничивает пределами [lo, hi].
+func clamp(v, lo, hi float32) float32 {
+	return min(max(float32(math.Round(float64(v))), lo), hi)
+}
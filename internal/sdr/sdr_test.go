@@ -0,0 +1,74 @@
+package sdr
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Format
+	}{
+		{"cu8", FormatCU8},
+		{"CS16", FormatCS16},
+		{"ci16_le", FormatCS16},
+		{"cf32_le", FormatCF32},
+		{"cfile", FormatCF32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if err != nil || got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+
+	for _, in := range []string{"", "ci16_be", "wav"} {
+		if _, err := ParseFormat(in); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("ParseFormat(%q) error = %v, want ErrUnsupportedFormat", in, err)
+		}
+	}
+}
+
+func TestFormat_Decode(t *testing.T) {
+	cs16 := make([]byte, 8)
+	binary.LittleEndian.PutUint16(cs16[0:], uint16(16384))
+	binary.LittleEndian.PutUint16(cs16[2:], 0x8000) // −32768
+	binary.LittleEndian.PutUint16(cs16[4:], 0)
+	binary.LittleEndian.PutUint16(cs16[6:], uint16(0xC000)) // −16384
+
+	cf32 := make([]byte, 8)
+	binary.LittleEndian.PutUint32(cf32[0:], math.Float32bits(0.25))
+	binary.LittleEndian.PutUint32(cf32[4:], math.Float32bits(-0.75))
+
+	tests := []struct {
+		name   string
+		format Format
+		raw    []byte
+		want   []complex64
+	}{
+		{"cu8", FormatCU8, []byte{255, 0, 127, 128}, []complex64{complex(1, -1), complex(-1.0/255, 1.0/255)}},
+		{"cs16", FormatCS16, cs16, []complex64{complex(0.5, -1), complex(0, -0.5)}},
+		{"cf32", FormatCF32, cf32, []complex64{complex(0.25, -0.75)}},
+		{"partial sample dropped", FormatCU8, []byte{255, 255, 0}, []complex64{complex(1, 1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := make([]complex64, 4)
+			n := tt.format.decode(dst, tt.raw)
+			if n != len(tt.want) {
+				t.Fatalf("decode() = %d samples, want %d", n, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if d := dst[i] - want; math.Abs(float64(real(d))) > 1e-6 || math.Abs(float64(imag(d))) > 1e-6 {
+					t.Errorf("sample %d = %v, want %v", i, dst[i], want)
+				}
+			}
+		})
+	}
+}
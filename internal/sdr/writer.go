@@ -0,0 +1,83 @@
+package sdr
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// sigmfDatatypes — имена форматов в метаданных SigMF.
+var sigmfDatatypes = map[Format]string{
+	FormatCU8:  "cu8",
+	FormatCS16: "ci16_le",
+	FormatCF32: "cf32_le",
+}
+
+// SigMFWriter записывает отсчёты в запись SigMF: метаданные пишутся
+// при создании, отсчёты дописываются в файл данных.
+type SigMFWriter struct {
+	file   *os.File
+	writer *bufio.Writer
+	format Format
+	raw    []byte
+}
+
+// CreateSigMF создаёт запись base.sigmf-meta и base.sigmf-data
+// с частотой дискретизации sampleRate, центральной частотой frequency
+// (Гц) и временем начала start. Запись воспроизводится OpenFile.
+func CreateSigMF(base string, format Format, sampleRate, frequency float64, start time.Time) (*SigMFWriter, error) {
+	datatype, ok := sigmfDatatypes[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if sampleRate <= 0 {
+		return nil, ErrNoSampleRate
+	}
+
+	meta := map[string]any{
+		"global": map[string]any{
+			"core:datatype":    datatype,
+			"core:sample_rate": sampleRate,
+			"core:version":     "1.0.0",
+			"core:recorder":    "satwatch-go",
+		},
+		"captures": []map[string]any{{
+			"core:sample_start": 0,
+			"core:frequency":    frequency,
+			"core:datetime":     start.UTC().Format(time.RFC3339Nano),
+		}},
+		"annotations": []any{},
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(base+sigmfMetaExt, data, 0o640); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(base + sigmfDataExt)
+	if err != nil {
+		return nil, err
+	}
+	return &SigMFWriter{
+		file:   file,
+		writer: bufio.NewWriterSize(file, 256<<10),
+		format: format,
+	}, nil
+}
+
+// WriteIQ дописывает отсчёты в файл данных.
+func (w *SigMFWriter) WriteIQ(samples []complex64) error {
+	w.raw = w.format.encode(w.raw[:0], samples)
+	_, err := w.writer.Write(w.raw)
+	return err
+}
+
+// Close сбрасывает буфер и закрывает файл данных.
+func (w *SigMFWriter) Close() error {
+	return errors.Join(w.writer.Flush(), w.file.Close())
+}
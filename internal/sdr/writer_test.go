@@ -0,0 +1,73 @@
+package sdr
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateSigMF(t *testing.T) {
+	samples := []complex64{1, 1i, -1, complex(0.5, -0.5), complex(2, -2)}
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format Format
+		tol    float64
+	}{
+		{FormatCU8, 1.0 / 127.5},
+		{FormatCS16, 1.0 / 32768},
+		{FormatCF32, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			base := filepath.Join(t.TempDir(), "pass")
+			w, err := CreateSigMF(base, tt.format, 48000, 145.8e6, start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.WriteIQ(samples[:2]); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.WriteIQ(samples[2:]); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			src, err := OpenFile(base+sigmfMetaExt, FileOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+			if src.Format() != tt.format || src.SampleRate() != 48000 || src.CenterFrequency() != 145.8e6 {
+				t.Errorf("metadata = %s %v %v", src.Format(), src.SampleRate(), src.CenterFrequency())
+			}
+
+			got := readAll(t, src, 3)
+			if len(got) != len(samples) {
+				t.Fatalf("read %d samples, want %d", len(got), len(samples))
+			}
+			for i, want := range samples {
+				// Целочисленные форматы ограничивают отсчёты пределами [-1, 1].
+				if tt.format != FormatCF32 {
+					want = complex(min(max(real(want), -1), 1), min(max(imag(want), -1), 1))
+				}
+				d := got[i] - want
+				if math.Abs(float64(real(d))) > tt.tol || math.Abs(float64(imag(d))) > tt.tol {
+					t.Errorf("sample %d = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+
+	if _, err := CreateSigMF(filepath.Join(t.TempDir(), "bad"), "wav", 48000, 0, start); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("CreateSigMF(wav) error = %v, want ErrUnsupportedFormat", err)
+	}
+	if _, err := CreateSigMF(filepath.Join(t.TempDir(), "bad"), FormatCU8, 0, 0, start); !errors.Is(err, ErrNoSampleRate) {
+		t.Errorf("CreateSigMF(rate 0) error = %v, want ErrNoSampleRate", err)
+	}
+}
@@ -0,0 +1,136 @@
+package sids
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxServerBody ограничивает размер запроса к Server.
+	maxServerBody = 64 << 10
+
+	// serverHistory — число последних кадров, хранимых Server.
+	serverHistory = 1000
+)
+
+// ErrInvalidForm возвращается Server для запроса без обязательных полей.
+var ErrInvalidForm = errors.New("sids: invalid form")
+
+// Received — кадр, принятый Server.
+type Received struct {
+	NoradID   int       `json:"norad_id"`
+	Source    string    `json:"source"`
+	Time      time.Time `json:"timestamp"`
+	Frame     string    `json:"frame"` // hex
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+}
+
+// Server — локальная замена приёмника SiDS: POST проверяет обязательные
+// поля запроса и отвечает 201, GET возвращает последние принятые кадры
+// в JSON.
+type Server struct {
+	mu     sync.Mutex
+	frames []Received
+}
+
+// NewServer создаёт пустой приёмник.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Frames возвращает принятые кадры в порядке приёма.
+func (s *Server) Frames() []Received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Received(nil), s.frames...)
+}
+
+// ServeHTTP обрабатывает запросы приёмника.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.Frames())
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, maxServerBody)
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rec, err := parseForm(r.PostForm)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		if len(s.frames) == serverHistory {
+			s.frames = s.frames[1:]
+		}
+		s.frames = append(s.frames, rec)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// parseForm проверяет поля запроса SiDS.
+func parseForm(form url.Values) (Received, error) {
+	get := func(key string) string { return strings.TrimSpace(form.Get(key)) }
+
+	var rec Received
+	var err error
+	if rec.NoradID, err = strconv.Atoi(get("noradID")); err != nil || rec.NoradID <= 0 {
+		return Received{}, fmt.Errorf("%w: noradID must be a positive integer", ErrInvalidForm)
+	}
+	if rec.Source = get("source"); rec.Source == "" {
+		return Received{}, fmt.Errorf("%w: source is required", ErrInvalidForm)
+	}
+	if rec.Time, err = time.Parse(TimestampLayout, get("timestamp")); err != nil {
+		if rec.Time, err = time.Parse(time.RFC3339, get("timestamp")); err != nil {
+			return Received{}, fmt.Errorf("%w: timestamp: %w", ErrInvalidForm, err)
+		}
+	}
+	rec.Frame = strings.ToUpper(get("frame"))
+	if data, err := hex.DecodeString(rec.Frame); err != nil || len(data) == 0 {
+		return Received{}, fmt.Errorf("%w: frame must be non-empty hex", ErrInvalidForm)
+	}
+	if get("locator") != locatorLongLat {
+		return Received{}, fmt.Errorf("%w: locator must be %q", ErrInvalidForm, locatorLongLat)
+	}
+	if rec.Longitude, err = parseHemisphere(get("longitude"), "E", "W", 180); err != nil {
+		return Received{}, err
+	}
+	if rec.Latitude, err = parseHemisphere(get("latitude"), "N", "S", 90); err != nil {
+		return Received{}, err
+	}
+	return rec, nil
+}
+
+// parseHemisphere разбирает координату вида "37.6173E".
+func parseHemisphere(raw, pos, neg string, limit float64) (float64, error) {
+	s, sign := raw, 1.0
+	switch {
+	case strings.HasSuffix(s, pos):
+		s = strings.TrimSuffix(s, pos)
+	case strings.HasSuffix(s, neg):
+		s, sign = strings.TrimSuffix(s, neg), -1
+	default:
+		return 0, fmt.Errorf("%w: coordinate %q must end with %s or %s", ErrInvalidForm, raw, pos, neg)
+	}
+	deg, err := strconv.ParseFloat(s, 64)
+	if err != nil || deg < 0 || deg > limit {
+		return 0, fmt.Errorf("%w: invalid coordinate %q", ErrInvalidForm, raw)
+	}
+	return sign * deg, nil
+}
@@ -0,0 +1,66 @@
+package sids
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	valid := testStation.Form(testFrame)
+
+	tests := []struct {
+		name       string
+		key, value string
+		wantStatus int
+	}{
+		{"valid", "", "", http.StatusCreated},
+		{"RFC 3339 timestamp", "timestamp", "2026-10-16T12:00:05Z", http.StatusCreated},
+		{"no NORAD ID", "noradID", "", http.StatusBadRequest},
+		{"no source", "source", "", http.StatusBadRequest},
+		{"bad timestamp", "timestamp", "yesterday", http.StatusBadRequest},
+		{"frame is not hex", "frame", "XYZ", http.StatusBadRequest},
+		{"empty frame", "frame", "", http.StatusBadRequest},
+		{"unknown locator", "locator", "qth", http.StatusBadRequest},
+		{"longitude without hemisphere", "longitude", "37.6", http.StatusBadRequest},
+		{"latitude out of range", "latitude", "95.0N", http.StatusBadRequest},
+	}
+
+	srv := NewServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := maps.Clone(valid)
+			if tt.key != "" {
+				form.Set(tt.key, tt.value)
+			}
+			body := strings.NewReader(form.Encode())
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	var frames []Received
+	if err := json.NewDecoder(w.Body).Decode(&frames); err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 || frames[1].Source != "R4UAB" {
+		t.Errorf("GET frames = %+v, want 2 accepted", frames)
+	}
+
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE status = %d, want 405", w.Code)
+	}
+}
@@ -0,0 +1,137 @@
+// Package sids передаёт принятые кадры в базу телеметрии SatNOGS DB
+// по протоколу SiDS (Simple Downlink Sharing Convention): очередь
+// отправки сохраняется на диск и переживает перезапуск, отправка
+// ограничена по частоте и повторяется при сбоях сети и сервера.
+// Server — локальная замена приёмника SiDS для стендов без доступа
+// в интернет.
+package sids
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultURL — приёмник телеметрии SatNOGS DB.
+const DefaultURL = "https://db.satnogs.org/api/telemetry/"
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+
+	// TimestampLayout — формат времени приёма кадра в SiDS (UTC).
+	TimestampLayout = "2006-01-02T15:04:05.000Z"
+
+	// locatorLongLat — единственный тип координат станции в SiDS.
+	locatorLongLat = "longLat"
+)
+
+// Ошибки отправки.
+var (
+	// ErrRejected — сервер отклонил кадр (4xx): повтор не поможет.
+	ErrRejected       = errors.New("sids: frame rejected")
+	ErrInvalidStation = errors.New("sids: invalid station")
+)
+
+// Station — принимающая станция.
+type Station struct {
+	Callsign  string
+	Latitude  float64 // градусы, север положительный
+	Longitude float64 // градусы, восток положительный
+}
+
+// Validate проверяет позывной и координаты станции.
+func (s Station) Validate() error {
+	switch {
+	case strings.TrimSpace(s.Callsign) == "":
+		return fmt.Errorf("%w: callsign is required", ErrInvalidStation)
+	case s.Latitude < -90 || s.Latitude > 90:
+		return fmt.Errorf("%w: latitude %v out of range", ErrInvalidStation, s.Latitude)
+	case s.Longitude < -180 || s.Longitude > 180:
+		return fmt.Errorf("%w: longitude %v out of range", ErrInvalidStation, s.Longitude)
+	}
+	return nil
+}
+
+// Frame — кадр для отправки: данные кадра AX.25 без FCS.
+type Frame struct {
+	NoradID int       `json:"norad_id"`
+	Time    time.Time `json:"time"`
+	Data    []byte    `json:"data"`
+}
+
+// Form возвращает поля запроса SiDS для кадра f, принятого станцией s.
+func (s Station) Form(f Frame) url.Values {
+	return url.Values{
+		"noradID":   {strconv.Itoa(f.NoradID)},
+		"source":    {s.Callsign},
+		"timestamp": {f.Time.UTC().Format(TimestampLayout)},
+		"frame":     {strings.ToUpper(hex.EncodeToString(f.Data))},
+		"locator":   {locatorLongLat},
+		"longitude": {hemisphere(s.Longitude, "E", "W")},
+		"latitude":  {hemisphere(s.Latitude, "N", "S")},
+	}
+}
+
+// hemisphere записывает координату в виде "37.6173E".
+func hemisphere(deg float64, pos, neg string) string {
+	suffix := pos
+	if deg < 0 {
+		suffix = neg
+	}
+	return strconv.FormatFloat(math.Abs(deg), 'f', 4, 64) + suffix
+}
+
+// Client отправляет кадры в приёмник SiDS.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient создаёт клиента приёмника url. Если client равен nil,
+// используется клиент с тайм-аутом по умолчанию.
+func NewClient(url string, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &Client{url: url, client: client}
+}
+
+// String возвращает URL приёмника.
+func (c *Client) String() string {
+	return c.url
+}
+
+// Submit отправляет кадр. Ответ 4xx (кроме 429) возвращается как
+// ErrRejected, остальные ошибки временные.
+func (c *Client) Submit(ctx context.Context, station Station, f Frame) error {
+	body := strings.NewReader(station.Form(f).Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s: %s", ErrRejected, c.url, resp.Status)
+	default:
+		return fmt.Errorf("sids: %s: unexpected status %s", c.url, resp.Status)
+	}
+}
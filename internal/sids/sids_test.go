@@ -0,0 +1,100 @@
+package sids
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testStation — станция в Москве.
+var testStation = Station{Callsign: "R4UAB", Latitude: 55.7558, Longitude: 37.6173}
+
+// testFrame — кадр, принятый в полдень.
+var testFrame = Frame{
+	NoradID: 44909,
+	Time:    time.Date(2026, 10, 16, 12, 0, 5, 250e6, time.UTC),
+	Data:    []byte{0x86, 0xA2, 0x40, 0x1c},
+}
+
+func TestStation_Form(t *testing.T) {
+	form := Station{Callsign: "R4UAB", Latitude: -33.5, Longitude: -70.25}.Form(testFrame)
+
+	want := map[string]string{
+		"noradID":   "44909",
+		"source":    "R4UAB",
+		"timestamp": "2026-10-16T12:00:05.250Z",
+		"frame":     "86A2401C",
+		"locator":   "longLat",
+		"longitude": "70.2500W",
+		"latitude":  "33.5000S",
+	}
+	for key, value := range want {
+		if got := form.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestStation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		station Station
+		wantErr bool
+	}{
+		{"valid", testStation, false},
+		{"no callsign", Station{Latitude: 55, Longitude: 37}, true},
+		{"latitude out of range", Station{Callsign: "R4UAB", Latitude: 91}, true},
+		{"longitude out of range", Station{Callsign: "R4UAB", Longitude: -181}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.station.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Submit(t *testing.T) {
+	standIn := NewServer()
+	srv := httptest.NewServer(standIn)
+	t.Cleanup(srv.Close)
+
+	if err := NewClient(srv.URL, nil).Submit(context.Background(), testStation, testFrame); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	frames := standIn.Frames()
+	if len(frames) != 1 {
+		t.Fatalf("received %d frames, want 1", len(frames))
+	}
+	got := frames[0]
+	if got.NoradID != 44909 || got.Source != "R4UAB" || got.Frame != "86A2401C" ||
+		!got.Time.Equal(testFrame.Time) || got.Latitude != 55.7558 || got.Longitude != 37.6173 {
+		t.Errorf("received %+v", got)
+	}
+
+	// Отказ приёмника отличается от временной ошибки.
+	tests := []struct {
+		status       int
+		wantRejected bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusBadGateway, false},
+	}
+	for _, tt := range tests {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		err := NewClient(failing.URL, nil).Submit(context.Background(), testStation, testFrame)
+		failing.Close()
+		if err == nil || errors.Is(err, ErrRejected) != tt.wantRejected {
+			t.Errorf("status %d: error = %v, want rejected %v", tt.status, err, tt.wantRejected)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package sids
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/atomicfile"
+)
+
+// Параметры по умолчанию.
+const (
+	DefaultInterval = time.Second
+	DefaultMaxQueue = 5000
+	DefaultRetryMin = 30 * time.Second
+	DefaultRetryMax = 30 * time.Minute
+)
+
+// inboxSize — число кадров, ожидающих постановки в очередь в Run.
+const inboxSize = 256
+
+// ErrInvalidOptions возвращается для некорректных параметров отправки.
+var ErrInvalidOptions = errors.New("sids: invalid options")
+
+// Options — параметры отправки.
+type Options struct {
+	// Interval — минимальный интервал между запросами к приёмнику.
+	Interval time.Duration
+	// MaxQueue ограничивает очередь: при переполнении отбрасываются
+	// самые старые кадры.
+	MaxQueue int
+	// RetryMin и RetryMax — границы экспоненциальной задержки повтора
+	// после временной ошибки.
+	RetryMin time.Duration
+	RetryMax time.Duration
+}
+
+// DefaultOptions возвращает параметры по умолчанию.
+func DefaultOptions() Options {
+	return Options{
+		Interval: DefaultInterval,
+		MaxQueue: DefaultMaxQueue,
+		RetryMin: DefaultRetryMin,
+		RetryMax: DefaultRetryMax,
+	}
+}
+
+// queued — кадр в очереди; seq отличает кадры при удалении головы.
+type queued struct {
+	seq   uint64
+	frame Frame
+}
+
+// Uploader отправляет кадры по очереди. Кадр удаляется из очереди
+// после успешной отправки или отказа приёмника; при временной ошибке
+// отправка повторяется с нарастающей задержкой. Кадры из Enqueue
+// ставятся в очередь пачками, а очередь записывается в файл
+// в отдельной горутине после изменений.
+type Uploader struct {
+	client  *Client
+	station Station
+	path    string
+	opts    Options
+
+	inbox   chan Frame
+	inboxed atomic.Int64 // кадры, принятые Enqueue и ещё не поставленные в очередь
+
+	mu    sync.Mutex
+	queue []queued
+	seq   uint64
+	wake  chan struct{} // очередь пополнилась
+	dirty chan struct{} // очередь изменилась после записи в файл
+}
+
+// NewUploader создаёт отправку кадров станции station через client.
+// Очередь хранится в файле path (пустая строка — только в памяти);
+// кадры, оставшиеся с прошлого запуска, загружаются из него.
+func NewUploader(client *Client, station Station, path string, opts Options) (*Uploader, error) {
+	if err := station.Validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case opts.Interval <= 0:
+		return nil, fmt.Errorf("%w: interval must be positive", ErrInvalidOptions)
+	case opts.MaxQueue <= 0:
+		return nil, fmt.Errorf("%w: queue size must be positive", ErrInvalidOptions)
+	case opts.RetryMin <= 0 || opts.RetryMax < opts.RetryMin:
+		return nil, fmt.Errorf("%w: retry delays must be positive and ordered", ErrInvalidOptions)
+	}
+
+	u := &Uploader{
+		client:  client,
+		station: station,
+		path:    path,
+		opts:    opts,
+		inbox:   make(chan Frame, inboxSize),
+		wake:    make(chan struct{}, 1),
+		dirty:   make(chan struct{}, 1),
+	}
+	if path == "" {
+		return u, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return u, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read upload queue: %w", err)
+	}
+	var frames []Frame
+	if err := json.Unmarshal(data, &frames); err != nil {
+		return nil, fmt.Errorf("decode upload queue %s: %w", path, err)
+	}
+	for _, f := range frames {
+		u.push(f)
+	}
+	return u, nil
+}
+
+// Pending возвращает число кадров, ожидающих отправки.
+func (u *Uploader) Pending() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.queue) + int(u.inboxed.Load())
+}
+
+// Enqueue передаёт кадр в очередь отправки. Не блокируется и не
+// обращается к диску: кадр ставится в очередь в Run. Если Run не
+// успевает принимать кадры, новый кадр отбрасывается.
+func (u *Uploader) Enqueue(f Frame) {
+	u.inboxed.Add(1)
+	select {
+	case u.inbox <- f:
+	default:
+		u.inboxed.Add(-1)
+		slog.Warn("upload inbox is full, frame dropped", "norad_id", f.NoradID)
+	}
+}
+
+// Run отправляет кадры до отмены ctx. Перед выходом очередь
+// записывается в файл.
+func (u *Uploader) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Go(func() { u.collect(ctx) })
+	if u.path != "" {
+		wg.Go(func() { u.save(ctx) })
+	}
+	u.send(ctx)
+	wg.Wait()
+}
+
+// collect переносит кадры из Enqueue в очередь: все накопившиеся кадры
+// добавляются за одну блокировку. При переполнении очереди
+// отбрасываются самые старые кадры.
+func (u *Uploader) collect(ctx context.Context) {
+	for {
+		var batch []Frame
+		select {
+		case <-ctx.Done():
+			return
+		case f := <-u.inbox:
+			batch = append(batch, f)
+		}
+	drain:
+		for len(batch) < inboxSize {
+			select {
+			case f := <-u.inbox:
+				batch = append(batch, f)
+			default:
+				break drain
+			}
+		}
+
+		u.mu.Lock()
+		for _, f := range batch {
+			if len(u.queue) >= u.opts.MaxQueue {
+				slog.Warn("upload queue is full, oldest frame dropped", "norad_id", u.queue[0].frame.NoradID)
+				u.queue = u.queue[1:]
+			}
+			u.push(f)
+		}
+		u.inboxed.Add(-int64(len(batch)))
+		u.mu.Unlock()
+
+		notify(u.dirty)
+		notify(u.wake)
+	}
+}
+
+// save записывает очередь в файл после изменений до отмены ctx
+// и перед выходом, если очередь изменилась.
+func (u *Uploader) save(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-u.dirty:
+				u.persist()
+			default:
+			}
+			return
+		case <-u.dirty:
+			u.persist()
+		}
+	}
+}
+
+// send отправляет кадры из головы очереди до отмены ctx.
+func (u *Uploader) send(ctx context.Context) {
+	var backoff time.Duration
+	for {
+		item, ok := u.head()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-u.wake:
+			}
+			continue
+		}
+
+		err := u.client.Submit(ctx, u.station, item.frame)
+		wait := u.opts.Interval
+		switch {
+		case err == nil:
+			backoff = 0
+			u.remove(item.seq)
+		case errors.Is(err, ErrRejected):
+			slog.Warn("frame rejected by SiDS server", "norad_id", item.frame.NoradID, "error", err)
+			u.remove(item.seq)
+		case ctx.Err() != nil:
+			return
+		default:
+			backoff = min(max(2*backoff, u.opts.RetryMin), u.opts.RetryMax)
+			wait = backoff
+			slog.Warn("frame upload failed, retrying",
+				"url", u.client.String(), "pending", u.Pending(), "retry_in", wait, "error", err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// push добавляет кадр в конец очереди; вызывается под блокировкой.
+func (u *Uploader) push(f Frame) {
+	u.seq++
+	u.queue = append(u.queue, queued{seq: u.seq, frame: f})
+}
+
+// head возвращает первый кадр очереди.
+func (u *Uploader) head() (queued, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.queue) == 0 {
+		return queued{}, false
+	}
+	return u.queue[0], true
+}
+
+// remove удаляет отправленный кадр, если он не был вытеснен из очереди
+// за время отправки.
+func (u *Uploader) remove(seq uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.queue) == 0 || u.queue[0].seq != seq {
+		return
+	}
+	u.queue = u.queue[1:]
+	notify(u.dirty)
+}
+
+// notify сигнализирует в канал с буфером 1, не блокируясь.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// persist записывает снимок очереди в файл вне блокировки; вызывается
+// только из save. Ошибка записи не останавливает отправку: очередь
+// остаётся в памяти.
+func (u *Uploader) persist() {
+	u.mu.Lock()
+	frames := make([]Frame, len(u.queue))
+	for i, item := range u.queue {
+		frames[i] = item.frame
+	}
+	u.mu.Unlock()
+
+	data, err := json.Marshal(frames)
+	if err == nil {
+		err = atomicfile.WriteFile(u.path, data)
+	}
+	if err != nil {
+		slog.Error("failed to save upload queue", "path", u.path, "error", err)
+	}
+}
@@ -0,0 +1,146 @@
+package sids
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastOptions — параметры для тестов без долгих ожиданий.
+func fastOptions() Options {
+	return Options{Interval: time.Millisecond, MaxQueue: 10, RetryMin: 5 * time.Millisecond, RetryMax: 20 * time.Millisecond}
+}
+
+// waitFor ждёт выполнения условия не дольше секунды.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUploader_RetriesAndRejects(t *testing.T) {
+	standIn := NewServer()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch n := calls.Add(1); {
+		case n <= 2:
+			// Приёмник недоступен: кадр остаётся в очереди.
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			standIn.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := NewUploader(NewClient(srv.URL, nil), testStation, "", fastOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go u.Run(ctx)
+
+	u.Enqueue(testFrame)
+	// Кадр с пустыми данными отклоняется приёмником и не блокирует очередь.
+	u.Enqueue(Frame{NoradID: 44909, Time: testFrame.Time})
+	second := testFrame
+	second.Time = second.Time.Add(time.Second)
+	u.Enqueue(second)
+
+	waitFor(t, "queue drained", func() bool { return u.Pending() == 0 })
+	if got := len(standIn.Frames()); got != 2 {
+		t.Errorf("accepted %d frames, want 2", got)
+	}
+	if got := calls.Load(); got != 5 {
+		t.Errorf("requests = %d, want 5 (2 failures, 2 accepted, 1 rejected)", got)
+	}
+}
+
+func TestUploader_PersistsQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	opts := fastOptions()
+	opts.MaxQueue = 3
+
+	// Приёмник недоступен: кадры сохраняются в файле.
+	offline, err := NewUploader(NewClient("http://127.0.0.1:1", nil), testStation, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offlineCtx, stop := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		offline.Run(offlineCtx)
+		close(stopped)
+	}()
+	for i := range 5 {
+		f := testFrame
+		f.Time = f.Time.Add(time.Duration(i) * time.Second)
+		offline.Enqueue(f)
+	}
+	waitFor(t, "frames queued", func() bool { return offline.Pending() == 3 })
+	// Очередь записывается в файл не позднее остановки Run.
+	stop()
+	<-stopped
+
+	standIn := NewServer()
+	srv := httptest.NewServer(standIn)
+	t.Cleanup(srv.Close)
+
+	online, err := NewUploader(NewClient(srv.URL, nil), testStation, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := online.Pending(); got != 3 {
+		t.Fatalf("restored %d frames, want 3", got)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go online.Run(ctx)
+
+	waitFor(t, "queue drained", func() bool { return online.Pending() == 0 })
+	frames := standIn.Frames()
+	if len(frames) != 3 || !frames[0].Time.Equal(testFrame.Time.Add(2*time.Second)) {
+		t.Errorf("received %+v, want 3 newest frames in order", frames)
+	}
+
+	restarted, err := NewUploader(NewClient(srv.URL, nil), testStation, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restarted.Pending(); got != 0 {
+		t.Errorf("pending after restart = %d, want 0", got)
+	}
+}
+
+func TestNewUploader_Errors(t *testing.T) {
+	client := NewClient(DefaultURL, nil)
+	tests := []struct {
+		name    string
+		station Station
+		opts    func(*Options)
+	}{
+		{"no callsign", Station{}, func(*Options) {}},
+		{"zero interval", testStation, func(o *Options) { o.Interval = 0 }},
+		{"zero queue", testStation, func(o *Options) { o.MaxQueue = 0 }},
+		{"retry range reversed", testStation, func(o *Options) { o.RetryMax = o.RetryMin / 2 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			if _, err := NewUploader(client, tt.station, "", opts); err == nil {
+				t.Error("NewUploader() succeeded, want error")
+			}
+		})
+	}
+}
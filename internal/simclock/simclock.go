@@ -0,0 +1,162 @@
+// Package simclock реализует общее модельное время сервера: реальное время
+// со смещением, множителем скорости и паузой. Все вычисления положения
+// и пролётов, а также потоки SSE читают время из одного Clock, поэтому
+// ускоренное воспроизведение или переход к пролёту согласованы во всех видах.
+package simclock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxRate — наибольший допустимый множитель скорости.
+const MaxRate = 1000
+
+// Ошибки управления часами.
+var (
+	ErrInvalidRate = fmt.Errorf("simclock: rate must be within (0, %d]", MaxRate)
+	ErrZeroTime    = errors.New("simclock: time is required")
+)
+
+// State — снимок состояния часов.
+type State struct {
+	Time   time.Time `json:"time"`
+	Rate   float64   `json:"rate"`
+	Paused bool      `json:"paused"`
+	// Offset — опережение модельного времени относительно реального, с.
+	Offset float64 `json:"offset_s"`
+}
+
+// Clock — модельные часы. Время идёт от опорной точки (base, anchor)
+// со скоростью rate: now = base + (wall - anchor) * rate.
+// Методы безопасны для конкурентного использования.
+type Clock struct {
+	wall func() time.Time
+
+	mu      sync.RWMutex
+	base    time.Time // модельное время в опорной точке
+	anchor  time.Time // реальное время в опорной точке
+	rate    float64
+	paused  bool
+	changed chan struct{}
+}
+
+// New создаёт часы, идущие вместе с реальным временем.
+func New() *Clock {
+	return newClock(time.Now)
+}
+
+func newClock(wall func() time.Time) *Clock {
+	now := wall()
+	return &Clock{
+		wall:    wall,
+		base:    now.UTC(),
+		anchor:  now,
+		rate:    1,
+		changed: make(chan struct{}),
+	}
+}
+
+// Now возвращает текущее модельное время (UTC).
+func (c *Clock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.timeAt(c.wall())
+}
+
+// State возвращает состояние часов.
+func (c *Clock) State() State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	wall := c.wall()
+	now := c.timeAt(wall)
+	return State{
+		Time:   now,
+		Rate:   c.rate,
+		Paused: c.paused,
+		Offset: now.Sub(wall).Seconds(),
+	}
+}
+
+// Changed возвращает канал, который закрывается при следующем изменении
+// скорости, паузы или перехода во времени.
+func (c *Clock) Changed() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.changed
+}
+
+// SetRate задаёт множитель скорости; текущее модельное время сохраняется.
+func (c *Clock) SetRate(rate float64) error {
+	if !(rate > 0 && rate <= MaxRate) {
+		return ErrInvalidRate
+	}
+	c.update(func(now time.Time) {
+		c.base = now
+		c.rate = rate
+	})
+	return nil
+}
+
+// Pause останавливает модельное время.
+func (c *Clock) Pause() {
+	c.update(func(now time.Time) {
+		c.base = now
+		c.paused = true
+	})
+}
+
+// Resume продолжает ход модельного времени после паузы.
+func (c *Clock) Resume() {
+	c.update(func(now time.Time) {
+		c.base = now
+		c.paused = false
+	})
+}
+
+// Jump переводит часы на момент t; скорость и пауза сохраняются.
+func (c *Clock) Jump(t time.Time) error {
+	if t.IsZero() {
+		return ErrZeroTime
+	}
+	c.update(func(time.Time) {
+		c.base = t.UTC()
+	})
+	return nil
+}
+
+// Reset возвращает часы к реальному времени со скоростью 1.
+func (c *Clock) Reset() {
+	c.update(func(time.Time) {
+		c.base = c.anchor.UTC()
+		c.rate = 1
+		c.paused = false
+	})
+}
+
+// update применяет изменение под блокировкой: опорная точка переносится
+// в текущий реальный момент, подписчики Changed уведомляются.
+func (c *Clock) update(apply func(now time.Time)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wall := c.wall()
+	now := c.timeAt(wall)
+	c.anchor = wall
+	apply(now)
+
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// timeAt возвращает модельное время в реальный момент wall.
+func (c *Clock) timeAt(wall time.Time) time.Time {
+	if c.paused {
+		return c.base
+	}
+	elapsed := time.Duration(float64(wall.Sub(c.anchor)) * c.rate)
+	return c.base.Add(elapsed)
+}
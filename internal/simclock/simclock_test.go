@@ -0,0 +1,125 @@
+package simclock
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var testWall = time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeWall — управляемое реальное время.
+type fakeWall struct{ t time.Time }
+
+func (f *fakeWall) now() time.Time          { return f.t }
+func (f *fakeWall) advance(d time.Duration) { f.t = f.t.Add(d) }
+
+func newTestClock() (*Clock, *fakeWall) {
+	wall := &fakeWall{t: testWall}
+	return newClock(wall.now), wall
+}
+
+func TestClock_RealTime(t *testing.T) {
+	c, wall := newTestClock()
+	wall.advance(90 * time.Second)
+
+	st := c.State()
+	if !st.Time.Equal(testWall.Add(90*time.Second)) || st.Rate != 1 || st.Paused || st.Offset != 0 {
+		t.Errorf("State() = %+v, want real time at rate 1", st)
+	}
+}
+
+func TestClock_Rate(t *testing.T) {
+	c, wall := newTestClock()
+	wall.advance(10 * time.Second)
+	if err := c.SetRate(60); err != nil {
+		t.Fatal(err)
+	}
+	wall.advance(2 * time.Second)
+
+	// 10 с с множителем 1, затем 2 с с множителем 60.
+	want := testWall.Add(10*time.Second + 2*time.Minute)
+	if got := c.Now(); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+	if off := c.State().Offset; math.Abs(off-118) > 1e-9 {
+		t.Errorf("Offset = %v, want 118", off)
+	}
+
+	for _, rate := range []float64{0, -1, MaxRate + 1, math.NaN()} {
+		if err := c.SetRate(rate); !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("SetRate(%v) error = %v, want ErrInvalidRate", rate, err)
+		}
+	}
+}
+
+func TestClock_PauseResume(t *testing.T) {
+	c, wall := newTestClock()
+	if err := c.SetRate(10); err != nil {
+		t.Fatal(err)
+	}
+	wall.advance(time.Second)
+	c.Pause()
+	wall.advance(time.Hour)
+
+	frozen := testWall.Add(10 * time.Second)
+	if got := c.Now(); !got.Equal(frozen) || !c.State().Paused {
+		t.Fatalf("paused Now() = %v, want %v", got, frozen)
+	}
+
+	c.Resume()
+	wall.advance(time.Second)
+	if got, want := c.Now(), frozen.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("resumed Now() = %v, want %v", got, want)
+	}
+}
+
+func TestClock_JumpAndReset(t *testing.T) {
+	c, wall := newTestClock()
+	target := time.Date(2025, time.June, 2, 3, 4, 5, 0, time.FixedZone("MSK", 3*3600))
+
+	if err := c.SetRate(5); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Jump(target); err != nil {
+		t.Fatal(err)
+	}
+	wall.advance(time.Second)
+	if got, want := c.Now(), target.UTC().Add(5*time.Second); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("Now() after Jump = %v, want %v UTC", got, want)
+	}
+	if err := c.Jump(time.Time{}); !errors.Is(err, ErrZeroTime) {
+		t.Errorf("Jump(zero) error = %v, want ErrZeroTime", err)
+	}
+
+	c.Pause()
+	c.Reset()
+	wall.advance(time.Second)
+	st := c.State()
+	if !st.Time.Equal(wall.t) || st.Rate != 1 || st.Paused {
+		t.Errorf("State() after Reset = %+v, want real time", st)
+	}
+}
+
+func TestClock_Changed(t *testing.T) {
+	c, _ := newTestClock()
+
+	changed := c.Changed()
+	select {
+	case <-changed:
+		t.Fatal("Changed() closed before any change")
+	default:
+	}
+
+	c.Pause()
+	select {
+	case <-changed:
+	default:
+		t.Fatal("Changed() not closed after Pause")
+	}
+
+	if next := c.Changed(); next == changed {
+		t.Error("Changed() returned the closed channel again")
+	}
+}
@@ -0,0 +1,144 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Direction — направление движения спутника над точкой пролёта.
+type Direction string
+
+// Направления пролёта.
+const (
+	Ascending  Direction = "ascending"
+	Descending Direction = "descending"
+)
+
+// Модуляции имитируемого передатчика.
+const (
+	ModulationFSK  = "fsk"
+	ModulationAFSK = "afsk"
+)
+
+// Допустимые диапазоны параметров.
+const (
+	minAltitude = 150.0   // км, ниже орбита быстро деградирует
+	maxAltitude = 40000.0 // км
+)
+
+// ErrInvalidParams возвращается при некорректных параметрах имитации.
+var ErrInvalidParams = errors.New("simulation: invalid parameters")
+
+// ParamsError описывает некорректное поле параметров.
+type ParamsError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ParamsError) Error() string {
+	return fmt.Sprintf("simulation: %s: %s", e.Field, e.Reason)
+}
+
+func (e *ParamsError) Unwrap() error {
+	return ErrInvalidParams
+}
+
+// Orbit — ограничения на трассу: спутник на круговой орбите высотой Altitude
+// и наклонением Inclination проходит над точкой (PassLat, PassLon)
+// в момент PassTime в направлении Direction.
+type Orbit struct {
+	PassLat     float64   `json:"pass_lat"`    // градусы
+	PassLon     float64   `json:"pass_lon"`    // градусы
+	PassTime    time.Time `json:"pass_time"`   // UTC
+	Altitude    float64   `json:"altitude"`    // км
+	Inclination float64   `json:"inclination"` // градусы
+	Direction   Direction `json:"direction"`
+}
+
+// Radio — параметры имитируемого передатчика.
+type Radio struct {
+	Downlink          float64       `json:"downlink_mhz"`
+	Modulation        string        `json:"modulation"`
+	BaudRate          int           `json:"baud_rate"`
+	Deviation         float64       `json:"deviation_hz"`
+	TelemetryInterval time.Duration `json:"telemetry_interval"`
+}
+
+// Params — параметры имитации из формы вкладки "Имитация".
+type Params struct {
+	Orbit Orbit `json:"orbit"`
+	Radio Radio `json:"radio"`
+}
+
+// DefaultParams возвращает параметры, совпадающие со значениями формы по умолчанию;
+// пролёт назначается через 30 минут после now.
+func DefaultParams(now time.Time) Params {
+	return Params{
+		Orbit: Orbit{
+			PassLat:     55.75,
+			PassLon:     37.62,
+			PassTime:    now.UTC().Add(30 * time.Minute).Truncate(time.Minute),
+			Altitude:    400,
+			Inclination: 97.6,
+			Direction:   Ascending,
+		},
+		Radio: Radio{
+			Downlink:          145.8,
+			Modulation:        ModulationFSK,
+			BaudRate:          1200,
+			Deviation:         3000,
+			TelemetryInterval: 10 * time.Second,
+		},
+	}
+}
+
+// Validate проверяет диапазоны параметров.
+func (p Params) Validate() error {
+	if err := p.Orbit.Validate(); err != nil {
+		return err
+	}
+	r := p.Radio
+	switch {
+	case r.Downlink <= 0:
+		return &ParamsError{Field: "downlink_freq", Reason: "must be positive"}
+	case r.Modulation != ModulationFSK && r.Modulation != ModulationAFSK:
+		return &ParamsError{Field: "modulation", Reason: "must be fsk or afsk"}
+	case r.BaudRate <= 0:
+		return &ParamsError{Field: "baud_rate", Reason: "must be positive"}
+	case r.Deviation < 0:
+		return &ParamsError{Field: "deviation", Reason: "must not be negative"}
+	case r.TelemetryInterval < time.Second:
+		return &ParamsError{Field: "telemetry_interval", Reason: "must be at least 1 s"}
+	}
+	return nil
+}
+
+// Validate проверяет диапазоны параметров орбиты.
+func (o Orbit) Validate() error {
+	switch {
+	case o.PassLat < -90 || o.PassLat > 90:
+		return &ParamsError{Field: "pass_lat", Reason: "must be within [-90, 90]"}
+	case o.PassLon < -180 || o.PassLon > 180:
+		return &ParamsError{Field: "pass_lon", Reason: "must be within [-180, 180]"}
+	case o.PassTime.IsZero():
+		return &ParamsError{Field: "pass_time", Reason: "is required"}
+	case o.Altitude < minAltitude || o.Altitude > maxAltitude:
+		return &ParamsError{Field: "altitude", Reason: fmt.Sprintf("must be within [%g, %g] km", minAltitude, maxAltitude)}
+	case o.Inclination < 0 || o.Inclination > 180:
+		return &ParamsError{Field: "inclination", Reason: "must be within [0, 180]"}
+	case o.Direction != Ascending && o.Direction != Descending:
+		return &ParamsError{Field: "direction", Reason: "must be ascending or descending"}
+	}
+	return nil
+}
+
+// ParseDirection разбирает направление пролёта без учёта регистра.
+func ParseDirection(s string) (Direction, error) {
+	d := Direction(strings.ToLower(strings.TrimSpace(s)))
+	if d != Ascending && d != Descending {
+		return "", &ParamsError{Field: "direction", Reason: "must be ascending or descending"}
+	}
+	return d, nil
+}
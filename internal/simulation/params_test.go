@@ -0,0 +1,58 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParams_Validate(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		modify func(*Params)
+		field  string
+	}{
+		{"defaults", func(*Params) {}, ""},
+		{"latitude", func(p *Params) { p.Orbit.PassLat = 91 }, "pass_lat"},
+		{"longitude", func(p *Params) { p.Orbit.PassLon = -181 }, "pass_lon"},
+		{"no pass time", func(p *Params) { p.Orbit.PassTime = time.Time{} }, "pass_time"},
+		{"altitude", func(p *Params) { p.Orbit.Altitude = 50 }, "altitude"},
+		{"inclination", func(p *Params) { p.Orbit.Inclination = 181 }, "inclination"},
+		{"direction", func(p *Params) { p.Orbit.Direction = "up" }, "direction"},
+		{"downlink", func(p *Params) { p.Radio.Downlink = 0 }, "downlink_freq"},
+		{"modulation", func(p *Params) { p.Radio.Modulation = "bpsk" }, "modulation"},
+		{"baud rate", func(p *Params) { p.Radio.BaudRate = 0 }, "baud_rate"},
+		{"deviation", func(p *Params) { p.Radio.Deviation = -1 }, "deviation"},
+		{"telemetry interval", func(p *Params) { p.Radio.TelemetryInterval = 0 }, "telemetry_interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DefaultParams(now)
+			tt.modify(&p)
+			err := p.Validate()
+
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Validate() error: %v", err)
+				}
+				return
+			}
+			var pe *ParamsError
+			if !errors.As(err, &pe) || pe.Field != tt.field || !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("Validate() error = %v, want field %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	if d, err := ParseDirection(" Descending "); err != nil || d != Descending {
+		t.Errorf("ParseDirection() = %q, %v", d, err)
+	}
+	if _, err := ParseDirection("sideways"); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("ParseDirection(sideways) error = %v", err)
+	}
+}
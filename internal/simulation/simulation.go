@@ -0,0 +1,251 @@
+// Package simulation реализует имитацию пролёта спутника, который ещё
+// не запущен: параметры орбиты и передатчика, синтезированный TLE,
+// конечный автомат состояний и модельное время.
+//
+// Модельное время имитации — смещение относительно общих модельных часов
+// сервера: скорость, пауза и переходы этих часов действуют и на имитацию.
+package simulation
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// State — состояние имитации.
+type State string
+
+// Состояния имитации.
+const (
+	StateStopped State = "stopped"
+	StateRunning State = "running"
+	StatePaused  State = "paused"
+)
+
+// startLead — за сколько до времени пролёта начинается имитация.
+const startLead = 10 * time.Minute
+
+// Ошибки управления имитацией.
+var (
+	ErrInvalidTransition = errors.New("simulation: invalid state transition")
+	ErrRunning           = errors.New("simulation: not allowed while running")
+	ErrNoGenerator       = errors.New("simulation: TLE generator is not configured")
+)
+
+// Generator синтезирует TLE по ограничениям на трассу.
+// Возвращает три строки: имя и две строки элементов.
+type Generator func(o Orbit) (name, line1, line2 string, err error)
+
+// GeneratedTLE — синтезированный набор элементов.
+type GeneratedTLE struct {
+	Name  string
+	Line1 string
+	Line2 string
+	Set   tle.ElementSet
+}
+
+// Status — снимок состояния имитации.
+type Status struct {
+	State   State
+	Time    time.Time // модельное время
+	Params  Params
+	TLE     *GeneratedTLE
+	Look    *orbit.Look // направление на спутник, если есть TLE
+	Doppler float64     // сдвиг частоты downlink, Гц
+	NextAOS *time.Time
+	NextLOS *time.Time
+}
+
+// Simulator хранит состояние имитации. Методы безопасны для
+// одновременного вызова из обработчиков HTTP.
+type Simulator struct {
+	generate Generator
+	now      func() time.Time
+
+	mu        sync.Mutex
+	predictor *pass.Predictor
+	state     State
+	params    Params
+	tle       *GeneratedTLE
+	prop      *orbit.SGP4
+	frozen    time.Time     // модельное время, пока имитация не запущена
+	offset    time.Duration // опережение общих часов, пока имитация запущена
+}
+
+// NewSimulator создаёт имитацию в состоянии "остановлена" с параметрами
+// по умолчанию. generate может быть nil — тогда синтез TLE недоступен.
+// now возвращает время общих модельных часов.
+func NewSimulator(predictor *pass.Predictor, generate Generator, now func() time.Time) *Simulator {
+	s := &Simulator{
+		predictor: predictor,
+		generate:  generate,
+		now:       now,
+		state:     StateStopped,
+		params:    DefaultParams(now()),
+	}
+	s.frozen = s.startTime()
+	return s
+}
+
+// SetPredictor заменяет прогноз пролётов, например при смене станции
+// или её маски горизонта.
+func (s *Simulator) SetPredictor(predictor *pass.Predictor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.predictor = predictor
+}
+
+// Params возвращает текущие параметры.
+func (s *Simulator) Params() Params {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.params
+}
+
+// Configure заменяет параметры имитации. Во время работы параметры
+// менять нельзя. Изменение орбиты сбрасывает синтезированный TLE.
+func (s *Simulator) Configure(p Params) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == StateRunning {
+		return ErrRunning
+	}
+	if p.Orbit != s.params.Orbit {
+		s.tle, s.prop = nil, nil
+	}
+	s.params = p
+	if s.state == StateStopped {
+		s.frozen = s.startTime()
+	}
+	return nil
+}
+
+// GenerateTLE синтезирует TLE по текущим параметрам орбиты.
+func (s *Simulator) GenerateTLE() (GeneratedTLE, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == StateRunning {
+		return GeneratedTLE{}, ErrRunning
+	}
+	if s.generate == nil {
+		return GeneratedTLE{}, ErrNoGenerator
+	}
+
+	name, line1, line2, err := s.generate(s.params.Orbit)
+	if err != nil {
+		return GeneratedTLE{}, err
+	}
+	set, err := tle.ParseTLE(name, line1, line2)
+	if err != nil {
+		return GeneratedTLE{}, err
+	}
+	prop, err := orbit.New(set.Elements())
+	if err != nil {
+		return GeneratedTLE{}, err
+	}
+
+	s.tle = &GeneratedTLE{Name: strings.TrimSpace(name), Line1: line1, Line2: line2, Set: set}
+	s.prop = prop
+	return *s.tle, nil
+}
+
+// Start запускает остановленную имитацию или продолжает приостановленную.
+func (s *Simulator) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.state {
+	case StateStopped:
+		s.frozen = s.startTime()
+	case StatePaused:
+	default:
+		return ErrInvalidTransition
+	}
+	s.state = StateRunning
+	s.offset = s.frozen.Sub(s.now())
+	return nil
+}
+
+// Stop приостанавливает работающую имитацию; модельное время замирает.
+func (s *Simulator) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != StateRunning {
+		return ErrInvalidTransition
+	}
+	s.frozen = s.time()
+	s.state = StatePaused
+	return nil
+}
+
+// Reset останавливает имитацию и возвращает модельное время к началу.
+// Параметры и синтезированный TLE сохраняются.
+func (s *Simulator) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = StateStopped
+	s.frozen = s.startTime()
+}
+
+// Status возвращает состояние имитации на текущее модельное время.
+func (s *Simulator) Status() (Status, error) {
+	s.mu.Lock()
+	st := Status{
+		State:  s.state,
+		Time:   s.time(),
+		Params: s.params,
+	}
+	prop, predictor := s.prop, s.predictor
+	if s.tle != nil {
+		generated := *s.tle
+		st.TLE = &generated
+	}
+	s.mu.Unlock()
+
+	if prop == nil {
+		return st, nil
+	}
+
+	look, err := predictor.Look(prop, st.Time)
+	if err != nil {
+		return st, err
+	}
+	st.Look = &look
+	st.Doppler = look.Doppler(st.Params.Radio.Downlink * 1e6)
+
+	next, found, err := predictor.NextPass(prop, st.Time)
+	if err != nil {
+		return st, err
+	}
+	if found {
+		st.NextAOS, st.NextLOS = &next.AOS, &next.LOS
+	}
+	return st, nil
+}
+
+// time возвращает модельное время имитации: идёт вместе с общими часами,
+// пока имитация запущена, и стоит на паузе и в остановленном состоянии.
+func (s *Simulator) time() time.Time {
+	if s.state != StateRunning {
+		return s.frozen
+	}
+	return s.now().Add(s.offset).UTC()
+}
+
+// startTime возвращает модельное время начала имитации.
+func (s *Simulator) startTime() time.Time {
+	return s.params.Orbit.PassTime.Add(-startLead)
+}
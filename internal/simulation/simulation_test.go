@@ -0,0 +1,202 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+)
+
+const (
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+// fakeNow — управляемые общие модельные часы для тестов.
+type fakeNow struct {
+	t time.Time
+}
+
+func (f *fakeNow) now() time.Time          { return f.t }
+func (f *fakeNow) advance(d time.Duration) { f.t = f.t.Add(d) }
+func issGenerator(Orbit) (string, string, string, error) {
+	return "SIM", issLine1, issLine2, nil
+}
+
+func newTestSimulator(t *testing.T, gen Generator) (*Simulator, *fakeNow) {
+	t.Helper()
+
+	predictor, err := pass.NewPredictor(orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clk := &fakeNow{t: time.Date(2008, time.September, 20, 12, 0, 0, 0, time.UTC)}
+	return NewSimulator(predictor, gen, clk.now), clk
+}
+
+func TestSimulator_StateMachine(t *testing.T) {
+	sim, clk := newTestSimulator(t, nil)
+
+	steps := []struct {
+		name    string
+		action  func() error
+		wantErr error
+		want    State
+	}{
+		{"stop while stopped", sim.Stop, ErrInvalidTransition, StateStopped},
+		{"start", sim.Start, nil, StateRunning},
+		{"start while running", sim.Start, ErrInvalidTransition, StateRunning},
+		{"pause", sim.Stop, nil, StatePaused},
+		{"pause again", sim.Stop, ErrInvalidTransition, StatePaused},
+		{"resume", sim.Start, nil, StateRunning},
+		{"reset", func() error { sim.Reset(); return nil }, nil, StateStopped},
+	}
+
+	for _, step := range steps {
+		if err := step.action(); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		st, err := sim.Status()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.State != step.want {
+			t.Fatalf("%s: state = %s, want %s", step.name, st.State, step.want)
+		}
+		clk.advance(time.Minute)
+	}
+}
+
+func TestSimulator_Clock(t *testing.T) {
+	sim, clk := newTestSimulator(t, nil)
+	start := sim.Params().Orbit.PassTime.Add(-startLead)
+
+	status := func() time.Time {
+		st, err := sim.Status()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return st.Time
+	}
+
+	if got := status(); !got.Equal(start) {
+		t.Fatalf("initial time = %v, want %v", got, start)
+	}
+
+	// Остановленные часы стоят.
+	clk.advance(time.Minute)
+	if got := status(); !got.Equal(start) {
+		t.Errorf("stopped clock moved to %v", got)
+	}
+
+	if err := sim.Start(); err != nil {
+		t.Fatal(err)
+	}
+	clk.advance(90 * time.Second)
+	if got := status(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("running time = %v, want %v", got, start.Add(90*time.Second))
+	}
+
+	// Пауза замораживает модельное время, продолжение идёт с того же места.
+	if err := sim.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	clk.advance(time.Hour)
+	if err := sim.Start(); err != nil {
+		t.Fatal(err)
+	}
+	clk.advance(10 * time.Second)
+	if got := status(); !got.Equal(start.Add(100 * time.Second)) {
+		t.Errorf("resumed time = %v, want %v", got, start.Add(100*time.Second))
+	}
+
+	// Переход общих часов сдвигает работающую имитацию на то же время.
+	clk.advance(2 * time.Hour)
+	if got := status(); !got.Equal(start.Add(2*time.Hour + 100*time.Second)) {
+		t.Errorf("time after shared clock jump = %v, want %v", got, start.Add(2*time.Hour+100*time.Second))
+	}
+
+	sim.Reset()
+	if got := status(); !got.Equal(start) {
+		t.Errorf("time after reset = %v, want %v", got, start)
+	}
+}
+
+func TestSimulator_Configure(t *testing.T) {
+	sim, _ := newTestSimulator(t, issGenerator)
+
+	p := sim.Params()
+	p.Orbit.Altitude = 10
+	if err := sim.Configure(p); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("invalid altitude error = %v, want ErrInvalidParams", err)
+	}
+
+	if _, err := sim.GenerateTLE(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Изменение параметров радио сохраняет TLE, изменение орбиты — сбрасывает.
+	p = sim.Params()
+	p.Radio.Downlink = 437.5
+	if err := sim.Configure(p); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := sim.Status(); st.TLE == nil {
+		t.Error("radio change dropped generated TLE")
+	}
+
+	p.Orbit.Inclination = 97.5
+	if err := sim.Configure(p); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := sim.Status(); st.TLE != nil {
+		t.Error("orbit change kept stale TLE")
+	}
+
+	if err := sim.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Configure(p); !errors.Is(err, ErrRunning) {
+		t.Errorf("Configure while running error = %v, want ErrRunning", err)
+	}
+	if _, err := sim.GenerateTLE(); !errors.Is(err, ErrRunning) {
+		t.Errorf("GenerateTLE while running error = %v, want ErrRunning", err)
+	}
+}
+
+func TestSimulator_GenerateTLE(t *testing.T) {
+	sim, _ := newTestSimulator(t, nil)
+	if _, err := sim.GenerateTLE(); !errors.Is(err, ErrNoGenerator) {
+		t.Errorf("error = %v, want ErrNoGenerator", err)
+	}
+
+	sim, _ = newTestSimulator(t, issGenerator)
+	generated, err := sim.GenerateTLE()
+	if err != nil {
+		t.Fatalf("GenerateTLE() error: %v", err)
+	}
+	if generated.Set.NoradID != 25544 || generated.Name != "SIM" {
+		t.Errorf("GenerateTLE() = %+v", generated)
+	}
+
+	st, err := sim.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Look == nil || st.NextAOS == nil {
+		t.Fatalf("Status() = %+v, want look angles and next pass", st)
+	}
+	if want := st.Look.Doppler(145.8e6); st.Doppler != want {
+		t.Errorf("Doppler = %v, want %v", st.Doppler, want)
+	}
+
+	bad := func(Orbit) (string, string, string, error) {
+		return "BAD", issLine1, issLine2[:68] + "0", nil
+	}
+	sim, _ = newTestSimulator(t, bad)
+	if _, err := sim.GenerateTLE(); err == nil {
+		t.Error("expected checksum error from generated TLE")
+	}
+}
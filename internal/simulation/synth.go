@@ -0,0 +1,199 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// Диапазон синтетических номеров NORAD. Номера 90000–99999 не выдаются
+// реальным объектам и зарезервированы для аналитических наборов.
+const (
+	SyntheticNoradBase  = 90000
+	syntheticNoradCount = 10000
+)
+
+// Параметры подбора элементов.
+const (
+	synthIterations = 20
+	synthTolerance  = 1e-6  // градусы, условие остановки Ньютона
+	synthMaxError   = 0.005 // градусы, допустимая невязка подспутниковой точки
+	synthDelta      = 1e-4  // градусы, шаг численной производной
+	synthElementSet = 999
+
+	deg2rad = math.Pi / 180
+)
+
+// Synthesize подбирает элементы круговой орбиты, проходящей над точкой
+// (o.PassLat, o.PassLon) в момент o.PassTime в направлении o.Direction.
+//
+// Начальное приближение даёт сферическая геометрия: аргумент широты u
+// из sin φ = sin i · sin u (ветвь выбирается направлением), долгота узла
+// из разности прямых восхождений. Затем RAAN и средняя аномалия уточняются
+// методом Ньютона по подспутниковой точке SGP4, чтобы учесть сжатие Земли
+// и короткопериодические возмущения.
+func Synthesize(o Orbit, noradID int) (tle.ElementSet, error) {
+	if err := o.Validate(); err != nil {
+		return tle.ElementSet{}, err
+	}
+
+	epoch := o.PassTime.UTC().Round(time.Second)
+	set := tle.ElementSet{
+		Name:         fmt.Sprintf("SIM-%d", noradID),
+		NoradID:      noradID,
+		Epoch:        epoch,
+		Inclination:  o.Inclination,
+		MeanMotion:   orbit.MeanMotionForAltitude(o.Altitude, o.Inclination, 0),
+		ElementSetNo: synthElementSet,
+	}
+
+	sinI := math.Sin(o.Inclination * deg2rad)
+	sinU := math.Sin(o.PassLat*deg2rad) / sinI
+	if math.Abs(sinU) > 1 || sinI == 0 {
+		return tle.ElementSet{}, &ParamsError{
+			Field:  "pass_lat",
+			Reason: fmt.Sprintf("unreachable with inclination %g°", o.Inclination),
+		}
+	}
+	u := math.Asin(sinU)
+	if o.Direction == Descending {
+		u = math.Pi - u
+	}
+	cosI := math.Cos(o.Inclination * deg2rad)
+	dAlpha := math.Atan2(cosI*math.Sin(u), math.Cos(u))
+	set.RAAN = o.PassLon*deg2rad + orbit.GMST(epoch) - dAlpha
+	set.RAAN /= deg2rad
+	set.MeanAnomaly = u / deg2rad
+
+	residual := func(raan, anomaly float64) (dLat, dLon float64, err error) {
+		set.RAAN, set.MeanAnomaly = raan, anomaly
+		g, err := subPoint(set, epoch)
+		if err != nil {
+			return 0, 0, err
+		}
+		return g.Latitude - o.PassLat, wrapDegrees(g.Longitude - o.PassLon), nil
+	}
+
+	raan, anomaly := set.RAAN, set.MeanAnomaly
+	for range synthIterations {
+		f1, f2, err := residual(raan, anomaly)
+		if err != nil {
+			return tle.ElementSet{}, err
+		}
+		if math.Hypot(f1, f2) < synthTolerance {
+			break
+		}
+		// Якобиан численно: столбцы — производные по RAAN и средней аномалии.
+		a1, a2, err := residual(raan+synthDelta, anomaly)
+		if err != nil {
+			return tle.ElementSet{}, err
+		}
+		b1, b2, err := residual(raan, anomaly+synthDelta)
+		if err != nil {
+			return tle.ElementSet{}, err
+		}
+		j11, j21 := (a1-f1)/synthDelta, (a2-f2)/synthDelta
+		j12, j22 := (b1-f1)/synthDelta, (b2-f2)/synthDelta
+		det := j11*j22 - j12*j21
+		if det == 0 {
+			break
+		}
+		raan -= (j22*f1 - j12*f2) / det
+		anomaly -= (j11*f2 - j21*f1) / det
+	}
+
+	set.RAAN, set.MeanAnomaly = normalizeDegrees(raan), normalizeDegrees(anomaly)
+	if err := checkSynthesized(set, o); err != nil {
+		return tle.ElementSet{}, err
+	}
+	return set, nil
+}
+
+// checkSynthesized проверяет, что орбита действительно проходит над точкой
+// в нужном направлении. Вблизи вершины трассы (|φ| ≈ i) задача вырождена,
+// и метод Ньютона может не сойтись.
+func checkSynthesized(set tle.ElementSet, o Orbit) error {
+	at, err := subPoint(set, set.Epoch)
+	if err != nil {
+		return err
+	}
+	if math.Hypot(at.Latitude-o.PassLat, wrapDegrees(at.Longitude-o.PassLon)) > synthMaxError {
+		return &ParamsError{
+			Field:  "pass_lat",
+			Reason: fmt.Sprintf("too close to the track apex for inclination %g°", o.Inclination),
+		}
+	}
+
+	later, err := subPoint(set, set.Epoch.Add(time.Second))
+	if err != nil {
+		return err
+	}
+	if ascending := later.Latitude > at.Latitude; ascending != (o.Direction == Ascending) {
+		return &ParamsError{
+			Field:  "direction",
+			Reason: fmt.Sprintf("%s pass is unreachable at the track apex", o.Direction),
+		}
+	}
+	return nil
+}
+
+// subPoint возвращает подспутниковую точку набора set в момент t.
+func subPoint(set tle.ElementSet, t time.Time) (orbit.Geodetic, error) {
+	prop, err := orbit.New(set.Elements())
+	if err != nil {
+		return orbit.Geodetic{}, err
+	}
+	st, err := prop.Propagate(t)
+	if err != nil {
+		return orbit.Geodetic{}, err
+	}
+	return orbit.SubPoint(st), nil
+}
+
+// normalizeDegrees приводит угол к диапазону [0, 360).
+func normalizeDegrees(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
+
+// wrapDegrees приводит разность углов к диапазону [-180, 180).
+func wrapDegrees(a float64) float64 {
+	return normalizeDegrees(a+180) - 180
+}
+
+// Synthesizer выдаёт синтезированные TLE с последовательными
+// синтетическими номерами NORAD. Безопасен для конкурентного использования.
+type Synthesizer struct {
+	mu   sync.Mutex
+	next int
+}
+
+// NewSynthesizer создаёт генератор с номерами от SyntheticNoradBase.
+func NewSynthesizer() *Synthesizer {
+	return &Synthesizer{}
+}
+
+// Generate синтезирует TLE для o; сигнатура совпадает с Generator.
+func (s *Synthesizer) Generate(o Orbit) (name, line1, line2 string, err error) {
+	s.mu.Lock()
+	id := SyntheticNoradBase + s.next
+	s.next = (s.next + 1) % syntheticNoradCount
+	s.mu.Unlock()
+
+	set, err := Synthesize(o, id)
+	if err != nil {
+		return "", "", "", err
+	}
+	line1, line2, err = tle.Format(set)
+	if err != nil {
+		return "", "", "", err
+	}
+	return set.Name, line1, line2, nil
+}
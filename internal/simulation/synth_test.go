@@ -0,0 +1,124 @@
+package simulation
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+func synthOrbit(lat, lon, alt, incl float64, dir Direction) Orbit {
+	return Orbit{
+		PassLat:     lat,
+		PassLon:     lon,
+		PassTime:    time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC),
+		Altitude:    alt,
+		Inclination: incl,
+		Direction:   dir,
+	}
+}
+
+func TestSynthesizer_Generate(t *testing.T) {
+	tests := []struct {
+		name  string
+		orbit Orbit
+	}{
+		{"iss ascending", synthOrbit(45, 37.62, 400, 51.6, Ascending)},
+		{"iss descending", synthOrbit(45, 37.62, 400, 51.6, Descending)},
+		{"default form values", synthOrbit(55.75, 37.62, 400, 97.6, Ascending)},
+		{"sun-synchronous southern", synthOrbit(-33.9, 151.2, 550, 97.6, Descending)},
+		{"sun-synchronous date line", synthOrbit(10, 179.99, 800, 98.7, Ascending)},
+		{"equatorial", synthOrbit(0, -60, 1200, 5, Ascending)},
+		{"medium orbit", synthOrbit(-40, -70, 20200, 55, Ascending)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, line1, line2, err := NewSynthesizer().Generate(tt.orbit)
+			if err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			}
+			// ParseTLE проверяет контрольные суммы и формат колонок.
+			set, err := tle.ParseTLE(name, line1, line2)
+			if err != nil {
+				t.Fatalf("ParseTLE() error: %v\n%s\n%s", err, line1, line2)
+			}
+			if set.NoradID != SyntheticNoradBase || set.Eccentricity != 0 {
+				t.Errorf("NoradID/Eccentricity = %d/%v", set.NoradID, set.Eccentricity)
+			}
+
+			prop, err := orbit.New(set.Elements())
+			if err != nil {
+				t.Fatal(err)
+			}
+			at := subPointAt(t, prop, tt.orbit.PassTime)
+			if d := math.Hypot(at.Latitude-tt.orbit.PassLat, wrapDegrees(at.Longitude-tt.orbit.PassLon)); d > 0.01 {
+				t.Errorf("sub-point %.4f, %.4f is %.4f° from target", at.Latitude, at.Longitude, d)
+			}
+			if math.Abs(at.Altitude-tt.orbit.Altitude) > 30 {
+				t.Errorf("altitude = %.1f km, want about %.0f", at.Altitude, tt.orbit.Altitude)
+			}
+
+			later := subPointAt(t, prop, tt.orbit.PassTime.Add(10*time.Second))
+			if ascending := later.Latitude > at.Latitude; ascending != (tt.orbit.Direction == Ascending) {
+				t.Errorf("latitude %.4f -> %.4f does not match direction %s", at.Latitude, later.Latitude, tt.orbit.Direction)
+			}
+		})
+	}
+}
+
+func subPointAt(t *testing.T, prop *orbit.SGP4, at time.Time) orbit.Geodetic {
+	t.Helper()
+	st, err := prop.Propagate(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return orbit.SubPoint(st)
+}
+
+func TestSynthesizer_SequentialIDs(t *testing.T) {
+	s := NewSynthesizer()
+	o := DefaultParams(time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)).Orbit
+	for i := range 3 {
+		name, line1, line2, err := s.Generate(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		set, err := tle.ParseTLE(name, line1, line2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := SyntheticNoradBase + i; set.NoradID != want || set.Name != name {
+			t.Errorf("call %d: NoradID = %d, Name = %q, want %d", i, set.NoradID, set.Name, want)
+		}
+	}
+}
+
+func TestSynthesize_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		orbit Orbit
+		field string
+	}{
+		{"latitude above inclination", synthOrbit(60, 0, 400, 51.6, Ascending), "pass_lat"},
+		{"latitude above retrograde limit", synthOrbit(-85, 0, 600, 97.6, Ascending), "pass_lat"},
+		{"equatorial orbit off equator", synthOrbit(1, 0, 400, 0, Ascending), "pass_lat"},
+		{"invalid altitude", synthOrbit(0, 0, 50, 51.6, Ascending), "altitude"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Synthesize(tt.orbit, SyntheticNoradBase)
+			var pe *ParamsError
+			if !errors.As(err, &pe) || pe.Field != tt.field {
+				t.Fatalf("Synthesize() error = %v, want ParamsError on %s", err, tt.field)
+			}
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("error %v does not wrap ErrInvalidParams", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package station
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/art-injener/satwatch-go/internal/atomicfile"
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/pass"
+)
+
+// fileFormat — содержимое файла станций.
+type fileFormat struct {
+	Active   string    `json:"active"`
+	Stations []Station `json:"stations"`
+}
+
+// Registry — реестр станций с выбором активной. Прогноз пролётов для
+// станции строится при загрузке и заново при замене её маски горизонта.
+// Выбор активной станции и маски сохраняются в файле реестра.
+//
+// Оборудование подключается один раз при запуске: после BindHardware
+// активной можно сделать только станцию с тем же оборудованием.
+type Registry struct {
+	path string
+	opts pass.Options
+
+	changeMu sync.Mutex // упорядочивает изменения и уведомления наблюдателей
+
+	mu         sync.RWMutex
+	stations   []Station
+	predictors map[string]*pass.Predictor
+	active     string
+	bound      bool
+	defaults   Hardware // адреса общей конфигурации
+	hardware   Hardware // подключённое оборудование
+	watchers   []func(Station, *pass.Predictor)
+}
+
+// NewRegistry загружает станции из файла path с параметрами поиска
+// пролётов opts. Если путь пуст или файла нет, реестр состоит из одной
+// станции fallback. Изменения записываются в файл path, который
+// создаётся при первой записи; при пустом пути реестр хранится в памяти.
+func NewRegistry(path string, fallback Station, opts pass.Options) (*Registry, error) {
+	f := fileFormat{Active: fallback.ID, Stations: []Station{fallback}}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("read stations: %w", err)
+		default:
+			f = fileFormat{}
+			if err := json.Unmarshal(data, &f); err != nil {
+				return nil, fmt.Errorf("decode stations %s: %w", path, err)
+			}
+		}
+	}
+	if len(f.Stations) == 0 {
+		return nil, fmt.Errorf("%w: no stations in %s", ErrInvalidStation, path)
+	}
+
+	r := &Registry{
+		path:       path,
+		opts:       opts,
+		stations:   f.Stations,
+		predictors: make(map[string]*pass.Predictor, len(f.Stations)),
+		active:     f.Active,
+	}
+	for _, st := range f.Stations {
+		if err := st.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := r.predictors[st.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate id %q", ErrInvalidStation, st.ID)
+		}
+		predictor, err := r.predictor(st)
+		if err != nil {
+			return nil, err
+		}
+		r.predictors[st.ID] = predictor
+	}
+	if r.active == "" {
+		r.active = f.Stations[0].ID
+	}
+	if _, ok := r.predictors[r.active]; !ok {
+		return nil, fmt.Errorf("%w: active station %q", ErrNotFound, r.active)
+	}
+	return r, nil
+}
+
+// List возвращает станции в порядке файла реестра.
+func (r *Registry) List() []Station {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.stations)
+}
+
+// Active возвращает активную станцию.
+func (r *Registry) Active() Station {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	st, _ := r.get(r.active)
+	return st
+}
+
+// Get возвращает станцию по идентификатору.
+func (r *Registry) Get(id string) (Station, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.get(id)
+}
+
+// Resolve возвращает станцию id вместе с прогнозом пролётов для неё;
+// пустой id означает активную станцию.
+func (r *Registry) Resolve(id string) (Station, *pass.Predictor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id == "" {
+		id = r.active
+	}
+	st, err := r.get(id)
+	if err != nil {
+		return Station{}, nil, err
+	}
+	return st, r.predictors[id], nil
+}
+
+// BindHardware закрепляет за реестром оборудование активной станции;
+// defaults — адреса общей конфигурации для пустых полей профилей.
+// Возвращает адреса подключённого оборудования.
+func (r *Registry) BindHardware(defaults Hardware) Hardware {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, _ := r.get(r.active)
+	r.bound, r.defaults, r.hardware = true, defaults, st.Hardware.Or(defaults)
+	return r.hardware
+}
+
+// Watch регистрирует fn, вызываемую со станцией и прогнозом пролётов
+// после смены активной станции или маски её горизонта. Вызовы fn
+// следуют в порядке изменений.
+func (r *Registry) Watch(fn func(Station, *pass.Predictor)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watchers = append(r.watchers, fn)
+}
+
+// SetActive делает станцию id активной и сохраняет выбор в файле реестра.
+// Станцию с другим оборудованием после BindHardware выбрать нельзя:
+// возвращается ErrHardwareBound.
+func (r *Registry) SetActive(id string) (Station, error) {
+	r.changeMu.Lock()
+	defer r.changeMu.Unlock()
+
+	st, predictor, changed, err := r.setActive(id)
+	if err != nil {
+		return Station{}, err
+	}
+	if changed {
+		r.notify(st, predictor)
+	}
+	return st, nil
+}
+
+// setActive меняет активную станцию; changed сообщает, что она
+// сменилась.
+func (r *Registry) setActive(id string) (st Station, predictor *pass.Predictor, changed bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, err = r.get(id)
+	if err != nil {
+		return Station{}, nil, false, err
+	}
+	if r.bound && st.Hardware.Or(r.defaults) != r.hardware {
+		return Station{}, nil, false, fmt.Errorf("%w: %q uses other devices, restart the server to switch", ErrHardwareBound, id)
+	}
+	if r.active == id {
+		return st, r.predictors[id], false, nil
+	}
+	if r.path != "" {
+		if err := r.save(id, r.stations); err != nil {
+			return Station{}, nil, false, err
+		}
+	}
+	r.active = id
+	return st, r.predictors[id], true, nil
+}
+
+// notify передаёт наблюдателям активную станцию и её прогноз;
+// вызывается под changeMu без блокировки mu.
+func (r *Registry) notify(st Station, predictor *pass.Predictor) {
+	r.mu.RLock()
+	watchers := slices.Clone(r.watchers)
+	r.mu.RUnlock()
+	for _, fn := range watchers {
+		fn(st, predictor)
+	}
+}
+
+// SetHorizon заменяет маску горизонта станции id и сохраняет её в файле
+// реестра. Пустая маска открывает горизонт. Новая маска действует для
+// прогнозов, полученных через Resolve после замены; для активной станции
+// новый прогноз передаётся наблюдателям Watch.
+func (r *Registry) SetHorizon(id string, mask horizon.Mask) (Station, error) {
+	r.changeMu.Lock()
+	defer r.changeMu.Unlock()
+
+	st, predictor, active, err := r.setHorizon(id, mask)
+	if err != nil {
+		return Station{}, err
+	}
+	if active {
+		r.notify(st, predictor)
+	}
+	return st, nil
+}
+
+// setHorizon заменяет маску станции; active сообщает, что станция
+// активна.
+func (r *Registry) setHorizon(id string, mask horizon.Mask) (st Station, predictor *pass.Predictor, active bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, err = r.get(id)
+	if err != nil {
+		return Station{}, nil, false, err
+	}
+	st.Horizon = mask
+	if err := st.Validate(); err != nil {
+		return Station{}, nil, false, err
+	}
+	predictor, err = r.predictor(st)
+	if err != nil {
+		return Station{}, nil, false, err
+	}
+
+	stations := slices.Clone(r.stations)
+	stations[slices.IndexFunc(stations, func(s Station) bool { return s.ID == id })] = st
+	if r.path != "" {
+		if err := r.save(r.active, stations); err != nil {
+			return Station{}, nil, false, err
+		}
+	}
+	r.stations = stations
+	r.predictors[id] = predictor
+	return st, predictor, id == r.active, nil
+}
+
+// predictor строит прогноз пролётов над станцией с её маской горизонта.
+func (r *Registry) predictor(st Station) (*pass.Predictor, error) {
+	opts := r.opts
+	opts.Horizon = st.Horizon
+	return pass.NewPredictor(st.Observer(), opts)
+}
+
+// get ищет станцию; вызывается под блокировкой mu.
+func (r *Registry) get(id string) (Station, error) {
+	i := slices.IndexFunc(r.stations, func(st Station) bool { return st.ID == id })
+	if i < 0 {
+		return Station{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	return r.stations[i], nil
+}
+
+// save атомарно записывает реестр станций stations с активной станцией active.
+func (r *Registry) save(active string, stations []Station) error {
+	data, err := json.MarshalIndent(fileFormat{Active: active, Stations: stations}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode stations: %w", err)
+	}
+	if err := atomicfile.WriteFile(r.path, data); err != nil {
+		return fmt.Errorf("save stations: %w", err)
+	}
+	return nil
+}
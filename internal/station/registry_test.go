@@ -0,0 +1,277 @@
+package station
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/pass"
+)
+
+// testFallback — станция из общей конфигурации.
+var testFallback = Station{ID: "default", Name: "Ростов-на-Дону", Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+
+// testStations — файл реестра с двумя станциями.
+const testStations = `{"active": "moscow", "stations": [
+	{"id": "rostov", "name": "Ростов-на-Дону", "lat": 47.315813, "lon": 39.788243, "alt": 70},
+	{"id": "moscow", "name": "Москва", "lat": 55.76, "lon": 37.62, "alt": 150, "hardware": {"rotator_addr": "localhost:4533"}}
+]}`
+
+func writeStations(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stations.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string // пустая строка — файла нет
+		wantActive string
+		wantCount  int
+		wantErr    error
+	}{
+		{name: "no file", wantActive: "default", wantCount: 1},
+		{name: "file", content: testStations, wantActive: "moscow", wantCount: 2},
+		{
+			name:       "first station is active by default",
+			content:    `{"stations": [{"id": "a", "name": "A"}, {"id": "b", "name": "B"}]}`,
+			wantActive: "a", wantCount: 2,
+		},
+		{name: "no stations", content: `{"stations": []}`, wantErr: ErrInvalidStation},
+		{name: "invalid station", content: `{"stations": [{"id": "a", "name": "A", "lat": 95}]}`, wantErr: ErrInvalidStation},
+		{
+			name:    "duplicate id",
+			content: `{"stations": [{"id": "a", "name": "A"}, {"id": "a", "name": "B"}]}`,
+			wantErr: ErrInvalidStation,
+		},
+		{name: "unknown active", content: `{"active": "c", "stations": [{"id": "a", "name": "A"}]}`, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "stations.json")
+			if tt.content != "" {
+				path = writeStations(t, tt.content)
+			}
+			r, err := NewRegistry(path, testFallback, pass.DefaultOptions())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewRegistry() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.Active().ID; got != tt.wantActive {
+				t.Errorf("Active() = %q, want %q", got, tt.wantActive)
+			}
+			if got := len(r.List()); got != tt.wantCount {
+				t.Errorf("List() has %d stations, want %d", got, tt.wantCount)
+			}
+		})
+	}
+
+	if _, err := NewRegistry(writeStations(t, `{"stations":`), testFallback, pass.DefaultOptions()); err == nil {
+		t.Error("NewRegistry() accepted a corrupted file")
+	}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	r, err := NewRegistry(writeStations(t, testStations), testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id      string
+		wantLat float64
+		wantErr bool
+	}{
+		{"", 55.76, false},
+		{"rostov", 47.315813, false},
+		{"moscow", 55.76, false},
+		{"kazan", 0, true},
+	}
+	for _, tt := range tests {
+		st, predictor, err := r.Resolve(tt.id)
+		if tt.wantErr {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Resolve(%q) error = %v, want ErrNotFound", tt.id, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Resolve(%q) error = %v", tt.id, err)
+		}
+		if st.Latitude != tt.wantLat || predictor.Observer().Latitude != tt.wantLat {
+			t.Errorf("Resolve(%q) = %v, predictor at %v; want latitude %v", tt.id, st.Latitude, predictor.Observer().Latitude, tt.wantLat)
+		}
+	}
+}
+
+func TestRegistry_SetActive(t *testing.T) {
+	path := writeStations(t, testStations)
+	r, err := NewRegistry(path, testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := r.SetActive("rostov")
+	if err != nil || st.ID != "rostov" || r.Active().ID != "rostov" {
+		t.Fatalf("SetActive() = %+v, %v; active %q", st, err, r.Active().ID)
+	}
+	if _, err := r.SetActive("kazan"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetActive(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	// Выбор сохраняется в файле реестра вместе с профилями.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	if f.Active != "rostov" || len(f.Stations) != 2 || f.Stations[1].Hardware.RotatorAddr != "localhost:4533" {
+		t.Errorf("saved registry = %+v", f)
+	}
+	reloaded, err := NewRegistry(path, testFallback, pass.DefaultOptions())
+	if err != nil || reloaded.Active().ID != "rostov" {
+		t.Errorf("reloaded active = %q, %v", reloaded.Active().ID, err)
+	}
+
+	// Файл реестра создаётся при первом изменении.
+	newPath := filepath.Join(t.TempDir(), "data", "stations.json")
+	created, err := NewRegistry(newPath, testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := created.SetActive("default"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(newPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("unchanged registry created %s: %v", newPath, err)
+	}
+	if _, err := created.SetHorizon("default", horizon.Mask{{Azimuth: 0, Elevation: 5}}); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err = NewRegistry(newPath, testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := reloaded.Active(); st.ID != "default" || len(st.Horizon) != 1 {
+		t.Errorf("reloaded created registry active = %+v", st)
+	}
+}
+
+func TestRegistry_BindHardware(t *testing.T) {
+	content := `{"active": "rostov", "stations": [
+		{"id": "rostov", "name": "Ростов-на-Дону", "lat": 47.315813, "lon": 39.788243},
+		{"id": "roof", "name": "Крыша", "lat": 47.3, "lon": 39.8, "hardware": {"rotator_addr": "localhost:4533"}},
+		{"id": "moscow", "name": "Москва", "lat": 55.76, "lon": 37.62, "hardware": {"rotator_addr": "moscow:4533"}}
+	]}`
+	r, err := NewRegistry(writeStations(t, content), testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes []string
+	r.Watch(func(st Station, p *pass.Predictor) {
+		if p == nil || p.Observer() != st.Observer() {
+			t.Errorf("watch %s: predictor for %+v", st.ID, p.Observer())
+		}
+		changes = append(changes, st.ID)
+	})
+
+	hw := r.BindHardware(Hardware{RotatorAddr: "localhost:4533", RigAddr: "localhost:4532"})
+	if hw != (Hardware{RotatorAddr: "localhost:4533", RigAddr: "localhost:4532"}) {
+		t.Errorf("bound hardware = %+v", hw)
+	}
+
+	// Станция с теми же адресами выбирается, с другими — отклоняется.
+	if _, err := r.SetActive("roof"); err != nil {
+		t.Errorf("SetActive(roof) error: %v", err)
+	}
+	if _, err := r.SetActive("moscow"); !errors.Is(err, ErrHardwareBound) || r.Active().ID != "roof" {
+		t.Errorf("SetActive(moscow) error = %v, active %q; want ErrHardwareBound", err, r.Active().ID)
+	}
+	if _, err := r.SetActive("roof"); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0] != "roof" {
+		t.Errorf("changes = %q, want [roof]", changes)
+	}
+}
+
+func TestRegistry_SetHorizon(t *testing.T) {
+	path := writeStations(t, testStations)
+	r, err := NewRegistry(path, testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, before, err := r.Resolve("rostov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var watched []float64
+	r.Watch(func(st Station, p *pass.Predictor) {
+		watched = append(watched, p.Horizon(0))
+	})
+
+	mask := horizon.Mask{{Azimuth: 0, Elevation: 20}, {Azimuth: 180, Elevation: 0}}
+	st, err := r.SetHorizon("rostov", mask)
+	if err != nil || len(st.Horizon) != 2 {
+		t.Fatalf("SetHorizon() = %+v, %v", st, err)
+	}
+	// Маска неактивной станции не меняет прогноз наблюдателей.
+	if len(watched) != 0 {
+		t.Errorf("watchers notified for inactive station: %v", watched)
+	}
+	if _, err := r.SetHorizon("moscow", horizon.Mask{{Azimuth: 0, Elevation: 15}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(watched) != 1 || watched[0] != 15 {
+		t.Errorf("watched horizons at north = %v, want [15]", watched)
+	}
+	_, after, err := r.Resolve("rostov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after == before || after.Horizon(0) != 20 || before.Horizon(0) != 0 {
+		t.Errorf("predictor horizon at north: before %v, after %v", before.Horizon(0), after.Horizon(0))
+	}
+
+	if _, err := r.SetHorizon("kazan", mask); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetHorizon(unknown) error = %v, want ErrNotFound", err)
+	}
+	invalid := horizon.Mask{{Azimuth: 10, Elevation: 95}}
+	if _, err := r.SetHorizon("rostov", invalid); !errors.Is(err, ErrInvalidStation) {
+		t.Errorf("SetHorizon(invalid) error = %v, want ErrInvalidStation", err)
+	}
+
+	// Маска сохраняется в файле реестра и восстанавливается при загрузке.
+	reloaded, err := NewRegistry(path, testFallback, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, predictor, err := reloaded.Resolve("rostov")
+	if err != nil || len(st.Horizon) != 2 || predictor.Horizon(90) != 10 {
+		t.Errorf("reloaded rostov = %+v, horizon at east %v, %v", st, predictor.Horizon(90), err)
+	}
+	if reloaded.Active().ID != "moscow" {
+		t.Errorf("reloaded active = %q, want moscow", reloaded.Active().ID)
+	}
+
+	// Пустая маска открывает горизонт.
+	if st, err := r.SetHorizon("rostov", nil); err != nil || st.Horizon != nil {
+		t.Errorf("SetHorizon(nil) = %+v, %v", st, err)
+	}
+}
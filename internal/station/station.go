@@ -0,0 +1,111 @@
+// Package station хранит профили наземных станций: местоположение
+// наблюдателя и адреса оборудования. Одна из станций активна — для неё
+// по умолчанию строятся прогнозы и показываются страницы.
+package station
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// Ошибки реестра станций.
+var (
+	ErrNotFound       = errors.New("station: not found")
+	ErrInvalidStation = errors.New("station: invalid station")
+	ErrHardwareBound  = errors.New("station: hardware is bound to another station")
+)
+
+// Hardware — адреса оборудования станции; пустое значение оставляет
+// адрес из общей конфигурации.
+type Hardware struct {
+	RotatorAddr string `json:"rotator_addr,omitempty"`
+	RigAddr     string `json:"rig_addr,omitempty"`
+	SDRSource   string `json:"sdr_source,omitempty"`
+}
+
+// Or возвращает адреса оборудования, в которых пустые значения заменены
+// адресами defaults.
+func (h Hardware) Or(defaults Hardware) Hardware {
+	if h.RotatorAddr == "" {
+		h.RotatorAddr = defaults.RotatorAddr
+	}
+	if h.RigAddr == "" {
+		h.RigAddr = defaults.RigAddr
+	}
+	if h.SDRSource == "" {
+		h.SDRSource = defaults.SDRSource
+	}
+	return h
+}
+
+// Station — профиль наземной станции.
+type Station struct {
+	// ID — короткий идентификатор для параметра ?station=.
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Latitude  float64  `json:"lat"`
+	Longitude float64  `json:"lon"`
+	Altitude  float64  `json:"alt"` // метры над уровнем моря
+	Hardware  Hardware `json:"hardware"`
+	// Horizon — маска горизонта; пустая означает открытый горизонт.
+	Horizon horizon.Mask `json:"horizon,omitempty"`
+}
+
+// Validate проверяет профиль станции.
+func (s Station) Validate() error {
+	switch {
+	case !validID(s.ID):
+		return fmt.Errorf("%w: id %q must consist of a-z, 0-9, '-' and '_'", ErrInvalidStation, s.ID)
+	case strings.TrimSpace(s.Name) == "":
+		return fmt.Errorf("%w: %s: name is required", ErrInvalidStation, s.ID)
+	case math.Abs(s.Latitude) > 90:
+		return fmt.Errorf("%w: %s: latitude must be within -90..90°", ErrInvalidStation, s.ID)
+	case math.Abs(s.Longitude) > 180:
+		return fmt.Errorf("%w: %s: longitude must be within -180..180°", ErrInvalidStation, s.ID)
+	}
+	if err := s.Horizon.Validate(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidStation, s.ID, err)
+	}
+	return nil
+}
+
+func validID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Observer возвращает местоположение станции для расчётов орбит.
+func (s Station) Observer() orbit.Observer {
+	return orbit.Observer{
+		Latitude:  s.Latitude,
+		Longitude: s.Longitude,
+		Altitude:  s.Altitude,
+	}
+}
+
+// Coordinates возвращает координаты станции для подписи,
+// например "47.32°N, 39.79°E".
+func (s Station) Coordinates() string {
+	return hemisphere(s.Latitude, "N", "S") + ", " + hemisphere(s.Longitude, "E", "W")
+}
+
+func hemisphere(deg float64, pos, neg string) string {
+	dir := pos
+	if deg < 0 {
+		dir = neg
+	}
+	return strconv.FormatFloat(math.Abs(deg), 'f', 2, 64) + "°" + dir
+}
@@ -0,0 +1,60 @@
+package station
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/art-injener/satwatch-go/internal/horizon"
+)
+
+func TestStation_Validate(t *testing.T) {
+	valid := Station{ID: "rostov", Name: "Ростов-на-Дону", Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+
+	tests := []struct {
+		name    string
+		modify  func(s *Station)
+		wantErr bool
+	}{
+		{"valid", func(s *Station) {}, false},
+		{"id with digits and dash", func(s *Station) { s.ID = "roof-2_b" }, false},
+		{"empty id", func(s *Station) { s.ID = "" }, true},
+		{"id with spaces", func(s *Station) { s.ID = "my roof" }, true},
+		{"uppercase id", func(s *Station) { s.ID = "Rostov" }, true},
+		{"no name", func(s *Station) { s.Name = " " }, true},
+		{"latitude above 90", func(s *Station) { s.Latitude = 91 }, true},
+		{"longitude below -180", func(s *Station) { s.Longitude = -180.5 }, true},
+		{"horizon", func(s *Station) { s.Horizon = horizon.Mask{{Azimuth: 0, Elevation: 20}, {Azimuth: 180, Elevation: 0}} }, false},
+		{"unsorted horizon", func(s *Station) { s.Horizon = horizon.Mask{{Azimuth: 180, Elevation: 0}, {Azimuth: 0, Elevation: 20}} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.modify(&s)
+			err := s.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidStation) {
+				t.Errorf("Validate() error = %v, want ErrInvalidStation", err)
+			}
+		})
+	}
+}
+
+func TestStation_Coordinates(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		want     string
+	}{
+		{47.315813, 39.788243, "47.32°N, 39.79°E"},
+		{-33.87, -70.65, "33.87°S, 70.65°W"},
+		{0, 0, "0.00°N, 0.00°E"},
+	}
+
+	for _, tt := range tests {
+		if got := (Station{Latitude: tt.lat, Longitude: tt.lon}).Coordinates(); got != tt.want {
+			t.Errorf("Coordinates(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
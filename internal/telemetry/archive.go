@@ -0,0 +1,281 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxSeriesPoints ограничивает число точек в ответе на запрос ряда.
+const MaxSeriesPoints = 10000
+
+// archiveDayLayout — имя файла архива за сутки (UTC).
+const archiveDayLayout = "2006-01-02"
+
+// archiveQueue — число записей, ожидающих сохранения в Run.
+const archiveQueue = 256
+
+// ErrInvalidQuery возвращается для некорректного запроса ряда.
+var ErrInvalidQuery = errors.New("telemetry: invalid query")
+
+// Archive хранит записи телеметрии в файлах JSON Lines: по каталогу
+// на спутник и по файлу на сутки UTC (dir/<norad>/<YYYY-MM-DD>.jsonl).
+// Записи только дописываются; повреждённые строки при чтении пропускаются.
+type Archive struct {
+	dir   string
+	queue chan Record
+	mu    sync.Mutex // упорядочивает запись в файлы
+}
+
+// OpenArchive открывает архив в каталоге dir, создавая его при
+// необходимости.
+func OpenArchive(dir string) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create telemetry archive: %w", err)
+	}
+	return &Archive{dir: dir, queue: make(chan Record, archiveQueue)}, nil
+}
+
+// Enqueue ставит запись в очередь сохранения для Run. Не блокируется:
+// при переполнении очереди запись отбрасывается и возвращается false.
+func (a *Archive) Enqueue(rec Record) bool {
+	select {
+	case a.queue <- rec:
+		return true
+	default:
+		slog.Warn("telemetry archive queue is full, record dropped", "norad_id", rec.NoradID)
+		return false
+	}
+}
+
+// Run сохраняет записи из Enqueue до отмены ctx; оставшиеся в очереди
+// записи сохраняются перед выходом.
+func (a *Archive) Run(ctx context.Context) {
+	for {
+		select {
+		case rec := <-a.queue:
+			a.store(rec)
+		case <-ctx.Done():
+			for {
+				select {
+				case rec := <-a.queue:
+					a.store(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// store сохраняет запись из очереди, записывая ошибку в журнал.
+func (a *Archive) store(rec Record) {
+	if err := a.Append(rec); err != nil {
+		slog.Error("failed to archive telemetry", "norad_id", rec.NoradID, "error", err)
+	}
+}
+
+// Append дописывает запись в файл суток её времени.
+func (a *Archive) Append(rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode telemetry record: %w", err)
+	}
+	line = append(line, '\n')
+
+	path := a.dayPath(rec.NoradID, rec.Time)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create telemetry archive: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return fmt.Errorf("open telemetry archive: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write telemetry archive: %w", err)
+	}
+	return f.Close()
+}
+
+// Records вызывает fn для каждой записи спутника noradID с временем
+// в интервале [from, to) в порядке файлов; false из fn прекращает обход.
+func (a *Archive) Records(noradID int, from, to time.Time, fn func(Record) bool) error {
+	for day := from.UTC().Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		more, err := a.scanDay(a.dayPath(noradID, day), from, to, fn)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+// scanDay читает файл суток; отсутствующий файл пропускается.
+func (a *Archive) scanDay(path string, from, to time.Time, fn func(Record) bool) (bool, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("open telemetry archive: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			// Строка могла остаться недописанной при аварийном завершении.
+			slog.Warn("skipping corrupted telemetry archive line", "file", path, "line", n, "error", err)
+			continue
+		}
+		if rec.Time.Before(from) || !rec.Time.Before(to) {
+			continue
+		}
+		if !fn(rec) {
+			return false, nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return false, fmt.Errorf("read telemetry archive %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// dayPath возвращает путь файла суток, содержащих t.
+func (a *Archive) dayPath(noradID int, t time.Time) string {
+	return filepath.Join(a.dir, strconv.Itoa(noradID), t.UTC().Format(archiveDayLayout)+".jsonl")
+}
+
+// Query — запрос временного ряда поля телеметрии.
+type Query struct {
+	NoradID  int
+	Field    string
+	From, To time.Time
+	// Step — ширина интервала усреднения; 0 — точки без прореживания.
+	Step time.Duration
+}
+
+// Point — точка ряда. Без прореживания Min = Max = Value, Count = 1;
+// с прореживанием Time — начало интервала, Value — среднее за интервал.
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Count int       `json:"count"`
+	// PassID задан только для точек без прореживания.
+	PassID string `json:"pass_id,omitempty"`
+}
+
+// Series — временной ряд поля телеметрии.
+type Series struct {
+	NoradID int     `json:"norad_id"`
+	Field   string  `json:"field"`
+	Unit    string  `json:"unit,omitempty"`
+	Points  []Point `json:"points"`
+}
+
+// Validate проверяет запрос: интервал непустой, число точек
+// с прореживанием не превышает MaxSeriesPoints.
+func (q Query) Validate() error {
+	switch {
+	case q.NoradID <= 0:
+		return fmt.Errorf("%w: norad id must be positive", ErrInvalidQuery)
+	case q.Field == "":
+		return fmt.Errorf("%w: field is required", ErrInvalidQuery)
+	case !q.From.Before(q.To):
+		return fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	case q.Step < 0:
+		return fmt.Errorf("%w: step must not be negative", ErrInvalidQuery)
+	case q.Step > 0 && q.To.Sub(q.From)/q.Step >= MaxSeriesPoints:
+		return fmt.Errorf("%w: more than %d points, increase step", ErrInvalidQuery, MaxSeriesPoints)
+	}
+	return nil
+}
+
+// Series возвращает ряд значений поля q.Field, упорядоченный по времени.
+// Записи без значения поля (в том числе с ошибкой декодирования)
+// не учитываются. Без прореживания ряд длиннее MaxSeriesPoints — ошибка.
+func (a *Archive) Series(q Query) (Series, error) {
+	if err := q.Validate(); err != nil {
+		return Series{}, err
+	}
+
+	s := Series{NoradID: q.NoradID, Field: q.Field, Points: []Point{}}
+	buckets := make(map[int64]*Point)
+	var tooMany bool
+	err := a.Records(q.NoradID, q.From, q.To, func(rec Record) bool {
+		v, ok := fieldValue(rec.Values, q.Field)
+		if !ok {
+			return true
+		}
+		if s.Unit == "" {
+			s.Unit = v.Unit
+		}
+
+		if q.Step == 0 {
+			if len(s.Points) == MaxSeriesPoints {
+				tooMany = true
+				return false
+			}
+			s.Points = append(s.Points, Point{
+				Time: rec.Time, Value: v.Value, Min: v.Value, Max: v.Value, Count: 1, PassID: rec.PassID,
+			})
+			return true
+		}
+
+		idx := int64(rec.Time.Sub(q.From) / q.Step)
+		p, ok := buckets[idx]
+		if !ok {
+			p = &Point{Time: q.From.Add(time.Duration(idx) * q.Step), Min: math.Inf(1), Max: math.Inf(-1)}
+			buckets[idx] = p
+		}
+		p.Value += v.Value // сумма, делится на Count после обхода
+		p.Min = min(p.Min, v.Value)
+		p.Max = max(p.Max, v.Value)
+		p.Count++
+		return true
+	})
+	if err != nil {
+		return Series{}, err
+	}
+	if tooMany {
+		return Series{}, fmt.Errorf("%w: more than %d points, set step", ErrInvalidQuery, MaxSeriesPoints)
+	}
+
+	for _, p := range buckets {
+		p.Value /= float64(p.Count)
+		s.Points = append(s.Points, *p)
+	}
+	slices.SortStableFunc(s.Points, func(a, b Point) int { return a.Time.Compare(b.Time) })
+	return s, nil
+}
+
+// fieldValue находит значение поля name.
+func fieldValue(values []Value, name string) (Value, bool) {
+	for _, v := range values {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Value{}, false
+}
@@ -0,0 +1,155 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// voltageRecord — запись с одним значением напряжения.
+func voltageRecord(t time.Time, volts float64, passID string) Record {
+	return Record{
+		Time:    t,
+		NoradID: 44909,
+		PassID:  passID,
+		Source:  "RS40S",
+		Values:  []Value{{Name: "voltage", Value: volts, Unit: "V"}},
+		Raw:     []byte{0x01, 0x02},
+	}
+}
+
+func TestArchive_Series(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := OpenArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Два пролёта в разные сутки и запись с ошибкой декодирования.
+	day1 := time.Date(2026, 10, 15, 23, 58, 0, 0, time.UTC)
+	day2 := time.Date(2026, 10, 16, 0, 1, 0, 0, time.UTC)
+	for _, rec := range []Record{
+		voltageRecord(day1, 7.0, "20261015T235500Z"),
+		voltageRecord(day1.Add(30*time.Second), 7.2, "20261015T235500Z"),
+		voltageRecord(day2, 7.4, "20261015T235500Z"),
+		{Time: day2.Add(10 * time.Second), NoradID: 44909, Error: "short payload"},
+		voltageRecord(day2.Add(12*time.Hour), 8.0, "20261016T120000Z"),
+	} {
+		if err := archive.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "44909", "2026-10-16.jsonl")); err != nil {
+		t.Fatalf("day file: %v", err)
+	}
+
+	// Повреждённая строка пропускается.
+	f, err := os.OpenFile(filepath.Join(dir, "44909", "2026-10-15.jsonl"), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"time":"2026-10`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	from := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	raw, err := archive.Series(Query{NoradID: 44909, Field: "voltage", From: from, To: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Unit != "V" || len(raw.Points) != 4 {
+		t.Fatalf("raw series = %+v, want 4 points in V", raw)
+	}
+	if p := raw.Points[3]; p.Value != 8.0 || p.Count != 1 || p.PassID != "20261016T120000Z" {
+		t.Errorf("last raw point = %+v", p)
+	}
+
+	hourly, err := archive.Series(Query{NoradID: 44909, Field: "voltage", From: from, To: to, Step: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hourly.Points) != 3 {
+		t.Fatalf("hourly points = %+v, want 3", hourly.Points)
+	}
+	if p := hourly.Points[0]; !p.Time.Equal(day1.Truncate(time.Hour)) || p.Count != 2 ||
+		p.Min != 7.0 || p.Max != 7.2 || p.Value < 7.09 || p.Value > 7.11 || p.PassID != "" {
+		t.Errorf("first hourly point = %+v", p)
+	}
+
+	// Интервал отбирает записи, поле без значений даёт пустой ряд.
+	late, err := archive.Series(Query{NoradID: 44909, Field: "voltage", From: day2, To: to})
+	if err != nil || len(late.Points) != 2 {
+		t.Errorf("late series = %+v, %v; want 2 points", late, err)
+	}
+	none, err := archive.Series(Query{NoradID: 44909, Field: "current", From: from, To: to})
+	if err != nil || none.Points == nil || len(none.Points) != 0 {
+		t.Errorf("unknown field series = %+v, %v; want empty", none, err)
+	}
+	other, err := archive.Series(Query{NoradID: 25544, Field: "voltage", From: from, To: to})
+	if err != nil || len(other.Points) != 0 {
+		t.Errorf("other satellite series = %+v, %v; want empty", other, err)
+	}
+}
+
+func TestArchive_Run(t *testing.T) {
+	archive, err := OpenArchive(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		if !archive.Enqueue(voltageRecord(at.Add(time.Duration(i)*time.Second), 7, "")) {
+			t.Fatal("Enqueue = false")
+		}
+	}
+
+	// Записи, оставшиеся в очереди при отмене, сохраняются перед выходом.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	archive.Run(ctx)
+
+	var n int
+	if err := archive.Records(44909, at, at.Add(time.Hour), func(Record) bool { n++; return true }); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("archived %d records, want 3", n)
+	}
+}
+
+func TestQuery_Validate(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		query   Query
+		wantErr bool
+	}{
+		{"raw points", Query{NoradID: 44909, Field: "voltage", From: from, To: to}, false},
+		{"hourly", Query{NoradID: 44909, Field: "voltage", From: from, To: to, Step: time.Hour}, false},
+		{"no satellite", Query{Field: "voltage", From: from, To: to}, true},
+		{"no field", Query{NoradID: 44909, From: from, To: to}, true},
+		{"empty interval", Query{NoradID: 44909, Field: "voltage", From: to, To: from}, true},
+		{"negative step", Query{NoradID: 44909, Field: "voltage", From: from, To: to, Step: -time.Minute}, true},
+		{"too many points", Query{NoradID: 44909, Field: "voltage", From: from, To: to, Step: time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("error %v is not ErrInvalidQuery", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/ax25"
+	"github.com/art-injener/satwatch-go/internal/catalog"
+)
+
+const (
+	// subscriberQueue — число записей в очереди подписчика.
+	subscriberQueue = 16
+	// frameQueue — число кадров, ожидающих обработки в Run.
+	frameQueue = 256
+	// indexRefresh — период обновления индекса позывных из каталога.
+	indexRefresh = 5 * time.Second
+)
+
+// ErrUnknownSource возвращается для кадров от позывного, которому
+// не соответствует спутник каталога.
+var ErrUnknownSource = errors.New("telemetry: no catalog satellite for source")
+
+// passIDLayout — формат идентификатора пролёта: время AOS в UTC.
+const passIDLayout = "20060102T150405Z"
+
+// Signal — параметры приёма кадра.
+type Signal struct {
+	Frequency float64 `json:"frequency,omitempty"` // центральная частота приёмника, Гц
+	Mode      string  `json:"mode,omitempty"`
+}
+
+// Record — принятый кадр спутника каталога и декодированные значения.
+type Record struct {
+	Time      time.Time `json:"time"`
+	NoradID   int       `json:"norad_id"`
+	Satellite string    `json:"satellite"`
+	// PassID — время AOS пролёта, во время которого принят кадр.
+	PassID string  `json:"pass_id,omitempty"`
+	Source string  `json:"source"` // позывной отправителя
+	Signal Signal  `json:"signal"`
+	Schema string  `json:"schema,omitempty"`
+	Values []Value `json:"values,omitempty"`
+	// Error — причина, по которой кадр не декодирован.
+	Error string `json:"error,omitempty"`
+	Raw   []byte `json:"raw"` // кадр AX.25 без FCS
+}
+
+// PassFunc возвращает AOS пролёта спутника noradID, идущего в момент t.
+type PassFunc func(noradID int, t time.Time) (aos time.Time, ok bool)
+
+// Monitor сопоставляет принятые кадры спутникам каталога по позывному,
+// декодирует их схемой спутника и рассылает записи подписчикам.
+type Monitor struct {
+	store    catalog.Store
+	registry *Registry
+	passes   PassFunc
+	frames   chan frame
+
+	indexMu sync.Mutex
+	index   callsignIndex
+	indexed time.Time // время построения индекса
+
+	mu   sync.Mutex
+	subs map[chan Record]struct{}
+}
+
+// frame — принятый кадр в очереди обработки.
+type frame struct {
+	t     time.Time
+	frame ax25.Frame
+	sig   Signal
+}
+
+// callsignIndex — спутники каталога по позывному в верхнем регистре:
+// exact — позывные с SSID, base — без SSID.
+type callsignIndex struct {
+	exact map[string]catalog.Satellite
+	base  map[string]catalog.Satellite
+}
+
+// NewMonitor создаёт монитор телеметрии; passes определяет пролёт
+// для записи (nil — записи без идентификатора пролёта).
+func NewMonitor(store catalog.Store, registry *Registry, passes PassFunc) *Monitor {
+	return &Monitor{
+		store:    store,
+		registry: registry,
+		passes:   passes,
+		frames:   make(chan frame, frameQueue),
+		subs:     make(map[chan Record]struct{}),
+	}
+}
+
+// Submit передаёт кадр, принятый в момент t, на обработку в Run.
+// Не блокируется: при переполнении очереди кадр отбрасывается и
+// возвращается false. Предназначен для горутины приёмника.
+func (m *Monitor) Submit(t time.Time, f ax25.Frame, sig Signal) bool {
+	select {
+	case m.frames <- frame{t: t, frame: f, sig: sig}:
+		return true
+	default:
+		slog.Warn("telemetry queue is full, frame dropped", "source", f.Source.String())
+		return false
+	}
+}
+
+// Run обрабатывает кадры из Submit до отмены ctx и передаёт handle
+// записи кадров спутников каталога.
+func (m *Monitor) Run(ctx context.Context, handle func(Record)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case f := <-m.frames:
+			rec, err := m.Handle(f.t, f.frame, f.sig)
+			if err != nil {
+				slog.Debug("telemetry frame is not decoded", "source", f.frame.Source.String(), "error", err)
+				continue
+			}
+			if handle != nil {
+				handle(rec)
+			}
+		}
+	}
+}
+
+// Handle сопоставляет кадр, принятый в момент t, спутнику каталога,
+// декодирует его и рассылает запись. Ошибка возвращается, только если
+// спутник не найден: кадр без схемы или с ошибкой декодирования
+// сохраняется в записи без значений.
+func (m *Monitor) Handle(t time.Time, frame ax25.Frame, sig Signal) (Record, error) {
+	sat, err := m.satellite(frame.Source)
+	if err != nil {
+		return Record{}, err
+	}
+
+	rec := Record{
+		Time:      t,
+		NoradID:   sat.NoradID,
+		Satellite: sat.Name,
+		Source:    frame.Source.String(),
+		Signal:    sig,
+		Schema:    sat.TelemetrySchema,
+		Raw:       frame.Encode(),
+	}
+	if m.passes != nil {
+		if aos, ok := m.passes(sat.NoradID, t); ok {
+			rec.PassID = aos.UTC().Format(passIDLayout)
+		}
+	}
+	if sat.TelemetrySchema != "" {
+		rec.Values, err = m.decode(sat.TelemetrySchema, frame.Info)
+		if err != nil {
+			rec.Error = err.Error()
+		}
+	}
+
+	m.publish(rec)
+	return rec, nil
+}
+
+// decode декодирует данные кадра схемой name.
+func (m *Monitor) decode(name string, payload []byte) ([]Value, error) {
+	dec, err := m.registry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return dec.Decode(payload)
+}
+
+// satellite находит спутник по позывному отправителя: позывной каталога
+// без SSID подходит к любому SSID. Индекс позывных строится из каталога
+// не чаще раза в indexRefresh.
+func (m *Monitor) satellite(src ax25.Address) (catalog.Satellite, error) {
+	m.indexMu.Lock()
+	defer m.indexMu.Unlock()
+
+	if m.index.base == nil || time.Since(m.indexed) >= indexRefresh {
+		sats, err := m.store.List()
+		if err != nil {
+			return catalog.Satellite{}, err
+		}
+		m.index, m.indexed = newCallsignIndex(sats), time.Now()
+	}
+	if sat, ok := m.index.exact[strings.ToUpper(src.String())]; ok {
+		return sat, nil
+	}
+	if sat, ok := m.index.base[strings.ToUpper(src.Call)]; ok {
+		return sat, nil
+	}
+	return catalog.Satellite{}, fmt.Errorf("%w %s", ErrUnknownSource, src)
+}
+
+// newCallsignIndex индексирует спутники с позывными; при совпадении
+// позывных выбирается спутник с меньшим номером NORAD.
+func newCallsignIndex(sats []catalog.Satellite) callsignIndex {
+	idx := callsignIndex{
+		exact: make(map[string]catalog.Satellite),
+		base:  make(map[string]catalog.Satellite),
+	}
+	for _, sat := range sats {
+		if sat.Callsign == "" {
+			continue
+		}
+		target := idx.base
+		if strings.Contains(sat.Callsign, "-") {
+			target = idx.exact
+		}
+		key := strings.ToUpper(sat.Callsign)
+		if _, ok := target[key]; !ok {
+			target[key] = sat
+		}
+	}
+	return idx
+}
+
+// Subscribe регистрирует получателя записей. Если получатель не успевает,
+// новые записи для него отбрасываются. Возвращаемая функция отменяет
+// подписку.
+func (m *Monitor) Subscribe() (<-chan Record, func()) {
+	ch := make(chan Record, subscriberQueue)
+
+	m.mu.Lock()
+	m.subs[ch] = struct{}{}
+	m.mu.Unlock()
+
+	return ch, func() {
+		m.mu.Lock()
+		delete(m.subs, ch)
+		m.mu.Unlock()
+	}
+}
+
+// publish рассылает запись подписчикам без блокировки.
+func (m *Monitor) publish(rec Record) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for ch := range m.subs {
+		select {
+		case ch <- rec:
+		default:
+			slog.Warn("telemetry subscriber is too slow, record dropped", "norad_id", rec.NoradID)
+		}
+	}
+}
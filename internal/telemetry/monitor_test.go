@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/ax25"
+	"github.com/art-injener/satwatch-go/internal/catalog"
+)
+
+// constDecoder — декодер, задаваемый в коде.
+type constDecoder []Value
+
+func (d constDecoder) Decode([]byte) ([]Value, error) { return d, nil }
+
+func TestMonitor_Handle(t *testing.T) {
+	store := catalog.NewMemoryStore()
+	for _, sat := range []catalog.Satellite{
+		{NoradID: 44909, Name: "RS40S", Callsign: "RS40S", TelemetrySchema: "beacon"},
+		{NoradID: 57167, Name: "UMKA-1", Callsign: "RS-71", TelemetrySchema: "umka"},
+		{NoradID: 25544, Name: "ISS", Callsign: "RS0ISS"},
+		{NoradID: 99999, Name: "NOCALL", TelemetrySchema: "beacon"},
+	} {
+		if err := store.Create(sat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	registry := NewRegistry()
+	if _, err := registry.LoadDir("testdata"); err != nil {
+		t.Fatal(err)
+	}
+	registry.Register("umka", constDecoder{{Name: "counter", Value: 1}})
+
+	aos := time.Date(2026, 10, 16, 11, 55, 0, 0, time.UTC)
+	m := NewMonitor(store, registry, func(noradID int, t time.Time) (time.Time, bool) {
+		return aos, noradID == 44909
+	})
+	records, unsubscribe := m.Subscribe()
+	defer unsubscribe()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	frame := ax25.NewUI(ax25.Address{Call: "CQ"}, ax25.Address{Call: "RS40S", SSID: 1}, beaconPayload)
+	sig := Signal{Frequency: 435.4e6, Mode: "fsk"}
+	rec, err := m.Handle(now, frame, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.NoradID != 44909 || rec.Source != "RS40S-1" || rec.Schema != "beacon" || len(rec.Values) != 6 ||
+		rec.PassID != "20261016T115500Z" || rec.Signal != sig || !bytes.Equal(rec.Raw, frame.Encode()) {
+		t.Errorf("record = %+v", rec)
+	}
+	select {
+	case got := <-records:
+		if got.NoradID != 44909 || !got.Time.Equal(now) {
+			t.Errorf("published record = %+v", got)
+		}
+	default:
+		t.Error("record was not published")
+	}
+
+	// Позывной каталога с SSID требует точного совпадения.
+	from := func(call string, ssid uint8, info []byte) ax25.Frame {
+		return ax25.NewUI(ax25.Address{Call: "CQ"}, ax25.Address{Call: call, SSID: ssid}, info)
+	}
+	if rec, err := m.Handle(now, from("RS", 71, nil), sig); err != nil || rec.PassID != "" {
+		t.Errorf("Handle(RS-71) = %+v, %v", rec, err)
+	}
+	if _, err := m.Handle(now, from("RS", 72, nil), sig); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Handle(RS-72) error = %v, want ErrUnknownSource", err)
+	}
+
+	// Кадр без схемы или с ошибкой декодирования сохраняется без значений.
+	if rec, err := m.Handle(now, from("RS0ISS", 0, []byte("hello")), sig); err != nil || rec.NoradID != 25544 || rec.Values != nil {
+		t.Errorf("Handle(no schema) = %+v, %v", rec, err)
+	}
+	if rec, err := m.Handle(now, from("RS40S", 0, []byte{1}), sig); err != nil || rec.Values != nil || rec.Error == "" {
+		t.Errorf("Handle(short) = %+v, %v", rec, err)
+	}
+}
+
+// countingStore считает чтения каталога.
+type countingStore struct {
+	catalog.Store
+	lists int
+}
+
+func (s *countingStore) List() ([]catalog.Satellite, error) {
+	s.lists++
+	return s.Store.List()
+}
+
+func TestMonitor_Run(t *testing.T) {
+	store := &countingStore{Store: catalog.NewMemoryStore()}
+	if err := store.Create(catalog.Satellite{NoradID: 44909, Name: "RS40S", Callsign: "rs40s"}); err != nil {
+		t.Fatal(err)
+	}
+	m := NewMonitor(store, NewRegistry(), nil)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for _, call := range []string{"RS40S", "UNKNOWN", "RS40S"} {
+		frame := ax25.NewUI(ax25.Address{Call: "CQ"}, ax25.Address{Call: call}, []byte("hi"))
+		if !m.Submit(now, frame, Signal{}) {
+			t.Fatalf("Submit(%s) = false", call)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan Record)
+	go m.Run(ctx, func(rec Record) { got <- rec })
+	for range 2 {
+		select {
+		case rec := <-got:
+			if rec.NoradID != 44909 {
+				t.Errorf("record = %+v", rec)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("record was not handled")
+		}
+	}
+	if store.lists != 1 {
+		t.Errorf("catalog listed %d times, want 1", store.lists)
+	}
+}
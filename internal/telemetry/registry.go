@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownSchema возвращается, если декодер с таким именем не
+// зарегистрирован.
+var ErrUnknownSchema = errors.New("telemetry: unknown schema")
+
+// Decoder преобразует данные кадра в инженерные значения. Основная
+// реализация — Schema; форматы, которые не описываются смещениями полей
+// (сжатие, переменная длина), регистрируются как отдельные декодеры.
+type Decoder interface {
+	Decode(payload []byte) ([]Value, error)
+}
+
+// Registry хранит декодеры по имени, на которое ссылается спутник
+// каталога.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewRegistry создаёт пустой реестр.
+func NewRegistry() *Registry {
+	return &Registry{decoders: make(map[string]Decoder)}
+}
+
+// Register добавляет или заменяет декодер name.
+func (r *Registry) Register(name string, d Decoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[name] = d
+}
+
+// Get возвращает декодер по имени.
+func (r *Registry) Get(name string) (Decoder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSchema, name)
+	}
+	return d, nil
+}
+
+// Names возвращает имена зарегистрированных декодеров по алфавиту.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.decoders))
+	for name := range r.decoders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LoadDir регистрирует схемы из файлов *.json каталога dir и возвращает
+// их число. Схема без имени получает имя файла без расширения.
+func (r *Registry) LoadDir(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		s, err := decodeSchema(data)
+		if err == nil && s.Name == "" {
+			s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if err == nil {
+			err = s.Validate()
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", path, err)
+		}
+		r.Register(s.Name, s)
+	}
+	return len(paths), nil
+}
@@ -0,0 +1,35 @@
+package telemetry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRegistry_LoadDir(t *testing.T) {
+	r := NewRegistry()
+	n, err := r.LoadDir("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Схема без поля name получает имя файла.
+	if n != 1 || !slices.Equal(r.Names(), []string{"beacon"}) {
+		t.Errorf("loaded %d schemas: %v", n, r.Names())
+	}
+	if _, err := r.Get("beacon"); err != nil {
+		t.Error(err)
+	}
+	if _, err := r.Get("missing"); !errors.Is(err, ErrUnknownSchema) {
+		t.Errorf("Get(missing) error = %v, want ErrUnknownSchema", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"fields":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRegistry().LoadDir(dir); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("LoadDir(broken) error = %v, want ErrInvalidSchema", err)
+	}
+}
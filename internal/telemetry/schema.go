@@ -0,0 +1,217 @@
+// Package telemetry декодирует данные кадров телеметрии в инженерные
+// значения: схема бикона описывает поля (смещение, разрядность, порядок
+// байт, масштаб, единицы, перечисления), спутник каталога ссылается
+// на схему по имени.
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Ошибки схем и декодирования.
+var (
+	ErrInvalidSchema = errors.New("telemetry: invalid schema")
+	ErrShortPayload  = errors.New("telemetry: payload is too short")
+)
+
+// Порядок байт и типы полей.
+const (
+	EndianBig    = "big"
+	EndianLittle = "little"
+
+	TypeUint  = "uint"
+	TypeInt   = "int"
+	TypeFloat = "float"
+)
+
+// Field описывает поле кадра. Положение задаётся байтом и битом внутри
+// него (0 — старший разряд); поля с порядком байт "little" выравниваются
+// по байтам. Инженерное значение — raw·Scale + Offset.
+type Field struct {
+	Name   string  `json:"name"`
+	Byte   int     `json:"byte"`
+	Bit    int     `json:"bit,omitempty"`
+	Bits   int     `json:"bits"`
+	Endian string  `json:"endian,omitempty"` // big (по умолчанию), little
+	Type   string  `json:"type,omitempty"`   // uint (по умолчанию), int, float
+	Scale  float64 `json:"scale,omitempty"`  // 0 — без масштабирования
+	Offset float64 `json:"offset,omitempty"`
+	Unit   string  `json:"unit,omitempty"`
+	// Enum сопоставляет сырым значениям (в десятичной записи) подписи.
+	Enum map[string]string `json:"enum,omitempty"`
+}
+
+// Schema — описание кадра телеметрии.
+type Schema struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Fields      []Field `json:"fields"`
+}
+
+// ParseSchema разбирает схему в JSON и проверяет её.
+func ParseSchema(data []byte) (*Schema, error) {
+	s, err := decodeSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// decodeSchema разбирает JSON без проверки; неизвестные ключи — ошибка,
+// чтобы опечатка в названии параметра поля не проходила незамеченной.
+func decodeSchema(data []byte) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	return &s, nil
+}
+
+// Validate проверяет схему: имена полей уникальны, разрядность
+// соответствует типу и порядку байт.
+func (s *Schema) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSchema)
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("%w: %s: no fields", ErrInvalidSchema, s.Name)
+	}
+	names := make(map[string]bool, len(s.Fields))
+	for _, f := range s.Fields {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("%w: %s: field %q: %s", ErrInvalidSchema, s.Name, f.Name, err)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("%w: %s: duplicate field %q", ErrInvalidSchema, s.Name, f.Name)
+		}
+		names[f.Name] = true
+	}
+	return nil
+}
+
+// validate возвращает описание первой ошибки поля.
+func (f *Field) validate() error {
+	switch {
+	case f.Name == "":
+		return errors.New("name is required")
+	case f.Byte < 0 || f.Bit < 0 || f.Bit > 7:
+		return errors.New("position is out of range")
+	case f.Bits < 1 || f.Bits > 64:
+		return errors.New("bits must be within 1..64")
+	}
+	switch f.Endian {
+	case "", EndianBig:
+	case EndianLittle:
+		if f.Bit != 0 || f.Bits%8 != 0 {
+			return errors.New("little-endian fields must be byte-aligned")
+		}
+	default:
+		return fmt.Errorf("unknown endian %q", f.Endian)
+	}
+	switch f.Type {
+	case "", TypeUint, TypeInt:
+	case TypeFloat:
+		if f.Bits != 32 && f.Bits != 64 {
+			return errors.New("float fields must be 32 or 64 bits")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", f.Type)
+	}
+	for key := range f.Enum {
+		if _, err := strconv.ParseInt(key, 10, 64); err != nil {
+			if _, err := strconv.ParseUint(key, 10, 64); err != nil {
+				return fmt.Errorf("enum key %q is not an integer", key)
+			}
+		}
+	}
+	return nil
+}
+
+// Value — инженерное значение поля.
+type Value struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+	// Text — подпись перечисления для сырого значения.
+	Text string `json:"text,omitempty"`
+	Raw  uint64 `json:"raw"`
+}
+
+// String возвращает значение для отображения: подпись перечисления или
+// число с единицами.
+func (v Value) String() string {
+	if v.Text != "" {
+		return v.Text
+	}
+	s := strconv.FormatFloat(v.Value, 'g', 6, 64)
+	if v.Unit != "" {
+		s += " " + v.Unit
+	}
+	return s
+}
+
+// Decode извлекает значения всех полей из данных кадра.
+func (s *Schema) Decode(payload []byte) ([]Value, error) {
+	values := make([]Value, 0, len(s.Fields))
+	for _, f := range s.Fields {
+		if end := f.Byte*8 + f.Bit + f.Bits; end > len(payload)*8 {
+			return nil, fmt.Errorf("%w: field %q needs %d bytes, got %d", ErrShortPayload, f.Name, (end+7)/8, len(payload))
+		}
+		values = append(values, f.decode(payload))
+	}
+	return values, nil
+}
+
+// decode извлекает значение поля; длина данных уже проверена.
+func (f *Field) decode(payload []byte) Value {
+	var raw uint64
+	if f.Endian == EndianLittle {
+		for i := f.Bits/8 - 1; i >= 0; i-- {
+			raw = raw<<8 | uint64(payload[f.Byte+i])
+		}
+	} else {
+		start := f.Byte*8 + f.Bit
+		for i := start; i < start+f.Bits; i++ {
+			raw = raw<<1 | uint64(payload[i/8]>>(7-i%8)&1)
+		}
+	}
+
+	var num float64
+	key := strconv.FormatUint(raw, 10)
+	switch f.Type {
+	case TypeInt:
+		v := int64(raw)
+		if f.Bits < 64 && raw>>(f.Bits-1)&1 != 0 {
+			v -= 1 << f.Bits
+		}
+		num, key = float64(v), strconv.FormatInt(v, 10)
+	case TypeFloat:
+		if f.Bits == 32 {
+			num = float64(math.Float32frombits(uint32(raw)))
+		} else {
+			num = math.Float64frombits(raw)
+		}
+	default:
+		num = float64(raw)
+	}
+	if f.Scale != 0 {
+		num *= f.Scale
+	}
+	return Value{
+		Name:  f.Name,
+		Value: num + f.Offset,
+		Unit:  f.Unit,
+		Text:  f.Enum[key],
+		Raw:   raw,
+	}
+}
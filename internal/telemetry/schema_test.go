@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+// beaconPayload — кадр для testdata/beacon.json: 7,400 В, −250 мА,
+// 25 °C, режим NOMINAL, нагреватель включён, 86400 с.
+var beaconPayload = []byte{0x1C, 0xE8, 0x06, 0xFF, 90, 0b001_1_0000, 0x80, 0x51, 0x01, 0x00}
+
+func TestSchema_Decode(t *testing.T) {
+	data, err := os.ReadFile("testdata/beacon.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := decodeSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Name = "beacon"
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := s.Decode(beaconPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		value float64
+		text  string
+		raw   uint64
+	}{
+		{value: 7.4, raw: 7400},
+		{value: -250, raw: 0xFF06},
+		{value: 25, raw: 90},
+		{value: 1, text: "NOMINAL", raw: 1},
+		{value: 1, text: "ON", raw: 1},
+		{value: 86400, raw: 86400},
+	}
+	if len(values) != len(want) {
+		t.Fatalf("got %d values, want %d", len(values), len(want))
+	}
+	for i, w := range want {
+		v := values[i]
+		if math.Abs(v.Value-w.value) > 1e-9 || v.Text != w.text || v.Raw != w.raw {
+			t.Errorf("%s = %+v, want %v %q raw %d", v.Name, v, w.value, w.text, w.raw)
+		}
+	}
+	if got := values[0].String(); got != "7.4 V" {
+		t.Errorf("String() = %q, want %q", got, "7.4 V")
+	}
+	if got := values[3].String(); got != "NOMINAL" {
+		t.Errorf("String() = %q, want NOMINAL", got)
+	}
+
+	if _, err := s.Decode(beaconPayload[:8]); !errors.Is(err, ErrShortPayload) {
+		t.Errorf("Decode(short) error = %v, want ErrShortPayload", err)
+	}
+}
+
+func TestSchema_DecodeSigned(t *testing.T) {
+	s := &Schema{Name: "signed", Fields: []Field{
+		{Name: "nibble", Byte: 0, Bit: 4, Bits: 4, Type: TypeInt, Enum: map[string]string{"-1": "MINUS ONE"}},
+		{Name: "float", Byte: 1, Bits: 32, Type: TypeFloat},
+	}}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bits := math.Float32bits(-1.5)
+	values, err := s.Decode([]byte{0x0F, byte(bits >> 24), byte(bits >> 16), byte(bits >> 8), byte(bits)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0].Value != -1 || values[0].Text != "MINUS ONE" || values[1].Value != -1.5 {
+		t.Errorf("values = %+v", values)
+	}
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"no name", `{"fields":[{"name":"a","byte":0,"bits":8}]}`},
+		{"no fields", `{"name":"s"}`},
+		{"unknown key", `{"name":"s","fields":[{"name":"a","byte":0,"bits":8,"scael":2}]}`},
+		{"duplicate field", `{"name":"s","fields":[{"name":"a","byte":0,"bits":8},{"name":"a","byte":1,"bits":8}]}`},
+		{"too wide", `{"name":"s","fields":[{"name":"a","byte":0,"bits":65}]}`},
+		{"bit out of range", `{"name":"s","fields":[{"name":"a","byte":0,"bit":8,"bits":1}]}`},
+		{"unaligned little-endian", `{"name":"s","fields":[{"name":"a","byte":0,"bit":2,"bits":8,"endian":"little"}]}`},
+		{"float width", `{"name":"s","fields":[{"name":"a","byte":0,"bits":16,"type":"float"}]}`},
+		{"unknown type", `{"name":"s","fields":[{"name":"a","byte":0,"bits":8,"type":"bcd"}]}`},
+		{"enum key", `{"name":"s","fields":[{"name":"a","byte":0,"bits":8,"enum":{"on":"ON"}}]}`},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSchema([]byte(tt.schema)); !errors.Is(err, ErrInvalidSchema) {
+			t.Errorf("%s: ParseSchema() error = %v, want ErrInvalidSchema", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package tle
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// maxAlpha5 — наибольший номер NORAD, представимый в формате Alpha-5 (Z9999).
+const maxAlpha5 = 339999
+
+// Format записывает набор элементов в две строки TLE с контрольными суммами.
+// Угловые элементы нормализуются в [0, 360), номера свыше 99999
+// кодируются в формате Alpha-5.
+func Format(set ElementSet) (line1, line2 string, err error) {
+	set.Inclination = math.Round(set.Inclination*1e4) / 1e4
+	set.RAAN = formatAngle(set.RAAN)
+	set.ArgPerigee = formatAngle(set.ArgPerigee)
+	set.MeanAnomaly = formatAngle(set.MeanAnomaly)
+
+	sat, err := formatSatellite(set.NoradID)
+	if err != nil {
+		return "", "", err
+	}
+	epoch, err := formatEpoch(set.Epoch)
+	if err != nil {
+		return "", "", err
+	}
+	ndot, err := formatDecimal(set.MeanMotionDot, fieldMeanMotionDot)
+	if err != nil {
+		return "", "", err
+	}
+	nddot, err := formatExponent(set.MeanMotionDDot, fieldMeanMotionDDot)
+	if err != nil {
+		return "", "", err
+	}
+	bstar, err := formatExponent(set.BStar, fieldBStar)
+	if err != nil {
+		return "", "", err
+	}
+	if err := set.validate(func(string) (int, int) { return 0, 0 }); err != nil {
+		return "", "", err
+	}
+
+	class := set.Classification
+	if class == "" {
+		class = "U"
+	}
+	ecc := int(math.Round(set.Eccentricity * 1e7))
+	if ecc > 9999999 {
+		return "", "", fmt.Errorf("tle: format %s: %w", fieldEccentricity, ErrOutOfRange)
+	}
+
+	line1 = fmt.Sprintf("1 %s%1.1s %-8.8s %s %s %s %s %1d %4d",
+		sat, class, set.IntlDesignator, epoch, ndot, nddot, bstar,
+		set.EphemerisType%10, set.ElementSetNo%10000)
+	line2 = fmt.Sprintf("2 %s %8.4f %8.4f %07d %8.4f %8.4f %11.8f%5d",
+		sat, set.Inclination, set.RAAN, ecc,
+		set.ArgPerigee, set.MeanAnomaly, set.MeanMotion, set.RevNumber%100000)
+
+	if len(line1) != lineLength-1 || len(line2) != lineLength-1 {
+		return "", "", fmt.Errorf("tle: format: %w", ErrColumnLayout)
+	}
+	line1 += string(rune('0' + Checksum(line1)))
+	line2 += string(rune('0' + Checksum(line2)))
+	return line1, line2, nil
+}
+
+// formatSatellite записывает номер NORAD, при необходимости в формате Alpha-5.
+func formatSatellite(id int) (string, error) {
+	switch {
+	case id < 0 || id > maxAlpha5:
+		return "", fmt.Errorf("tle: format %s: %w", fieldSatellite, ErrOutOfRange)
+	case id < 100000:
+		return fmt.Sprintf("%05d", id), nil
+	}
+
+	prefix := id / 10000
+	for c := byte('A'); c <= 'Z'; c++ {
+		if c != 'I' && c != 'O' && alpha5Value(c) == prefix {
+			return fmt.Sprintf("%c%04d", c, id%10000), nil
+		}
+	}
+	return "", fmt.Errorf("tle: format %s: %w", fieldSatellite, ErrOutOfRange)
+}
+
+// formatEpoch записывает эпоху как год (две цифры) и день года с дробной частью.
+func formatEpoch(t time.Time) (string, error) {
+	t = t.UTC()
+	if t.Year() < 1957 || t.Year() > 2056 {
+		return "", fmt.Errorf("tle: format %s: %w", fieldEpoch, ErrInvalidEpoch)
+	}
+	start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := 1 + float64(t.Sub(start))/float64(24*time.Hour)
+	return fmt.Sprintf("%02d%012.8f", t.Year()%100, day), nil
+}
+
+// formatDecimal записывает число вида "-.NNNNNNNN" (|v| < 1).
+func formatDecimal(v float64, field string) (string, error) {
+	s := fmt.Sprintf("%.8f", math.Abs(v))
+	if !strings.HasPrefix(s, "0.") {
+		return "", fmt.Errorf("tle: format %s: %w", field, ErrOutOfRange)
+	}
+	sign := " "
+	if v < 0 && s != "0.00000000" {
+		sign = "-"
+	}
+	return sign + s[1:], nil
+}
+
+// formatExponent записывает число в поле вида "±NNNNN±E".
+func formatExponent(v float64, field string) (string, error) {
+	if v == 0 {
+		return " 00000-0", nil
+	}
+
+	sign := " "
+	if v < 0 {
+		sign = "-"
+	}
+	mag := math.Abs(v)
+	exp := int(math.Floor(math.Log10(mag))) + 1
+	mantissa := int(math.Round(mag / math.Pow10(exp) * 1e5))
+	if mantissa >= 100000 {
+		mantissa /= 10
+		exp++
+	}
+	if exp < -9 || exp > 9 {
+		return "", fmt.Errorf("tle: format %s: %w", field, ErrInvalidExponent)
+	}
+
+	expSign := "+"
+	if exp < 0 {
+		expSign, exp = "-", -exp
+	}
+	return fmt.Sprintf("%s%05d%s%d", sign, mantissa, expSign, exp), nil
+}
+
+// formatAngle приводит угол к [0, 360) с учётом округления до 4 знаков.
+func formatAngle(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	if math.Round(deg*1e4) >= 360e4 {
+		return 0
+	}
+	return deg
+}
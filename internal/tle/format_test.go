@@ -0,0 +1,106 @@
+package tle
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormat_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		line1 string
+		line2 string
+	}{
+		{"iss", issLine1, issLine2},
+		{
+			"vanguard",
+			"1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+			"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := ParseTLE("", tt.line1, tt.line2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			line1, line2, err := Format(set)
+			if err != nil {
+				t.Fatalf("Format() error: %v", err)
+			}
+			if line1 != tt.line1 {
+				t.Errorf("line1:\n got %q\nwant %q", line1, tt.line1)
+			}
+			if line2 != tt.line2 {
+				t.Errorf("line2:\n got %q\nwant %q", line2, tt.line2)
+			}
+		})
+	}
+}
+
+func TestFormat_SyntheticElements(t *testing.T) {
+	set := ElementSet{
+		NoradID:      100001,
+		Epoch:        time.Date(2025, time.March, 1, 6, 30, 0, 0, time.UTC),
+		Inclination:  97.4,
+		RAAN:         -10,         // нормализуется в 350
+		ArgPerigee:   359.9999999, // округляется до 0
+		MeanAnomaly:  720.5,
+		MeanMotion:   15.2,
+		BStar:        0.00012345,
+		ElementSetNo: 999,
+	}
+
+	line1, line2, err := Format(set)
+	if err != nil {
+		t.Fatalf("Format() error: %v", err)
+	}
+	got, err := ParseTLE("", line1, line2)
+	if err != nil {
+		t.Fatalf("ParseTLE(Format()) error: %v\n%s\n%s", err, line1, line2)
+	}
+
+	if got.NoradID != 100001 || line1[2:7] != "A0001" {
+		t.Errorf("NoradID = %d (%q), want 100001 as A0001", got.NoradID, line1[2:7])
+	}
+	// Восемь знаков дробной части суток дают точность около миллисекунды.
+	if d := got.Epoch.Sub(set.Epoch).Abs(); d > time.Millisecond {
+		t.Errorf("Epoch = %v, want %v", got.Epoch, set.Epoch)
+	}
+	if got.RAAN != 350 || got.ArgPerigee != 0 || got.MeanAnomaly != 0.5 {
+		t.Errorf("angles = %v/%v/%v, want 350/0/0.5", got.RAAN, got.ArgPerigee, got.MeanAnomaly)
+	}
+	if math.Abs(got.BStar-set.BStar) > 1e-12 || got.Classification != "U" {
+		t.Errorf("BStar/Classification = %v/%q", got.BStar, got.Classification)
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	valid := ElementSet{NoradID: 1, Epoch: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), MeanMotion: 15}
+
+	tests := []struct {
+		name    string
+		modify  func(*ElementSet)
+		wantErr error
+	}{
+		{"norad id too large", func(s *ElementSet) { s.NoradID = 340000 }, ErrOutOfRange},
+		{"epoch outside two-digit years", func(s *ElementSet) { s.Epoch = time.Date(2060, 1, 1, 0, 0, 0, 0, time.UTC) }, ErrInvalidEpoch},
+		{"eccentricity", func(s *ElementSet) { s.Eccentricity = 1 }, ErrOutOfRange},
+		{"mean motion dot", func(s *ElementSet) { s.MeanMotionDot = 1.5 }, ErrOutOfRange},
+		{"bstar exponent", func(s *ElementSet) { s.BStar = 1e12 }, ErrInvalidExponent},
+		{"mean motion", func(s *ElementSet) { s.MeanMotion = 0 }, ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := valid
+			tt.modify(&set)
+			if _, _, err := Format(set); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Format() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package tle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ключевые слова CCSDS OMM.
+const (
+	ommVersion        = "CCSDS_OMM_VERS"
+	ommObjectName     = "OBJECT_NAME"
+	ommObjectID       = "OBJECT_ID"
+	ommEpoch          = "EPOCH"
+	ommMeanMotion     = "MEAN_MOTION"
+	ommEccentricity   = "ECCENTRICITY"
+	ommInclination    = "INCLINATION"
+	ommRAAN           = "RA_OF_ASC_NODE"
+	ommArgPericenter  = "ARG_OF_PERICENTER"
+	ommMeanAnomaly    = "MEAN_ANOMALY"
+	ommEphemerisType  = "EPHEMERIS_TYPE"
+	ommClassification = "CLASSIFICATION_TYPE"
+	ommNoradID        = "NORAD_CAT_ID"
+	ommElementSetNo   = "ELEMENT_SET_NO"
+	ommRevAtEpoch     = "REV_AT_EPOCH"
+	ommBStar          = "BSTAR"
+	ommMeanMotionDot  = "MEAN_MOTION_DOT"
+	ommMeanMotionDDot = "MEAN_MOTION_DDOT"
+	ommComment        = "COMMENT"
+)
+
+// ommFieldByName сопоставляет поля ElementSet ключевым словам OMM
+// для указания места ошибки валидации.
+var ommFieldByName = map[string]string{
+	fieldInclination:  ommInclination,
+	fieldRAAN:         ommRAAN,
+	fieldEccentricity: ommEccentricity,
+	fieldArgPerigee:   ommArgPericenter,
+	fieldMeanAnomaly:  ommMeanAnomaly,
+	fieldMeanMotion:   ommMeanMotion,
+}
+
+// Форматы эпохи OMM (UTC, календарная дата или день года).
+var ommEpochLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-002T15:04:05.999999999",
+	"2006-002T15:04:05.999999999Z",
+}
+
+// ommValue — значение ключевого слова вместе с его положением в источнике.
+type ommValue struct {
+	text string
+	line int
+	col  int
+}
+
+// ommRecord — одно сообщение OMM в виде "ключевое слово -> значение".
+type ommRecord struct {
+	line   int // строка начала сообщения
+	values map[string]ommValue
+}
+
+func newOMMRecord(line int) *ommRecord {
+	return &ommRecord{line: line, values: make(map[string]ommValue)}
+}
+
+// ParseOMMXML разбирает CCSDS OMM в формате XML (NDM/XML).
+// Поддерживаются как одиночный элемент <omm>, так и контейнер <ndm>.
+func ParseOMMXML(r io.Reader) ([]ElementSet, error) {
+	dec := xml.NewDecoder(r)
+
+	var (
+		sets    []ElementSet
+		rec     *ommRecord
+		text    strings.Builder
+		leaf    bool
+		leafPos ommValue
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var syntax *xml.SyntaxError
+			if errors.As(err, &syntax) {
+				return nil, &ParseError{Line: syntax.Line, Err: ErrInvalidField}
+			}
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			line, col := dec.InputPos()
+			if strings.EqualFold(t.Name.Local, "omm") {
+				rec = newOMMRecord(line)
+				continue
+			}
+			text.Reset()
+			leaf = true
+			leafPos = ommValue{line: line, col: col}
+		case xml.CharData:
+			if leaf {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if strings.EqualFold(t.Name.Local, "omm") && rec != nil {
+				set, err := rec.elementSet()
+				if err != nil {
+					return nil, err
+				}
+				sets = append(sets, set)
+				rec = nil
+				continue
+			}
+			if leaf && rec != nil {
+				leafPos.text = strings.TrimSpace(text.String())
+				rec.values[strings.ToUpper(t.Name.Local)] = leafPos
+			}
+			leaf = false
+		}
+	}
+
+	if len(sets) == 0 {
+		return nil, &ParseError{Err: ErrUnknownFormat}
+	}
+	return sets, nil
+}
+
+// ParseOMMJSON разбирает OMM в формате JSON (формат GP Celestrak и
+// Space-Track): один объект или массив объектов. Числовые значения
+// допускаются как числами, так и строками.
+func ParseOMMJSON(r io.Reader) ([]ElementSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	trimmed := bytes.TrimLeft(data, " \t\r\n\ufeff")
+	array := len(trimmed) > 0 && trimmed[0] == '['
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, jsonError(data, err)
+		}
+	}
+
+	var sets []ElementSet
+	for !array || dec.More() {
+		offset := dec.InputOffset()
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			if !array && errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, jsonError(data, err)
+		}
+
+		line := lineAt(data, offset)
+		rec := newOMMRecord(line)
+		for key, raw := range obj {
+			rec.values[strings.ToUpper(key)] = ommValue{text: jsonText(raw), line: line}
+		}
+		set, err := rec.elementSet()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+
+		if !array {
+			break
+		}
+	}
+
+	return sets, nil
+}
+
+// ParseOMMKVN разбирает OMM в текстовом формате KVN ("KEY = VALUE").
+// Каждое сообщение начинается с CCSDS_OMM_VERS.
+func ParseOMMKVN(r io.Reader) ([]ElementSet, error) {
+	var (
+		sets   []ElementSet
+		rec    *ommRecord
+		lineNo int
+	)
+
+	flush := func() error {
+		if rec == nil {
+			return nil
+		}
+		set, err := rec.elementSet()
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ommComment) {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, &ParseError{Line: lineNo, Column: 1, Err: ErrInvalidField}
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		// Единицы измерения в квадратных скобках отбрасываются.
+		if i := strings.IndexByte(value, '['); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+
+		if key == ommVersion {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			rec = newOMMRecord(lineNo)
+			continue
+		}
+		if rec == nil {
+			return nil, &ParseError{Line: lineNo, Column: 1, Field: ommVersion, Err: ErrMissingField}
+		}
+
+		col := strings.Index(scanner.Text(), "=") + 2
+		rec.values[key] = ommValue{text: value, line: lineNo, col: col}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+// ParseOMMCSV разбирает OMM в формате CSV (формат GP Celestrak):
+// первая строка содержит ключевые слова OMM, каждая следующая — одно сообщение.
+func ParseOMMCSV(r io.Reader) ([]ElementSet, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+	for i, key := range header {
+		header[i] = strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(key, "\ufeff")))
+	}
+
+	var sets []ElementSet
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		rec := newOMMRecord(line)
+		for i, key := range header {
+			fieldLine, col := cr.FieldPos(i)
+			rec.values[key] = ommValue{text: strings.TrimSpace(row[i]), line: fieldLine, col: col}
+		}
+		set, err := rec.elementSet()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+// csvError переводит ошибку разбора CSV в ParseError.
+func csvError(err error) error {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return &ParseError{Line: pe.Line, Column: pe.Column, Err: ErrInvalidField}
+	}
+	return err
+}
+
+// isOMMCSV сообщает, похожа ли первая строка данных на заголовок OMM CSV.
+func isOMMCSV(data []byte) bool {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	return bytes.ContainsRune(line, ',') && bytes.Contains(bytes.ToUpper(line), []byte(ommNoradID))
+}
+
+// elementSet преобразует сообщение OMM в ElementSet.
+func (r *ommRecord) elementSet() (ElementSet, error) {
+	var firstErr error
+	fail := func(key string, err error) {
+		if firstErr != nil {
+			return
+		}
+		v, ok := r.values[key]
+		if !ok {
+			v.line = r.line
+		}
+		firstErr = &ParseError{Line: v.line, Column: v.col, Field: key, Err: err}
+	}
+
+	float := func(key string, required bool) float64 {
+		v, ok := r.values[key]
+		if !ok || v.text == "" {
+			if required {
+				fail(key, ErrMissingField)
+			}
+			return 0
+		}
+		f, err := strconv.ParseFloat(v.text, 64)
+		if err != nil {
+			fail(key, ErrInvalidField)
+		}
+		return f
+	}
+	integer := func(key string, required bool) int {
+		v, ok := r.values[key]
+		if !ok || v.text == "" {
+			if required {
+				fail(key, ErrMissingField)
+			}
+			return 0
+		}
+		n, err := strconv.Atoi(v.text)
+		if err != nil {
+			fail(key, ErrInvalidField)
+		}
+		return n
+	}
+
+	set := ElementSet{
+		Name:           r.values[ommObjectName].text,
+		IntlDesignator: r.values[ommObjectID].text,
+		Classification: r.values[ommClassification].text,
+		NoradID:        integer(ommNoradID, true),
+		MeanMotion:     float(ommMeanMotion, true),
+		Eccentricity:   float(ommEccentricity, true),
+		Inclination:    float(ommInclination, true),
+		RAAN:           float(ommRAAN, true),
+		ArgPerigee:     float(ommArgPericenter, true),
+		MeanAnomaly:    float(ommMeanAnomaly, true),
+		EphemerisType:  integer(ommEphemerisType, false),
+		ElementSetNo:   integer(ommElementSetNo, false),
+		RevNumber:      integer(ommRevAtEpoch, false),
+		BStar:          float(ommBStar, false),
+		MeanMotionDot:  float(ommMeanMotionDot, false),
+		MeanMotionDDot: float(ommMeanMotionDDot, false),
+	}
+
+	if v, ok := r.values[ommEpoch]; ok {
+		epoch, err := parseOMMEpoch(v.text)
+		if err != nil {
+			fail(ommEpoch, ErrInvalidEpoch)
+		}
+		set.Epoch = epoch
+	} else {
+		fail(ommEpoch, ErrMissingField)
+	}
+
+	if firstErr != nil {
+		return ElementSet{}, firstErr
+	}
+
+	err := set.validate(func(field string) (int, int) {
+		v, ok := r.values[ommFieldByName[field]]
+		if !ok {
+			return r.line, 0
+		}
+		return v.line, v.col
+	})
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Field = ommFieldByName[pe.Field]
+		}
+		return ElementSet{}, err
+	}
+
+	return set, nil
+}
+
+// parseOMMEpoch разбирает эпоху OMM в UTC.
+func parseOMMEpoch(s string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range ommEpochLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t.UTC(), nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+// jsonText приводит значение JSON к строке для единообразного разбора.
+func jsonText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return ""
+	}
+}
+
+// jsonError добавляет номер строки к ошибке синтаксиса JSON.
+func jsonError(data []byte, err error) error {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		return &ParseError{Line: lineAt(data, syntax.Offset), Err: ErrInvalidField}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &ParseError{Line: lineAt(data, typeErr.Offset), Field: typeErr.Field, Err: ErrInvalidField}
+	}
+	return &ParseError{Err: err}
+}
+
+// lineAt возвращает номер строки (с 1) для смещения в данных,
+// пропуская пробельные символы перед значением.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,253 @@
+package tle
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var issEpoch = time.Date(2008, time.September, 20, 12, 25, 40, 104192000, time.UTC)
+
+const issOMMXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ndm xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<omm id="CCSDS_OMM_VERS" version="2.0">
+  <header><CREATION_DATE/><ORIGINATOR/></header>
+  <body><segment>
+    <metadata>
+      <OBJECT_NAME>ISS (ZARYA)</OBJECT_NAME>
+      <OBJECT_ID>1998-067A</OBJECT_ID>
+      <CENTER_NAME>EARTH</CENTER_NAME>
+      <REF_FRAME>TEME</REF_FRAME>
+      <TIME_SYSTEM>UTC</TIME_SYSTEM>
+      <MEAN_ELEMENT_THEORY>SGP4</MEAN_ELEMENT_THEORY>
+    </metadata>
+    <data>
+      <meanElements>
+        <EPOCH>2008-09-20T12:25:40.104192</EPOCH>
+        <MEAN_MOTION>15.72125391</MEAN_MOTION>
+        <ECCENTRICITY>.0006703</ECCENTRICITY>
+        <INCLINATION>51.6416</INCLINATION>
+        <RA_OF_ASC_NODE>247.4627</RA_OF_ASC_NODE>
+        <ARG_OF_PERICENTER>130.536</ARG_OF_PERICENTER>
+        <MEAN_ANOMALY>325.0288</MEAN_ANOMALY>
+      </meanElements>
+      <tleParameters>
+        <EPHEMERIS_TYPE>0</EPHEMERIS_TYPE>
+        <CLASSIFICATION_TYPE>U</CLASSIFICATION_TYPE>
+        <NORAD_CAT_ID>25544</NORAD_CAT_ID>
+        <ELEMENT_SET_NO>292</ELEMENT_SET_NO>
+        <REV_AT_EPOCH>56353</REV_AT_EPOCH>
+        <BSTAR>-.11606E-4</BSTAR>
+        <MEAN_MOTION_DOT>-.00002182</MEAN_MOTION_DOT>
+        <MEAN_MOTION_DDOT>0</MEAN_MOTION_DDOT>
+      </tleParameters>
+    </data>
+  </segment></body>
+</omm>
+</ndm>`
+
+const issOMMJSON = `[{
+  "OBJECT_NAME": "ISS (ZARYA)",
+  "OBJECT_ID": "1998-067A",
+  "EPOCH": "2008-09-20T12:25:40.104192",
+  "MEAN_MOTION": 15.72125391,
+  "ECCENTRICITY": 0.0006703,
+  "INCLINATION": 51.6416,
+  "RA_OF_ASC_NODE": 247.4627,
+  "ARG_OF_PERICENTER": 130.536,
+  "MEAN_ANOMALY": 325.0288,
+  "EPHEMERIS_TYPE": 0,
+  "CLASSIFICATION_TYPE": "U",
+  "NORAD_CAT_ID": 25544,
+  "ELEMENT_SET_NO": 292,
+  "REV_AT_EPOCH": 56353,
+  "BSTAR": -1.1606e-5,
+  "MEAN_MOTION_DOT": -2.182e-5,
+  "MEAN_MOTION_DDOT": 0
+}]`
+
+const issOMMKVN = `CCSDS_OMM_VERS = 2.0
+COMMENT Тестовое сообщение
+CREATION_DATE = 2008-09-20T13:00:00
+ORIGINATOR = TEST
+OBJECT_NAME = ISS (ZARYA)
+OBJECT_ID = 1998-067A
+CENTER_NAME = EARTH
+REF_FRAME = TEME
+TIME_SYSTEM = UTC
+MEAN_ELEMENT_THEORY = SGP4
+EPOCH = 2008-264T12:25:40.104192
+MEAN_MOTION = 15.72125391 [rev/day]
+ECCENTRICITY = 0.0006703
+INCLINATION = 51.6416 [deg]
+RA_OF_ASC_NODE = 247.4627 [deg]
+ARG_OF_PERICENTER = 130.536 [deg]
+MEAN_ANOMALY = 325.0288 [deg]
+EPHEMERIS_TYPE = 0
+CLASSIFICATION_TYPE = U
+NORAD_CAT_ID = 25544
+ELEMENT_SET_NO = 292
+REV_AT_EPOCH = 56353
+BSTAR = -0.11606E-4
+MEAN_MOTION_DOT = -0.00002182
+MEAN_MOTION_DDOT = 0.0
+`
+
+const issOMMCSV = "OBJECT_NAME,OBJECT_ID,EPOCH,MEAN_MOTION,ECCENTRICITY,INCLINATION,RA_OF_ASC_NODE," +
+	"ARG_OF_PERICENTER,MEAN_ANOMALY,EPHEMERIS_TYPE,CLASSIFICATION_TYPE,NORAD_CAT_ID,ELEMENT_SET_NO," +
+	"REV_AT_EPOCH,BSTAR,MEAN_MOTION_DOT,MEAN_MOTION_DDOT\r\n" +
+	"ISS (ZARYA),1998-067A,2008-09-20T12:25:40.104192,15.72125391,.0006703,51.6416,247.4627," +
+	"130.536,325.0288,0,U,25544,292,56353,-.11606E-4,-.00002182,0\r\n"
+
+func TestParseOMM_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func() ([]ElementSet, error)
+	}{
+		{"xml", func() ([]ElementSet, error) { return ParseOMMXML(strings.NewReader(issOMMXML)) }},
+		{"json", func() ([]ElementSet, error) { return ParseOMMJSON(strings.NewReader(issOMMJSON)) }},
+		{"kvn", func() ([]ElementSet, error) { return ParseOMMKVN(strings.NewReader(issOMMKVN)) }},
+		{"csv", func() ([]ElementSet, error) { return ParseOMMCSV(strings.NewReader(issOMMCSV)) }},
+	}
+
+	want, err := ParseTLE(issName, issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sets, err := tt.parse()
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if len(sets) != 1 {
+				t.Fatalf("got %d sets, want 1", len(sets))
+			}
+			got := sets[0]
+
+			if !got.Epoch.Equal(issEpoch) {
+				t.Errorf("Epoch = %v, want %v", got.Epoch, issEpoch)
+			}
+			if got.Name != want.Name || got.NoradID != want.NoradID || got.Classification != want.Classification {
+				t.Errorf("identity = %q/%d/%q, want %q/%d/%q",
+					got.Name, got.NoradID, got.Classification, want.Name, want.NoradID, want.Classification)
+			}
+			if got.IntlDesignator != "1998-067A" {
+				t.Errorf("IntlDesignator = %q, want 1998-067A", got.IntlDesignator)
+			}
+			if got.Inclination != want.Inclination || got.RAAN != want.RAAN ||
+				got.Eccentricity != want.Eccentricity || got.MeanMotion != want.MeanMotion ||
+				got.BStar != want.BStar || got.MeanMotionDot != want.MeanMotionDot {
+				t.Errorf("elements = %+v, want %+v", got, want)
+			}
+			if got.ElementSetNo != 292 || got.RevNumber != 56353 {
+				t.Errorf("ElementSetNo/RevNumber = %d/%d, want 292/56353", got.ElementSetNo, got.RevNumber)
+			}
+		})
+	}
+}
+
+func TestParseOMMJSON_StringValues(t *testing.T) {
+	// Space-Track отдаёт числа в виде строк.
+	input := `{"NORAD_CAT_ID":"25544","EPOCH":"2008-09-20T12:25:40.104192","MEAN_MOTION":"15.72125391",` +
+		`"ECCENTRICITY":"0.0006703","INCLINATION":"51.6416","RA_OF_ASC_NODE":"247.4627",` +
+		`"ARG_OF_PERICENTER":"130.536","MEAN_ANOMALY":"325.0288","BSTAR":"-0.000011606"}`
+
+	sets, err := ParseOMMJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseOMMJSON() error: %v", err)
+	}
+	if len(sets) != 1 || sets[0].NoradID != 25544 || sets[0].BStar != -0.000011606 {
+		t.Errorf("ParseOMMJSON() = %+v", sets)
+	}
+}
+
+func TestParseOMM_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		parse     func(string) ([]ElementSet, error)
+		input     string
+		wantErr   error
+		wantLine  int
+		wantField string
+	}{
+		{
+			name:      "kvn missing epoch",
+			parse:     kvn,
+			input:     strings.Replace(issOMMKVN, "EPOCH = 2008-264T12:25:40.104192\n", "", 1),
+			wantErr:   ErrMissingField,
+			wantLine:  1,
+			wantField: ommEpoch,
+		},
+		{
+			name:      "kvn bad mean motion",
+			parse:     kvn,
+			input:     strings.Replace(issOMMKVN, "15.72125391", "15,72", 1),
+			wantErr:   ErrInvalidField,
+			wantLine:  12,
+			wantField: ommMeanMotion,
+		},
+		{
+			name:      "kvn eccentricity out of range",
+			parse:     kvn,
+			input:     strings.Replace(issOMMKVN, "ECCENTRICITY = 0.0006703", "ECCENTRICITY = 1.2", 1),
+			wantErr:   ErrOutOfRange,
+			wantLine:  13,
+			wantField: ommEccentricity,
+		},
+		{
+			name:      "xml bad epoch",
+			parse:     xmlParse,
+			input:     strings.Replace(issOMMXML, "2008-09-20T12:25:40.104192", "yesterday", 1),
+			wantErr:   ErrInvalidEpoch,
+			wantLine:  16,
+			wantField: ommEpoch,
+		},
+		{
+			name:     "json syntax",
+			parse:    jsonParse,
+			input:    "[\n{\"NORAD_CAT_ID\": 25544,\n\"EPOCH\": }]",
+			wantErr:  ErrInvalidField,
+			wantLine: 3,
+		},
+		{
+			name:      "csv bad inclination",
+			parse:     csvParse,
+			input:     strings.Replace(issOMMCSV, "51.6416", "200", 1),
+			wantErr:   ErrOutOfRange,
+			wantLine:  2,
+			wantField: ommInclination,
+		},
+		{
+			name:     "csv wrong field count",
+			parse:    csvParse,
+			input:    issOMMCSV + "ISS,1998-067A\n",
+			wantErr:  ErrInvalidField,
+			wantLine: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error %T is not *ParseError", err)
+			}
+			if pe.Line != tt.wantLine || pe.Field != tt.wantField {
+				t.Errorf("ParseError at line %d, field %q; want line %d, field %q",
+					pe.Line, pe.Field, tt.wantLine, tt.wantField)
+			}
+		})
+	}
+}
+
+func kvn(s string) ([]ElementSet, error)       { return ParseOMMKVN(strings.NewReader(s)) }
+func xmlParse(s string) ([]ElementSet, error)  { return ParseOMMXML(strings.NewReader(s)) }
+func jsonParse(s string) ([]ElementSet, error) { return ParseOMMJSON(strings.NewReader(s)) }
+func csvParse(s string) ([]ElementSet, error)  { return ParseOMMCSV(strings.NewReader(s)) }
@@ -0,0 +1,214 @@
+// Package tle разбирает наборы орбитальных элементов NORAD: классические
+// двух- и трёхстрочные TLE, а также CCSDS OMM в форматах XML, JSON, KVN и CSV.
+// Все форматы приводятся к единому типу ElementSet.
+package tle
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/orbit"
+)
+
+// Ошибки разбора. Конкретное место ошибки сообщает ParseError.
+var (
+	ErrChecksum          = errors.New("checksum mismatch")
+	ErrLineLength        = errors.New("invalid line length")
+	ErrLineNumber        = errors.New("unexpected line number")
+	ErrColumnLayout      = errors.New("unexpected character in separator column")
+	ErrSatelliteMismatch = errors.New("satellite numbers differ between lines")
+	ErrInvalidField      = errors.New("invalid field")
+	ErrInvalidEpoch      = errors.New("invalid epoch")
+	ErrInvalidExponent   = errors.New("invalid exponent field")
+	ErrOutOfRange        = errors.New("value out of range")
+	ErrMissingField      = errors.New("missing field")
+	ErrMissingLine       = errors.New("missing line")
+	ErrUnknownFormat     = errors.New("unknown element set format")
+)
+
+// ParseError описывает ошибку разбора с указанием места в исходных данных.
+type ParseError struct {
+	Line   int    // номер строки, начиная с 1 (0 — неизвестен)
+	Column int    // номер колонки, начиная с 1 (0 — неизвестен)
+	Field  string // имя поля, если ошибка относится к полю
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString("tle: ")
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, ", column %d", e.Column)
+		}
+		b.WriteString(": ")
+	}
+	if e.Field != "" {
+		b.WriteString(e.Field)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ElementSet — набор средних элементов орбиты одного спутника.
+type ElementSet struct {
+	Name           string    `json:"name,omitempty"`
+	NoradID        int       `json:"norad_id"`
+	Classification string    `json:"classification,omitempty"`
+	IntlDesignator string    `json:"intl_designator,omitempty"`
+	Epoch          time.Time `json:"epoch"`
+	MeanMotionDot  float64   `json:"mean_motion_dot"`  // об/сут², как в TLE (ndot/2)
+	MeanMotionDDot float64   `json:"mean_motion_ddot"` // об/сут³, как в TLE (nddot/6)
+	BStar          float64   `json:"bstar"`
+	EphemerisType  int       `json:"ephemeris_type"`
+	ElementSetNo   int       `json:"element_set_no"`
+	Inclination    float64   `json:"inclination"` // градусы
+	RAAN           float64   `json:"raan"`        // градусы
+	Eccentricity   float64   `json:"eccentricity"`
+	ArgPerigee     float64   `json:"arg_perigee"`  // градусы
+	MeanAnomaly    float64   `json:"mean_anomaly"` // градусы
+	MeanMotion     float64   `json:"mean_motion"`  // об/сут
+	RevNumber      int       `json:"rev_number"`
+}
+
+// Elements возвращает элементы в виде, пригодном для orbit.New.
+func (e *ElementSet) Elements() orbit.Elements {
+	return orbit.Elements{
+		Epoch:        e.Epoch,
+		Inclination:  e.Inclination,
+		RAAN:         e.RAAN,
+		Eccentricity: e.Eccentricity,
+		ArgPerigee:   e.ArgPerigee,
+		MeanAnomaly:  e.MeanAnomaly,
+		MeanMotion:   e.MeanMotion,
+		BStar:        e.BStar,
+	}
+}
+
+// Parse определяет формат данных и разбирает все наборы элементов.
+// Поддерживаются TLE (2 или 3 строки на спутник), OMM XML, OMM JSON, OMM KVN
+// и OMM CSV.
+func Parse(r io.Reader) ([]ElementSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimLeft(data, " \t\r\n\ufeff")
+	switch {
+	case len(trimmed) == 0:
+		return nil, nil
+	case trimmed[0] == '<':
+		return ParseOMMXML(bytes.NewReader(data))
+	case trimmed[0] == '{' || trimmed[0] == '[':
+		return ParseOMMJSON(bytes.NewReader(data))
+	case bytes.HasPrefix(trimmed, []byte("CCSDS_OMM_VERS")):
+		return ParseOMMKVN(bytes.NewReader(data))
+	case isOMMCSV(trimmed):
+		return ParseOMMCSV(bytes.NewReader(data))
+	default:
+		return ParseTLEs(bytes.NewReader(data))
+	}
+}
+
+// ParseTLEs разбирает поток двух- или трёхстрочных TLE.
+// Пустые строки пропускаются, строка имени может начинаться с "0 ".
+func ParseTLEs(r io.Reader) ([]ElementSet, error) {
+	var (
+		sets    []ElementSet
+		name    string
+		line1   string
+		line1No int
+		lineNo  int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch {
+		case line1 != "":
+			set, err := parseLines(name, line1, line, line1No)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, set)
+			name, line1 = "", ""
+		case strings.HasPrefix(line, "1 "):
+			line1, line1No = line, lineNo
+		case strings.HasPrefix(line, "2 "):
+			return nil, &ParseError{Line: lineNo, Column: 1, Err: ErrMissingLine}
+		default:
+			name = strings.TrimSpace(strings.TrimPrefix(line, "0 "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line1 != "" {
+		return nil, &ParseError{Line: line1No + 1, Err: ErrMissingLine}
+	}
+
+	return sets, nil
+}
+
+// validate проверяет физическую допустимость элементов.
+// fieldPos сообщает положение поля в исходных данных для ParseError.
+func (e *ElementSet) validate(fieldPos func(field string) (line, col int)) error {
+	checks := []struct {
+		field string
+		ok    bool
+	}{
+		{fieldInclination, e.Inclination >= 0 && e.Inclination <= 180},
+		{fieldRAAN, e.RAAN >= 0 && e.RAAN < 360},
+		{fieldEccentricity, e.Eccentricity >= 0 && e.Eccentricity < 1},
+		{fieldArgPerigee, e.ArgPerigee >= 0 && e.ArgPerigee < 360},
+		{fieldMeanAnomaly, e.MeanAnomaly >= 0 && e.MeanAnomaly < 360},
+		{fieldMeanMotion, e.MeanMotion > 0},
+	}
+	for _, c := range checks {
+		if !c.ok {
+			line, col := fieldPos(c.field)
+			return &ParseError{Line: line, Column: col, Field: c.field, Err: ErrOutOfRange}
+		}
+	}
+	return nil
+}
+
+// Имена полей для сообщений об ошибках.
+const (
+	fieldLineNumber     = "line number"
+	fieldSatellite      = "satellite number"
+	fieldClassification = "classification"
+	fieldEpochYear      = "epoch year"
+	fieldEpochDay       = "epoch day"
+	fieldEpoch          = "epoch"
+	fieldMeanMotionDot  = "mean motion dot"
+	fieldMeanMotionDDot = "mean motion ddot"
+	fieldBStar          = "bstar"
+	fieldEphemerisType  = "ephemeris type"
+	fieldElementSetNo   = "element set number"
+	fieldInclination    = "inclination"
+	fieldRAAN           = "raan"
+	fieldEccentricity   = "eccentricity"
+	fieldArgPerigee     = "argument of perigee"
+	fieldMeanAnomaly    = "mean anomaly"
+	fieldMeanMotion     = "mean motion"
+	fieldRevNumber      = "revolution number"
+	fieldChecksum       = "checksum"
+)
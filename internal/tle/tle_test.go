@@ -0,0 +1,303 @@
+package tle
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	issName  = "ISS (ZARYA)"
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+func TestParseTLE(t *testing.T) {
+	set, err := ParseTLE(issName, issLine1, issLine2)
+	if err != nil {
+		t.Fatalf("ParseTLE() error: %v", err)
+	}
+
+	wantEpoch := time.Date(2008, time.September, 20, 12, 25, 40, 104192000, time.UTC)
+	if !set.Epoch.Equal(wantEpoch) {
+		t.Errorf("Epoch = %v, want %v", set.Epoch, wantEpoch)
+	}
+
+	floats := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"MeanMotionDot", set.MeanMotionDot, -0.00002182},
+		{"MeanMotionDDot", set.MeanMotionDDot, 0},
+		{"BStar", set.BStar, -0.11606e-4},
+		{"Inclination", set.Inclination, 51.6416},
+		{"RAAN", set.RAAN, 247.4627},
+		{"Eccentricity", set.Eccentricity, 0.0006703},
+		{"ArgPerigee", set.ArgPerigee, 130.5360},
+		{"MeanAnomaly", set.MeanAnomaly, 325.0288},
+		{"MeanMotion", set.MeanMotion, 15.72125391},
+	}
+	for _, f := range floats {
+		if math.Abs(f.got-f.want) > 1e-12 {
+			t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+
+	if set.Name != issName {
+		t.Errorf("Name = %q, want %q", set.Name, issName)
+	}
+	if set.NoradID != 25544 {
+		t.Errorf("NoradID = %d, want 25544", set.NoradID)
+	}
+	if set.Classification != "U" {
+		t.Errorf("Classification = %q, want U", set.Classification)
+	}
+	if set.IntlDesignator != "98067A" {
+		t.Errorf("IntlDesignator = %q, want 98067A", set.IntlDesignator)
+	}
+	if set.ElementSetNo != 292 {
+		t.Errorf("ElementSetNo = %d, want 292", set.ElementSetNo)
+	}
+	if set.RevNumber != 56353 {
+		t.Errorf("RevNumber = %d, want 56353", set.RevNumber)
+	}
+}
+
+func TestParseTLE_Errors(t *testing.T) {
+	replace := func(line string, col int, s string) string {
+		b := []byte(line)
+		copy(b[col-1:], s)
+		return string(b)
+	}
+	// fix пересчитывает контрольную сумму после подмены символов.
+	fix := func(line string) string {
+		return line[:68] + string(rune('0'+Checksum(line[:68])))
+	}
+
+	tests := []struct {
+		name      string
+		line1     string
+		line2     string
+		wantErr   error
+		wantLine  int
+		wantCol   int
+		wantField string
+	}{
+		{
+			name:     "short line",
+			line1:    issLine1[:60],
+			line2:    issLine2,
+			wantErr:  ErrLineLength,
+			wantLine: 1,
+			wantCol:  61,
+		},
+		{
+			name:      "bad checksum",
+			line1:     issLine1,
+			line2:     replace(issLine2, 69, "0"),
+			wantErr:   ErrChecksum,
+			wantLine:  2,
+			wantCol:   69,
+			wantField: fieldChecksum,
+		},
+		{
+			name:      "swapped lines",
+			line1:     issLine2,
+			line2:     issLine1,
+			wantErr:   ErrLineNumber,
+			wantLine:  1,
+			wantCol:   1,
+			wantField: fieldLineNumber,
+		},
+		{
+			name:     "broken separator",
+			line1:    fix(replace(issLine1, 18, "X")),
+			line2:    issLine2,
+			wantErr:  ErrColumnLayout,
+			wantLine: 1,
+			wantCol:  18,
+		},
+		{
+			name:      "bad exponent",
+			line1:     fix(replace(issLine1, 54, "-11606x4")),
+			line2:     issLine2,
+			wantErr:   ErrInvalidExponent,
+			wantLine:  1,
+			wantCol:   54,
+			wantField: fieldBStar,
+		},
+		{
+			name:      "bad epoch day",
+			line1:     fix(replace(issLine1, 21, "400.51782528")),
+			line2:     issLine2,
+			wantErr:   ErrInvalidEpoch,
+			wantLine:  1,
+			wantCol:   21,
+			wantField: fieldEpochDay,
+		},
+		{
+			name:      "bad eccentricity",
+			line1:     issLine1,
+			line2:     fix(replace(issLine2, 27, "00.6703")),
+			wantErr:   ErrInvalidField,
+			wantLine:  2,
+			wantCol:   27,
+			wantField: fieldEccentricity,
+		},
+		{
+			name:      "inclination out of range",
+			line1:     issLine1,
+			line2:     fix(replace(issLine2, 9, "251.6416")),
+			wantErr:   ErrOutOfRange,
+			wantLine:  2,
+			wantCol:   9,
+			wantField: fieldInclination,
+		},
+		{
+			name:      "satellite mismatch",
+			line1:     issLine1,
+			line2:     fix(replace(issLine2, 3, "25545")),
+			wantErr:   ErrSatelliteMismatch,
+			wantLine:  2,
+			wantCol:   3,
+			wantField: fieldSatellite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTLE("", tt.line1, tt.line2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTLE() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error %T is not *ParseError", err)
+			}
+			if pe.Line != tt.wantLine || pe.Column != tt.wantCol || pe.Field != tt.wantField {
+				t.Errorf("ParseError at line %d, column %d, field %q; want line %d, column %d, field %q",
+					pe.Line, pe.Column, pe.Field, tt.wantLine, tt.wantCol, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestParseTLE_Alpha5(t *testing.T) {
+	line1 := "1 A0001U 20001A   20001.00000000  .00000000  00000-0  00000-0 0  999"
+	line1 += string(rune('0' + Checksum(line1)))
+	line2 := "2 A0001  97.0000 100.0000 0010000  90.0000 270.0000 15.00000000    1"
+	line2 += string(rune('0' + Checksum(line2)))
+
+	set, err := ParseTLE("", line1, line2)
+	if err != nil {
+		t.Fatalf("ParseTLE() error: %v", err)
+	}
+	if set.NoradID != 100001 {
+		t.Errorf("NoradID = %d, want 100001", set.NoradID)
+	}
+}
+
+func TestParseTLEs(t *testing.T) {
+	input := strings.Join([]string{
+		"0 " + issName,
+		issLine1,
+		issLine2,
+		"",
+		"1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+		"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+	}, "\n")
+
+	sets, err := ParseTLEs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTLEs() error: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("got %d sets, want 2", len(sets))
+	}
+	if sets[0].Name != issName || sets[0].NoradID != 25544 {
+		t.Errorf("first set = %q/%d, want %q/25544", sets[0].Name, sets[0].NoradID, issName)
+	}
+	if sets[1].Name != "" || sets[1].NoradID != 5 {
+		t.Errorf("second set = %q/%d, want \"\"/5", sets[1].Name, sets[1].NoradID)
+	}
+}
+
+func TestParseTLEs_ErrorLineNumbers(t *testing.T) {
+	input := issName + "\n" + issLine1 + "\n" + issLine2[:68] + "0\n"
+
+	_, err := ParseTLEs(strings.NewReader(input))
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *ParseError, got %v", err)
+	}
+	if pe.Line != 3 || !errors.Is(err, ErrChecksum) {
+		t.Errorf("error = %v, want checksum error on line 3", err)
+	}
+}
+
+func TestParseTLEs_MissingLine(t *testing.T) {
+	_, err := ParseTLEs(strings.NewReader(issLine1 + "\n"))
+	if !errors.Is(err, ErrMissingLine) {
+		t.Errorf("error = %v, want ErrMissingLine", err)
+	}
+}
+
+func TestParse_DetectsFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"tle", issLine1 + "\n" + issLine2},
+		{"json", `[{"NORAD_CAT_ID":25544,"EPOCH":"2008-09-20T12:25:40.104192","MEAN_MOTION":15.72125391,` +
+			`"ECCENTRICITY":0.0006703,"INCLINATION":51.6416,"RA_OF_ASC_NODE":247.4627,` +
+			`"ARG_OF_PERICENTER":130.536,"MEAN_ANOMALY":325.0288}]`},
+		{"kvn", "CCSDS_OMM_VERS = 2.0\nNORAD_CAT_ID = 25544\nEPOCH = 2008-09-20T12:25:40.104192\n" +
+			"MEAN_MOTION = 15.72125391\nECCENTRICITY = 0.0006703\nINCLINATION = 51.6416\n" +
+			"RA_OF_ASC_NODE = 247.4627\nARG_OF_PERICENTER = 130.536\nMEAN_ANOMALY = 325.0288\n"},
+		{"csv", "\n" + issOMMCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sets, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if len(sets) != 1 || sets[0].NoradID != 25544 {
+				t.Errorf("Parse() = %+v, want one set for 25544", sets)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	if got := Checksum(issLine1[:68]); got != 7 {
+		t.Errorf("Checksum(line1) = %d, want 7", got)
+	}
+	if got := Checksum(issLine2[:68]); got != 7 {
+		t.Errorf("Checksum(line2) = %d, want 7", got)
+	}
+}
+
+func TestElementSet_Elements(t *testing.T) {
+	set, err := ParseTLE(issName, issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	el := set.Elements()
+	if el.MeanMotion != set.MeanMotion || !el.Epoch.Equal(set.Epoch) || el.BStar != set.BStar {
+		t.Errorf("Elements() = %+v does not match element set", el)
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{Line: 2, Column: 27, Field: fieldEccentricity, Err: ErrInvalidField}
+	want := "tle: line 2, column 27: eccentricity: invalid field"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
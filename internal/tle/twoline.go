@@ -0,0 +1,354 @@
+package tle
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lineLength — длина строки TLE вместе с контрольной суммой.
+const lineLength = 69
+
+// span задаёт колонки поля в строке TLE (нумерация с 1, включительно).
+type span struct {
+	first, last int
+}
+
+func (s span) of(line string) string {
+	return line[s.first-1 : s.last]
+}
+
+// Расположение полей строки 1.
+var (
+	l1Satellite      = span{3, 7}
+	l1Classification = span{8, 8}
+	l1IntlDesignator = span{10, 17}
+	l1EpochYear      = span{19, 20}
+	l1EpochDay       = span{21, 32}
+	l1MeanMotionDot  = span{34, 43}
+	l1MeanMotionDDot = span{45, 52}
+	l1BStar          = span{54, 61}
+	l1EphemerisType  = span{63, 63}
+	l1ElementSetNo   = span{65, 68}
+	l1Separators     = []int{2, 9, 18, 33, 44, 53, 62, 64}
+)
+
+// Расположение полей строки 2.
+var (
+	l2Satellite    = span{3, 7}
+	l2Inclination  = span{9, 16}
+	l2RAAN         = span{18, 25}
+	l2Eccentricity = span{27, 33}
+	l2ArgPerigee   = span{35, 42}
+	l2MeanAnomaly  = span{44, 51}
+	l2MeanMotion   = span{53, 63}
+	l2RevNumber    = span{64, 68}
+	l2Separators   = []int{2, 8, 17, 26, 34, 43, 52}
+)
+
+// ParseTLE разбирает один набор элементов из двух строк TLE.
+// name может быть пустым (двухстрочный формат).
+func ParseTLE(name, line1, line2 string) (ElementSet, error) {
+	return parseLines(name, line1, line2, 1)
+}
+
+// parseLines разбирает пару строк; first — номер первой строки в источнике.
+//
+//nolint:funlen // последовательный разбор полей фиксированной ширины
+func parseLines(name, line1, line2 string, first int) (ElementSet, error) {
+	line1 = strings.TrimRight(line1, " \r\n")
+	line2 = strings.TrimRight(line2, " \r\n")
+
+	if err := checkLine(line1, '1', first, l1Separators); err != nil {
+		return ElementSet{}, err
+	}
+	if err := checkLine(line2, '2', first+1, l2Separators); err != nil {
+		return ElementSet{}, err
+	}
+
+	p1 := fieldParser{line: line1, lineNo: first}
+	p2 := fieldParser{line: line2, lineNo: first + 1}
+
+	set := ElementSet{
+		Name:           strings.TrimSpace(name),
+		NoradID:        p1.satellite(l1Satellite),
+		Classification: p1.classification(),
+		IntlDesignator: strings.TrimSpace(l1IntlDesignator.of(line1)),
+		MeanMotionDot:  p1.float(l1MeanMotionDot, fieldMeanMotionDot, false),
+		MeanMotionDDot: p1.exponent(l1MeanMotionDDot, fieldMeanMotionDDot),
+		BStar:          p1.exponent(l1BStar, fieldBStar),
+		EphemerisType:  p1.int(l1EphemerisType, fieldEphemerisType),
+		ElementSetNo:   p1.int(l1ElementSetNo, fieldElementSetNo),
+		Inclination:    p2.float(l2Inclination, fieldInclination, true),
+		RAAN:           p2.float(l2RAAN, fieldRAAN, true),
+		Eccentricity:   p2.decimal(l2Eccentricity, fieldEccentricity),
+		ArgPerigee:     p2.float(l2ArgPerigee, fieldArgPerigee, true),
+		MeanAnomaly:    p2.float(l2MeanAnomaly, fieldMeanAnomaly, true),
+		MeanMotion:     p2.float(l2MeanMotion, fieldMeanMotion, true),
+		RevNumber:      p2.int(l2RevNumber, fieldRevNumber),
+	}
+	set.Epoch = p1.epoch()
+	sat2 := p2.satellite(l2Satellite)
+
+	if p1.err != nil {
+		return ElementSet{}, p1.err
+	}
+	if p2.err != nil {
+		return ElementSet{}, p2.err
+	}
+	if set.NoradID != sat2 {
+		return ElementSet{}, &ParseError{
+			Line: first + 1, Column: l2Satellite.first, Field: fieldSatellite, Err: ErrSatelliteMismatch,
+		}
+	}
+
+	positions := map[string]struct {
+		line int
+		span span
+	}{
+		fieldInclination:  {first + 1, l2Inclination},
+		fieldRAAN:         {first + 1, l2RAAN},
+		fieldEccentricity: {first + 1, l2Eccentricity},
+		fieldArgPerigee:   {first + 1, l2ArgPerigee},
+		fieldMeanAnomaly:  {first + 1, l2MeanAnomaly},
+		fieldMeanMotion:   {first + 1, l2MeanMotion},
+	}
+	err := set.validate(func(field string) (int, int) {
+		pos := positions[field]
+		return pos.line, pos.span.first
+	})
+	if err != nil {
+		return ElementSet{}, err
+	}
+
+	return set, nil
+}
+
+// checkLine проверяет длину, номер строки, контрольную сумму и разделители.
+func checkLine(line string, number byte, lineNo int, separators []int) error {
+	if len(line) != lineLength {
+		col := min(len(line), lineLength) + 1
+		return &ParseError{Line: lineNo, Column: col, Err: ErrLineLength}
+	}
+	if line[0] != number {
+		return &ParseError{Line: lineNo, Column: 1, Field: fieldLineNumber, Err: ErrLineNumber}
+	}
+
+	want := int(line[lineLength-1] - '0')
+	if want < 0 || want > 9 || Checksum(line[:lineLength-1]) != want {
+		return &ParseError{Line: lineNo, Column: lineLength, Field: fieldChecksum, Err: ErrChecksum}
+	}
+
+	for _, col := range separators {
+		if line[col-1] != ' ' {
+			return &ParseError{Line: lineNo, Column: col, Err: ErrColumnLayout}
+		}
+	}
+	return nil
+}
+
+// Checksum вычисляет контрольную сумму строки TLE по модулю 10:
+// сумма цифр плюс единица за каждый знак минус.
+func Checksum(line string) int {
+	sum := 0
+	for i := range len(line) {
+		switch c := line[i]; {
+		case c >= '0' && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// fieldParser разбирает поля одной строки и запоминает первую ошибку.
+type fieldParser struct {
+	line   string
+	lineNo int
+	err    error
+}
+
+func (p *fieldParser) fail(s span, field string, err error) {
+	if p.err == nil {
+		p.err = &ParseError{Line: p.lineNo, Column: s.first, Field: field, Err: err}
+	}
+}
+
+// float разбирает десятичное число; пустое поле допустимо, если !required.
+func (p *fieldParser) float(s span, field string, required bool) float64 {
+	raw := strings.TrimSpace(s.of(p.line))
+	if raw == "" {
+		if required {
+			p.fail(s, field, ErrMissingField)
+		}
+		return 0
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		p.fail(s, field, ErrInvalidField)
+		return 0
+	}
+	return v
+}
+
+// decimal разбирает число с подразумеваемой ведущей десятичной точкой.
+func (p *fieldParser) decimal(s span, field string) float64 {
+	raw := strings.TrimSpace(s.of(p.line))
+	if raw == "" || strings.ContainsAny(raw, ".+- ") {
+		p.fail(s, field, ErrInvalidField)
+		return 0
+	}
+	v, err := strconv.ParseFloat("0."+raw, 64)
+	if err != nil {
+		p.fail(s, field, ErrInvalidField)
+		return 0
+	}
+	return v
+}
+
+// int разбирает целое; пустое поле означает ноль.
+func (p *fieldParser) int(s span, field string) int {
+	raw := strings.TrimSpace(s.of(p.line))
+	if raw == "" {
+		return 0
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		p.fail(s, field, ErrInvalidField)
+		return 0
+	}
+	return v
+}
+
+// exponent разбирает поле вида "±NNNNN±E" (мантисса с подразумеваемой точкой).
+func (p *fieldParser) exponent(s span, field string) float64 {
+	raw := strings.TrimSpace(s.of(p.line))
+	if raw == "" {
+		return 0
+	}
+
+	sign := 1.0
+	switch raw[0] {
+	case '-':
+		sign = -1
+		raw = raw[1:]
+	case '+':
+		raw = raw[1:]
+	}
+	if len(raw) < 3 {
+		p.fail(s, field, ErrInvalidExponent)
+		return 0
+	}
+
+	mantissa, exp := raw[:len(raw)-2], raw[len(raw)-2:]
+	if exp[0] != '-' && exp[0] != '+' || !isDigits(mantissa) || !isDigits(exp[1:]) {
+		p.fail(s, field, ErrInvalidExponent)
+		return 0
+	}
+
+	m, err := strconv.ParseFloat("0."+mantissa, 64)
+	if err != nil {
+		p.fail(s, field, ErrInvalidExponent)
+		return 0
+	}
+	e := int(exp[1] - '0')
+	if exp[0] == '-' {
+		e = -e
+	}
+	return sign * m * math.Pow10(e)
+}
+
+// satellite разбирает номер NORAD, включая формат Alpha-5 (A0000–Z9999).
+func (p *fieldParser) satellite(s span) int {
+	raw := strings.TrimSpace(s.of(p.line))
+	if raw == "" {
+		p.fail(s, fieldSatellite, ErrMissingField)
+		return 0
+	}
+
+	prefix := 0
+	if c := raw[0]; c >= 'A' && c <= 'Z' {
+		if c == 'I' || c == 'O' || len(raw) != 5 {
+			p.fail(s, fieldSatellite, ErrInvalidField)
+			return 0
+		}
+		prefix = alpha5Value(c)
+		raw = raw[1:]
+	}
+	if !isDigits(raw) {
+		p.fail(s, fieldSatellite, ErrInvalidField)
+		return 0
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		p.fail(s, fieldSatellite, ErrInvalidField)
+		return 0
+	}
+	return prefix*10000 + v
+}
+
+// classification проверяет признак секретности (U, C или S).
+func (p *fieldParser) classification() string {
+	raw := strings.TrimSpace(l1Classification.of(p.line))
+	if raw != "" && !strings.Contains("UCS", raw) {
+		p.fail(l1Classification, fieldClassification, ErrInvalidField)
+	}
+	return raw
+}
+
+// epoch разбирает год и день года эпохи.
+func (p *fieldParser) epoch() time.Time {
+	yearRaw := l1EpochYear.of(p.line)
+	if !isDigits(yearRaw) {
+		p.fail(l1EpochYear, fieldEpochYear, ErrInvalidEpoch)
+		return time.Time{}
+	}
+	year, err := strconv.Atoi(yearRaw)
+	if err != nil {
+		p.fail(l1EpochYear, fieldEpochYear, ErrInvalidEpoch)
+		return time.Time{}
+	}
+	// Соглашение NORAD: 57–99 -> 1957–1999, 00–56 -> 2000–2056.
+	if year < 57 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+
+	day, err := strconv.ParseFloat(strings.TrimSpace(l1EpochDay.of(p.line)), 64)
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	daysInYear := float64(start.AddDate(1, 0, 0).Sub(start) / (24 * time.Hour))
+	if err != nil || day < 1 || day >= daysInYear+1 {
+		p.fail(l1EpochDay, fieldEpochDay, ErrInvalidEpoch)
+		return time.Time{}
+	}
+
+	offset := time.Duration((day - 1) * float64(24*time.Hour))
+	return start.Add(offset.Round(time.Microsecond))
+}
+
+// alpha5Value возвращает значение буквы Alpha-5 (A=10, ..., Z=33 без I и O).
+func alpha5Value(c byte) int {
+	v := int(c-'A') + 10
+	if c > 'I' {
+		v--
+	}
+	if c > 'O' {
+		v--
+	}
+	return v
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
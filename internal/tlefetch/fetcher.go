@@ -0,0 +1,230 @@
+// Package tlefetch периодически обновляет орбитальные элементы спутников
+// каталога из внешнего источника: URL в формате GP Celestrak или
+// локального каталога с файлами TLE/OMM.
+package tlefetch
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+// Параметры по умолчанию. Celestrak просит запрашивать одни и те же
+// данные не чаще раза в два часа; меньший период имеет смысл только
+// для локального каталога.
+const (
+	DefaultInterval   = 6 * time.Hour
+	MinInterval       = time.Minute
+	DefaultMaxHistory = 64
+)
+
+// Ошибки обновления.
+var (
+	ErrNotModified     = errors.New("tlefetch: source not modified")
+	ErrEpochRegression = errors.New("tlefetch: epoch is older than the latest known element set")
+	ErrInvalidOptions  = errors.New("tlefetch: invalid options")
+)
+
+// Options — параметры обновления.
+type Options struct {
+	// Interval — период опроса источника.
+	Interval time.Duration
+	// AddNew разрешает добавлять в каталог спутники, которых в нём нет;
+	// иначе обновляются только уже известные спутники.
+	AddNew bool
+	// MaxHistory ограничивает число хранимых наборов на спутник.
+	MaxHistory int
+}
+
+// DefaultOptions возвращает параметры по умолчанию.
+func DefaultOptions() Options {
+	return Options{
+		Interval:   DefaultInterval,
+		MaxHistory: DefaultMaxHistory,
+	}
+}
+
+// Result — итог одного обновления.
+type Result struct {
+	NotModified bool `json:"not_modified"`
+	Fetched     int  `json:"fetched"`  // получено наборов
+	Updated     int  `json:"updated"`  // добавлено новых эпох
+	Added       int  `json:"added"`    // добавлено спутников
+	Skipped     int  `json:"skipped"`  // известные эпохи и спутники вне каталога
+	Rejected    int  `json:"rejected"` // эпоха раньше последней известной
+}
+
+// Fetcher переносит наборы элементов из источника в каталог.
+type Fetcher struct {
+	store  catalog.Store
+	source Source
+	opts   Options
+}
+
+// NewFetcher создаёт обновление каталога store из source.
+func NewFetcher(store catalog.Store, source Source, opts Options) (*Fetcher, error) {
+	if opts.Interval < MinInterval {
+		return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidOptions, MinInterval)
+	}
+	if opts.MaxHistory <= 0 {
+		return nil, fmt.Errorf("%w: max history must be positive", ErrInvalidOptions)
+	}
+	return &Fetcher{store: store, source: source, opts: opts}, nil
+}
+
+// Run обновляет каталог сразу и затем с периодом Interval до отмены ctx.
+// Ошибки обновления записываются в журнал и не прерывают работу.
+func (f *Fetcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := f.Update(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Error("element set update failed", "source", f.source.String(), "error", err)
+		case err == nil && !res.NotModified:
+			slog.Info("element sets updated",
+				"source", f.source.String(),
+				"fetched", res.Fetched,
+				"updated", res.Updated,
+				"added", res.Added,
+				"skipped", res.Skipped,
+				"rejected", res.Rejected,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update выполняет одно обновление. Наборы одного спутника применяются
+// по возрастанию эпохи; более старые эпохи, чем последняя известная,
+// отклоняются, а предыдущие наборы остаются в истории.
+func (f *Fetcher) Update(ctx context.Context) (Result, error) {
+	sets, err := f.source.Fetch(ctx)
+	if errors.Is(err, ErrNotModified) {
+		return Result{NotModified: true}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Fetched: len(sets)}
+	slices.SortStableFunc(sets, func(a, b tle.ElementSet) int {
+		return cmp.Or(cmp.Compare(a.NoradID, b.NoradID), a.Epoch.Compare(b.Epoch))
+	})
+	for start := 0; start < len(sets); {
+		end := start + 1
+		for end < len(sets) && sets[end].NoradID == sets[start].NoradID {
+			end++
+		}
+		if err := f.apply(sets[start:end], &res); err != nil {
+			return res, err
+		}
+		start = end
+	}
+	return res, nil
+}
+
+// apply добавляет наборы одного спутника. История изменяется атомарно
+// через Modify, поэтому параллельное изменение спутника через API
+// не затирает добавленные эпохи.
+func (f *Fetcher) apply(sets []tle.ElementSet, res *Result) error {
+	id := sets[0].NoradID
+	var counts Result
+	merge := func(sat *catalog.Satellite) error {
+		counts = f.merge(sat, sets)
+		if counts.Updated == 0 {
+			return errUnchanged
+		}
+		return nil
+	}
+
+	_, err := f.store.Modify(id, merge)
+	create := errors.Is(err, catalog.ErrNotFound)
+	switch {
+	case create && !f.opts.AddNew:
+		res.Skipped += len(sets)
+		return nil
+	case create:
+		sat := catalog.Satellite{NoradID: id, Name: sets[0].Name}
+		if sat.Name == "" {
+			sat.Name = fmt.Sprintf("NORAD %d", id)
+		}
+		if err = merge(&sat); err == nil {
+			err = f.store.Create(sat)
+		}
+		if errors.Is(err, catalog.ErrExists) {
+			// Спутник добавлен параллельно — дополняем его историю.
+			create = false
+			_, err = f.store.Modify(id, merge)
+		}
+	}
+	res.Rejected += counts.Rejected
+	res.Skipped += counts.Skipped
+	switch {
+	case errors.Is(err, errUnchanged):
+		return nil
+	case err != nil:
+		return fmt.Errorf("tlefetch: store satellite %d: %w", id, err)
+	}
+	if create {
+		res.Added++
+	}
+	res.Updated += counts.Updated
+	return nil
+}
+
+// merge добавляет наборы в историю спутника и обрезает её до MaxHistory.
+// Возвращает счётчики добавленных, отклонённых и пропущенных наборов.
+func (f *Fetcher) merge(sat *catalog.Satellite, sets []tle.ElementSet) Result {
+	var counts Result
+	for _, set := range sets {
+		switch err := appendSet(sat, set); {
+		case err == nil:
+			counts.Updated++
+		case errors.Is(err, ErrEpochRegression):
+			counts.Rejected++
+			slog.Warn("rejecting element set", "norad_id", sat.NoradID, "epoch", set.Epoch, "error", err)
+		default:
+			counts.Skipped++
+		}
+	}
+	if n := len(sat.TLEHistory) - f.opts.MaxHistory; n > 0 {
+		sat.TLEHistory = slices.Delete(sat.TLEHistory, 0, n)
+	}
+	return counts
+}
+
+// Внутренние признаки: набор с уже известной эпохой и спутник без новых эпох.
+var (
+	errDuplicate = errors.New("tlefetch: element set already known")
+	errUnchanged = errors.New("tlefetch: no new element sets")
+)
+
+// appendSet добавляет набор в конец истории, если его эпоха новее последней.
+func appendSet(sat *catalog.Satellite, set tle.ElementSet) error {
+	latest, ok := sat.LatestTLE()
+	switch {
+	case !ok || set.Epoch.After(latest.Epoch):
+		sat.TLEHistory = append(sat.TLEHistory, set)
+		return nil
+	case set.Epoch.Equal(latest.Epoch):
+		return errDuplicate
+	default:
+		return fmt.Errorf("%w: %s < %s", ErrEpochRegression,
+			set.Epoch.Format(time.RFC3339), latest.Epoch.Format(time.RFC3339))
+	}
+}
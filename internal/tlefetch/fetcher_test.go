@@ -0,0 +1,262 @@
+package tlefetch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+const (
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+// issSet возвращает набор элементов МКС, сдвинутый по эпохе на days суток.
+func issSet(t *testing.T, days float64) tle.ElementSet {
+	t.Helper()
+
+	set, err := tle.ParseTLE("ISS (ZARYA)", issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.Epoch = set.Epoch.Add(time.Duration(days * float64(24*time.Hour))).Truncate(time.Millisecond)
+	return set
+}
+
+// issTLE форматирует набор в трёхстрочный TLE.
+func issTLE(t *testing.T, set tle.ElementSet) string {
+	t.Helper()
+
+	line1, line2, err := tle.Format(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set.Name + "\n" + line1 + "\n" + line2 + "\n"
+}
+
+// fakeSource возвращает заданные наборы или ошибку.
+type fakeSource struct {
+	sets []tle.ElementSet
+	err  error
+}
+
+func (s *fakeSource) Fetch(context.Context) ([]tle.ElementSet, error) { return s.sets, s.err }
+func (s *fakeSource) String() string                                  { return "fake" }
+
+func newISSStore(t *testing.T, history ...tle.ElementSet) catalog.Store {
+	t.Helper()
+
+	store := catalog.NewMemoryStore()
+	if err := store.Create(catalog.Satellite{NoradID: 25544, Name: "ISS", Downlink: 145.8, TLEHistory: history}); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func epochs(t *testing.T, store catalog.Store, id int) []time.Time {
+	t.Helper()
+
+	sat, err := store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []time.Time
+	for _, set := range sat.TLEHistory {
+		out = append(out, set.Epoch)
+	}
+	return out
+}
+
+func TestFetcher_Update(t *testing.T) {
+	base := issSet(t, 0)
+	store := newISSStore(t, base)
+
+	other := issSet(t, 1)
+	other.NoradID, other.Name = 99001, "UNKNOWN"
+
+	source := &fakeSource{sets: []tle.ElementSet{
+		issSet(t, 2),  // новее, применяется после +1 благодаря сортировке
+		issSet(t, 1),  // новее
+		base,          // уже известна
+		issSet(t, -1), // регресс эпохи
+		other,         // нет в каталоге
+	}}
+	f, err := NewFetcher(store, source, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := f.Update(context.Background())
+	if err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	want := Result{Fetched: 5, Updated: 2, Skipped: 2, Rejected: 1}
+	if res != want {
+		t.Errorf("Update() = %+v, want %+v", res, want)
+	}
+
+	got := epochs(t, store, 25544)
+	if len(got) != 3 || !got[0].Equal(base.Epoch) || !got[2].Equal(issSet(t, 2).Epoch) {
+		t.Errorf("history epochs = %v, want base, +1 d, +2 d", got)
+	}
+	sat, _ := store.Get(25544)
+	if sat.Name != "ISS" || sat.Downlink != 145.8 {
+		t.Errorf("satellite metadata changed: %+v", sat)
+	}
+	if _, err := store.Get(99001); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("unknown satellite added without AddNew: %v", err)
+	}
+
+	// Повторное применение тех же данных ничего не меняет, а регрессия
+	// теперь относится и к +1 суткам.
+	res, err = f.Update(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Updated != 0 || res.Rejected != 3 {
+		t.Errorf("second Update() = %+v, want no updates and 3 rejected", res)
+	}
+}
+
+func TestFetcher_AddNewAndHistoryLimit(t *testing.T) {
+	store := catalog.NewMemoryStore()
+	source := &fakeSource{sets: []tle.ElementSet{issSet(t, 0), issSet(t, 1), issSet(t, 2)}}
+
+	f, err := NewFetcher(store, source, Options{Interval: time.Hour, AddNew: true, MaxHistory: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.Update(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Added != 1 || res.Updated != 3 {
+		t.Errorf("Update() = %+v, want 1 added satellite with 3 epochs", res)
+	}
+
+	sat, err := store.Get(25544)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sat.Name != "ISS (ZARYA)" {
+		t.Errorf("Name = %q, want name from element set", sat.Name)
+	}
+	got := epochs(t, store, 25544)
+	if len(got) != 2 || !got[0].Equal(issSet(t, 1).Epoch) {
+		t.Errorf("history epochs = %v, want the 2 latest", got)
+	}
+}
+
+// racingStore изменяет спутник параллельно с обновлением: перед каждым
+// Modify выполняется правка, как от одновременного запроса PUT.
+type racingStore struct {
+	*catalog.MemoryStore
+	race func()
+}
+
+func (s *racingStore) Modify(id int, apply func(sat *catalog.Satellite) error) (catalog.Satellite, error) {
+	s.race()
+	return s.MemoryStore.Modify(id, apply)
+}
+
+func TestFetcher_ConcurrentModify(t *testing.T) {
+	mem := catalog.NewMemoryStore()
+	if err := mem.Create(catalog.Satellite{NoradID: 25544, Name: "ISS", TLEHistory: []tle.ElementSet{issSet(t, 0)}}); err != nil {
+		t.Fatal(err)
+	}
+	store := &racingStore{MemoryStore: mem, race: func() {
+		if _, err := mem.Modify(25544, func(sat *catalog.Satellite) error {
+			sat.Downlink = 437.8
+			return nil
+		}); err != nil {
+			t.Error(err)
+		}
+	}}
+
+	f, err := NewFetcher(store, &fakeSource{sets: []tle.ElementSet{issSet(t, 1)}}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Сохраняются и параллельная правка, и новая эпоха.
+	sat, _ := mem.Get(25544)
+	if sat.Downlink != 437.8 || len(sat.TLEHistory) != 2 {
+		t.Errorf("satellite = downlink %v, %d epochs; want 437.8 and 2", sat.Downlink, len(sat.TLEHistory))
+	}
+}
+
+func TestFetcher_SourceErrors(t *testing.T) {
+	store := newISSStore(t)
+
+	f, err := NewFetcher(store, &fakeSource{err: ErrNotModified}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.Update(context.Background())
+	if err != nil || !res.NotModified {
+		t.Errorf("Update() = %+v, %v; want NotModified", res, err)
+	}
+
+	failure := errors.New("boom")
+	f.source = &fakeSource{err: failure}
+	if _, err := f.Update(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("Update() error = %v, want %v", err, failure)
+	}
+}
+
+func TestNewFetcher_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"interval too short", Options{Interval: time.Second, MaxHistory: 1}},
+		{"no history", Options{Interval: time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFetcher(catalog.NewMemoryStore(), &fakeSource{}, tt.opts)
+			if !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("NewFetcher() error = %v, want ErrInvalidOptions", err)
+			}
+		})
+	}
+}
+
+func TestFetcher_Run(t *testing.T) {
+	store := newISSStore(t)
+	f, err := NewFetcher(store, &fakeSource{sets: []tle.ElementSet{issSet(t, 0)}}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+
+	// Первое обновление выполняется сразу при запуске.
+	deadline := time.After(2 * time.Second)
+	for len(epochs(t, store, 25544)) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("Run() did not update the catalog")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return after cancel")
+	}
+}
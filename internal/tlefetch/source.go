@@ -0,0 +1,196 @@
+package tlefetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+const (
+	// maxResponseBody ограничивает размер ответа источника (полный каталог
+	// Celestrak в формате JSON занимает около 20 МБ).
+	maxResponseBody = 64 << 20
+
+	defaultHTTPTimeout = time.Minute
+)
+
+// dirExtensions — расширения файлов, читаемых DirSource.
+var dirExtensions = []string{".tle", ".3le", ".txt", ".json", ".csv", ".xml", ".kvn"}
+
+// Source — источник наборов элементов. Fetch возвращает ErrNotModified,
+// если данные не изменились с предыдущего успешного вызова.
+type Source interface {
+	Fetch(ctx context.Context) ([]tle.ElementSet, error)
+	// String описывает источник для журнала.
+	String() string
+}
+
+// HTTPSource загружает элементы по URL в формате GP Celestrak
+// (TLE, OMM JSON, CSV или XML) с условными запросами по ETag
+// и Last-Modified.
+type HTTPSource struct {
+	url    string
+	client *http.Client
+
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+}
+
+// NewHTTPSource создаёт источник для url. Если client равен nil,
+// используется клиент с тайм-аутом по умолчанию.
+func NewHTTPSource(url string, client *http.Client) *HTTPSource {
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &HTTPSource{url: url, client: client}
+}
+
+// String возвращает URL источника.
+func (s *HTTPSource) String() string {
+	return s.url
+}
+
+// Fetch загружает и разбирает элементы. Валидаторы ответа запоминаются
+// только после успешного разбора, чтобы повреждённые данные были
+// запрошены повторно.
+func (s *HTTPSource) Fetch(ctx context.Context) ([]tle.ElementSet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	if s.lastModified != "" {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
+	s.mu.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, ErrNotModified
+	default:
+		return nil, fmt.Errorf("tlefetch: %s: unexpected status %s", s.url, resp.Status)
+	}
+
+	sets, err := tle.Parse(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("tlefetch: %s: %w", s.url, err)
+	}
+
+	s.mu.Lock()
+	s.etag = resp.Header.Get("ETag")
+	s.lastModified = resp.Header.Get("Last-Modified")
+	s.mu.Unlock()
+	return sets, nil
+}
+
+// DirSource читает элементы из файлов локального каталога — замена
+// сетевого источника для стендов без доступа в интернет. Повторно
+// читаются только файлы, изменившиеся с предыдущего вызова.
+type DirSource struct {
+	dir string
+
+	mu   sync.Mutex
+	seen map[string]fileStamp
+}
+
+// fileStamp — признаки неизменности файла.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewDirSource создаёт источник для каталога dir.
+func NewDirSource(dir string) (*DirSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("tlefetch: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("tlefetch: %s is not a directory", dir)
+	}
+	return &DirSource{dir: dir, seen: make(map[string]fileStamp)}, nil
+}
+
+// String возвращает путь к каталогу.
+func (s *DirSource) String() string {
+	return s.dir
+}
+
+// Fetch читает новые и изменённые файлы. Файл с ошибкой разбора
+// пропускается и будет прочитан снова при следующем вызове.
+func (s *DirSource) Fetch(ctx context.Context) ([]tle.ElementSet, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("tlefetch: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		sets    []tle.ElementSet
+		changed bool
+	)
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if !e.Type().IsRegular() || !slices.Contains(dirExtensions, ext) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("tlefetch: %w", err)
+		}
+		stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+		if s.seen[e.Name()] == stamp {
+			continue
+		}
+
+		fileSets, err := readFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			slog.Warn("skipping element set file", "path", filepath.Join(s.dir, e.Name()), "error", err)
+			continue
+		}
+		s.seen[e.Name()] = stamp
+		sets = append(sets, fileSets...)
+		changed = true
+	}
+
+	if !changed {
+		return nil, ErrNotModified
+	}
+	return sets, nil
+}
+
+func readFile(path string) ([]tle.ElementSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return tle.Parse(f)
+}
@@ -0,0 +1,219 @@
+package tlefetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const issOMMJSON = `[{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2008-09-21T12:00:00",` +
+	`"MEAN_MOTION":15.72125391,"ECCENTRICITY":0.0006703,"INCLINATION":51.6416,"RA_OF_ASC_NODE":247.4627,` +
+	`"ARG_OF_PERICENTER":130.536,"MEAN_ANOMALY":325.0288,"NORAD_CAT_ID":25544,"BSTAR":-1.1606e-5}]`
+
+const issOMMCSV = "OBJECT_NAME,OBJECT_ID,EPOCH,MEAN_MOTION,ECCENTRICITY,INCLINATION,RA_OF_ASC_NODE," +
+	"ARG_OF_PERICENTER,MEAN_ANOMALY,NORAD_CAT_ID,BSTAR\r\n" +
+	"ISS (ZARYA),1998-067A,2008-09-22T12:00:00,15.72125391,.0006703,51.6416,247.4627," +
+	"130.536,325.0288,25544,-.11606E-4\r\n"
+
+// gpServer имитирует Celestrak: отдаёт body с валидаторами и отвечает
+// 304 на условные запросы с совпадающим ETag.
+type gpServer struct {
+	mu       sync.Mutex
+	body     string
+	etag     string
+	modified time.Time
+	requests []http.Header
+}
+
+func (s *gpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Header.Clone())
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Header().Set("Last-Modified", s.modified.Format(http.TimeFormat))
+	_, _ = w.Write([]byte(s.body))
+}
+
+func (s *gpServer) set(body, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body, s.etag = body, etag
+	s.modified = s.modified.Add(time.Hour)
+}
+
+func TestHTTPSource_ConditionalRequests(t *testing.T) {
+	gp := &gpServer{modified: time.Date(2008, 9, 20, 0, 0, 0, 0, time.UTC)}
+	gp.set(issTLE(t, issSet(t, 0)), `"v1"`)
+	srv := httptest.NewServer(gp)
+	defer srv.Close()
+
+	src := NewHTTPSource(srv.URL+"/NORAD/elements/gp.php?CATNR=25544", srv.Client())
+	sets, err := src.Fetch(context.Background())
+	if err != nil || len(sets) != 1 || sets[0].NoradID != 25544 {
+		t.Fatalf("first Fetch() = %+v, %v", sets, err)
+	}
+
+	if _, err := src.Fetch(context.Background()); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("second Fetch() error = %v, want ErrNotModified", err)
+	}
+	h := gp.requests[1]
+	if h.Get("If-None-Match") != `"v1"` || h.Get("If-Modified-Since") == "" {
+		t.Errorf("conditional headers = %v", h)
+	}
+
+	gp.set(issOMMJSON, `"v2"`)
+	if sets, err := src.Fetch(context.Background()); err != nil || len(sets) != 1 {
+		t.Fatalf("Fetch() after change = %+v, %v", sets, err)
+	}
+}
+
+func TestHTTPSource_Formats(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"tle", issTLE(t, issSet(t, 0))},
+		{"omm json", issOMMJSON},
+		{"omm csv", issOMMCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			sets, err := NewHTTPSource(srv.URL, srv.Client()).Fetch(context.Background())
+			if err != nil {
+				t.Fatalf("Fetch() error: %v", err)
+			}
+			if len(sets) != 1 || sets[0].NoradID != 25544 || sets[0].Name != "ISS (ZARYA)" {
+				t.Errorf("Fetch() = %+v", sets)
+			}
+		})
+	}
+}
+
+func TestHTTPSource_Errors(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("ETag", `"broken"`)
+		_, _ = w.Write([]byte(issLine1 + "\n"))
+	}))
+	defer srv.Close()
+	src := NewHTTPSource(srv.URL, srv.Client())
+
+	if _, err := src.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Fetch() error = %v, want unexpected status", err)
+	}
+
+	// Повреждённые данные не запоминают ETag: следующий запрос безусловный.
+	status = http.StatusOK
+	if _, err := src.Fetch(context.Background()); err == nil {
+		t.Error("Fetch() of truncated TLE succeeded")
+	}
+	if src.etag != "" {
+		t.Errorf("etag = %q stored after failed parse", src.etag)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string, mtime time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mtime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	write("iss.tle", issTLE(t, issSet(t, 0)), mtime)
+	write("gp.json", issOMMJSON, mtime)
+	write("broken.txt", "garbage\n"+issLine1+"\n", mtime)
+	write("README.md", "not elements", mtime)
+
+	src, err := NewDirSource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sets, err := src.Fetch(context.Background())
+	if err != nil || len(sets) != 2 {
+		t.Fatalf("first Fetch() = %d sets, %v; want 2 sets", len(sets), err)
+	}
+
+	if _, err := src.Fetch(context.Background()); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("second Fetch() error = %v, want ErrNotModified", err)
+	}
+
+	// Изменённый файл читается заново, остальные — нет.
+	write("iss.tle", issTLE(t, issSet(t, 1)), mtime.Add(time.Hour))
+	sets, err = src.Fetch(context.Background())
+	if err != nil || len(sets) != 1 {
+		t.Fatalf("Fetch() after change = %d sets, %v; want 1 set", len(sets), err)
+	}
+
+	if _, err := NewDirSource(filepath.Join(dir, "iss.tle")); err == nil {
+		t.Error("NewDirSource(file) succeeded")
+	}
+}
+
+func TestFetcher_HTTPEndToEnd(t *testing.T) {
+	gp := &gpServer{}
+	gp.set(issTLE(t, issSet(t, 0)), `"v1"`)
+	srv := httptest.NewServer(gp)
+	defer srv.Close()
+
+	store := newISSStore(t)
+	f, err := NewFetcher(store, NewHTTPSource(srv.URL, srv.Client()), DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		body, etag string
+		want       Result
+	}{
+		{issTLE(t, issSet(t, 0)), `"v1"`, Result{Fetched: 1, Updated: 1}},
+		{issTLE(t, issSet(t, 0)), `"v1"`, Result{NotModified: true}},
+		{issTLE(t, issSet(t, -3)), `"v2"`, Result{Fetched: 1, Rejected: 1}},
+		{issOMMCSV, `"v3"`, Result{Fetched: 1, Updated: 1}},
+	}
+	for i, step := range steps {
+		gp.set(step.body, step.etag)
+		res, err := f.Update(context.Background())
+		if err != nil {
+			t.Fatalf("step %d: Update() error: %v", i, err)
+		}
+		if res != step.want {
+			t.Errorf("step %d: Update() = %+v, want %+v", i, res, step.want)
+		}
+	}
+
+	sat, err := store.Get(25544)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest, _ := sat.LatestTLE(); len(sat.TLEHistory) != 2 || latest.Epoch.Day() != 22 {
+		t.Errorf("history = %d sets, latest %v; want 2 sets ending 2008-09-22", len(sat.TLEHistory), latest.Epoch)
+	}
+}
@@ -0,0 +1,200 @@
+// Package tracking вычисляет текущее положение спутников каталога
+// относительно наблюдателя для потоковой передачи клиентам.
+package tracking
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+)
+
+// noPassRetry — через сколько повторять поиск пролёта, если он не найден в окне прогноза.
+const noPassRetry = 10 * time.Minute
+
+// ErrNoTLE возвращается для спутника без элементов орбиты.
+var ErrNoTLE = errors.New("tracking: satellite has no TLE")
+
+// Snapshot — положение спутника в момент Time: подспутниковая точка,
+// направление с наблюдателя и ближайший пролёт.
+type Snapshot struct {
+	Time    time.Time `json:"time"`
+	NoradID int       `json:"norad_id"`
+	Name    string    `json:"name"`
+	orbit.Geodetic
+	orbit.Look
+	Visible  bool       `json:"visible"`
+	Downlink float64    `json:"downlink_mhz,omitempty"` // МГц
+	Doppler  float64    `json:"doppler_hz"`             // сдвиг downlink, Гц; ноль без частоты
+	NextAOS  *time.Time `json:"next_aos,omitempty"`
+	NextLOS  *time.Time `json:"next_los,omitempty"`
+}
+
+// Tracker вычисляет снимки положения. Безопасен для одновременного
+// использования: пропагаторы и ближайшие пролёты кэшируются по спутнику.
+type Tracker struct {
+	store catalog.Store
+
+	mu        sync.Mutex
+	predictor *pass.Predictor
+	entries   map[int]*entry
+}
+
+// entry — кэш для одного спутника, действительный для одной эпохи TLE
+// и одного прогноза пролётов.
+type entry struct {
+	epoch      time.Time
+	predictor  *pass.Predictor
+	prop       *orbit.SGP4
+	next       pass.Pass
+	found      bool
+	validFrom  time.Time
+	validUntil time.Time
+}
+
+// NewTracker создаёт трекер спутников каталога.
+func NewTracker(store catalog.Store, predictor *pass.Predictor) *Tracker {
+	return &Tracker{
+		store:     store,
+		predictor: predictor,
+		entries:   make(map[int]*entry),
+	}
+}
+
+// SetPredictor заменяет прогноз пролётов, например при смене станции
+// или её маски горизонта; найденные пролёты сбрасываются.
+func (t *Tracker) SetPredictor(predictor *pass.Predictor) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.predictor = predictor
+	t.entries = make(map[int]*entry)
+}
+
+// Snapshot вычисляет положение спутника noradID в момент at.
+func (t *Tracker) Snapshot(noradID int, at time.Time) (Snapshot, error) {
+	sat, e, err := t.satellite(noradID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	st, err := e.prop.Propagate(at)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	look := e.predictor.Observer().Look(st)
+	snap := Snapshot{
+		Time:     at,
+		NoradID:  sat.NoradID,
+		Name:     sat.Name,
+		Geodetic: orbit.SubPoint(st),
+		Look:     look,
+		Visible:  look.Elevation >= e.predictor.Horizon(look.Azimuth),
+		Downlink: sat.Downlink,
+		Doppler:  look.Doppler(sat.Downlink * 1e6),
+	}
+
+	next, found, err := t.nextPass(e, at)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if found {
+		snap.NextAOS = &next.AOS
+		snap.NextLOS = &next.LOS
+	}
+	return snap, nil
+}
+
+// Look вычисляет только направление на спутник noradID в момент at,
+// без поиска пролёта, — для выборки траектории по многим моментам.
+func (t *Tracker) Look(noradID int, at time.Time) (orbit.Look, error) {
+	_, e, err := t.satellite(noradID)
+	if err != nil {
+		return orbit.Look{}, err
+	}
+	st, err := e.prop.Propagate(at)
+	if err != nil {
+		return orbit.Look{}, err
+	}
+	return e.predictor.Observer().Look(st), nil
+}
+
+// satellite возвращает спутник каталога и кэш для его последнего TLE.
+func (t *Tracker) satellite(noradID int) (catalog.Satellite, *entry, error) {
+	sat, err := t.store.Get(noradID)
+	if err != nil {
+		return catalog.Satellite{}, nil, err
+	}
+	set, ok := sat.LatestTLE()
+	if !ok {
+		return catalog.Satellite{}, nil, ErrNoTLE
+	}
+	e, err := t.entry(noradID, set.Epoch, set.Elements())
+	if err != nil {
+		return catalog.Satellite{}, nil, err
+	}
+	return sat, e, nil
+}
+
+// entry возвращает кэш спутника, пересоздавая его при смене эпохи TLE
+// или прогноза пролётов. Кэш, построенный для прогноза, заменённого
+// SetPredictor во время построения, не сохраняется: он строится заново.
+func (t *Tracker) entry(noradID int, epoch time.Time, el orbit.Elements) (*entry, error) {
+	var prop *orbit.SGP4
+	for {
+		t.mu.Lock()
+		e, ok := t.entries[noradID]
+		predictor := t.predictor
+		t.mu.Unlock()
+		if ok && e.epoch.Equal(epoch) && e.predictor == predictor {
+			return e, nil
+		}
+
+		if prop == nil {
+			var err error
+			if prop, err = orbit.New(el); err != nil {
+				return nil, err
+			}
+		}
+		e = &entry{epoch: epoch, predictor: predictor, prop: prop}
+
+		t.mu.Lock()
+		if t.predictor == predictor {
+			t.entries[noradID] = e
+			t.mu.Unlock()
+			return e, nil
+		}
+		t.mu.Unlock()
+	}
+}
+
+// nextPass возвращает текущий или ближайший пролёт; поиск выполняется
+// заново после LOS закэшированного пролёта или при переходе времени назад.
+func (t *Tracker) nextPass(e *entry, at time.Time) (pass.Pass, bool, error) {
+	t.mu.Lock()
+	if !at.Before(e.validFrom) && at.Before(e.validUntil) {
+		next, found := e.next, e.found
+		t.mu.Unlock()
+		return next, found, nil
+	}
+	t.mu.Unlock()
+
+	// Поиск пролёта выполняется без блокировки, чтобы не задерживать других клиентов.
+	next, found, err := e.predictor.NextPass(e.prop, at)
+	if err != nil {
+		return pass.Pass{}, false, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e.next, e.found = next, found
+	e.validFrom = at
+	if found {
+		e.validUntil = next.LOS
+	} else {
+		e.validUntil = at.Add(noPassRetry)
+	}
+	return next, found, nil
+}
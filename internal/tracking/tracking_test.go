@@ -0,0 +1,188 @@
+package tracking
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satwatch-go/internal/catalog"
+	"github.com/art-injener/satwatch-go/internal/orbit"
+	"github.com/art-injener/satwatch-go/internal/pass"
+	"github.com/art-injener/satwatch-go/internal/tle"
+)
+
+const (
+	issLine1 = "1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927"
+	issLine2 = "2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537"
+)
+
+func newTestTracker(t *testing.T) (*Tracker, time.Time) {
+	t.Helper()
+
+	set, err := tle.ParseTLE("ISS", issLine1, issLine2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := catalog.NewMemoryStore()
+	if err := store.Create(catalog.Satellite{NoradID: 25544, Name: "ISS", Downlink: 145.8, TLEHistory: []tle.ElementSet{set}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(catalog.Satellite{NoradID: 99999, Name: "NO TLE"}); err != nil {
+		t.Fatal(err)
+	}
+
+	observer := orbit.Observer{Latitude: 47.315813, Longitude: 39.788243, Altitude: 70}
+	predictor, err := pass.NewPredictor(observer, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTracker(store, predictor), set.Epoch
+}
+
+func TestTracker_Snapshot(t *testing.T) {
+	tracker, epoch := newTestTracker(t)
+
+	snap, err := tracker.Snapshot(25544, epoch)
+	if err != nil {
+		t.Fatalf("Snapshot() error: %v", err)
+	}
+	if snap.NoradID != 25544 || snap.Name != "ISS" || !snap.Time.Equal(epoch) {
+		t.Errorf("Snapshot() identity = %d/%q/%v", snap.NoradID, snap.Name, snap.Time)
+	}
+	if math.Abs(snap.Latitude) > 51.7 || snap.Altitude < 300 || snap.Altitude > 450 {
+		t.Errorf("subpoint = %+v, want ISS-like orbit", snap.Geodetic)
+	}
+	if snap.Range < snap.Altitude {
+		t.Errorf("Range = %v less than altitude %v", snap.Range, snap.Altitude)
+	}
+	if math.Abs(snap.RangeRate) > 8 {
+		t.Errorf("RangeRate = %v km/s exceeds orbital speed", snap.RangeRate)
+	}
+	if want := orbit.DopplerShift(145.8e6, snap.RangeRate); snap.Doppler != want || snap.Doppler == 0 {
+		t.Errorf("Doppler = %v, want %v", snap.Doppler, want)
+	}
+	if snap.NextAOS == nil || snap.NextLOS == nil || !snap.NextLOS.After(epoch) {
+		t.Fatalf("next pass = %v/%v, want pass after epoch", snap.NextAOS, snap.NextLOS)
+	}
+
+	// Во время пролёта спутник видим, а ближайший пролёт — текущий.
+	during, err := tracker.Snapshot(25544, snap.NextAOS.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !during.Visible || !during.NextAOS.Equal(*snap.NextAOS) {
+		t.Errorf("during pass: visible=%v, AOS=%v; want visible and AOS %v", during.Visible, during.NextAOS, snap.NextAOS)
+	}
+
+	// После LOS ищется следующий пролёт.
+	after, err := tracker.Snapshot(25544, snap.NextLOS.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.NextAOS == nil || !after.NextAOS.After(*snap.NextLOS) {
+		t.Errorf("after LOS: next AOS = %v, want after %v", after.NextAOS, snap.NextLOS)
+	}
+
+	// Переход времени назад сбрасывает кэш пролёта.
+	back, err := tracker.Snapshot(25544, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.NextAOS.Equal(*snap.NextAOS) {
+		t.Errorf("after rewind: next AOS = %v, want %v", back.NextAOS, snap.NextAOS)
+	}
+}
+
+func TestTracker_SetPredictor(t *testing.T) {
+	tracker, epoch := newTestTracker(t)
+	before, err := tracker.Snapshot(25544, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Станция в южном полушарии: другой ближайший пролёт и направление.
+	observer := orbit.Observer{Latitude: -33.45, Longitude: -70.66, Altitude: 570}
+	predictor, err := pass.NewPredictor(observer, pass.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.SetPredictor(predictor)
+	after, err := tracker.Snapshot(25544, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Azimuth == before.Azimuth || after.NextAOS == nil || after.NextAOS.Equal(*before.NextAOS) {
+		t.Errorf("snapshot after SetPredictor = %+v, want new station view", after)
+	}
+}
+
+func TestTracker_SetPredictorRace(t *testing.T) {
+	tracker, epoch := newTestTracker(t)
+	var predictors []*pass.Predictor
+	for _, lat := range []float64{-33.45, 55.76} {
+		p, err := pass.NewPredictor(orbit.Observer{Latitude: lat, Longitude: 37.62}, pass.DefaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		predictors = append(predictors, p)
+	}
+
+	for i := range 10 {
+		want := predictors[i%2]
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Go(func() {
+				if _, err := tracker.Snapshot(25544, epoch); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+		tracker.SetPredictor(want)
+		wg.Wait()
+
+		// Снимок, начатый до замены, не оставляет в кэше старую станцию.
+		if _, err := tracker.Snapshot(25544, epoch); err != nil {
+			t.Fatal(err)
+		}
+		tracker.mu.Lock()
+		got := tracker.entries[25544].predictor.Observer()
+		tracker.mu.Unlock()
+		if got != want.Observer() {
+			t.Fatalf("iteration %d: cached observer = %+v, want %+v", i, got, want.Observer())
+		}
+	}
+}
+
+func TestTracker_SnapshotErrors(t *testing.T) {
+	tracker, epoch := newTestTracker(t)
+
+	if _, err := tracker.Snapshot(1, epoch); !errors.Is(err, catalog.ErrNotFound) {
+		t.Errorf("unknown satellite error = %v, want ErrNotFound", err)
+	}
+	if _, err := tracker.Snapshot(99999, epoch); !errors.Is(err, ErrNoTLE) {
+		t.Errorf("satellite without TLE error = %v, want ErrNoTLE", err)
+	}
+}
+
+func TestTracker_Look(t *testing.T) {
+	tracker, epoch := newTestTracker(t)
+
+	at := epoch.Add(17 * time.Minute)
+	snap, err := tracker.Snapshot(25544, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	look, err := tracker.Look(25544, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if look != snap.Look {
+		t.Errorf("Look() = %+v, want snapshot direction %+v", look, snap.Look)
+	}
+
+	if _, err := tracker.Look(99999, epoch); !errors.Is(err, ErrNoTLE) {
+		t.Errorf("satellite without TLE error = %v, want ErrNoTLE", err)
+	}
+}
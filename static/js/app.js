@@ -13,6 +13,305 @@
         }
     }
 
+    // Поток положения спутника (SSE)
+    let trackingSource = null;
+    let trackingLive = false;
+
+    function formatTime(iso) {
+        return iso ? new Date(iso).toISOString().slice(11, 19) : '--:--:--';
+    }
+
+    function setText(id, text) {
+        const el = document.getElementById(id);
+        if (el) {
+            el.textContent = text;
+        }
+    }
+
+    function onTrackingEvent(evt) {
+        const snap = JSON.parse(evt.data);
+        setText('info-norad', snap.norad_id);
+        setText('info-name', snap.name);
+        setText('info-time', formatTime(snap.time));
+        setText('info-lat', snap.lat.toFixed(2) + '°');
+        setText('info-lon', snap.lon.toFixed(2) + '°');
+        setText('info-alt', snap.alt.toFixed(0) + ' km');
+        document.dispatchEvent(new CustomEvent('satwatch:tracking', { detail: snap }));
+    }
+
+    // Реальные данные заменяют демо-анимацию индикаторов
+    function applyTracking(snap) {
+        trackingLive = true;
+        if (window.azimuthIndicator) {
+            window.azimuthIndicator.stopDemo();
+            window.azimuthIndicator.setAzimuth(snap.azimuth);
+        }
+        if (window.elevationIndicator) {
+            window.elevationIndicator.stopDemo();
+            window.elevationIndicator.setElevation(Math.max(0, snap.elevation));
+        }
+        if (window.skyView) {
+            window.skyView.stopDemo();
+            window.skyView.setSatelliteInfo(snap.name);
+            window.skyView.setSatellitePosition(snap.azimuth, snap.elevation);
+            if (snap.next_aos && snap.next_los) {
+                window.skyView.setPassTimes(Date.parse(snap.next_aos), Date.parse(snap.next_los));
+            }
+            window.skyView.draw();
+        }
+        if (window.earthView) {
+            window.earthView.stopDemo();
+            window.earthView.setSatelliteInfo(snap.name, snap.norad_id);
+            window.earthView.setSatellitePosition(snap.lon, snap.lat, snap.alt);
+            window.earthView.draw();
+        }
+    }
+
+    document.addEventListener('satwatch:tracking', function(evt) {
+        applyTracking(evt.detail);
+        refreshRotator(Date.parse(evt.detail.time));
+    });
+
+    // Поворотное устройство: плановая траектория пролёта и фактическое
+    // положение поверх индикаторов. Без настроенного rotctld API отвечает
+    // 404, и опрос прекращается.
+    const rotatorPollInterval = 2000;
+    let rotatorEnabled = true;
+    let rotatorPolled = 0;
+    let rotatorPlan = null;
+    let rotatorPlanSat = null;
+
+    function getJSON(url) {
+        return fetch(url).then(function(resp) {
+            if (!resp.ok) {
+                const err = new Error(resp.statusText);
+                err.status = resp.status;
+                throw err;
+            }
+            return resp.json();
+        });
+    }
+
+    function setRotatorPlan(plan) {
+        const points = plan ? plan.points : null;
+        if (window.azimuthIndicator) {
+            window.azimuthIndicator.setPlan(points && points.map(function(p) { return p.azimuth; }));
+        }
+        if (window.elevationIndicator) {
+            window.elevationIndicator.setPlan(points && points.map(function(p) { return p.elevation; }));
+        }
+    }
+
+    function applyRotator(status, simTime) {
+        const actual = status.connected && status.actual ? status.actual : null;
+        if (window.azimuthIndicator) {
+            window.azimuthIndicator.setActual(actual && actual.azimuth);
+        }
+        if (window.elevationIndicator) {
+            window.elevationIndicator.setActual(actual && actual.elevation);
+        }
+        if (status.mode !== 'tracking' || status.norad_id !== rotatorPlanSat) {
+            rotatorPlan = null;
+            rotatorPlanSat = status.norad_id;
+            setRotatorPlan(null);
+        }
+        // Траектория запрашивается заново только после LOS текущего пролёта
+        if (status.mode !== 'tracking' || (rotatorPlan && Date.parse(rotatorPlan.los) > simTime)) {
+            return;
+        }
+        getJSON('/api/rotator/plan').then(function(plan) {
+            rotatorPlan = plan;
+            setRotatorPlan(plan);
+        }).catch(function() {
+            // Траектория ещё не рассчитана или пролёт не найден
+            rotatorPlan = null;
+            setRotatorPlan(null);
+        });
+    }
+
+    // simTime — модельное время последнего события потока, мс
+    function refreshRotator(simTime) {
+        const now = Date.now();
+        if (!rotatorEnabled || now - rotatorPolled < rotatorPollInterval) {
+            return;
+        }
+        rotatorPolled = now;
+        getJSON('/api/rotator').then(function(status) {
+            applyRotator(status, simTime);
+        }).catch(function(err) {
+            if (err.status === 404) {
+                rotatorEnabled = false;
+            }
+        });
+    }
+
+    // Модельные часы сервера: скорость, пауза и переходы во времени
+    function applyClock(state) {
+        const rate = state.rate >= 10 ? state.rate.toFixed(0) : String(state.rate);
+        setText('info-clock', (state.paused ? '⏸ ×' : '×') + rate);
+        document.querySelectorAll('[data-clock-rate]').forEach(function(btn) {
+            btn.classList.toggle('active', Number(btn.dataset.clockRate) === state.rate);
+        });
+    }
+
+    function postClock(path, body) {
+        const init = { method: 'POST' };
+        if (body) {
+            init.headers = { 'Content-Type': 'application/json' };
+            init.body = JSON.stringify(body);
+        }
+        // Новое состояние приходит в поток событием "clock"
+        return fetch('/api/clock/' + path, init).then(function(resp) {
+            if (!resp.ok) {
+                return resp.json().then(function(data) {
+                    throw new Error(data.error || resp.statusText);
+                });
+            }
+            return resp.json();
+        }).catch(function(err) {
+            // eslint-disable-next-line no-console
+            console.error('Clock control failed:', err);
+        });
+    }
+
+    document.addEventListener('click', function(evt) {
+        const btn = evt.target.closest('[data-clock-rate], [data-clock-action]');
+        if (!btn) {
+            return;
+        }
+        if (btn.dataset.clockRate) {
+            postClock('rate', { rate: Number(btn.dataset.clockRate) });
+        } else {
+            postClock(btn.dataset.clockAction);
+        }
+    });
+
+    // Подключение к потоку только на странице отслеживания
+    function initTrackingStream() {
+        const onTrackingPage = document.getElementById('satellite-info') !== null;
+        if (!onTrackingPage || !window.EventSource) {
+            if (trackingSource) {
+                trackingSource.close();
+                trackingSource = null;
+                trackingLive = false;
+                setConnected(false);
+            }
+            return;
+        }
+        if (trackingSource) {
+            return;
+        }
+
+        // Страница показывает станцию, активную на момент её загрузки
+        const station = document.getElementById('satellite-info').dataset.station;
+        trackingSource = new EventSource('/api/stream/tracking' +
+            (station ? '?station=' + encodeURIComponent(station) : ''));
+        trackingSource.addEventListener('open', function() {
+            setConnected(true);
+        });
+        trackingSource.addEventListener('error', function() {
+            // EventSource переподключается автоматически
+            setConnected(false);
+        });
+        trackingSource.addEventListener('tracking', onTrackingEvent);
+        trackingSource.addEventListener('clock', function(evt) {
+            applyClock(JSON.parse(evt.data));
+        });
+        trackingSource.addEventListener('heartbeat', function(evt) {
+            setConnected(true);
+            applyClock(JSON.parse(evt.data));
+        });
+    }
+
+    // Водопад приёмника SDR (SSE)
+    let waterfallSource = null;
+    let waterfallView = null;
+
+    function setWaterfallStatus(text) {
+        const el = document.getElementById('waterfall-status');
+        if (el) {
+            el.textContent = text;
+            el.hidden = text === '';
+        }
+    }
+
+    function describeReceiver(status) {
+        if (!status.connected) {
+            return status.error ? 'SDR недоступен: ' + status.error : 'SDR не подключён';
+        }
+        return (status.frequency / 1e6).toFixed(3) + ' MHz, ' +
+            (status.sample_rate / 1e3).toFixed(0) + ' kS/s';
+    }
+
+    function stopWaterfall() {
+        if (waterfallSource) {
+            waterfallSource.close();
+            waterfallSource = null;
+        }
+        const button = document.getElementById('sdr-connect');
+        if (button) {
+            button.textContent = 'Подключить SDR';
+        }
+    }
+
+    function startWaterfall(canvas, button) {
+        const params = new URLSearchParams();
+        const freq = document.getElementById('frequency');
+        const gain = document.getElementById('gain');
+        if (freq && freq.value) {
+            params.set('frequency', freq.value);
+        }
+        if (gain) {
+            params.set('gain', gain.value);
+        }
+
+        // Запись перестроить нельзя (409), приёмник мог ещё не подключиться
+        // (503) — водопад всё равно открывается.
+        fetch('/api/receiver/tune?' + params.toString(), { method: 'POST' }).catch(function() {});
+
+        waterfallView = new window.WaterfallView(canvas);
+        waterfallSource = new EventSource('/api/stream/waterfall?width=' + canvas.width);
+        waterfallSource.addEventListener('waterfall', function(evt) {
+            const row = JSON.parse(evt.data);
+            waterfallView.addRow(window.WaterfallView.decodeBins(row.bins));
+            setWaterfallStatus('');
+        });
+        waterfallSource.addEventListener('heartbeat', function(evt) {
+            const status = JSON.parse(evt.data);
+            if (!status.connected) {
+                setWaterfallStatus(describeReceiver(status));
+            }
+        });
+        button.textContent = 'Отключить SDR';
+    }
+
+    // Кнопка приёмника доступна, только если сервер запущен с SDR_SOURCE
+    function initReceiver() {
+        const canvas = document.getElementById('waterfall');
+        const button = document.getElementById('sdr-connect');
+        stopWaterfall();
+        if (!canvas || !button || !window.EventSource || !window.WaterfallView) {
+            return;
+        }
+
+        getJSON('/api/receiver').then(function(status) {
+            button.disabled = false;
+            setWaterfallStatus(describeReceiver(status));
+        }).catch(function() {
+            button.disabled = true;
+        });
+
+        button.addEventListener('click', function() {
+            if (waterfallSource) {
+                stopWaterfall();
+                drawWaterfallPlaceholder(canvas);
+                setWaterfallStatus('SDR не подключён');
+                return;
+            }
+            startWaterfall(canvas, button);
+        });
+    }
+
     // Initialize when DOM is ready
     document.addEventListener('DOMContentLoaded', function() {
         // eslint-disable-next-line no-console
@@ -37,6 +336,8 @@
 
         // Initialize canvas placeholders
         initCanvasPlaceholders();
+        initTrackingStream();
+        initReceiver();
     });
 
     // Initialize canvas elements with placeholder content
@@ -49,9 +350,12 @@
                 window.earthView.stopDemo();
             }
             window.earthView = new window.EarthView(earthCanvas);
+            setStationObserver(window.earthView);
             window.earthView.init().then(function() {
-                // Запуск демо-анимации после загрузки данных
-                window.earthView.startDemo(2);
+                // Демо-анимация только до прихода реальных данных
+                if (!trackingLive) {
+                    window.earthView.startDemo(2);
+                }
             }).catch(function(err) {
                 // eslint-disable-next-line no-console
                 console.error('EarthView init failed:', err);
@@ -68,6 +372,7 @@
                 window.skyView.stopDemo();
             }
             window.skyView = new window.SkyView(skyCanvas);
+            loadHorizonMask(window.skyView);
             window.skyView.startDemo(2);
         } else if (skyCanvas) {
             drawPlaceholder(skyCanvas, '', 'Небесная сфера');
@@ -98,6 +403,32 @@
         }
     }
 
+    // Наблюдатель на карте — станция из атрибутов панели спутника
+    function setStationObserver(view) {
+        const info = document.getElementById('satellite-info');
+        if (!info || !info.dataset.observerName) {
+            return;
+        }
+        view.setObserver(parseFloat(info.dataset.observerLon), parseFloat(info.dataset.observerLat),
+            info.dataset.observerName);
+    }
+
+    // Маска горизонта станции — под траекторией на карте неба
+    function loadHorizonMask(view) {
+        const info = document.getElementById('satellite-info');
+        if (!info || !info.dataset.station) {
+            return;
+        }
+        fetch('/api/stations/' + encodeURIComponent(info.dataset.station) + '/horizon').then(function(resp) {
+            return resp.ok ? resp.json() : null;
+        }).then(function(data) {
+            if (data) {
+                view.setHorizonMask(data.polygon);
+                view.draw();
+            }
+        }).catch(function() {});
+    }
+
     // Draw a generic placeholder on canvas
     function drawPlaceholder(canvas, title, subtitle) {
         const ctx = canvas.getContext('2d');
@@ -169,6 +500,17 @@
     document.body.addEventListener('htmx:afterSwap', function() {
         // Reinitialize canvas after HTMX swap
         initCanvasPlaceholders();
+        initTrackingStream();
+        initReceiver();
+    });
+
+    // Таблица телеметрии пополняется через htmx SSE; старые строки удаляются
+    const maxTelemetryRows = 200;
+    document.body.addEventListener('htmx:sseMessage', function(evt) {
+        const rows = evt.target.id === 'telemetry-rows' ? evt.target.querySelectorAll('tr:not(.empty-row)') : [];
+        for (let i = maxTelemetryRows; i < rows.length; i++) {
+            rows[i].remove();
+        }
     });
 
     // Переключение активного класса на табах при клике
@@ -27,6 +27,10 @@
         this.radius = Math.min(logicalWidth, logicalHeight) / 2 - 25; // Отступ для подписей
         this.currentAzimuth = 0;
 
+        // Плановая траектория поворотного устройства и его фактический азимут
+        this.planAzimuths = null;
+        this.actualAzimuth = null;
+
         // Цвета
         this.colors = {
             bgPrimary: '#0a0e14',
@@ -171,6 +175,10 @@
         // Основание платформы на заднем плане
         this.drawPlatformBase();
 
+        // Плановая траектория и фактическое положение устройства
+        this.drawPlan();
+        this.drawActual();
+
         // Динамическая антенна
         this.drawAntenna(this.currentAzimuth);
         this.drawAzimuthValue(this.currentAzimuth);
@@ -219,6 +227,74 @@
         ctx.setLineDash([]);
     };
 
+    /**
+     * Плановая траектория: пунктирная кривая внутри лимба. Азимуты в зоне
+     * перекрытия (больше 360°) рисуются ближе к центру, чтобы второй оборот
+     * не накладывался на первый.
+     */
+    AzimuthIndicator.prototype.drawPlan = function() {
+        const points = this.planAzimuths;
+        if (!points || points.length < 2) {
+            return;
+        }
+        const ctx = this.ctx;
+        const cx = this.centerX;
+        const cy = this.centerY;
+
+        ctx.strokeStyle = this.colors.accentBlue;
+        ctx.lineWidth = 2;
+        ctx.setLineDash([4, 4]);
+        ctx.beginPath();
+        for (let i = 0; i < points.length; i++) {
+            const az = points[i];
+            const r = az >= 360 ? this.radius - 34 : this.radius - 26;
+            const rad = this.degToRad(az - 90);
+            const x = cx + Math.cos(rad) * r;
+            const y = cy + Math.sin(rad) * r;
+            if (i === 0) {
+                ctx.moveTo(x, y);
+            } else {
+                ctx.lineTo(x, y);
+            }
+        }
+        ctx.stroke();
+        ctx.setLineDash([]);
+    };
+
+    /**
+     * Фактический азимут устройства: метка на внутреннем круге лимба
+     */
+    AzimuthIndicator.prototype.drawActual = function() {
+        if (this.actualAzimuth === null) {
+            return;
+        }
+        const ctx = this.ctx;
+        const rad = this.degToRad(this.actualAzimuth - 90);
+        const r = this.radius - 18;
+
+        ctx.fillStyle = this.colors.accentRed;
+        ctx.beginPath();
+        ctx.arc(this.centerX + Math.cos(rad) * r, this.centerY + Math.sin(rad) * r, 4, 0, Math.PI * 2);
+        ctx.fill();
+    };
+
+    /**
+     * Установка плановой траектории (массив азимутов устройства, может
+     * выходить за 360° в зоне перекрытия); null — скрыть
+     */
+    AzimuthIndicator.prototype.setPlan = function(azimuths) {
+        this.planAzimuths = azimuths;
+        this.draw();
+    };
+
+    /**
+     * Установка фактического азимута устройства; null — скрыть
+     */
+    AzimuthIndicator.prototype.setActual = function(deg) {
+        this.actualAzimuth = deg;
+        this.draw();
+    };
+
     /**
      * Установка азимута и перерисовка
      */
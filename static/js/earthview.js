@@ -659,8 +659,10 @@
 
         // Тестовые данные МКС-подобной орбиты
         this.setSatelliteInfo('ISS', 25544);
-        // Ростов-на-Дону
-        this.setObserver(39.7, 47.23, 'Rostov-on-Don');
+        // Без станции — Ростов-на-Дону
+        if (!this.observer) {
+            this.setObserver(39.7, 47.23, 'Rostov-on-Don');
+        }
 
         const inclination = 51.6; // Наклонение орбиты МКС (градусы)
         const orbitalPeriod = 92 * 60 * 1000; // Период орбиты в мс (~92 минуты)
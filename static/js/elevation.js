@@ -35,6 +35,10 @@
 
         this.currentElevation = 45;
 
+        // Плановая траектория поворотного устройства и его фактический угол места
+        this.planElevations = null;
+        this.actualElevation = null;
+
         // Цвета
         this.colors = {
             bgPrimary: '#0a0e14',
@@ -220,11 +224,90 @@
         // Сначала постамент (будет под антенной)
         this.drawPedestal();
 
+        // Плановая траектория и фактическое положение устройства
+        this.drawPlan();
+        this.drawActual();
+
         // Потом антенна (будет поверх постамента)
         this.drawAntenna(this.currentElevation);
         this.drawElevationValue(this.currentElevation);
     };
 
+    /**
+     * Угол на холсте для значения шкалы (как в drawLimb)
+     */
+    ElevationIndicator.prototype.scaleToRad = function(elev) {
+        elev = Math.max(-90, Math.min(90, elev));
+        return Math.PI - (elev + 90) * Math.PI / 180;
+    };
+
+    /**
+     * Плановая траектория: пунктирная дуга внутри лимба от минимального
+     * до максимального угла места пролёта. В режиме переворота угол места
+     * устройства больше 90° и дуга продолжается за зенит.
+     */
+    ElevationIndicator.prototype.drawPlan = function() {
+        const points = this.planElevations;
+        if (!points || points.length < 2) {
+            return;
+        }
+        const ctx = this.ctx;
+        const r = this.radius - 26;
+
+        ctx.strokeStyle = this.colors.accentBlue;
+        ctx.lineWidth = 2;
+        ctx.setLineDash([4, 4]);
+        ctx.beginPath();
+        for (let i = 0; i < points.length; i++) {
+            // Шкала индикатора: 0° — вверх, поэтому угол места устройства
+            // отсчитывается от горизонта справа налево через зенит
+            const rad = this.scaleToRad(90 - points[i]);
+            const x = this.centerX + Math.cos(rad) * r;
+            const y = this.centerY - Math.sin(rad) * r;
+            if (i === 0) {
+                ctx.moveTo(x, y);
+            } else {
+                ctx.lineTo(x, y);
+            }
+        }
+        ctx.stroke();
+        ctx.setLineDash([]);
+    };
+
+    /**
+     * Фактический угол места устройства: метка на внутренней дуге лимба
+     */
+    ElevationIndicator.prototype.drawActual = function() {
+        if (this.actualElevation === null) {
+            return;
+        }
+        const ctx = this.ctx;
+        const rad = this.scaleToRad(90 - this.actualElevation);
+        const r = this.radius - 18;
+
+        ctx.fillStyle = this.colors.accentRed;
+        ctx.beginPath();
+        ctx.arc(this.centerX + Math.cos(rad) * r, this.centerY - Math.sin(rad) * r, 4, 0, Math.PI * 2);
+        ctx.fill();
+    };
+
+    /**
+     * Установка плановой траектории (массив углов места устройства,
+     * до 180° в режиме переворота); null — скрыть
+     */
+    ElevationIndicator.prototype.setPlan = function(elevations) {
+        this.planElevations = elevations;
+        this.draw();
+    };
+
+    /**
+     * Установка фактического угла места устройства; null — скрыть
+     */
+    ElevationIndicator.prototype.setActual = function(deg) {
+        this.actualElevation = deg;
+        this.draw();
+    };
+
     /**
      * Установка угла места и перерисовка
      */
@@ -58,6 +58,10 @@
             // Метки азимута на внешней окружности
             azimuthLabel: '#00cccc', // Бирюзовый цвет для азимутальных меток
 
+            // Маска горизонта (здания и рельеф)
+            horizonMask: 'rgba(120, 90, 60, 0.55)',
+            horizonMaskBorder: '#b08050',
+
             // Траектория
             track: '#00cc00',
             trackArrow: '#88ff88', // Цвет стрелок направления
@@ -104,6 +108,9 @@
             maxElTime: null // Время максимального угла места
         };
 
+        // Маска горизонта станции: контур [{az, el}, ...] по возрастанию азимута
+        this.horizonMask = [];
+
         // Observer
         this.observer = {
             lat: 47.23,
@@ -871,6 +878,40 @@
         ctx.fillText(satName, col3X + 30, row2Y);
     };
 
+    /**
+     * Отрисовка маски горизонта: закрытая часть неба между горизонтом
+     * и контуром маски, под траекторией
+     */
+    SkyView.prototype._drawHorizonMask = function() {
+        const mask = this.horizonMask;
+        if (mask.length === 0) {
+            return;
+        }
+        const ctx = this.ctx;
+
+        const outline = new Path2D();
+        for (let i = 0; i < mask.length; i++) {
+            const p = this.azElToXY(mask[i].az, mask[i].el);
+            if (i === 0) {
+                outline.moveTo(p.x, p.y);
+            } else {
+                outline.lineTo(p.x, p.y);
+            }
+        }
+        outline.closePath();
+
+        // Кольцо между окружностью горизонта и контуром маски
+        const area = new Path2D();
+        area.arc(this.centerX, this.centerY, this.radius, 0, Math.PI * 2);
+        area.addPath(outline);
+        ctx.fillStyle = this.colors.horizonMask;
+        ctx.fill(area, 'evenodd');
+
+        ctx.strokeStyle = this.colors.horizonMaskBorder;
+        ctx.lineWidth = 1;
+        ctx.stroke(outline);
+    };
+
     /**
      * Обновление фазы анимации
      */
@@ -893,6 +934,7 @@
         this._updateAnimation();
         this._updateGeometry();
         this._drawBackground();
+        this._drawHorizonMask();
         this._drawObserver();
         this._drawSatelliteAura(); // Круг на заднем плане (до траектории и спутника)
         this._drawTrack();
@@ -900,6 +942,14 @@
         this._drawInfo();
     };
 
+    /**
+     * Установка маски горизонта
+     * @param {Array} polygon - Контур маски [{az, el}, ...] по возрастанию азимута
+     */
+    SkyView.prototype.setHorizonMask = function(polygon) {
+        this.horizonMask = polygon || [];
+    };
+
     /**
      * Установка информации о спутнике
      */
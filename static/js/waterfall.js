@@ -0,0 +1,94 @@
+// Waterfall View - Водопад спектра приёмника
+// Строки приходят из потока /api/stream/waterfall: уровни 0–255
+
+(function() {
+    'use strict';
+
+    /**
+     * Класс водопада: новые строки добавляются сверху, старые сдвигаются вниз
+     * @param {HTMLCanvasElement} canvas - Canvas элемент для отрисовки
+     */
+    function WaterfallView(canvas) {
+        this.canvas = canvas;
+        this.ctx = canvas.getContext('2d');
+        this.width = canvas.width;
+        this.height = canvas.height;
+        this.row = this.ctx.createImageData(this.width, 1);
+        this.palette = this.buildPalette([
+            [0, 0, 0, 34],
+            [0.25, 0, 40, 140],
+            [0.5, 0, 170, 200],
+            [0.75, 240, 220, 0],
+            [1, 255, 60, 40]
+        ]);
+        this.clear();
+    }
+
+    /**
+     * Палитра из 256 цветов линейной интерполяцией опорных точек
+     * [позиция 0–1, r, g, b]
+     */
+    WaterfallView.prototype.buildPalette = function(stops) {
+        const palette = new Uint8ClampedArray(256 * 3);
+        for (let i = 0; i < 256; i++) {
+            const x = i / 255;
+            let k = 1;
+            while (k < stops.length - 1 && stops[k][0] < x) {
+                k++;
+            }
+            const a = stops[k - 1];
+            const b = stops[k];
+            const f = (x - a[0]) / (b[0] - a[0]);
+            for (let c = 1; c <= 3; c++) {
+                palette[i * 3 + c - 1] = a[c] + (b[c] - a[c]) * f;
+            }
+        }
+        return palette;
+    };
+
+    /**
+     * Очистка до фона нулевого уровня
+     */
+    WaterfallView.prototype.clear = function() {
+        this.ctx.fillStyle = 'rgb(0, 0, 34)';
+        this.ctx.fillRect(0, 0, this.width, this.height);
+    };
+
+    /**
+     * Добавление строки: bins растягивается на ширину canvas
+     * @param {Uint8Array} bins - Уровни 0–255 от нижней частоты к верхней
+     */
+    WaterfallView.prototype.addRow = function(bins) {
+        if (!bins.length) {
+            return;
+        }
+        const ctx = this.ctx;
+        ctx.drawImage(this.canvas, 0, 0, this.width, this.height - 1, 0, 1, this.width, this.height - 1);
+
+        const data = this.row.data;
+        for (let x = 0; x < this.width; x++) {
+            const v = bins[Math.floor(x * bins.length / this.width)];
+            data[x * 4] = this.palette[v * 3];
+            data[x * 4 + 1] = this.palette[v * 3 + 1];
+            data[x * 4 + 2] = this.palette[v * 3 + 2];
+            data[x * 4 + 3] = 255;
+        }
+        ctx.putImageData(this.row, 0, 0);
+    };
+
+    /**
+     * Декодирование строки из JSON (base64) в массив уровней
+     */
+    WaterfallView.decodeBins = function(base64) {
+        const raw = atob(base64);
+        const bins = new Uint8Array(raw.length);
+        for (let i = 0; i < raw.length; i++) {
+            bins[i] = raw.charCodeAt(i);
+        }
+        return bins;
+    };
+
+    // Экспорт
+    window.WaterfallView = WaterfallView;
+
+})();